@@ -208,6 +208,10 @@ func deleteAccount(service account.UseCase) http.Handler {
 		case nil:
 			w.WriteHeader(http.StatusOK)
 			return
+		case entity.ErrDeletePending:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(err.Error()))
+			return
 		case entity.ErrNotFound:
 			w.WriteHeader(http.StatusNotFound)
 			_, _ = w.Write([]byte("Account doesn't exist"))
@@ -228,15 +232,15 @@ func updateAccount(service account.UseCase) http.Handler {
 		username := vars["username"]
 
 		var input entity.Account
-		// tenant := r.Header.Get(common.HttpHeaderTenantID)
-		// tenantID, err := entity.StringToID(tenant)
-		// if err != nil {
-		// 	w.WriteHeader(http.StatusBadRequest)
-		// 	_, _ = w.Write([]byte("Missing tenant ID"))
-		// 	return
-		// }
-
-		err := json.NewDecoder(r.Body).Decode(&input)
+		tenant := r.Header.Get(common.HttpHeaderTenantID)
+		tenantID, err := entity.StringToID(tenant)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Missing tenant ID"))
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&input)
 		if err != nil {
 			log.Println(err.Error())
 			w.WriteHeader(http.StatusBadRequest)
@@ -244,8 +248,32 @@ func updateAccount(service account.UseCase) http.Handler {
 			return
 		}
 
+		// the account of another tenant is not found by its username
+		saved, err := service.GetAccountByName(tenantID, username)
+		if err == entity.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account doesn't exist"))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		input.ID = saved.ID
+		input.TenantID = tenantID
 		input.Username = username
+		// Note: the account is found by its cognito id on sign in
+		if input.CognitoID == "" {
+			input.CognitoID = saved.CognitoID
+		}
 		err = service.UpdateAccount(&input)
+		if err == entity.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account doesn't exist"))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -262,7 +290,7 @@ func updateAccount(service account.UseCase) http.Handler {
 			Type:      input.Type,
 		}
 
-		w.Header().Set(common.HttpHeaderTenantID, "")
+		w.Header().Set(common.HttpHeaderTenantID, tenant)
 		w.WriteHeader(http.StatusOK)
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
 			log.Println(err.Error())
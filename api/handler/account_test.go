@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"sudhagar/glad/api/presenter"
@@ -206,4 +207,43 @@ func Test_deleteAccountNonExistent(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
 
-// TODO: Test case for updating account
+func Test_updateAccount(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeAccountHandlers(r, *n, service)
+	path, err := r.GetRoute("updateAccount").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/accounts/{username}", path)
+
+	username := accountUsernamePrimary
+	saved := &entity.Account{
+		ID:        accountIDPrimary,
+		TenantID:  tenantAlice,
+		CognitoID: "alice-cognito",
+		Username:  username,
+		Type:      entity.AccountTeacher,
+	}
+	service.EXPECT().GetAccountByName(tenantAlice, username).Return(saved, nil).Times(2)
+	var updated []string
+	service.EXPECT().UpdateAccount(gomock.Any()).DoAndReturn(func(e *entity.Account) error {
+		assert.Equal(t, accountIDPrimary, e.ID)
+		updated = append(updated, e.CognitoID)
+		return nil
+	}).Times(2)
+
+	// the cognito id is kept unless set
+	for _, body := range []string{
+		`{"Type": "teacher", "FirstName": "Alice"}`,
+		`{"Type": "teacher", "CognitoID": "alice-cognito-2"}`,
+	} {
+		req, _ := http.NewRequest(http.MethodPut, "/v1/accounts/"+username, strings.NewReader(body))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+	assert.Equal(t, []string{"alice-cognito", "alice-cognito-2"}, updated)
+}
@@ -38,6 +38,7 @@ func listCenters(service center.UseCase) http.Handler {
 		search := r.URL.Query().Get(httpParamQuery)
 		page, _ := strconv.Atoi(r.URL.Query().Get(httpParamPage))
 		limit, _ := strconv.Atoi(r.URL.Query().Get(httpParamLimit))
+		syncStatus := entity.SyncStatus(r.URL.Query().Get(httpParamSyncStatus))
 
 		tenantID, err := entity.StringToID(tenant)
 		if err != nil {
@@ -46,7 +47,15 @@ func listCenters(service center.UseCase) http.Handler {
 			return
 		}
 
+		if syncStatus != "" && !syncStatus.IsValid() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid sync status"))
+			return
+		}
+
 		switch {
+		case syncStatus != "":
+			data, err = service.ListCentersBySyncStatus(tenantID, syncStatus, page, limit)
 		case search == "":
 			data, err = service.ListCenters(tenantID, page, limit)
 		default:
@@ -72,12 +81,16 @@ func listCenters(service center.UseCase) http.Handler {
 		}
 		var toJ []*presenter.Center
 		for _, d := range data {
-			toJ = append(toJ, &presenter.Center{
+			pc := &presenter.Center{
 				ID:      d.ID,
 				Name:    d.Name,
 				Mode:    d.Mode,
 				ExtName: d.ExtName,
-			})
+			}
+			pc.Sync = &presenter.SyncState{}
+			pc.Sync.CopyFrom(d.Sync)
+
+			toJ = append(toJ, pc)
 		}
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
 			w.Header().Set(common.HttpHeaderTenantID, tenant)
@@ -146,6 +159,10 @@ func createCenter(service center.UseCase) http.Handler {
 func getCenter(service center.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading center"
+		tenantID, ok := getTenantID(w, r)
+		if !ok {
+			return
+		}
 		vars := mux.Vars(r)
 		id, err := entity.StringToID(vars["id"])
 		if err != nil {
@@ -153,7 +170,7 @@ func getCenter(service center.UseCase) http.Handler {
 			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
-		data, err := service.GetCenter(id)
+		data, err := service.GetCenter(tenantID, id)
 		if err != nil && err != entity.ErrNotFound {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -172,6 +189,11 @@ func getCenter(service center.UseCase) http.Handler {
 			Mode:    data.Mode,
 			ExtName: data.ExtName,
 		}
+		toJ.Sync = &presenter.SyncState{}
+		toJ.Sync.CopyFrom(data.Sync)
+		if len(data.Contacts) > 0 {
+			toJ.Contacts = presenter.CenterContacts(data.Contacts)
+		}
 
 		w.Header().Set(common.HttpHeaderTenantID, data.TenantID.String())
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
@@ -184,6 +206,10 @@ func getCenter(service center.UseCase) http.Handler {
 func deleteCenter(service center.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error removing center"
+		tenantID, ok := getTenantID(w, r)
+		if !ok {
+			return
+		}
 		vars := mux.Vars(r)
 		id, err := entity.StringToID(vars["id"])
 		if err != nil {
@@ -191,11 +217,15 @@ func deleteCenter(service center.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
-		err = service.DeleteCenter(id)
+		err = service.DeleteCenter(tenantID, id)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
 			return
+		case entity.ErrDeletePending:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(err.Error()))
+			return
 		case entity.ErrNotFound:
 			w.WriteHeader(http.StatusNotFound)
 			_, _ = w.Write([]byte("Center doesn't exist"))
@@ -240,6 +270,11 @@ func updateCenter(service center.UseCase) http.Handler {
 		input.ID = id
 		input.TenantID = tenantID
 		err = service.UpdateCenter(&input)
+		if err == entity.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Center doesn't exist"))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -284,4 +319,20 @@ func MakeCenterHandlers(r *mux.Router, n negroni.Negroni, service center.UseCase
 	r.Handle("/v1/centers/{id}", n.With(
 		negroni.Wrap(updateCenter(service)),
 	)).Methods("PUT", "OPTIONS").Name("updateCenter")
+
+	r.Handle("/v1/centers/{id}/contacts", n.With(
+		negroni.Wrap(listCenterContacts(service)),
+	)).Methods("GET", "OPTIONS").Name("listCenterContacts")
+
+	r.Handle("/v1/centers/{id}/contacts", n.With(
+		negroni.Wrap(createCenterContact(service)),
+	)).Methods("POST", "OPTIONS").Name("createCenterContact")
+
+	r.Handle("/v1/centers/{id}/contacts/{contactId}", n.With(
+		negroni.Wrap(updateCenterContact(service)),
+	)).Methods("PUT", "OPTIONS").Name("updateCenterContact")
+
+	r.Handle("/v1/centers/{id}/contacts/{contactId}", n.With(
+		negroni.Wrap(deleteCenterContact(service)),
+	)).Methods("DELETE", "OPTIONS").Name("deleteCenterContact")
 }
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/usecase/center"
+
+	"sudhagar/glad/api/presenter"
+
+	"sudhagar/glad/entity"
+
+	"github.com/gorilla/mux"
+)
+
+// contactInput a center contact as sent by the caller
+type contactInput struct {
+	Name      string `json:"name"`
+	Phone     string `json:"phone"`
+	Email     string `json:"email"`
+	IsPrimary bool   `json:"isPrimary"`
+}
+
+// getContactsCenter gets the center of the request; writes the error
+// response and returns nil if it can't be read
+func getContactsCenter(w http.ResponseWriter, r *http.Request, service center.UseCase) *entity.Center {
+	tenantID, ok := getTenantID(w, r)
+	if !ok {
+		return nil
+	}
+	id, err := entity.StringToID(mux.Vars(r)["id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return nil
+	}
+	c, err := service.GetCenter(tenantID, id)
+	if err == entity.ErrNotFound || (err == nil && c == nil) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Center doesn't exist"))
+		return nil
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Error reading center:" + err.Error()))
+		return nil
+	}
+	return c
+}
+
+// getCenterContact gets the contact of the request if it belongs to the
+// center; writes the error response and returns nil otherwise
+func getCenterContact(w http.ResponseWriter, r *http.Request, c *entity.Center, service center.UseCase) *entity.CenterContact {
+	id, err := entity.StringToID(mux.Vars(r)["contactId"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return nil
+	}
+	contact, err := service.GetContact(c.TenantID, id)
+	if err == entity.ErrNotFound || (err == nil && contact.CenterID != c.ID) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Center contact doesn't exist"))
+		return nil
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Error reading center contact:" + err.Error()))
+		return nil
+	}
+	return contact
+}
+
+// writeContactError writes the response of a failed contact write
+func writeContactError(w http.ResponseWriter, errorMessage string, err error) {
+	if err == entity.ErrInvalidEntity {
+		w.WriteHeader(http.StatusBadRequest)
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+}
+
+func listCenterContacts(service center.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := getContactsCenter(w, r, service)
+		if c == nil {
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+		if err := json.NewEncoder(w).Encode(presenter.CenterContacts(c.Contacts)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode center contacts"))
+		}
+	})
+}
+
+func createCenterContact(service center.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error adding center contact"
+		c := getContactsCenter(w, r, service)
+		if c == nil {
+			return
+		}
+
+		var input contactInput
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		id, err := service.CreateContact(c.TenantID, c.ID, input.Name, input.Phone, input.Email, input.IsPrimary)
+		if err != nil {
+			writeContactError(w, errorMessage, err)
+			return
+		}
+		toJ := &presenter.CenterContact{
+			ID:        id,
+			Name:      input.Name,
+			Phone:     input.Phone,
+			Email:     input.Email,
+			IsPrimary: input.IsPrimary,
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			log.Println(err.Error())
+		}
+	})
+}
+
+func updateCenterContact(service center.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating center contact"
+		c := getContactsCenter(w, r, service)
+		if c == nil {
+			return
+		}
+		contact := getCenterContact(w, r, c, service)
+		if contact == nil {
+			return
+		}
+
+		var input contactInput
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		contact.Name = input.Name
+		contact.Phone = input.Phone
+		contact.Email = input.Email
+		contact.IsPrimary = input.IsPrimary
+		err = service.UpdateContact(c.TenantID, contact)
+		if err != nil {
+			writeContactError(w, errorMessage, err)
+			return
+		}
+		toJ := &presenter.CenterContact{}
+		toJ.CopyFrom(contact)
+
+		w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			log.Println(err.Error())
+		}
+	})
+}
+
+func deleteCenterContact(service center.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing center contact"
+		c := getContactsCenter(w, r, service)
+		if c == nil {
+			return
+		}
+		contact := getCenterContact(w, r, c, service)
+		if contact == nil {
+			return
+		}
+
+		err := service.DeleteContact(c.TenantID, contact.ID)
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusOK)
+		case entity.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Center contact doesn't exist"))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
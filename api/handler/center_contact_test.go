@@ -0,0 +1,130 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudhagar/glad/api/presenter"
+	"sudhagar/glad/entity"
+
+	mock "sudhagar/glad/usecase/center/mock"
+
+	"github.com/codegangsta/negroni"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContactRouter(t *testing.T) (*mux.Router, *mock.MockUseCase) {
+	controller := gomock.NewController(t)
+	t.Cleanup(controller.Finish)
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeCenterHandlers(r, *n, service)
+	return r, service
+}
+
+func Test_listCenterContacts(t *testing.T) {
+	r, service := newContactRouter(t)
+	path, err := r.GetRoute("listCenterContacts").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/centers/{id}/contacts", path)
+
+	c := &entity.Center{ID: entity.NewID(), TenantID: tenantAlice}
+	c.Contacts = []*entity.CenterContact{{ID: entity.NewID(), CenterID: c.ID, Name: "Alice", Phone: "123", IsPrimary: true}}
+	service.EXPECT().GetCenter(tenantAlice, c.ID).Return(c, nil)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodGet, "/v1/centers/"+c.ID.String()+"/contacts", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var d []presenter.CenterContact
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, "Alice", d[0].Name)
+	assert.True(t, d[0].IsPrimary)
+
+	// unknown center
+	id := entity.NewID()
+	service.EXPECT().GetCenter(tenantAlice, id).Return(nil, entity.ErrNotFound)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodGet, "/v1/centers/"+id.String()+"/contacts", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func Test_createCenterContact(t *testing.T) {
+	r, service := newContactRouter(t)
+
+	c := &entity.Center{ID: entity.NewID(), TenantID: tenantAlice}
+	id := entity.NewID()
+	service.EXPECT().GetCenter(tenantAlice, c.ID).Return(c, nil).Times(2)
+	service.EXPECT().CreateContact(tenantAlice, c.ID, "Alice", "", "alice@example.org", true).Return(id, nil)
+	service.EXPECT().CreateContact(tenantAlice, c.ID, "Alice", "", "", false).
+		Return(entity.ID(entity.IDInvalid), entity.ErrInvalidEntity)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPost, "/v1/centers/"+c.ID.String()+"/contacts",
+		strings.NewReader(`{"name": "Alice", "email": "alice@example.org", "isPrimary": true}`)))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var d presenter.CenterContact
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, id, d.ID)
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPost, "/v1/centers/"+c.ID.String()+"/contacts",
+		strings.NewReader(`{"name": "Alice"}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func Test_updateCenterContact(t *testing.T) {
+	r, service := newContactRouter(t)
+	path, err := r.GetRoute("updateCenterContact").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/centers/{id}/contacts/{contactId}", path)
+
+	c := &entity.Center{ID: entity.NewID(), TenantID: tenantAlice}
+	contact := &entity.CenterContact{ID: entity.NewID(), CenterID: c.ID, Name: "Alice", Phone: "123"}
+	other := &entity.CenterContact{ID: entity.NewID(), CenterID: entity.NewID(), Name: "Bob", Phone: "456"}
+	service.EXPECT().GetCenter(tenantAlice, c.ID).Return(c, nil).Times(2)
+	service.EXPECT().GetContact(tenantAlice, contact.ID).Return(contact, nil)
+	service.EXPECT().GetContact(tenantAlice, other.ID).Return(other, nil)
+	service.EXPECT().UpdateContact(tenantAlice, gomock.Any()).DoAndReturn(func(_ entity.ID, e *entity.CenterContact) error {
+		assert.Equal(t, "Alice Smith", e.Name)
+		assert.Equal(t, "789", e.Phone)
+		return nil
+	})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPut, "/v1/centers/"+c.ID.String()+"/contacts/"+contact.ID.String(),
+		strings.NewReader(`{"name": "Alice Smith", "phone": "789"}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// contact of another center
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPut, "/v1/centers/"+c.ID.String()+"/contacts/"+other.ID.String(),
+		strings.NewReader(`{"name": "Bob"}`)))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func Test_deleteCenterContact(t *testing.T) {
+	r, service := newContactRouter(t)
+
+	c := &entity.Center{ID: entity.NewID(), TenantID: tenantAlice}
+	contact := &entity.CenterContact{ID: entity.NewID(), CenterID: c.ID, Name: "Alice", Phone: "123"}
+	service.EXPECT().GetCenter(tenantAlice, c.ID).Return(c, nil)
+	service.EXPECT().GetContact(tenantAlice, contact.ID).Return(contact, nil)
+	service.EXPECT().DeleteContact(tenantAlice, contact.ID).Return(nil)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodDelete, "/v1/centers/"+c.ID.String()+"/contacts/"+contact.ID.String(), nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -110,6 +110,36 @@ func Test_listCenters_Search(t *testing.T) {
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 }
 
+func Test_listCenters_SyncStatus(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	tmpl := &entity.Center{
+		ID:       entity.NewID(),
+		TenantID: tenantAlice,
+		Name:     "default-0",
+		Sync:     entity.SyncState{Status: entity.SyncPending},
+	}
+	service.EXPECT().GetCount(tmpl.TenantID).Return(1)
+	service.EXPECT().
+		ListCentersBySyncStatus(tmpl.TenantID, entity.SyncPending, gomock.Any(), gomock.Any()).
+		Return([]*entity.Center{tmpl}, nil)
+	ts := httptest.NewServer(listCenters(service))
+	defer ts.Close()
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"?syncStatus=pending", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.Center
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, entity.SyncPending, d[0].Sync.Status)
+}
+
 func Test_createCenter(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -185,13 +215,16 @@ func Test_getCenter(t *testing.T) {
 		Mode:     entity.CenterInPerson,
 	}
 	service.EXPECT().
-		GetCenter(tmpl.ID).
+		GetCenter(tenantAlice, tmpl.ID).
 		Return(tmpl, nil)
 	handler := getCenter(service)
 	r.Handle("/v1/centers/{id}", handler)
 	ts := httptest.NewServer(r)
 	defer ts.Close()
-	res, err := http.Get(ts.URL + "/v1/centers/" + tmpl.ID.String())
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/centers/"+tmpl.ID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 
@@ -218,15 +251,33 @@ func Test_deleteCenter(t *testing.T) {
 	assert.Equal(t, "/v1/centers/{id}", path)
 
 	id := entity.NewID()
-	service.EXPECT().DeleteCenter(id).Return(nil)
+	service.EXPECT().DeleteCenter(tenantAlice, id).Return(nil)
 	handler := deleteCenter(service)
 	req, _ := http.NewRequest("DELETE", "/v1/centers/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/centers/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
 
+func Test_deleteCenterPending(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+
+	id := entity.NewID()
+	service.EXPECT().DeleteCenter(tenantAlice, id).Return(entity.ErrDeletePending)
+	handler := deleteCenter(service)
+	req, _ := http.NewRequest("DELETE", "/v1/centers/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	r.Handle("/v1/centers/{id}", handler).Methods("DELETE", "OPTIONS")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+}
+
 func Test_deleteCenterNonExistent(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -239,9 +290,10 @@ func Test_deleteCenterNonExistent(t *testing.T) {
 	assert.Equal(t, "/v1/centers/{id}", path)
 
 	id := entity.NewID()
-	service.EXPECT().DeleteCenter(id).Return(entity.ErrNotFound)
+	service.EXPECT().DeleteCenter(tenantAlice, id).Return(entity.ErrNotFound)
 	handler := deleteCenter(service)
 	req, _ := http.NewRequest("DELETE", "/v1/centers/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/centers/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -6,6 +6,13 @@
 
 package handler
 
+import (
+	"net/http"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+)
+
 const (
 	apiQueryParamKeyIndex  string = "index"
 	apiQueryParamKeyLimit  string = "limit"
@@ -14,5 +21,22 @@ const (
 	httpParamPage                 = "page"
 	httpParamLimit                = "limit"
 	httpParamType                 = "type"
+	httpParamSyncStatus           = "syncStatus"
+	httpParamUserID               = "userID"
+	httpParamRole                 = "role"
+	httpParamStatus               = "status"
+	httpParamVersion              = "v"
 	maxHttpPaginationLimit        = 50
 )
+
+// getTenantID parses the tenant of the request; writes the error response
+// and returns false if it can't be parsed
+func getTenantID(w http.ResponseWriter, r *http.Request) (entity.ID, bool) {
+	tenantID, err := entity.StringToID(r.Header.Get(common.HttpHeaderTenantID))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Unable to parse tenant id"))
+		return entity.IDInvalid, false
+	}
+	return tenantID, true
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/usecase/config"
+
+	"sudhagar/glad/api/presenter"
+
+	"sudhagar/glad/entity"
+
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+)
+
+// getConfig returns the config of the tenant, or 304 Not Modified with no
+// body if the version the client has is current
+func getConfig(service config.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading config"
+		tenantID, err := entity.StringToID(r.Header.Get(common.HttpHeaderTenantID))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse tenant id"))
+			return
+		}
+
+		// no version at the first request of a client
+		var version int64
+		if v := r.URL.Query().Get(httpParamVersion); v != "" {
+			version, err = strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid config version"))
+				return
+			}
+		}
+
+		data, err := service.GetConfig(tenantID, version)
+		if err == entity.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+		if data == nil {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		toJ := &presenter.Config{}
+		toJ.CopyFrom(data)
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode config"))
+		}
+	})
+}
+
+// MakeConfigHandlers make url handlers
+func MakeConfigHandlers(r *mux.Router, n negroni.Negroni, service config.UseCase) {
+	r.Handle("/v1/config", n.With(
+		negroni.Wrap(getConfig(service)),
+	)).Methods("GET", "OPTIONS").Name("getConfig")
+}
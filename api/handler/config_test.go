@@ -0,0 +1,80 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudhagar/glad/api/presenter"
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+
+	mock "sudhagar/glad/usecase/config/mock"
+
+	"github.com/codegangsta/negroni"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getConfig(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeConfigHandlers(r, *n, service)
+	path, err := r.GetRoute("getConfig").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/config", path)
+
+	c := &entity.Config{
+		Version:        42,
+		Tenant:         entity.ConfigTenant{ID: tenantAlice, Name: "alice", Country: "USA"},
+		Timezones:      []string{"EST", "PST"},
+		CourseStatuses: entity.CourseStatuses,
+		CourseModes:    entity.CourseModes,
+		AccountTypes:   entity.AccountTypes,
+		ProductFormats: entity.ProductFormats,
+	}
+	service.EXPECT().GetConfig(tenantAlice, int64(0)).Return(c, nil)
+	service.EXPECT().GetConfig(tenantAlice, int64(42)).Return(nil, nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/config", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var data presenter.Config
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&data))
+	res.Body.Close()
+	assert.Equal(t, int64(42), data.Version)
+	assert.Equal(t, "alice", data.Tenant.Name)
+	assert.Equal(t, entity.CourseStatuses, data.CourseStatuses)
+	assert.Equal(t, 0, len(data.Endpoints))
+
+	// current
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+"/v1/config?v=42", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	res.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+"/v1/config?v=latest", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	res.Body.Close()
+}
@@ -8,13 +8,19 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"sudhagar/glad/pkg/common"
 	"sudhagar/glad/usecase/course"
+	"sudhagar/glad/usecase/notify"
+	"sudhagar/glad/usecase/timing"
 
+	"sudhagar/glad/api/middleware"
 	"sudhagar/glad/api/presenter"
 
 	"sudhagar/glad/entity"
@@ -32,6 +38,7 @@ func listCourses(service course.UseCase) http.Handler {
 		search := r.URL.Query().Get(httpParamQuery)
 		page, _ := strconv.Atoi(r.URL.Query().Get(httpParamPage))
 		limit, _ := strconv.Atoi(r.URL.Query().Get(httpParamLimit))
+		syncStatus := entity.SyncStatus(r.URL.Query().Get(httpParamSyncStatus))
 		tenantID, err := entity.StringToID(tenant)
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
@@ -39,7 +46,15 @@ func listCourses(service course.UseCase) http.Handler {
 			return
 		}
 
+		if syncStatus != "" && !syncStatus.IsValid() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid sync status"))
+			return
+		}
+
 		switch {
+		case syncStatus != "":
+			data, err = service.ListCoursesBySyncStatus(tenantID, syncStatus, page, limit)
 		case search == "":
 			data, err = service.ListCourses(tenantID, page, limit)
 		default:
@@ -63,51 +78,141 @@ func listCourses(service course.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
-		var toJ []*presenter.Course
-		for _, d := range data {
-			pc := &presenter.Course{
-				ID:           d.ID,
-				Name:         &d.Name,
-				Mode:         &d.Mode,
-				CenterID:     &d.CenterID,
-				Notes:        &d.Notes,
-				Timezone:     &d.Timezone,
-				Status:       &d.Status,
-				MaxAttendees: &d.MaxAttendees,
-				NumAttendees: &d.NumAttendees,
+		if err := json.NewEncoder(w).Encode(courseList(data)); err != nil {
+			w.Header().Set(common.HttpHeaderTenantID, tenant)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode course"))
+		}
+	})
+}
+
+// courseList presents the courses of a list
+func courseList(data []*entity.Course) []*presenter.Course {
+	var toJ []*presenter.Course
+	for _, d := range data {
+		pc := &presenter.Course{
+			ID:           d.ID,
+			Name:         &d.Name,
+			Mode:         &d.Mode,
+			CenterID:     &d.CenterID,
+			Notes:        &d.Notes,
+			Timezone:     &d.Timezone,
+			Status:       &d.Status,
+			MaxAttendees: &d.MaxAttendees,
+			NumAttendees: &d.NumAttendees,
+		}
+		pc.Address = &presenter.Address{}
+		pc.Address.CopyFrom(d.Address)
+		pc.Sync = &presenter.SyncState{}
+		pc.Sync.CopyFrom(d.Sync)
+
+		toJ = append(toJ, pc)
+	}
+	return toJ
+}
+
+// findCoursesByUser lists the courses of the comma separated users; role
+// and status optionally take comma separated lists too
+func findCoursesByUser(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading courses"
+		tenant := r.Header.Get(common.HttpHeaderTenantID)
+		page, _ := strconv.Atoi(r.URL.Query().Get(httpParamPage))
+		limit, _ := strconv.Atoi(r.URL.Query().Get(httpParamLimit))
+		tenantID, err := entity.StringToID(tenant)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse tenant id"))
+			return
+		}
+
+		var userIDs []entity.ID
+		for _, s := range splitParam(r.URL.Query().Get(httpParamUserID)) {
+			id, err := entity.StringToID(s)
+			if err != nil || id == entity.IDInvalid {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid user id " + s))
+				return
 			}
-			pc.Address = &presenter.Address{}
-			pc.Address.CopyFrom(d.Address)
+			userIDs = append(userIDs, id)
+		}
+		if len(userIDs) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Missing user id"))
+			return
+		}
 
-			toJ = append(toJ, pc)
+		var roles []entity.CourseRole
+		for _, s := range splitParam(r.URL.Query().Get(httpParamRole)) {
+			role := entity.CourseRole(s)
+			if !role.IsValid() {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid role " + s))
+				return
+			}
+			roles = append(roles, role)
 		}
-		if err := json.NewEncoder(w).Encode(toJ); err != nil {
-			w.Header().Set(common.HttpHeaderTenantID, tenant)
+
+		var statuses []entity.CourseStatus
+		for _, s := range splitParam(r.URL.Query().Get(httpParamStatus)) {
+			status := entity.CourseStatus(s)
+			if !status.IsValid() {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid status " + s))
+				return
+			}
+			statuses = append(statuses, status)
+		}
+
+		data, err := service.FindCoursesByUser(tenantID, userIDs, roles, statuses, page, limit)
+		w.Header().Set("Content-Type", "application/json")
+		if err == entity.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+		if err != nil {
+			writeCourseError(w, errorMessage, err)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(courseList(data)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte("Unable to encode course"))
 		}
 	})
 }
 
-func createCourse(service course.UseCase) http.Handler {
+// splitParam splits a comma separated query parameter; empty values are
+// dropped
+func splitParam(v string) []string {
+	var values []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+func createCourse(service course.UseCase, timingService timing.UseCase, notifyService notify.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error adding course"
 		var input struct {
 			ExtID        *string
-			Name         string                  `json:"name"`
-			CenterID     entity.ID               `json:"centerId"`
-			ProductID    entity.ID               `json:"productId"`
-			Organizer    []entity.ID             `json:"organizer"`
-			Contact      []entity.ID             `json:"contact"`
-			Teacher      []entity.ID             `json:"teacher"`
-			Notes        string                  `json:"notes"`
-			Status       entity.CourseStatus     `json:"status"`
-			MaxAttendees int32                   `json:"maxAttendees"`
-			Dates        []entity.CourseDateTime `json:"dates"`
-			Timezone     string                  `json:"timezone"`
-			Address      entity.CourseAddress    `json:"address"`
-			Mode         entity.CourseMode       `json:"mode"`
-			Notify       []entity.ID             `json:"notify"`
+			Name         string                    `json:"name"`
+			CenterID     entity.ID                 `json:"centerId"`
+			ProductID    entity.ID                 `json:"productId"`
+			Organizer    []entity.ID               `json:"organizer"`
+			Contact      []entity.ID               `json:"contact"`
+			Teacher      []presenter.CourseTeacher `json:"teacher"`
+			Notes        string                    `json:"notes"`
+			Status       entity.CourseStatus       `json:"status"`
+			MaxAttendees int32                     `json:"maxAttendees"`
+			Dates        []entity.CourseDateTime   `json:"dates"`
+			Timezone     string                    `json:"timezone"`
+			Address      entity.CourseAddress      `json:"address"`
+			Mode         entity.CourseMode         `json:"mode"`
+			Notify       []entity.ID               `json:"notify"`
 		}
 
 		tenant := r.Header.Get(common.HttpHeaderTenantID)
@@ -125,6 +230,32 @@ func createCourse(service course.UseCase) http.Handler {
 			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
 			return
 		}
+		for _, dt := range input.Dates {
+			if err := dt.Validate(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(errorMessage + ": invalid date " + dt.Date))
+				return
+			}
+		}
+		accounts := presenter.CourseAccounts(entity.IDInvalid, input.Teacher, input.Organizer, input.Contact)
+		addCreator(accounts, middleware.AccountFromContext(r.Context()))
+		err = service.CheckCourseAccounts(&entity.Course{TenantID: tenantID, ProductID: input.ProductID}, accounts)
+		if err != nil {
+			writeCourseError(w, errorMessage, err)
+			return
+		}
+		// checked before the course is written, so that a course is not left
+		// without its subscribers
+		err = notifyService.CheckSubscribers(tenantID, input.Notify)
+		if err == entity.ErrNotFound {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ": notify account doesn't exist"))
+			return
+		}
+		if err != nil {
+			writeCourseError(w, errorMessage, err)
+			return
+		}
 
 		id, err := service.CreateCourse(
 			tenantID,
@@ -146,6 +277,31 @@ func createCourse(service course.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
 			return
 		}
+		if len(input.Dates) > 0 {
+			_, err = timingService.ReplaceTimings(id, input.Dates)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+				return
+			}
+		}
+		if len(accounts.Teachers)+len(accounts.Organizers)+len(accounts.Contacts) > 0 {
+			accounts.CourseID = id
+			err = service.UpdateCourseAccounts(&entity.Course{ID: id, TenantID: tenantID, ProductID: input.ProductID}, accounts)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+				return
+			}
+		}
+		for _, accountID := range input.Notify {
+			err = notifyService.Subscribe(id, accountID)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+				return
+			}
+		}
 		toJ := &presenter.Course{
 			ID: id,
 		}
@@ -161,9 +317,31 @@ func createCourse(service course.UseCase) http.Handler {
 	})
 }
 
-func getCourse(service course.UseCase) http.Handler {
+// addCreator adds the account creating a course to its organizers unless it
+// teaches or organizes it already; one that edits its own courses only could
+// not edit the course otherwise
+func addCreator(accounts *entity.CourseAccounts, acc *entity.Account) {
+	if acc == nil || acc.Type.Can(entity.PermissionEditAnyCourse) {
+		return
+	}
+	for _, t := range accounts.Teachers {
+		if t.ID == acc.ID {
+			return
+		}
+	}
+	if slices.Contains(accounts.Organizers, acc.ID) {
+		return
+	}
+	accounts.Organizers = append(accounts.Organizers, acc.ID)
+}
+
+func getCourse(service course.UseCase, timingService timing.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading course"
+		tenantID, ok := getTenantID(w, r)
+		if !ok {
+			return
+		}
 		vars := mux.Vars(r)
 		id, err := entity.StringToID(vars["id"])
 		if err != nil {
@@ -171,7 +349,7 @@ func getCourse(service course.UseCase) http.Handler {
 			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
-		data, err := service.GetCourse(id)
+		data, err := service.GetCourse(tenantID, id)
 		if err != nil && err != entity.ErrNotFound {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -198,6 +376,24 @@ func getCourse(service course.UseCase) http.Handler {
 
 		toJ.Address = &presenter.Address{}
 		toJ.Address.CopyFrom(data.Address)
+		toJ.Sync = &presenter.SyncState{}
+		toJ.Sync.CopyFrom(data.Sync)
+
+		timings, err := timingService.ListTimings(data.ID)
+		if err != nil && err != entity.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+		toJ.Timings = presenter.CourseTimings(timings)
+
+		accounts, err := service.GetCourseAccounts(data.ID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+		toJ.CopyAccounts(accounts)
 
 		w.Header().Set(common.HttpHeaderTenantID, data.TenantID.String())
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
@@ -210,6 +406,10 @@ func getCourse(service course.UseCase) http.Handler {
 func deleteCourse(service course.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error removing course"
+		tenantID, ok := getTenantID(w, r)
+		if !ok {
+			return
+		}
 		vars := mux.Vars(r)
 		id, err := entity.StringToID(vars["id"])
 		if err != nil {
@@ -217,11 +417,18 @@ func deleteCourse(service course.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
-		err = service.DeleteCourse(id)
+		if !checkCourseEditor(w, r, service, id) {
+			return
+		}
+		err = service.DeleteCourse(tenantID, id)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
 			return
+		case entity.ErrDeletePending:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(err.Error()))
+			return
 		case entity.ErrNotFound:
 			w.WriteHeader(http.StatusNotFound)
 			_, _ = w.Write([]byte("Course doesn't exist"))
@@ -246,7 +453,13 @@ func updateCourse(service course.UseCase) http.Handler {
 			return
 		}
 
-		var input entity.Course
+		var input struct {
+			entity.Course
+			// the roles not set are left as they are
+			Organizer *[]entity.ID               `json:"organizer"`
+			Contact   *[]entity.ID               `json:"contact"`
+			Teacher   *[]presenter.CourseTeacher `json:"teacher"`
+		}
 		tenant := r.Header.Get(common.HttpHeaderTenantID)
 		tenantID, err := entity.StringToID(tenant)
 		if err != nil {
@@ -255,6 +468,10 @@ func updateCourse(service course.UseCase) http.Handler {
 			return
 		}
 
+		if !checkCourseEditor(w, r, service, id) {
+			return
+		}
+
 		err = json.NewDecoder(r.Body).Decode(&input)
 		if err != nil {
 			log.Println(err.Error())
@@ -265,12 +482,43 @@ func updateCourse(service course.UseCase) http.Handler {
 
 		input.ID = id
 		input.TenantID = tenantID
-		err = service.UpdateCourse(&input)
+		var accounts *entity.CourseAccounts
+		if input.Teacher != nil || input.Organizer != nil || input.Contact != nil {
+			accounts, err = service.GetCourseAccounts(id)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+				return
+			}
+			if input.Teacher != nil {
+				accounts.Teachers = presenter.CourseAccounts(id, *input.Teacher, nil, nil).Teachers
+			}
+			if input.Organizer != nil {
+				accounts.Organizers = *input.Organizer
+			}
+			if input.Contact != nil {
+				accounts.Contacts = *input.Contact
+			}
+			err = service.CheckCourseAccounts(&input.Course, accounts)
+			if err != nil {
+				writeCourseError(w, errorMessage, err)
+				return
+			}
+		}
+
+		err = service.UpdateCourse(&input.Course, accounts)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			writeCourseError(w, errorMessage, err)
 			return
 		}
+		if accounts != nil {
+			err = service.UpdateCourseAccounts(&input.Course, accounts)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+				return
+			}
+		}
 
 		toJ := &presenter.Course{
 			ID: input.ID,
@@ -287,18 +535,44 @@ func updateCourse(service course.UseCase) http.Handler {
 	})
 }
 
+// writeCourseError writes the response of a failed course write; invalid
+// courses are bad requests
+func writeCourseError(w http.ResponseWriter, errorMessage string, err error) {
+	if err == entity.ErrNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Course doesn't exist"))
+		return
+	}
+	if errors.Is(err, entity.ErrInvalidEntity) {
+		w.WriteHeader(http.StatusBadRequest)
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+}
+
 // MakeCourseHandlers make url handlers
-func MakeCourseHandlers(r *mux.Router, n negroni.Negroni, service course.UseCase) {
+func MakeCourseHandlers(r *mux.Router,
+	n negroni.Negroni,
+	service course.UseCase,
+	timingService timing.UseCase,
+	notifyService notify.UseCase,
+) {
 	r.Handle("/v1/courses", n.With(
 		negroni.Wrap(listCourses(service)),
 	)).Methods("GET", "OPTIONS").Name("listCourses")
 
 	r.Handle("/v1/courses", n.With(
-		negroni.Wrap(createCourse(service)),
+		negroni.Wrap(createCourse(service, timingService, notifyService)),
 	)).Methods("POST", "OPTIONS").Name("createCourse")
 
+	// registered before /v1/courses/{id} which matches it too
+	r.Handle("/v1/courses/findByUser", n.With(
+		negroni.Wrap(findCoursesByUser(service)),
+	)).Methods("GET", "OPTIONS").Name("findCoursesByUser")
+
 	r.Handle("/v1/courses/{id}", n.With(
-		negroni.Wrap(getCourse(service)),
+		negroni.Wrap(getCourse(service, timingService)),
 	)).Methods("GET", "OPTIONS").Name("getCourse")
 
 	r.Handle("/v1/courses/{id}", n.With(
@@ -308,4 +582,24 @@ func MakeCourseHandlers(r *mux.Router, n negroni.Negroni, service course.UseCase
 	r.Handle("/v1/courses/{id}", n.With(
 		negroni.Wrap(updateCourse(service)),
 	)).Methods("PUT", "OPTIONS").Name("updateCourse")
+
+	r.Handle("/v1/courses/{id}/timings", n.With(
+		negroni.Wrap(listTimings(service, timingService)),
+	)).Methods("GET", "OPTIONS").Name("listTimings")
+
+	r.Handle("/v1/courses/{id}/timings", n.With(
+		negroni.Wrap(createTiming(service, timingService)),
+	)).Methods("POST", "OPTIONS").Name("createTiming")
+
+	r.Handle("/v1/courses/{id}/timings", n.With(
+		negroni.Wrap(replaceTimings(service, timingService)),
+	)).Methods("PUT", "OPTIONS").Name("replaceTimings")
+
+	r.Handle("/v1/courses/{id}/timings/{timingId}", n.With(
+		negroni.Wrap(updateTiming(service, timingService)),
+	)).Methods("PUT", "OPTIONS").Name("updateTiming")
+
+	r.Handle("/v1/courses/{id}/timings/{timingId}", n.With(
+		negroni.Wrap(deleteTiming(service, timingService)),
+	)).Methods("DELETE", "OPTIONS").Name("deleteTiming")
 }
@@ -11,13 +11,20 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	"sudhagar/glad/api/middleware"
 	"sudhagar/glad/api/presenter"
 	"sudhagar/glad/entity"
 	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/usecase/center"
+	"sudhagar/glad/usecase/course"
+	"sudhagar/glad/usecase/product"
 
 	mock "sudhagar/glad/usecase/course/mock"
+	notifymock "sudhagar/glad/usecase/notify/mock"
+	timingmock "sudhagar/glad/usecase/timing/mock"
 
 	"github.com/codegangsta/negroni"
 	"github.com/golang/mock/gomock"
@@ -25,13 +32,19 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+const (
+	teacherAlice   entity.ID = 7264348473653242882
+	organizerAlice entity.ID = 7264348473653242883
+)
+
 func Test_listCourses(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
 	r := mux.NewRouter()
 	n := negroni.New()
-	MakeCourseHandlers(r, *n, service)
+	MakeCourseHandlers(r, *n, service, timingService, nil)
 	path, err := r.GetRoute("listCourses").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses", path)
@@ -104,13 +117,122 @@ func Test_listCourses_Search(t *testing.T) {
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 }
 
+func Test_listCourses_SyncStatus(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	syncErr := "SF returned non-200 status: 500"
+	tmpl := &entity.Course{
+		ID:       entity.NewID(),
+		TenantID: tenantAlice,
+		Name:     "default-0",
+		Sync: entity.SyncState{
+			LastSyncDirection: entity.SyncOutbound,
+			Status:            entity.SyncFailed,
+			LastError:         syncErr,
+		},
+	}
+	service.EXPECT().GetCount(tmpl.TenantID).Return(1)
+	service.EXPECT().
+		ListCoursesBySyncStatus(tmpl.TenantID, entity.SyncFailed, gomock.Any(), gomock.Any()).
+		Return([]*entity.Course{tmpl}, nil)
+	ts := httptest.NewServer(listCourses(service))
+	defer ts.Close()
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"?syncStatus=failed", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.Course
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.NotNil(t, d[0].Sync)
+	assert.Equal(t, entity.SyncFailed, d[0].Sync.Status)
+	assert.Equal(t, entity.SyncOutbound, d[0].Sync.LastSyncDirection)
+	assert.Equal(t, syncErr, d[0].Sync.LastError)
+}
+
+func Test_listCourses_InvalidSyncStatus(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	ts := httptest.NewServer(listCourses(service))
+	defer ts.Close()
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"?syncStatus=unknown", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func Test_findCoursesByUser(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeCourseHandlers(r, *n, service, timingService, nil)
+	path, err := r.GetRoute("findCoursesByUser").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/findByUser", path)
+	tmpl := &entity.Course{
+		ID:       entity.NewID(),
+		TenantID: tenantAlice,
+		Name:     "default-0",
+		Status:   entity.CourseActive,
+	}
+	service.EXPECT().
+		FindCoursesByUser(tenantAlice,
+			[]entity.ID{teacherAlice, organizerAlice},
+			[]entity.CourseRole{entity.CourseRoleTeacher, entity.CourseRoleOrganizer},
+			[]entity.CourseStatus{entity.CourseActive},
+			2, 10).
+		Return([]*entity.Course{tmpl}, nil)
+	service.EXPECT().
+		FindCoursesByUser(tenantAlice, []entity.ID{teacherAlice}, nil, nil, 0, 0).
+		Return(nil, entity.ErrNotFound)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := &http.Client{}
+	for query, status := range map[string]int{
+		"userID=" + teacherAlice.String() + "," + organizerAlice.String() +
+			"&role=teacher,organizer&status=active&page=2&limit=10": http.StatusOK,
+		"userID=" + teacherAlice.String(): http.StatusNotFound,
+		"":                                http.StatusBadRequest,
+		"userID=alice":                    http.StatusBadRequest,
+		"userID=" + teacherAlice.String() + "&role=attendee":  http.StatusBadRequest,
+		"userID=" + teacherAlice.String() + "&status=unknown": http.StatusBadRequest,
+	} {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/courses/findByUser?"+query, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := client.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, status, res.StatusCode, query)
+		if status == http.StatusOK {
+			var courses []*presenter.Course
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&courses))
+			assert.Equal(t, 1, len(courses))
+			assert.Equal(t, tmpl.ID, courses[0].ID)
+		}
+		res.Body.Close()
+	}
+}
+
 func Test_createCourse(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
 	r := mux.NewRouter()
 	n := negroni.New()
-	MakeCourseHandlers(r, *n, service)
+	MakeCourseHandlers(r, *n, service, timingService, nil)
 	path, err := r.GetRoute("createCourse").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses", path)
@@ -130,7 +252,13 @@ func Test_createCourse(t *testing.T) {
 			gomock.Any(),
 			gomock.Any()).
 		Return(id, nil)
-	h := createCourse(service)
+	service.EXPECT().
+		CheckCourseAccounts(gomock.Any(), gomock.Any()).
+		Return(nil)
+	notifyService := notifymock.NewMockUseCase(controller)
+	notifyService.EXPECT().CheckSubscribers(tenantAlice, []entity.ID{organizerAlice}).Return(nil)
+	notifyService.EXPECT().Subscribe(id, organizerAlice).Return(nil)
+	h := createCourse(service, timingService, notifyService)
 
 	ts := httptest.NewServer(h)
 	defer ts.Close()
@@ -140,11 +268,13 @@ func Test_createCourse(t *testing.T) {
 		ExtID    string            `json:"extId"`
 		Name     string            `json:"name"`
 		Mode     entity.CourseMode `json:"mode"`
+		Notify   []entity.ID       `json:"notify"`
 		// CenterID entity.ID         `json:"center_id"`
 	}{TenantID: tenantAlice,
-		ExtID: aliceExtID,
-		Name:  "default-0",
-		Mode:  (entity.CourseInPerson),
+		ExtID:  aliceExtID,
+		Name:   "default-0",
+		Mode:   (entity.CourseInPerson),
+		Notify: []entity.ID{organizerAlice},
 		// CenterID: aliceCenterID,
 	}
 	payloadBytes, err := json.Marshal(payload)
@@ -167,13 +297,92 @@ func Test_createCourse(t *testing.T) {
 	assert.Equal(t, tenantAlice.String(), res.Header.Get(common.HttpHeaderTenantID))
 }
 
+func Test_createCourseInvalidSubscriber(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
+	notifyService := notifymock.NewMockUseCase(controller)
+	service.EXPECT().
+		CheckCourseAccounts(gomock.Any(), gomock.Any()).
+		Return(nil)
+	notifyService.EXPECT().
+		CheckSubscribers(tenantAlice, []entity.ID{organizerAlice}).
+		Return(entity.ErrNotFound)
+	// nothing is written
+	h := createCourse(service, timingService, notifyService)
+
+	payloadBytes, err := json.Marshal(map[string]interface{}{
+		"name":   "default-0",
+		"mode":   entity.CourseInPerson,
+		"notify": []entity.ID{organizerAlice},
+	})
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, newTenantRequest(http.MethodPost, "/v1/courses", bytes.NewReader(payloadBytes)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func Test_createCourseCreator(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
+	notifyService := notifymock.NewMockUseCase(controller)
+	notifyService.EXPECT().CheckSubscribers(tenantAlice, gomock.Any()).Return(nil).AnyTimes()
+	h := createCourse(service, timingService, notifyService)
+
+	create := func(acc *entity.Account, organizers []entity.ID) *entity.CourseAccounts {
+		var saved *entity.CourseAccounts
+		id := entity.NewID()
+		service.EXPECT().CheckCourseAccounts(gomock.Any(), gomock.Any()).Return(nil)
+		service.EXPECT().
+			CreateCourse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(id, nil)
+		service.EXPECT().UpdateCourseAccounts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(c *entity.Course, a *entity.CourseAccounts) error {
+				saved = a
+				return nil
+			}).MaxTimes(1)
+
+		payloadBytes, err := json.Marshal(map[string]interface{}{
+			"name":      "default-0",
+			"mode":      entity.CourseInPerson,
+			"organizer": organizers,
+		})
+		assert.Nil(t, err)
+		req := newTenantRequest(http.MethodPost, "/v1/courses", bytes.NewReader(payloadBytes))
+		req = req.WithContext(middleware.WithAccount(req.Context(), acc))
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		return saved
+	}
+	teacher := &entity.Account{ID: teacherAlice, TenantID: tenantAlice, Type: entity.AccountTeacher}
+	admin := &entity.Account{ID: entity.NewID(), TenantID: tenantAlice, Type: entity.AccountAdmin}
+
+	// the creating teacher is linked to the course
+	saved := create(teacher, nil)
+	assert.Equal(t, []entity.ID{teacherAlice}, saved.Organizers)
+	saved = create(teacher, []entity.ID{organizerAlice})
+	assert.Equal(t, []entity.ID{organizerAlice, teacherAlice}, saved.Organizers)
+	// once
+	saved = create(teacher, []entity.ID{teacherAlice})
+	assert.Equal(t, []entity.ID{teacherAlice}, saved.Organizers)
+
+	// an admin edits any course without it
+	assert.Nil(t, create(admin, nil))
+}
+
 func Test_getCourse(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
 	r := mux.NewRouter()
 	n := negroni.New()
-	MakeCourseHandlers(r, *n, service)
+	MakeCourseHandlers(r, *n, service, timingService, nil)
 	path, err := r.GetRoute("getCourse").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses/{id}", path)
@@ -186,13 +395,24 @@ func Test_getCourse(t *testing.T) {
 		Mode:     entity.CourseInPerson,
 	}
 	service.EXPECT().
-		GetCourse(tmpl.ID).
+		GetCourse(tenantAlice, tmpl.ID).
 		Return(tmpl, nil)
-	handler := getCourse(service)
+	timingService.EXPECT().
+		ListTimings(tmpl.ID).
+		Return([]*entity.CourseTiming{{ID: entity.NewID(), CourseID: tmpl.ID,
+			DateTime: entity.CourseDateTime{Date: "2024-05-02", StartTime: "09:00:00"}}}, nil)
+	service.EXPECT().
+		GetCourseAccounts(tmpl.ID).
+		Return(&entity.CourseAccounts{CourseID: tmpl.ID,
+			Teachers: []entity.CourseTeacher{{ID: teacherAlice, IsPrimary: true}}}, nil)
+	handler := getCourse(service, timingService)
 	r.Handle("/v1/courses/{id}", handler)
 	ts := httptest.NewServer(r)
 	defer ts.Close()
-	res, err := http.Get(ts.URL + "/v1/courses/" + tmpl.ID.String())
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/courses/"+tmpl.ID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 
@@ -204,24 +424,46 @@ func Test_getCourse(t *testing.T) {
 	assert.Equal(t, tmpl.ID, d.ID)
 	assert.Equal(t, tmpl.Name, *d.Name)
 	assert.Equal(t, tmpl.Mode, *d.Mode)
+	assert.Equal(t, 1, len(d.Timings))
+	assert.Equal(t, "2024-05-02", d.Timings[0].Date)
+	assert.Equal(t, []*presenter.CourseTeacher{{ID: teacherAlice, IsPrimary: true}}, d.Teachers)
 	assert.Equal(t, tenantAlice.String(), res.Header.Get(common.HttpHeaderTenantID))
 }
 
+func Test_getCourseOtherTenant(t *testing.T) {
+	r, service, _ := newTimingRouter(t)
+
+	// the course is of tenantAlice
+	id := entity.NewID()
+	service.EXPECT().GetCourse(tenantAlice+1, id).Return(nil, entity.ErrNotFound)
+	service.EXPECT().DeleteCourse(tenantAlice+1, id).Return(entity.ErrNotFound)
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/v1/courses/"+id.String(), nil)
+		req.Header.Set(common.HttpHeaderTenantID, (tenantAlice + 1).String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code, method)
+	}
+}
+
 func Test_deleteCourse(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
 	r := mux.NewRouter()
 	n := negroni.New()
-	MakeCourseHandlers(r, *n, service)
+	MakeCourseHandlers(r, *n, service, timingService, nil)
 	path, err := r.GetRoute("deleteCourse").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses/{id}", path)
 
 	id := entity.NewID()
-	service.EXPECT().DeleteCourse(id).Return(nil)
+	service.EXPECT().DeleteCourse(tenantAlice, id).Return(nil)
 	handler := deleteCourse(service)
 	req, _ := http.NewRequest("DELETE", "/v1/courses/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/courses/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -232,21 +474,75 @@ func Test_deleteCourseNonExistent(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
 	r := mux.NewRouter()
 	n := negroni.New()
-	MakeCourseHandlers(r, *n, service)
+	MakeCourseHandlers(r, *n, service, timingService, nil)
 	path, err := r.GetRoute("deleteCourse").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses/{id}", path)
 
 	id := entity.NewID()
-	service.EXPECT().DeleteCourse(id).Return(entity.ErrNotFound)
+	service.EXPECT().DeleteCourse(tenantAlice, id).Return(entity.ErrNotFound)
 	handler := deleteCourse(service)
 	req, _ := http.NewRequest("DELETE", "/v1/courses/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/courses/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
 
-// TODO: Test case for updating course
+func Test_updateCourse_Accounts(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeCourseHandlers(r, *n, service, timingService, nil)
+
+	id := entity.NewID()
+	saved := &entity.CourseAccounts{CourseID: id, Organizers: []entity.ID{organizerAlice}}
+	want := &entity.CourseAccounts{CourseID: id,
+		Teachers:   []entity.CourseTeacher{{ID: teacherAlice, IsPrimary: true}},
+		Organizers: []entity.ID{organizerAlice},
+	}
+	service.EXPECT().GetCourseAccounts(id).Return(saved, nil)
+	service.EXPECT().CheckCourseAccounts(gomock.Any(), want).Return(nil)
+	service.EXPECT().UpdateCourse(gomock.Any(), gomock.Any()).Return(nil)
+	service.EXPECT().UpdateCourseAccounts(gomock.Any(), want).Return(nil)
+
+	// the organizers are left as they are
+	req, _ := http.NewRequest(http.MethodPut, "/v1/courses/"+id.String(),
+		bytes.NewReader([]byte(`{"name": "default-0", "teacher": [{"id": 7264348473653242882, "is_primary": true}]}`)))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// too many contacts
+	service.EXPECT().GetCourseAccounts(id).Return(&entity.CourseAccounts{CourseID: id}, nil)
+	service.EXPECT().CheckCourseAccounts(gomock.Any(), gomock.Any()).Return(entity.ErrInvalidEntity)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/courses/"+id.String(),
+		bytes.NewReader([]byte(`{"name": "default-0", "contact": [1, 2]}`)))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+// The sync state and SF id writes take only an id; the handlers can't reach
+// them, so no id of a request is written without its tenant
+func Test_syncWritesNotInUseCases(t *testing.T) {
+	for _, uc := range []reflect.Type{
+		reflect.TypeOf((*course.UseCase)(nil)).Elem(),
+		reflect.TypeOf((*center.UseCase)(nil)).Elem(),
+		reflect.TypeOf((*product.UseCase)(nil)).Elem(),
+	} {
+		for _, name := range []string{"UpdateSyncState", "UpdateExtID"} {
+			_, ok := uc.MethodByName(name)
+			assert.False(t, ok, uc.String()+"."+name)
+		}
+	}
+}
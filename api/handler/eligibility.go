@@ -0,0 +1,178 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/usecase/account"
+	"sudhagar/glad/usecase/eligibility"
+
+	"sudhagar/glad/api/presenter"
+
+	"sudhagar/glad/entity"
+
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+)
+
+// getEligibilityAccount gets the account of the request; writes the error
+// response and returns nil if it can't be read
+func getEligibilityAccount(w http.ResponseWriter, r *http.Request, accountService account.UseCase) *entity.Account {
+	tenantID, err := entity.StringToID(r.Header.Get(common.HttpHeaderTenantID))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Unable to parse tenant id"))
+		return nil
+	}
+	data, err := accountService.GetAccountByName(tenantID, mux.Vars(r)["username"])
+	if err == entity.ErrNotFound || (err == nil && data == nil) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Account doesn't exist"))
+		return nil
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Error reading account:" + err.Error()))
+		return nil
+	}
+	return data
+}
+
+func listEligibility(service eligibility.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading teacher eligibility"
+		acc := getEligibilityAccount(w, r, accountService)
+		if acc == nil {
+			return
+		}
+		et := entity.EligibilityType(r.URL.Query().Get(httpParamType))
+		if et != "" && !et.IsValid() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid eligibility type"))
+			return
+		}
+
+		data, err := service.ListEligibility(acc.ID)
+		if err != nil && err != entity.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		toJ := []*presenter.TeacherEligibility{}
+		for _, e := range data {
+			// primary eligibility covers assisting
+			if et == entity.EligibilityPrimary && !e.Allows(true) {
+				continue
+			}
+			var te presenter.TeacherEligibility
+			te.CopyFrom(e)
+			toJ = append(toJ, &te)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, acc.TenantID.String())
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode teacher eligibility"))
+		}
+	})
+}
+
+func grantEligibility(service eligibility.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error granting teacher eligibility"
+		acc := getEligibilityAccount(w, r, accountService)
+		if acc == nil {
+			return
+		}
+		productID, err := entity.StringToID(mux.Vars(r)["productId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		var input struct {
+			Type entity.EligibilityType `json:"type"`
+		}
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		err = service.GrantEligibility(acc.TenantID, acc.ID, productID, input.Type)
+		if err != nil {
+			if errors.Is(err, entity.ErrInvalidEntity) {
+				w.WriteHeader(http.StatusBadRequest)
+			} else if err == entity.ErrNotFound {
+				w.WriteHeader(http.StatusNotFound)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, acc.TenantID.String())
+		w.WriteHeader(http.StatusOK)
+		toJ := &presenter.TeacherEligibility{ProductID: productID, Type: input.Type}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			log.Println(err.Error())
+		}
+	})
+}
+
+func revokeEligibility(service eligibility.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error revoking teacher eligibility"
+		acc := getEligibilityAccount(w, r, accountService)
+		if acc == nil {
+			return
+		}
+		productID, err := entity.StringToID(mux.Vars(r)["productId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		err = service.RevokeEligibility(acc.ID, productID)
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusOK)
+		case entity.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Teacher eligibility doesn't exist"))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// MakeEligibilityHandlers make url handlers
+func MakeEligibilityHandlers(r *mux.Router, n negroni.Negroni, service eligibility.UseCase, accountService account.UseCase) {
+	r.Handle("/v1/accounts/{username}/eligibility", n.With(
+		negroni.Wrap(listEligibility(service, accountService)),
+	)).Methods("GET", "OPTIONS").Name("listEligibility")
+
+	r.Handle("/v1/accounts/{username}/eligibility/{productId}", n.With(
+		negroni.Wrap(grantEligibility(service, accountService)),
+	)).Methods("PUT", "OPTIONS").Name("grantEligibility")
+
+	r.Handle("/v1/accounts/{username}/eligibility/{productId}", n.With(
+		negroni.Wrap(revokeEligibility(service, accountService)),
+	)).Methods("DELETE", "OPTIONS").Name("revokeEligibility")
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudhagar/glad/api/presenter"
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+
+	accountmock "sudhagar/glad/usecase/account/mock"
+	mock "sudhagar/glad/usecase/eligibility/mock"
+
+	"github.com/codegangsta/negroni"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_listEligibility(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	accountService := accountmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeEligibilityHandlers(r, *n, service, accountService)
+	path, err := r.GetRoute("listEligibility").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/accounts/{username}/eligibility", path)
+
+	acc := &entity.Account{ID: teacherAlice, TenantID: tenantAlice, Username: "alice", Type: entity.AccountTeacher}
+	product1, product2 := entity.NewID(), entity.NewID()
+	accountService.EXPECT().GetAccountByName(tenantAlice, "alice").Return(acc, nil).Times(2)
+	service.EXPECT().ListEligibility(teacherAlice).Return([]*entity.TeacherEligibility{
+		{ProductID: product1, TeacherID: teacherAlice, Type: entity.EligibilityPrimary},
+		{ProductID: product2, TeacherID: teacherAlice, Type: entity.EligibilityAssistant},
+	}, nil).Times(2)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/accounts/alice/eligibility", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var d []*presenter.TeacherEligibility
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, 2, len(d))
+
+	// the products the teacher can lead
+	req = httptest.NewRequest(http.MethodGet, "/v1/accounts/alice/eligibility?type=primary", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	d = nil
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, []*presenter.TeacherEligibility{{ProductID: product1, Type: entity.EligibilityPrimary}}, d)
+}
+
+func Test_grantAndRevokeEligibility(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	accountService := accountmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeEligibilityHandlers(r, *n, service, accountService)
+
+	acc := &entity.Account{ID: teacherAlice, TenantID: tenantAlice, Username: "alice", Type: entity.AccountTeacher}
+	productID, otherProduct := entity.NewID(), entity.NewID()
+	accountService.EXPECT().GetAccountByName(tenantAlice, "alice").Return(acc, nil).AnyTimes()
+	accountService.EXPECT().GetAccountByName(tenantAlice, "bob").Return(nil, entity.ErrNotFound)
+	service.EXPECT().GrantEligibility(tenantAlice, teacherAlice, productID, entity.EligibilityPrimary).Return(nil)
+	service.EXPECT().GrantEligibility(tenantAlice, teacherAlice, productID, entity.EligibilityType("lead")).
+		Return(entity.ErrInvalidEntity)
+	service.EXPECT().GrantEligibility(tenantAlice, teacherAlice, otherProduct, entity.EligibilityPrimary).
+		Return(entity.ErrNotFound)
+	service.EXPECT().RevokeEligibility(teacherAlice, productID).Return(nil)
+
+	for _, tc := range []struct {
+		method, path, body string
+		code               int
+	}{
+		{http.MethodPut, "/v1/accounts/alice/eligibility/" + productID.String(), `{"type": "primary"}`, http.StatusOK},
+		{http.MethodPut, "/v1/accounts/alice/eligibility/" + productID.String(), `{"type": "lead"}`, http.StatusBadRequest},
+		{http.MethodPut, "/v1/accounts/bob/eligibility/" + productID.String(), `{"type": "primary"}`, http.StatusNotFound},
+		{http.MethodPut, "/v1/accounts/alice/eligibility/" + otherProduct.String(), `{"type": "primary"}`, http.StatusNotFound},
+		{http.MethodDelete, "/v1/accounts/alice/eligibility/" + productID.String(), ``, http.StatusOK},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, tc.code, rr.Code, tc.method+" "+tc.path+" "+tc.body)
+	}
+}
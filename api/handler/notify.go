@@ -0,0 +1,134 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"sudhagar/glad/api/middleware"
+	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/usecase/course"
+	"sudhagar/glad/usecase/notify"
+
+	"sudhagar/glad/entity"
+
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+)
+
+func listSubscribers(service course.UseCase, notifyService notify.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading course subscribers"
+		c := getEditedCourse(w, r, service)
+		if c == nil {
+			return
+		}
+		data, err := notifyService.ListSubscribers(c.ID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+		if data == nil {
+			data = []entity.ID{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode course subscribers"))
+		}
+	})
+}
+
+func subscribe(service course.UseCase, notifyService notify.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error subscribing to course"
+		c := getTimingsCourse(w, r, service)
+		if c == nil {
+			return
+		}
+		accountID, err := entity.StringToID(mux.Vars(r)["accountId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		// others are (un)subscribed by the editors of the course only
+		if acc := middleware.AccountFromContext(r.Context()); acc != nil && acc.ID != accountID &&
+			!checkCourseEditor(w, r, service, c.ID) {
+			return
+		}
+
+		err = notifyService.Subscribe(c.ID, accountID)
+		switch {
+		case err == nil:
+			w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+			w.WriteHeader(http.StatusOK)
+		case err == entity.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account doesn't exist"))
+		case errors.Is(err, entity.ErrInvalidEntity):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+		}
+	})
+}
+
+func unsubscribe(service course.UseCase, notifyService notify.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error unsubscribing from course"
+		c := getTimingsCourse(w, r, service)
+		if c == nil {
+			return
+		}
+		accountID, err := entity.StringToID(mux.Vars(r)["accountId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		// others are (un)subscribed by the editors of the course only
+		if acc := middleware.AccountFromContext(r.Context()); acc != nil && acc.ID != accountID &&
+			!checkCourseEditor(w, r, service, c.ID) {
+			return
+		}
+
+		err = notifyService.Unsubscribe(c.ID, accountID)
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusOK)
+		case entity.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account isn't subscribed to the course"))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// MakeNotifyHandlers make url handlers
+func MakeNotifyHandlers(r *mux.Router, n negroni.Negroni, service course.UseCase, notifyService notify.UseCase) {
+	r.Handle("/v1/courses/{id}/notify", n.With(
+		negroni.Wrap(listSubscribers(service, notifyService)),
+	)).Methods("GET", "OPTIONS").Name("listSubscribers")
+
+	r.Handle("/v1/courses/{id}/notify/{accountId}", n.With(
+		negroni.Wrap(subscribe(service, notifyService)),
+	)).Methods("PUT", "OPTIONS").Name("subscribe")
+
+	r.Handle("/v1/courses/{id}/notify/{accountId}", n.With(
+		negroni.Wrap(unsubscribe(service, notifyService)),
+	)).Methods("DELETE", "OPTIONS").Name("unsubscribe")
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudhagar/glad/entity"
+
+	mock "sudhagar/glad/usecase/course/mock"
+	notifymock "sudhagar/glad/usecase/notify/mock"
+
+	"github.com/codegangsta/negroni"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNotifyRouter(t *testing.T) (*mux.Router, *mock.MockUseCase, *notifymock.MockUseCase) {
+	controller := gomock.NewController(t)
+	t.Cleanup(controller.Finish)
+	service := mock.NewMockUseCase(controller)
+	notifyService := notifymock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeNotifyHandlers(r, *n, service, notifyService)
+	return r, service, notifyService
+}
+
+func Test_listSubscribers(t *testing.T) {
+	r, service, notifyService := newNotifyRouter(t)
+	path, err := r.GetRoute("listSubscribers").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{id}/notify", path)
+
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	subscriber := entity.NewID()
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil).Times(2)
+	notifyService.EXPECT().ListSubscribers(c.ID).Return([]entity.ID{subscriber}, nil)
+	notifyService.EXPECT().ListSubscribers(c.ID).Return(nil, nil)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodGet, "/v1/courses/"+c.ID.String()+"/notify", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var d []entity.ID
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, []entity.ID{subscriber}, d)
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodGet, "/v1/courses/"+c.ID.String()+"/notify", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "[]\n", rr.Body.String())
+}
+
+func Test_subscribe(t *testing.T) {
+	r, service, notifyService := newNotifyRouter(t)
+	path, err := r.GetRoute("subscribe").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{id}/notify/{accountId}", path)
+
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil).AnyTimes()
+	ok, other, unknown := entity.NewID(), entity.NewID(), entity.NewID()
+	notifyService.EXPECT().Subscribe(c.ID, ok).Return(nil)
+	notifyService.EXPECT().Subscribe(c.ID, other).
+		Return(fmt.Errorf("%w: account of another tenant", entity.ErrInvalidEntity))
+	notifyService.EXPECT().Subscribe(c.ID, unknown).Return(entity.ErrNotFound)
+
+	for _, tc := range []struct {
+		accountID string
+		code      int
+	}{
+		{ok.String(), http.StatusOK},
+		{other.String(), http.StatusBadRequest},
+		{unknown.String(), http.StatusNotFound},
+		{"bob", http.StatusBadRequest},
+	} {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, newTenantRequest(http.MethodPut,
+			"/v1/courses/"+c.ID.String()+"/notify/"+tc.accountID, nil))
+		assert.Equal(t, tc.code, rr.Code, tc.accountID)
+	}
+}
+
+func Test_unsubscribe(t *testing.T) {
+	r, service, notifyService := newNotifyRouter(t)
+	path, err := r.GetRoute("unsubscribe").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{id}/notify/{accountId}", path)
+
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	accountID := entity.NewID()
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil).Times(2)
+	notifyService.EXPECT().Unsubscribe(c.ID, accountID).Return(nil)
+	notifyService.EXPECT().Unsubscribe(c.ID, accountID).Return(entity.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodDelete,
+		"/v1/courses/"+c.ID.String()+"/notify/"+accountID.String(), nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodDelete,
+		"/v1/courses/"+c.ID.String()+"/notify/"+accountID.String(), nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"net/http"
+
+	"sudhagar/glad/api/middleware"
+	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/course"
+)
+
+// RoutePermissions the permission needed on each route, by route name; the
+// routes not listed are for admins only. The course edits also check that the
+// account teaches or organizes the course. The Salesforce callouts under
+// /sync are authenticated by their sync secret instead.
+var RoutePermissions = map[string]entity.Permission{
+	"getConfig": entity.PermissionRead,
+
+	"listTenants":  entity.PermissionManageTenants,
+	"createTenant": entity.PermissionManageTenants,
+	"getTenant":    entity.PermissionRead,
+	"updateTenant": entity.PermissionManageTenants,
+	"deleteTenant": entity.PermissionManageTenants,
+	"login":        entity.PermissionRead,
+
+	"listAccounts":      entity.PermissionRead,
+	"getAccount":        entity.PermissionRead,
+	"updateAccount":     entity.PermissionManageAccounts,
+	"deleteAccount":     entity.PermissionManageAccounts,
+	"listEligibility":   entity.PermissionRead,
+	"grantEligibility":  entity.PermissionManageAccounts,
+	"revokeEligibility": entity.PermissionManageAccounts,
+
+	"listProducts":  entity.PermissionRead,
+	"getProduct":    entity.PermissionRead,
+	"createProduct": entity.PermissionManageProducts,
+	"updateProduct": entity.PermissionManageProducts,
+	"deleteProduct": entity.PermissionManageProducts,
+
+	"listCenters":         entity.PermissionRead,
+	"getCenter":           entity.PermissionRead,
+	"createCenter":        entity.PermissionManageCenters,
+	"updateCenter":        entity.PermissionManageCenters,
+	"deleteCenter":        entity.PermissionManageCenters,
+	"listCenterContacts":  entity.PermissionRead,
+	"createCenterContact": entity.PermissionManageCenters,
+	"updateCenterContact": entity.PermissionManageCenters,
+	"deleteCenterContact": entity.PermissionManageCenters,
+
+	"listCourses":       entity.PermissionRead,
+	"findCoursesByUser": entity.PermissionRead,
+	"getCourse":         entity.PermissionRead,
+	"createCourse":      entity.PermissionCreateCourse,
+	"updateCourse":      entity.PermissionEditOwnCourse,
+	"deleteCourse":      entity.PermissionEditOwnCourse,
+	"listTimings":       entity.PermissionRead,
+	"createTiming":      entity.PermissionEditOwnCourse,
+	"updateTiming":      entity.PermissionEditOwnCourse,
+	"replaceTimings":    entity.PermissionEditOwnCourse,
+	"deleteTiming":      entity.PermissionEditOwnCourse,
+	"listSubscribers":   entity.PermissionEditOwnCourse,
+	"subscribe":         entity.PermissionSubscribe,
+	"unsubscribe":       entity.PermissionSubscribe,
+
+	"listSyncControls": entity.PermissionManageSync,
+	"pauseSync":        entity.PermissionManageSync,
+	"resumeSync":       entity.PermissionManageSync,
+	"throttleSync":     entity.PermissionManageSync,
+	"releaseHeld":      entity.PermissionManageSync,
+	"exportTombstones": entity.PermissionManageSync,
+	"previewExport":    entity.PermissionManageSync,
+	"exportOutbox":     entity.PermissionManageSync,
+	"exportCourse":     entity.PermissionManageSync,
+}
+
+// checkCourseEditor checks that the authenticated account of the request can
+// edit the course; writes the error response and returns false if it can't.
+// Any request can without authentication.
+func checkCourseEditor(w http.ResponseWriter, r *http.Request, service course.UseCase, courseID entity.ID) bool {
+	acc := middleware.AccountFromContext(r.Context())
+	if acc == nil {
+		return true
+	}
+	err := service.CheckCourseEditor(acc, courseID)
+	switch err {
+	case nil:
+		return true
+	case entity.ErrForbidden:
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("Account can't edit the course"))
+	case entity.ErrNotFound:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Course doesn't exist"))
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Error reading course:" + err.Error()))
+	}
+	return false
+}
+
+// getEditedCourse gets the course of the request if the authenticated
+// account can edit it; writes the error response and returns nil otherwise
+func getEditedCourse(w http.ResponseWriter, r *http.Request, service course.UseCase) *entity.Course {
+	c := getTimingsCourse(w, r, service)
+	if c == nil || !checkCourseEditor(w, r, service, c.ID) {
+		return nil
+	}
+	return c
+}
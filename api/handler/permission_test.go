@@ -0,0 +1,124 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudhagar/glad/api/middleware"
+	"sudhagar/glad/api/syncer"
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+
+	"github.com/codegangsta/negroni"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RoutePermissions(t *testing.T) {
+	r := mux.NewRouter()
+	n := *negroni.New()
+	MakeAccountHandlers(r, n, nil)
+	MakeCenterHandlers(r, n, nil)
+	MakeConfigHandlers(r, n, nil)
+	MakeCourseHandlers(r, n, nil, nil, nil)
+	MakeEligibilityHandlers(r, n, nil, nil)
+	MakeNotifyHandlers(r, n, nil, nil)
+	MakeProductHandlers(r, n, nil)
+	MakeTenantHandlers(r, n, nil)
+	syncer.MakeSyncHandlers(r.PathPrefix(syncer.PathPrefix).Subrouter(), n, n)
+
+	// the callouts are authenticated by their sync secret
+	callouts := map[string]bool{
+		"syncAccount": true, "syncCenter": true, "syncCourse": true, "syncProduct": true, "syncTiming": true,
+	}
+
+	// every route has a permission and every permission a route
+	names := map[string]bool{}
+	_ = r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		if route.GetName() == "" || callouts[route.GetName()] {
+			return nil
+		}
+		names[route.GetName()] = true
+		_, ok := RoutePermissions[route.GetName()]
+		assert.True(t, ok, route.GetName())
+		return nil
+	})
+	for name := range RoutePermissions {
+		assert.True(t, names[name], name)
+	}
+}
+
+// withAccount serves the request as authenticated by the account
+func withAccount(req *http.Request, a *entity.Account) *http.Request {
+	return req.WithContext(middleware.WithAccount(req.Context(), a))
+}
+
+// newTenantRequest creates a test request for tenantAlice
+func newTenantRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	return req
+}
+
+func Test_checkCourseEditor(t *testing.T) {
+	r, service, timingService := newTimingRouter(t)
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	teacher := &entity.Account{ID: entity.NewID(), TenantID: tenantAlice, Type: entity.AccountTeacher}
+	other := &entity.Account{ID: entity.NewID(), TenantID: tenantAlice, Type: entity.AccountTeacher}
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil).AnyTimes()
+	service.EXPECT().CheckCourseEditor(teacher, c.ID).Return(nil).AnyTimes()
+	service.EXPECT().CheckCourseEditor(other, c.ID).Return(entity.ErrForbidden).AnyTimes()
+
+	// the teacher of the course
+	timingService.EXPECT().ReplaceTimings(c.ID, gomock.Any()).Return(nil, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, withAccount(newTenantRequest(http.MethodPut,
+		"/v1/courses/"+c.ID.String()+"/timings", strings.NewReader(`[]`)), teacher))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// another teacher
+	for _, req := range []*http.Request{
+		newTenantRequest(http.MethodPut, "/v1/courses/"+c.ID.String()+"/timings", strings.NewReader(`[]`)),
+		newTenantRequest(http.MethodPut, "/v1/courses/"+c.ID.String(), strings.NewReader(`{}`)),
+		newTenantRequest(http.MethodDelete, "/v1/courses/"+c.ID.String(), nil),
+		newTenantRequest(http.MethodDelete, "/v1/courses/"+c.ID.String()+"/timings/"+entity.NewID().String(), nil),
+	} {
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, withAccount(req, other))
+		assert.Equal(t, http.StatusForbidden, rr.Code, req.Method+" "+req.URL.Path)
+	}
+}
+
+func Test_subscribeOthers(t *testing.T) {
+	r, service, notifyService := newNotifyRouter(t)
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	member := &entity.Account{ID: entity.NewID(), TenantID: tenantAlice, Type: entity.AccountMember}
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil).AnyTimes()
+
+	// oneself
+	notifyService.EXPECT().Subscribe(c.ID, member.ID).Return(nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, withAccount(newTenantRequest(http.MethodPut,
+		"/v1/courses/"+c.ID.String()+"/notify/"+member.ID.String(), nil), member))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// others
+	service.EXPECT().CheckCourseEditor(member, c.ID).Return(entity.ErrForbidden).Times(2)
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, withAccount(newTenantRequest(method,
+			"/v1/courses/"+c.ID.String()+"/notify/"+entity.NewID().String(), nil), member))
+		assert.Equal(t, http.StatusForbidden, rr.Code, method)
+	}
+}
@@ -30,6 +30,7 @@ func listProducts(service product.UseCase) http.Handler {
 		search := r.URL.Query().Get(httpParamQuery)
 		page, _ := strconv.Atoi(r.URL.Query().Get(httpParamPage))
 		limit, _ := strconv.Atoi(r.URL.Query().Get(httpParamLimit))
+		syncStatus := entity.SyncStatus(r.URL.Query().Get(httpParamSyncStatus))
 
 		tenantID, err := entity.StringToID(tenant)
 		if err != nil {
@@ -38,7 +39,15 @@ func listProducts(service product.UseCase) http.Handler {
 			return
 		}
 
+		if syncStatus != "" && !syncStatus.IsValid() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid sync status"))
+			return
+		}
+
 		switch {
+		case syncStatus != "":
+			data, err = service.ListProductsBySyncStatus(tenantID, syncStatus, page, limit)
 		case search == "":
 			data, err = service.ListProducts(tenantID, page, limit)
 		default:
@@ -62,7 +71,7 @@ func listProducts(service product.UseCase) http.Handler {
 
 		var toJ []*presenter.Product
 		for _, d := range data {
-			toJ = append(toJ, &presenter.Product{
+			pp := &presenter.Product{
 				ID:               d.ID,
 				ExtName:          d.ExtName,
 				Title:            d.Title,
@@ -73,7 +82,11 @@ func listProducts(service product.UseCase) http.Handler {
 				MaxAttendees:     d.MaxAttendees,
 				Format:           d.Format,
 				IsAutoApprove:    d.IsAutoApprove,
-			})
+			}
+			pp.Sync = &presenter.SyncState{}
+			pp.Sync.CopyFrom(d.Sync)
+
+			toJ = append(toJ, pp)
 		}
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -158,6 +171,10 @@ func createProduct(service product.UseCase) http.Handler {
 func getProduct(service product.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading product"
+		tenantID, ok := getTenantID(w, r)
+		if !ok {
+			return
+		}
 		vars := mux.Vars(r)
 		id, err := entity.StringToID(vars["id"])
 		if err != nil {
@@ -165,7 +182,7 @@ func getProduct(service product.UseCase) http.Handler {
 			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
-		data, err := service.GetProduct(id)
+		data, err := service.GetProduct(tenantID, id)
 		if err != nil && err != entity.ErrNotFound {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -190,6 +207,8 @@ func getProduct(service product.UseCase) http.Handler {
 			Format:           data.Format,
 			IsAutoApprove:    data.IsAutoApprove,
 		}
+		toJ.Sync = &presenter.SyncState{}
+		toJ.Sync.CopyFrom(data.Sync)
 
 		w.Header().Set(common.HttpHeaderTenantID, data.TenantID.String())
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
@@ -202,6 +221,10 @@ func getProduct(service product.UseCase) http.Handler {
 func deleteProduct(service product.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error removing product"
+		tenantID, ok := getTenantID(w, r)
+		if !ok {
+			return
+		}
 		vars := mux.Vars(r)
 		id, err := entity.StringToID(vars["id"])
 		if err != nil {
@@ -209,11 +232,15 @@ func deleteProduct(service product.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
-		err = service.DeleteProduct(id)
+		err = service.DeleteProduct(tenantID, id)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
 			return
+		case entity.ErrDeletePending:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(err.Error()))
+			return
 		case entity.ErrNotFound:
 			w.WriteHeader(http.StatusNotFound)
 			_, _ = w.Write([]byte("Product doesn't exist"))
@@ -258,6 +285,11 @@ func updateProduct(service product.UseCase) http.Handler {
 		input.ID = id
 		input.TenantID = tenantID
 		err = service.UpdateProduct(&input)
+		if err == entity.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Product doesn't exist"))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
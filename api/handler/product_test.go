@@ -120,6 +120,41 @@ func Test_listProducts_Search(t *testing.T) {
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 }
 
+func Test_listProducts_SyncStatus(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	tmpl := &entity.Product{
+		ID:       entity.NewID(),
+		TenantID: tenantAlice,
+		ExtID:    aliceExtID,
+		ExtName:  "product-1",
+		Title:    "Product One",
+		CType:    "TYPE-1",
+		Sync:     entity.SyncState{Status: entity.SyncSynced, LastSyncDirection: entity.SyncInbound},
+	}
+	service.EXPECT().GetCount(tmpl.TenantID).Return(1)
+	service.EXPECT().
+		ListProductsBySyncStatus(tmpl.TenantID, entity.SyncSynced, gomock.Any(), gomock.Any()).
+		Return([]*entity.Product{tmpl}, nil)
+
+	ts := httptest.NewServer(listProducts(service))
+	defer ts.Close()
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"?syncStatus=synced", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.Product
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, entity.SyncSynced, d[0].Sync.Status)
+	assert.Equal(t, entity.SyncInbound, d[0].Sync.LastSyncDirection)
+}
+
 func Test_createProduct(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -225,13 +260,16 @@ func Test_getProduct(t *testing.T) {
 		Format:       entity.ProductFormatInPerson,
 	}
 	service.EXPECT().
-		GetProduct(tmpl.ID).
+		GetProduct(tenantAlice, tmpl.ID).
 		Return(tmpl, nil)
 	handler := getProduct(service)
 	r.Handle("/v1/products/{id}", handler)
 	ts := httptest.NewServer(r)
 	defer ts.Close()
-	res, err := http.Get(ts.URL + "/v1/products/" + tmpl.ID.String())
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/products/"+tmpl.ID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 
@@ -261,9 +299,10 @@ func Test_deleteProduct(t *testing.T) {
 	assert.Equal(t, "/v1/products/{id}", path)
 
 	id := entity.NewID()
-	service.EXPECT().DeleteProduct(id).Return(nil)
+	service.EXPECT().DeleteProduct(tenantAlice, id).Return(nil)
 	handler := deleteProduct(service)
 	req, _ := http.NewRequest("DELETE", "/v1/products/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/products/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -282,9 +321,10 @@ func Test_deleteProductNonExistent(t *testing.T) {
 	assert.Equal(t, "/v1/products/{id}", path)
 
 	id := entity.NewID()
-	service.EXPECT().DeleteProduct(id).Return(entity.ErrNotFound)
+	service.EXPECT().DeleteProduct(tenantAlice, id).Return(entity.ErrNotFound)
 	handler := deleteProduct(service)
 	req, _ := http.NewRequest("DELETE", "/v1/products/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/products/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -0,0 +1,259 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/usecase/course"
+	"sudhagar/glad/usecase/timing"
+
+	"sudhagar/glad/api/presenter"
+
+	"sudhagar/glad/entity"
+
+	"github.com/gorilla/mux"
+)
+
+// timingInput a course timing as sent by the caller
+type timingInput struct {
+	ExtID     *string `json:"extId"`
+	Date      string  `json:"date"`
+	StartTime string  `json:"startTime"`
+	EndTime   string  `json:"endTime"`
+}
+
+func (i *timingInput) dateTime() entity.CourseDateTime {
+	return entity.CourseDateTime{
+		Date:      i.Date,
+		StartTime: i.StartTime,
+		EndTime:   i.EndTime,
+	}
+}
+
+// getTimingsCourse gets the course of the request; writes the error
+// response and returns nil if it can't be read
+func getTimingsCourse(w http.ResponseWriter, r *http.Request, service course.UseCase) *entity.Course {
+	tenantID, ok := getTenantID(w, r)
+	if !ok {
+		return nil
+	}
+	id, err := entity.StringToID(mux.Vars(r)["id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return nil
+	}
+	c, err := service.GetCourse(tenantID, id)
+	if err == entity.ErrNotFound || (err == nil && c == nil) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Course doesn't exist"))
+		return nil
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Error reading course:" + err.Error()))
+		return nil
+	}
+	return c
+}
+
+// getCourseTiming gets the timing of the request if it belongs to the
+// course; writes the error response and returns nil otherwise
+func getCourseTiming(w http.ResponseWriter, r *http.Request, c *entity.Course, timingService timing.UseCase) *entity.CourseTiming {
+	id, err := entity.StringToID(mux.Vars(r)["timingId"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return nil
+	}
+	t, err := timingService.GetTiming(c.TenantID, id)
+	if err == entity.ErrNotFound || (err == nil && t.CourseID != c.ID) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Course timing doesn't exist"))
+		return nil
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Error reading course timing:" + err.Error()))
+		return nil
+	}
+	return t
+}
+
+// writeTimingError writes the response of a failed timing write
+func writeTimingError(w http.ResponseWriter, errorMessage string, err error) {
+	if err == entity.ErrInvalidEntity {
+		w.WriteHeader(http.StatusBadRequest)
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+}
+
+func listTimings(service course.UseCase, timingService timing.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading course timings"
+		c := getTimingsCourse(w, r, service)
+		if c == nil {
+			return
+		}
+		data, err := timingService.ListTimings(c.ID)
+		if err != nil && err != entity.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+		if err := json.NewEncoder(w).Encode(presenter.CourseTimings(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode course timings"))
+		}
+	})
+}
+
+func createTiming(service course.UseCase, timingService timing.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error adding course timing"
+		c := getEditedCourse(w, r, service)
+		if c == nil {
+			return
+		}
+
+		var input timingInput
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		id, err := timingService.CreateTiming(c.ID, input.ExtID, input.dateTime())
+		if err != nil {
+			writeTimingError(w, errorMessage, err)
+			return
+		}
+		toJ := &presenter.CourseTiming{
+			ID: id,
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			log.Println(err.Error())
+		}
+	})
+}
+
+func updateTiming(service course.UseCase, timingService timing.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating course timing"
+		c := getEditedCourse(w, r, service)
+		if c == nil {
+			return
+		}
+		t := getCourseTiming(w, r, c, timingService)
+		if t == nil {
+			return
+		}
+
+		var input timingInput
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		if input.ExtID != nil {
+			t.ExtID = input.ExtID
+		}
+		t.DateTime = input.dateTime()
+		err = timingService.UpdateTiming(c.TenantID, t)
+		if err != nil {
+			writeTimingError(w, errorMessage, err)
+			return
+		}
+		toJ := &presenter.CourseTiming{}
+		toJ.CopyFrom(t)
+
+		w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			log.Println(err.Error())
+		}
+	})
+}
+
+func deleteTiming(service course.UseCase, timingService timing.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing course timing"
+		c := getEditedCourse(w, r, service)
+		if c == nil {
+			return
+		}
+		t := getCourseTiming(w, r, c, timingService)
+		if t == nil {
+			return
+		}
+
+		err := timingService.DeleteTiming(c.TenantID, t.ID)
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusOK)
+		case entity.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Course timing doesn't exist"))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+func replaceTimings(service course.UseCase, timingService timing.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error replacing course timings"
+		c := getEditedCourse(w, r, service)
+		if c == nil {
+			return
+		}
+
+		var input []timingInput
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		var dateTimes []entity.CourseDateTime
+		for _, i := range input {
+			dateTimes = append(dateTimes, i.dateTime())
+		}
+		data, err := timingService.ReplaceTimings(c.ID, dateTimes)
+		if err != nil {
+			writeTimingError(w, errorMessage, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, c.TenantID.String())
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(presenter.CourseTimings(data)); err != nil {
+			log.Println(err.Error())
+		}
+	})
+}
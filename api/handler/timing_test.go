@@ -0,0 +1,155 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudhagar/glad/api/presenter"
+	"sudhagar/glad/entity"
+
+	mock "sudhagar/glad/usecase/course/mock"
+	timingmock "sudhagar/glad/usecase/timing/mock"
+
+	"github.com/codegangsta/negroni"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTimingRouter(t *testing.T) (*mux.Router, *mock.MockUseCase, *timingmock.MockUseCase) {
+	controller := gomock.NewController(t)
+	t.Cleanup(controller.Finish)
+	service := mock.NewMockUseCase(controller)
+	timingService := timingmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeCourseHandlers(r, *n, service, timingService, nil)
+	return r, service, timingService
+}
+
+func Test_listTimings(t *testing.T) {
+	r, service, timingService := newTimingRouter(t)
+	path, err := r.GetRoute("listTimings").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{id}/timings", path)
+
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil)
+	timingService.EXPECT().ListTimings(c.ID).Return(nil, entity.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodGet, "/v1/courses/"+c.ID.String()+"/timings", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "[]\n", rr.Body.String())
+
+	// unknown course
+	id := entity.NewID()
+	service.EXPECT().GetCourse(tenantAlice, id).Return(nil, entity.ErrNotFound)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodGet, "/v1/courses/"+id.String()+"/timings", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func Test_createTiming(t *testing.T) {
+	r, service, timingService := newTimingRouter(t)
+
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	id := entity.NewID()
+	dt := entity.CourseDateTime{Date: "2024-05-02", StartTime: "09:00", EndTime: "12:00"}
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil).Times(2)
+	timingService.EXPECT().CreateTiming(c.ID, nil, dt).Return(id, nil)
+	timingService.EXPECT().CreateTiming(c.ID, nil, entity.CourseDateTime{Date: "May 2"}).
+		Return(entity.ID(entity.IDInvalid), entity.ErrInvalidEntity)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPost, "/v1/courses/"+c.ID.String()+"/timings",
+		strings.NewReader(`{"date": "2024-05-02", "startTime": "09:00", "endTime": "12:00"}`)))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var d presenter.CourseTiming
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, id, d.ID)
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPost, "/v1/courses/"+c.ID.String()+"/timings",
+		strings.NewReader(`{"date": "May 2"}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func Test_updateTiming(t *testing.T) {
+	r, service, timingService := newTimingRouter(t)
+	path, err := r.GetRoute("updateTiming").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{id}/timings/{timingId}", path)
+
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	timing := &entity.CourseTiming{ID: entity.NewID(), CourseID: c.ID,
+		DateTime: entity.CourseDateTime{Date: "2024-05-02"}}
+	other := &entity.CourseTiming{ID: entity.NewID(), CourseID: entity.NewID()}
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil).Times(2)
+	timingService.EXPECT().GetTiming(tenantAlice, timing.ID).Return(timing, nil)
+	timingService.EXPECT().GetTiming(tenantAlice, other.ID).Return(other, nil)
+	timingService.EXPECT().UpdateTiming(tenantAlice, gomock.Any()).Return(nil)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPut,
+		"/v1/courses/"+c.ID.String()+"/timings/"+timing.ID.String(),
+		strings.NewReader(`{"date": "2024-05-03", "startTime": "10:00:00"}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2024-05-03", timing.DateTime.Date)
+	assert.Equal(t, "10:00:00", timing.DateTime.StartTime)
+
+	// timing of another course
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPut,
+		"/v1/courses/"+c.ID.String()+"/timings/"+other.ID.String(),
+		strings.NewReader(`{"date": "2024-05-03"}`)))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func Test_deleteTiming(t *testing.T) {
+	r, service, timingService := newTimingRouter(t)
+
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	timing := &entity.CourseTiming{ID: entity.NewID(), CourseID: c.ID}
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil)
+	timingService.EXPECT().GetTiming(tenantAlice, timing.ID).Return(timing, nil)
+	timingService.EXPECT().DeleteTiming(tenantAlice, timing.ID).Return(nil)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodDelete,
+		"/v1/courses/"+c.ID.String()+"/timings/"+timing.ID.String(), nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func Test_replaceTimings(t *testing.T) {
+	r, service, timingService := newTimingRouter(t)
+	path, err := r.GetRoute("replaceTimings").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{id}/timings", path)
+
+	c := &entity.Course{ID: entity.NewID(), TenantID: tenantAlice}
+	dts := []entity.CourseDateTime{{Date: "2024-05-02"}, {Date: "2024-05-03"}}
+	service.EXPECT().GetCourse(tenantAlice, c.ID).Return(c, nil)
+	timingService.EXPECT().ReplaceTimings(c.ID, dts).Return([]*entity.CourseTiming{
+		{ID: entity.NewID(), CourseID: c.ID, DateTime: dts[0]},
+		{ID: entity.NewID(), CourseID: c.ID, DateTime: dts[1]},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, newTenantRequest(http.MethodPut, "/v1/courses/"+c.ID.String()+"/timings",
+		strings.NewReader(`[{"date": "2024-05-02"}, {"date": "2024-05-03"}]`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var d []*presenter.CourseTiming
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, 2, len(d))
+	assert.Equal(t, "2024-05-03", d[1].Date)
+}
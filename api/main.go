@@ -7,27 +7,39 @@
 package main
 
 import (
+	stdcontext "context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"sudhagar/glad/repository"
 
 	"sudhagar/glad/usecase/account"
 	"sudhagar/glad/usecase/center"
+	clientconfig "sudhagar/glad/usecase/config"
 	"sudhagar/glad/usecase/course"
+	"sudhagar/glad/usecase/eligibility"
+	"sudhagar/glad/usecase/notify"
 	"sudhagar/glad/usecase/product"
 	"sudhagar/glad/usecase/tenant"
+	"sudhagar/glad/usecase/timing"
+	"sudhagar/glad/usecase/tombstone"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"sudhagar/glad/api/handler"
 	"sudhagar/glad/api/middleware"
+	"sudhagar/glad/api/syncer"
 	"sudhagar/glad/config"
+	"sudhagar/glad/entity"
+	infra "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/jwt"
+	"sudhagar/glad/pkg/mailer"
 	"sudhagar/glad/pkg/metric"
 	"sudhagar/glad/pkg/util"
 
@@ -54,20 +66,58 @@ func main() {
 	}
 	defer db.Close()
 
+	tombstoneRepo := repository.NewTombstonePGSQL(db)
+	tombstoneService := tombstone.NewService(tombstoneRepo,
+		map[entity.SyncObject]entity.TombstoneAction{
+			entity.SyncObjectCourse: entity.TombstoneAction(
+				util.GetStrEnvOrConfig("SF_DELETE_ACTION_COURSE", config.SF_DELETE_ACTION_COURSE)),
+			entity.SyncObjectCenter: entity.TombstoneAction(
+				util.GetStrEnvOrConfig("SF_DELETE_ACTION_CENTER", config.SF_DELETE_ACTION_CENTER)),
+			entity.SyncObjectProduct: entity.TombstoneAction(
+				util.GetStrEnvOrConfig("SF_DELETE_ACTION_PRODUCT", config.SF_DELETE_ACTION_PRODUCT)),
+			entity.SyncObjectAccount: entity.TombstoneAction(
+				util.GetStrEnvOrConfig("SF_DELETE_ACTION_ACCOUNT", config.SF_DELETE_ACTION_ACCOUNT)),
+		})
+
 	productRepo := repository.NewProductPGSQL(db)
-	productService := product.NewService(productRepo)
+	productService := product.NewService(productRepo, tombstoneService)
 
 	centerRepo := repository.NewCenterPGSQL(db)
-	centerService := center.NewService(centerRepo)
+	centerService := center.NewService(centerRepo, tombstoneService)
 
 	tenantRepo := repository.NewTenantPGSQL(db)
 	tenantService := tenant.NewService(tenantRepo)
 
 	accountRepo := repository.NewAccountPGSQL(db)
-	accountService := account.NewService(accountRepo)
+	accountService := account.NewService(accountRepo, tombstoneService)
+
+	eligibilityRepo := repository.NewEligibilityPGSQL(db)
+	eligibilityService := eligibility.NewService(eligibilityRepo, accountService, productService)
 
+	sender, err := newNotifySender()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 	courseRepo := repository.NewCoursePGSQL(db)
-	courseService := course.NewService(courseRepo)
+	courseService := course.NewService(courseRepo, tombstoneService, accountService, eligibilityService)
+
+	timingRepo := repository.NewTimingPGSQL(db)
+	timingService := timing.NewService(timingRepo)
+
+	notifyRepo := repository.NewNotifyPGSQL(db)
+	notifyService := notify.NewService(notifyRepo, courseRepo, timingRepo, accountService, sender)
+	if interval := util.GetIntEnvOrConfig("NOTIFY_INTERVAL_SECONDS", config.NOTIFY_INTERVAL_SECONDS); interval > 0 {
+		go notifyChanges(notifyService, time.Duration(interval)*time.Second)
+	}
+
+	configEndpoints, err := entity.ParseConfigEndpoints(
+		util.GetStrEnvOrConfig("CONFIG_ENDPOINTS", config.CONFIG_ENDPOINTS))
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	configService := clientconfig.NewService(tenantService,
+		strings.Split(util.GetStrEnvOrConfig("CONFIG_TIMEZONES", config.CONFIG_TIMEZONES), ","),
+		configEndpoints)
 
 	metricService, err := metric.NewPrometheusService()
 	if err != nil {
@@ -78,9 +128,18 @@ func main() {
 	n := negroni.New(
 		negroni.HandlerFunc(middleware.Metrics(metricService)),
 		negroni.HandlerFunc(middleware.Cors),
-		negroni.HandlerFunc(middleware.AddDefaultTenant),
-		negroni.NewLogger(),
 	)
+	if util.GetBoolEnvOrConfig("AUTH_ENABLED", config.AUTH_ENABLED) {
+		validator, err := newTokenValidator()
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		n.Use(negroni.HandlerFunc(middleware.Authenticate(validator, accountService,
+			strings.Split(util.GetStrEnvOrConfig("AUTH_PUBLIC_PATHS", config.AUTH_PUBLIC_PATHS), ",")...)))
+		n.Use(negroni.HandlerFunc(middleware.Authorize(handler.RoutePermissions)))
+	}
+	n.Use(negroni.HandlerFunc(middleware.AddDefaultTenant))
+	n.Use(negroni.NewLogger())
 	// center
 	handler.MakeCenterHandlers(r, *n, centerService)
 
@@ -90,12 +149,47 @@ func main() {
 	// account
 	handler.MakeAccountHandlers(r, *n, accountService)
 
+	// teacher eligibility
+	handler.MakeEligibilityHandlers(r, *n, eligibilityService, accountService)
+
 	// course
-	handler.MakeCourseHandlers(r, *n, courseService)
+	handler.MakeCourseHandlers(r, *n, courseService, timingService, notifyService)
+
+	// course notifications
+	handler.MakeNotifyHandlers(r, *n, courseService, notifyService)
 
 	// product
 	handler.MakeProductHandlers(r, *n, productService)
 
+	// client config
+	handler.MakeConfigHandlers(r, *n, configService)
+
+	// salesforce sync; shares the db pool and the middleware
+	if util.GetBoolEnvOrConfig("SYNC_ENABLED", config.SYNC_ENABLED) {
+		err = infra.Init(dataSourceName, db)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		secrets, err := middleware.ParseCalloutSecrets(
+			util.GetStrEnvOrConfig("SYNC_CALLOUT_SECRETS", config.SYNC_CALLOUT_SECRETS))
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		callout := negroni.New(
+			negroni.HandlerFunc(middleware.Metrics(metricService)),
+			negroni.HandlerFunc(middleware.AuthenticateCallout(secrets)),
+			negroni.NewLogger(),
+		)
+		// the operator requests are authenticated and authorized as any other
+		syncer.MakeSyncHandlers(r.PathPrefix(syncer.PathPrefix).Subrouter(), *callout, *n)
+
+		go func() {
+			if err := syncer.RunJobs(stdcontext.Background()); err != nil {
+				log.Println("sync jobs stopped:", err)
+			}
+		}()
+	}
+
 	http.Handle("/", r)
 	http.Handle("/metrics", promhttp.Handler())
 	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -120,3 +214,68 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// newTokenValidator creates the validator of the bearer tokens, with the
+// local key set if configured, else the key set fetched from the issuer
+func newTokenValidator() (*jwt.Validator, error) {
+	issuer := util.GetStrEnvOrConfig("AUTH_ISSUER", config.AUTH_ISSUER)
+	audience := util.GetStrEnvOrConfig("AUTH_AUDIENCE", config.AUTH_AUDIENCE)
+	if path := util.GetStrEnvOrConfig("AUTH_JWKS_FILE", config.AUTH_JWKS_FILE); path != "" {
+		keys, err := jwt.LoadJWKS(path)
+		if err != nil {
+			return nil, err
+		}
+		return jwt.NewValidator(keys, issuer, audience), nil
+	}
+	url := util.GetStrEnvOrConfig("AUTH_JWKS_URL", config.AUTH_JWKS_URL)
+	if url == "" {
+		if issuer == "" {
+			return nil, fmt.Errorf("authentication needs AUTH_ISSUER, AUTH_JWKS_URL or AUTH_JWKS_FILE")
+		}
+		url = strings.TrimSuffix(issuer, "/") + "/.well-known/jwks.json"
+	}
+	refresh := time.Duration(util.GetIntEnvOrConfig("AUTH_JWKS_REFRESH_SECONDS", config.AUTH_JWKS_REFRESH_SECONDS)) * time.Second
+	return jwt.NewValidator(jwt.NewRemoteKeys(url, refresh), issuer, audience), nil
+}
+
+// newNotifySender creates the sender of the course notifications; nil if
+// they are not sent
+func newNotifySender() (mailer.Sender, error) {
+	from := util.GetStrEnvOrConfig("NOTIFY_FROM", config.NOTIFY_FROM)
+	switch sender := util.GetStrEnvOrConfig("NOTIFY_SENDER", config.NOTIFY_SENDER); sender {
+	case "smtp":
+		return mailer.NewSMTP(
+			util.GetStrEnvOrConfig("SMTP_HOST", config.SMTP_HOST),
+			util.GetIntEnvOrConfig("SMTP_PORT", config.SMTP_PORT),
+			util.GetStrEnvOrConfig("SMTP_USERNAME", config.SMTP_USERNAME),
+			util.GetStrEnvOrConfig("SMTP_PASSWORD", config.SMTP_PASSWORD),
+			from,
+			time.Duration(util.GetIntEnvOrConfig("SMTP_TIMEOUT_SECONDS", config.SMTP_TIMEOUT_SECONDS))*time.Second), nil
+	case "file":
+		path := util.GetStrEnvOrConfig("NOTIFY_FILE", config.NOTIFY_FILE)
+		if path == "" {
+			return mailer.NewFile(os.Stderr, from), nil
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		return mailer.NewFile(f, from), nil
+	case "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown notification sender %q", sender)
+	}
+}
+
+// notifyChanges notifies the subscribers of the changed courses every
+// interval; the database queues the courses as they change
+func notifyChanges(service notify.UseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := service.NotifyQueuedChanges(0); err != nil {
+			log.Println("unable to notify the course changes:", err)
+		}
+	}
+}
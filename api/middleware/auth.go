@@ -0,0 +1,126 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/pkg/jwt"
+	"sudhagar/glad/usecase/account"
+
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+)
+
+type contextKey int
+
+const accountKey contextKey = iota
+
+// WithAccount returns a copy of ctx carrying the authenticated account
+func WithAccount(ctx context.Context, a *entity.Account) context.Context {
+	return context.WithValue(ctx, accountKey, a)
+}
+
+// AccountFromContext gives the authenticated account of the request; nil
+// if it isn't authenticated
+func AccountFromContext(ctx context.Context) *entity.Account {
+	a, _ := ctx.Value(accountKey).(*entity.Account)
+	return a
+}
+
+// TenantFromContext gives the tenant of the authenticated account;
+// IDInvalid if the request isn't authenticated
+func TenantFromContext(ctx context.Context) entity.ID {
+	a := AccountFromContext(ctx)
+	if a == nil {
+		return entity.IDInvalid
+	}
+	return a.TenantID
+}
+
+// Authenticate validates the bearer token of the request and maps its
+// subject to an account by the Cognito id. The account is put on the request
+// context and its tenant on the tenant header; a request for another tenant
+// is forbidden. The public paths are served without a token.
+func Authenticate(v *jwt.Validator, accounts account.UseCase, public ...string) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		for _, p := range public {
+			if r.URL.Path == p {
+				next(w, r)
+				return
+			}
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			unauthorized(w, "Missing bearer token")
+			return
+		}
+		claims, err := v.Validate(token)
+		if err != nil {
+			unauthorized(w, "Invalid bearer token: "+err.Error())
+			return
+		}
+		acc, err := accounts.GetAccountByCognitoID(claims.Subject)
+		if err == entity.ErrNotFound {
+			unauthorized(w, "Unknown account")
+			return
+		}
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Error reading account"))
+			return
+		}
+
+		tenant := r.Header.Get(common.HttpHeaderTenantID)
+		if tenant != "" && tenant != acc.TenantID.String() {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("Account doesn't belong to the tenant"))
+			return
+		}
+		r.Header.Set(common.HttpHeaderTenantID, acc.TenantID.String())
+		next(w, r.WithContext(WithAccount(r.Context(), acc)))
+	}
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write([]byte(message))
+}
+
+// Authorize checks that the authenticated account has the permission of the
+// route, by route name; the routes without one are for admins only. The
+// requests without an account, to the public paths or with authentication
+// disabled, are let through.
+func Authorize(permissions map[string]entity.Permission) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		acc := AccountFromContext(r.Context())
+		if acc == nil {
+			next(w, r)
+			return
+		}
+		allowed := acc.Type == entity.AccountAdmin
+		if route := mux.CurrentRoute(r); route != nil {
+			if p, ok := permissions[route.GetName()]; ok {
+				allowed = acc.Type.Can(p)
+			}
+		}
+		if !allowed {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("Account isn't allowed to do it"))
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/pkg/jwt"
+	mock "sudhagar/glad/usecase/account/mock"
+
+	"github.com/codegangsta/negroni"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantAlice entity.ID = 13790492210917015554
+
+func Test_Authenticate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	accounts := mock.NewMockUseCase(controller)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	v := jwt.NewValidator(jwt.StaticKeys{"k1": &key.PublicKey}, "https://issuer.example.org", "client1")
+
+	var seen *http.Request
+	n := negroni.New(negroni.HandlerFunc(Authenticate(v, accounts, "/v1/config")))
+	n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r
+	})
+	serve := func(path, token, tenant string) *httptest.ResponseRecorder {
+		seen = nil
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if tenant != "" {
+			req.Header.Set(common.HttpHeaderTenantID, tenant)
+		}
+		rr := httptest.NewRecorder()
+		n.ServeHTTP(rr, req)
+		return rr
+	}
+	sign := func(sub string, exp time.Duration) string {
+		token, err := jwt.Sign(key, "k1", &jwt.Claims{
+			Subject:   sub,
+			Issuer:    "https://issuer.example.org",
+			Audience:  jwt.Audience{"client1"},
+			ExpiresAt: time.Now().Add(exp).Unix(),
+		})
+		assert.Nil(t, err)
+		return token
+	}
+
+	alice := &entity.Account{ID: entity.NewID(), TenantID: tenantAlice, CognitoID: "alice"}
+	accounts.EXPECT().GetAccountByCognitoID("alice").Return(alice, nil).Times(3)
+	accounts.EXPECT().GetAccountByCognitoID("bob").Return(nil, entity.ErrNotFound)
+
+	rr := serve("/v1/courses", sign("alice", time.Hour), "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, alice, AccountFromContext(seen.Context()))
+	assert.Equal(t, tenantAlice, TenantFromContext(seen.Context()))
+	assert.Equal(t, tenantAlice.String(), seen.Header.Get(common.HttpHeaderTenantID))
+
+	rr = serve("/v1/courses", sign("alice", time.Hour), tenantAlice.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// another tenant
+	rr = serve("/v1/courses", sign("alice", time.Hour), entity.NewID().String())
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Nil(t, seen)
+
+	for _, token := range []string{"", "abc", sign("alice", -time.Hour), sign("bob", time.Hour)} {
+		rr = serve("/v1/courses", token, "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, token)
+		assert.Equal(t, "Bearer", rr.Header().Get("WWW-Authenticate"))
+		assert.Nil(t, seen)
+	}
+
+	// public
+	rr = serve("/v1/config", "", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, AccountFromContext(seen.Context()))
+	assert.Equal(t, entity.ID(entity.IDInvalid), TenantFromContext(seen.Context()))
+}
+
+func Test_Authorize(t *testing.T) {
+	r := mux.NewRouter()
+	n := negroni.New(negroni.HandlerFunc(Authorize(map[string]entity.Permission{
+		"listCourses":  entity.PermissionRead,
+		"createCourse": entity.PermissionCreateCourse,
+	})))
+	ok := n.With(negroni.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+	r.Handle("/v1/courses", ok).Methods("GET").Name("listCourses")
+	r.Handle("/v1/courses", ok).Methods("POST").Name("createCourse")
+	r.Handle("/v1/tenants", ok).Methods("POST").Name("createTenant")
+
+	for _, tc := range []struct {
+		t      entity.AccountType
+		method string
+		path   string
+		code   int
+	}{
+		{entity.AccountMember, http.MethodGet, "/v1/courses", http.StatusOK},
+		{entity.AccountMember, http.MethodPost, "/v1/courses", http.StatusForbidden},
+		{entity.AccountTeacher, http.MethodPost, "/v1/courses", http.StatusOK},
+		{entity.AccountAssistantTeacher, http.MethodPost, "/v1/courses", http.StatusForbidden},
+		// not in the policy
+		{entity.AccountOrganizer, http.MethodPost, "/v1/tenants", http.StatusForbidden},
+		{entity.AccountAdmin, http.MethodPost, "/v1/tenants", http.StatusOK},
+		{"", http.MethodPost, "/v1/tenants", http.StatusOK},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.t != "" {
+			req = req.WithContext(WithAccount(req.Context(), &entity.Account{TenantID: tenantAlice, Type: tc.t}))
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, tc.code, rr.Code, "%s %s %s", tc.t, tc.method, tc.path)
+	}
+}
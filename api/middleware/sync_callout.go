@@ -0,0 +1,76 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+
+	"github.com/codegangsta/negroni"
+)
+
+// CalloutSecret shared secret of the Salesforce org of a tenant
+type CalloutSecret struct {
+	TenantID entity.ID
+	Secret   string
+}
+
+// ParseCalloutSecrets parses comma separated "<tenant id>:<secret>" pairs
+func ParseCalloutSecrets(s string) ([]CalloutSecret, error) {
+	var secrets []CalloutSecret
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		tenant, secret, ok := strings.Cut(pair, ":")
+		if !ok || secret == "" {
+			return nil, fmt.Errorf("invalid callout secret of tenant %q", tenant)
+		}
+		tenantID, err := entity.StringToID(tenant)
+		if err != nil {
+			return nil, fmt.Errorf("invalid callout secret tenant %q", tenant)
+		}
+		secrets = append(secrets, CalloutSecret{TenantID: tenantID, Secret: secret})
+	}
+	return secrets, nil
+}
+
+// AuthenticateCallout authenticates the Salesforce callouts by the shared
+// secret of the org of a tenant, and sets the tenant header to that tenant;
+// a callout for another tenant is forbidden. No callout is let through
+// without secrets.
+func AuthenticateCallout(secrets []CalloutSecret) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		secret := r.Header.Get(common.HttpHeaderSyncSecret)
+		tenantID := entity.ID(entity.IDInvalid)
+		for _, s := range secrets {
+			if subtle.ConstantTimeCompare([]byte(secret), []byte(s.Secret)) == 1 {
+				tenantID = s.TenantID
+			}
+		}
+		if secret == "" || tenantID == entity.IDInvalid {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Invalid sync secret"))
+			return
+		}
+
+		tenant := r.Header.Get(common.HttpHeaderTenantID)
+		if tenant != "" && tenant != tenantID.String() {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("Sync secret doesn't belong to the tenant"))
+			return
+		}
+		r.Header.Set(common.HttpHeaderTenantID, tenantID.String())
+		next(w, r)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+
+	"github.com/codegangsta/negroni"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseCalloutSecrets(t *testing.T) {
+	secrets, err := ParseCalloutSecrets(" 13790492210917015554:s1, 13790492210917015555:s2:x ")
+	assert.Nil(t, err)
+	assert.Equal(t, []CalloutSecret{
+		{TenantID: tenantAlice, Secret: "s1"},
+		{TenantID: 13790492210917015555, Secret: "s2:x"},
+	}, secrets)
+
+	secrets, err = ParseCalloutSecrets("")
+	assert.Nil(t, err)
+	assert.Empty(t, secrets)
+
+	_, err = ParseCalloutSecrets("13790492210917015554")
+	assert.NotNil(t, err)
+	_, err = ParseCalloutSecrets("alice:s1")
+	assert.NotNil(t, err)
+}
+
+func Test_AuthenticateCallout(t *testing.T) {
+	var seen *http.Request
+	serve := func(secrets []CalloutSecret, secret, tenant string) int {
+		seen = nil
+		n := negroni.New(negroni.HandlerFunc(AuthenticateCallout(secrets)))
+		n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = r
+		})
+		req := httptest.NewRequest(http.MethodPost, "/sync/course", nil)
+		if secret != "" {
+			req.Header.Set(common.HttpHeaderSyncSecret, secret)
+		}
+		if tenant != "" {
+			req.Header.Set(common.HttpHeaderTenantID, tenant)
+		}
+		rr := httptest.NewRecorder()
+		n.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	secrets := []CalloutSecret{
+		{TenantID: tenantAlice, Secret: "s1"},
+		{TenantID: 13790492210917015555, Secret: "s2"},
+	}
+
+	assert.Equal(t, http.StatusOK, serve(secrets, "s1", ""))
+	assert.Equal(t, tenantAlice.String(), seen.Header.Get(common.HttpHeaderTenantID))
+	assert.Equal(t, http.StatusOK, serve(secrets, "s1", tenantAlice.String()))
+
+	// the secret of another tenant
+	assert.Equal(t, http.StatusForbidden, serve(secrets, "s2", tenantAlice.String()))
+	assert.Nil(t, seen)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(secrets, "s3", ""))
+	assert.Equal(t, http.StatusUnauthorized, serve(secrets, "", ""))
+	assert.Equal(t, http.StatusUnauthorized, serve(nil, "", ""))
+	assert.Equal(t, http.StatusUnauthorized, serve([]CalloutSecret{{TenantID: entity.IDInvalid}}, "", ""))
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"sudhagar/glad/pkg/common"
+
+	"github.com/codegangsta/negroni"
+)
+
+// AuthenticateOperator authenticates the operator requests to the sync
+// controls and exports by the operator token; no request is let through without a token.
+func AuthenticateOperator(token string) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		given := r.Header.Get(common.HttpHeaderOperatorToken)
+		if token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Invalid operator token"))
+			return
+		}
+		next(w, r)
+	}
+}
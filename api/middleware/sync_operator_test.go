@@ -0,0 +1,37 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudhagar/glad/pkg/common"
+
+	"github.com/codegangsta/negroni"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuthenticateOperator(t *testing.T) {
+	serve := func(token, given string) int {
+		n := negroni.New(negroni.HandlerFunc(AuthenticateOperator(token)))
+		n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		req := httptest.NewRequest(http.MethodPost, "/controls/pause", nil)
+		if given != "" {
+			req.Header.Set(common.HttpHeaderOperatorToken, given)
+		}
+		rr := httptest.NewRecorder()
+		n.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("op", "op"))
+	assert.Equal(t, http.StatusUnauthorized, serve("op", "other"))
+	assert.Equal(t, http.StatusUnauthorized, serve("op", ""))
+	assert.Equal(t, http.StatusUnauthorized, serve("", ""))
+}
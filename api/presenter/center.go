@@ -13,8 +13,38 @@ import (
 // Center data - TenantID is returned in the HTTP header
 // X-GLAD-TenantID
 type Center struct {
-	ID      entity.ID         `json:"id"`
-	Name    string            `json:"name"`
-	ExtName string            `json:"extName"`
-	Mode    entity.CenterMode `json:"mode"`
+	ID       entity.ID         `json:"id"`
+	Name     string            `json:"name"`
+	ExtName  string            `json:"extName"`
+	Mode     entity.CenterMode `json:"mode"`
+	Sync     *SyncState        `json:"sync,omitempty"`
+	Contacts []*CenterContact  `json:"contacts,omitempty"`
+}
+
+// CenterContact a contact of a center
+type CenterContact struct {
+	ID        entity.ID `json:"id"`
+	Name      string    `json:"name"`
+	Phone     string    `json:"phone,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	IsPrimary bool      `json:"isPrimary"`
+}
+
+func (cc *CenterContact) CopyFrom(c *entity.CenterContact) {
+	cc.ID = c.ID
+	cc.Name = c.Name
+	cc.Phone = c.Phone
+	cc.Email = c.Email
+	cc.IsPrimary = c.IsPrimary
+}
+
+// CenterContacts converts the contacts of a center
+func CenterContacts(contacts []*entity.CenterContact) []*CenterContact {
+	toJ := []*CenterContact{}
+	for _, c := range contacts {
+		cc := &CenterContact{}
+		cc.CopyFrom(c)
+		toJ = append(toJ, cc)
+	}
+	return toJ
 }
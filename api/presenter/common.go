@@ -6,7 +6,11 @@
 
 package presenter
 
-import "sudhagar/glad/entity"
+import (
+	"time"
+
+	"sudhagar/glad/entity"
+)
 
 // Address
 type Address struct {
@@ -25,6 +29,15 @@ type DateTime struct {
 	EndTime   string `json:"endTime,omitempty"`
 }
 
+// Sync state
+type SyncState struct {
+	LastSyncedAt      *time.Time           `json:"lastSyncedAt,omitempty"`
+	LastSyncDirection entity.SyncDirection `json:"lastSyncDirection,omitempty"`
+	Status            entity.SyncStatus    `json:"syncStatus,omitempty"`
+	LastError         string               `json:"lastSyncError,omitempty"`
+	SFLastModified    *time.Time           `json:"sfLastModified,omitempty"`
+}
+
 func (a *Address) CopyFrom(sa entity.CourseAddress) {
 	a.Street1 = sa.Street1
 	a.Street2 = sa.Street2
@@ -39,3 +52,11 @@ func (dt *DateTime) CopyFrom(sdt entity.CourseDateTime) {
 	dt.StartTime = sdt.StartTime
 	dt.EndTime = sdt.EndTime
 }
+
+func (ss *SyncState) CopyFrom(s entity.SyncState) {
+	ss.LastSyncedAt = s.LastSyncedAt
+	ss.LastSyncDirection = s.LastSyncDirection
+	ss.Status = s.Status
+	ss.LastError = s.LastError
+	ss.SFLastModified = s.SFLastModified
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"sudhagar/glad/entity"
+)
+
+// Config the configuration of the clients
+type Config struct {
+	Version        int64                  `json:"version"`
+	Tenant         Tenant                 `json:"tenant"`
+	Timezones      []string               `json:"timezones"`
+	CourseStatuses []entity.CourseStatus  `json:"courseStatuses"`
+	CourseModes    []entity.CourseMode    `json:"courseModes"`
+	AccountTypes   []entity.AccountType   `json:"accountTypes"`
+	ProductFormats []entity.ProductFormat `json:"productFormats"`
+	Endpoints      []*ConfigEndpoint      `json:"endpoints"`
+}
+
+// ConfigEndpoint a service endpoint of the clients
+type ConfigEndpoint struct {
+	Type entity.ConfigEndpointType `json:"type"`
+	URL  string                    `json:"url"`
+}
+
+func (c *Config) CopyFrom(e *entity.Config) {
+	c.Version = e.Version
+	c.Tenant = Tenant{
+		ID:      e.Tenant.ID,
+		Name:    e.Tenant.Name,
+		Country: e.Tenant.Country,
+	}
+	c.Timezones = e.Timezones
+	c.CourseStatuses = e.CourseStatuses
+	c.CourseModes = e.CourseModes
+	c.AccountTypes = e.AccountTypes
+	c.ProductFormats = e.ProductFormats
+	c.Endpoints = []*ConfigEndpoint{}
+	for _, ep := range e.Endpoints {
+		c.Endpoints = append(c.Endpoints, &ConfigEndpoint{Type: ep.Type, URL: ep.URL})
+	}
+}
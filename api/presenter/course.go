@@ -24,4 +24,62 @@ type Course struct {
 	Mode         *entity.CourseMode   `json:"mode,omitempty"`
 	MaxAttendees *int32               `json:"maxAttendees,omitempty"`
 	NumAttendees *int32               `json:"numAttendees,omitempty"`
+	Sync         *SyncState           `json:"sync,omitempty"`
+	Timings      []*CourseTiming      `json:"timings,omitempty"`
+	Teachers     []*CourseTeacher     `json:"teacher,omitempty"`
+	Organizers   []entity.ID          `json:"organizer,omitempty"`
+	Contacts     []entity.ID          `json:"contact,omitempty"`
+}
+
+// CourseTeacher a teacher of a course
+type CourseTeacher struct {
+	ID        entity.ID `json:"id"`
+	IsPrimary bool      `json:"is_primary"`
+}
+
+// CopyAccounts copies the teachers, organizers and contacts of the course
+func (c *Course) CopyAccounts(a *entity.CourseAccounts) {
+	for _, t := range a.Teachers {
+		c.Teachers = append(c.Teachers, &CourseTeacher{ID: t.ID, IsPrimary: t.IsPrimary})
+	}
+	c.Organizers = a.Organizers
+	c.Contacts = a.Contacts
+}
+
+// CourseAccounts converts the teachers, organizers and contacts of a course
+// request
+func CourseAccounts(courseID entity.ID, teachers []CourseTeacher, organizers, contacts []entity.ID) *entity.CourseAccounts {
+	a := &entity.CourseAccounts{
+		CourseID:   courseID,
+		Organizers: organizers,
+		Contacts:   contacts,
+	}
+	for _, t := range teachers {
+		a.Teachers = append(a.Teachers, entity.CourseTeacher{ID: t.ID, IsPrimary: t.IsPrimary})
+	}
+	return a
+}
+
+// CourseTiming a date of a course with its start and end time
+type CourseTiming struct {
+	ID    entity.ID `json:"id"`
+	ExtID *string   `json:"extId,omitempty"`
+	DateTime
+}
+
+func (ct *CourseTiming) CopyFrom(t *entity.CourseTiming) {
+	ct.ID = t.ID
+	ct.ExtID = t.ExtID
+	ct.DateTime.CopyFrom(t.DateTime)
+}
+
+// CourseTimings converts the timings of a course
+func CourseTimings(timings []*entity.CourseTiming) []*CourseTiming {
+	toJ := []*CourseTiming{}
+	for _, t := range timings {
+		var ct CourseTiming
+		ct.CopyFrom(t)
+		toJ = append(toJ, &ct)
+	}
+	return toJ
 }
@@ -0,0 +1,22 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"sudhagar/glad/entity"
+)
+
+// TeacherEligibility a product a teacher can teach
+type TeacherEligibility struct {
+	ProductID entity.ID              `json:"productId"`
+	Type      entity.EligibilityType `json:"type"`
+}
+
+func (te *TeacherEligibility) CopyFrom(e *entity.TeacherEligibility) {
+	te.ProductID = e.ProductID
+	te.Type = e.Type
+}
@@ -23,4 +23,5 @@ type Product struct {
 	MaxAttendees     int32                    `json:"maxAttendees,omitempty"`
 	Format           entity.ProductFormat     `json:"format,omitempty"`
 	IsAutoApprove    bool                     `json:"isAutoApprove"`
+	Sync             *SyncState               `json:"sync,omitempty"`
 }
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// SyncControl pause and throttle of the sync of an object of a tenant
+type SyncControl struct {
+	// 0 for all the tenants
+	TenantID  entity.ID            `json:"tenantId"`
+	Object    entity.SyncObject    `json:"object"`
+	Direction entity.SyncDirection `json:"direction"`
+	Paused    bool                 `json:"paused"`
+	Throttle  int                  `json:"throttle,omitempty"`
+	Reason    string               `json:"reason,omitempty"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+// SyncControls controls in effect and the inbound records held meanwhile
+type SyncControls struct {
+	Controls   []*SyncControl `json:"controls"`
+	Held       int            `json:"held"`
+	OldestHeld *time.Time     `json:"oldestHeld,omitempty"`
+}
+
+func (c *SyncControl) CopyFrom(sc *entity.SyncControl) {
+	c.TenantID = sc.TenantID
+	c.Object = sc.Object
+	c.Direction = sc.Direction
+	c.Paused = sc.Paused
+	c.Throttle = sc.Throttle
+	c.Reason = sc.Reason
+	c.UpdatedAt = sc.UpdatedAt
+}
@@ -29,10 +29,17 @@
 package rds_export
 
 import (
+	"context"
+	"encoding/json"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"sudhagar/glad/entity"
+	infra "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/metric"
+	"sudhagar/glad/repository"
+	"sudhagar/glad/usecase/outbox"
 	service "sudhagar/glad/usecase/sf_export"
 
 	"github.com/gorilla/mux"
@@ -74,9 +81,126 @@ func ExportHandler(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	log.Println(params)
 	course_id := params["id"]
-	id, err := strconv.Atoi(course_id)
+	id, err := strconv.ParseUint(course_id, 10, 64)
 	if err != nil {
 		log.Println("unable to convert the parameter")
 	}
+
+	// dryRun=true returns the payload instead of sending it
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")); dryRun {
+		writePreviews(w, []entity.ID{entity.ID(id)})
+		return
+	}
 	Export(entity.ID(id))
 }
+
+// PreviewHandler returns the payloads an export of the comma separated
+// course ids would send to SF, with the changes since the last export
+func PreviewHandler(w http.ResponseWriter, r *http.Request) {
+	var ids []entity.ID
+	for _, v := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if v == "" {
+			continue
+		}
+		id, err := entity.StringToID(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id " + v))
+			return
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Missing course ids"))
+		return
+	}
+	writePreviews(w, ids)
+}
+
+func writePreviews(w http.ResponseWriter, ids []entity.ID) {
+	sfService, err := service.NewSFExportService()
+	if err != nil {
+		log.Printf("Failed to initialize SF export service: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sfService.PreviewCourses(ids)); err != nil {
+		log.Printf("Failed to encode the preview: %v", err)
+	}
+}
+
+// ExportTombstonesHandler sends pending deletes to SF; limit bounds the batch
+func ExportTombstonesHandler(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	sfService, err := service.NewSFExportService()
+	if err != nil {
+		log.Printf("Failed to initialize SF export service: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	count, err := sfService.ExportTombstones(limit)
+	if err != nil {
+		log.Printf("Failed to export tombstones to SF: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	log.Printf("Exported %d tombstones to SF", count)
+	w.WriteHeader(http.StatusOK)
+}
+
+// ExportOutboxHandler exports the pending changes captured from the database
+func ExportOutboxHandler(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	sfService, err := service.NewSFExportService()
+	if err != nil {
+		log.Printf("Failed to initialize SF export service: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	count, err := sfService.ExportOutbox(limit)
+	if err != nil {
+		log.Printf("Failed to export outbox to SF: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	log.Printf("Exported %d changes to SF", count)
+	w.WriteHeader(http.StatusOK)
+}
+
+// ListenForChanges turns the database change notifications into export
+// work until ctx is done
+func ListenForChanges(ctx context.Context) error {
+	sqlDB, err := infra.GetDB()
+	if err != nil {
+		return err
+	}
+	db, err := sqlDB.DB()
+	if err != nil {
+		return err
+	}
+
+	metricService, err := metric.NewPrometheusService()
+	if err != nil {
+		return err
+	}
+
+	outboxService := outbox.NewService(repository.NewOutboxPGSQL(db), service.OutboxRetry())
+	return infra.Listen(ctx, outbox.SyncChangeChannel, func(payload string) {
+		if err := outboxService.HandleChange(payload); err != nil {
+			log.Printf("Failed to enqueue change %s: %v", payload, err)
+			return
+		}
+		if stats, err := outboxService.GetPendingStats(); err == nil {
+			metricService.SaveQueue(metric.NewQueue("outbox", stats.Depth, stats.Oldest))
+		}
+	})
+}
@@ -16,23 +16,26 @@ func AccountHandler(w http.ResponseWriter, r *http.Request) {
 	var repo repository.Mongo
 	collection := repo.Connect()
 	parse, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		log.Println("there was an error reading the request body", err)
+	defer r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(parse, &response)
 	}
-	err = json.Unmarshal(parse, &response)
 	if err != nil {
-		log.Println("there was an error unmarshalling the body", err)
+		badRecords(w, err)
+		return
 	}
-	defer r.Body.Close()
-	for _, record := range response {
+	present := tapi.PresentFields(parse, len(response))
+	var results []any
+	failed := 0
+	for i, record := range response {
 		values := record.Value
-		_, err := tapi.WriteToDB(record.NewAccount(values.Ext_Id, values.Tenant_Id, values.Cognito_Id, values.Name, values.First_Name, values.Last_Name, values.Phone, values.Email, values.Type, values.Updated_at, values.Created_at))
+		_, err := tapi.WriteToDB(record.NewAccount(values.Ext_Id, values.Tenant_Id, values.Cognito_Id, values.Name, values.First_Name, values.Last_Name, values.Phone, values.Email, values.Type, values.Updated_at, values.Created_at), present[i])
 		if err == nil {
-			json.NewEncoder(w).Encode(record.Value)
+			results = append(results, record.Value)
 			log.Println("insertion was successful")
 		} else {
-			json.NewEncoder(w).Encode(err)
-			json.NewEncoder(w).Encode("failed")
+			results = append(results, err, "failed")
+			failed++
 		}
 
 		result, err := collection.InsertOne(context.Background(), record)
@@ -45,5 +48,6 @@ func AccountHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Println("you sent the following:", response)
+	writeResults(w, results, failed)
 
 }
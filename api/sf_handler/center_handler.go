@@ -13,21 +13,31 @@ func CenterHandler(w http.ResponseWriter, r *http.Request) {
 	var centers []entity.Center
 	parsed_response, err := ioutil.ReadAll(r.Body)
 	defer r.Body.Close()
-	if err != nil {
-		log.Println("an error occurred")
+	if err == nil {
+		err = json.Unmarshal(parsed_response, &centers)
 	}
-	err = json.Unmarshal(parsed_response, &centers)
 	if err != nil {
-		log.Println("an error occurred in the unmarshalling opf the centers")
+		badRecords(w, err)
+		return
 	}
-	for _, record := range centers {
+	present := tapi.PresentFields(parsed_response, len(centers))
+	var results []any
+	failed := 0
+	for i, record := range centers {
 		value := record.Value
-		_, err := tapi.WriteToDB(record.NewCenter(value.Ext_id, value.Tenant_id, value.Ext_name, value.Address, value.Geo_Location, value.Capacity, value.Mode, value.Webpage, value.Is_national_center, value.Is_enabled, value.Created_at, value.Updated_at))
+		center := record.NewCenter(value.Ext_id, value.Tenant_id, value.Ext_name, value.Address, value.Geo_Location, value.Capacity, value.Mode, value.Webpage, value.Is_national_center, value.Is_enabled, value.Created_at, value.Updated_at)
+		_, err := tapi.WriteToDB(center, present[i])
+		if err == nil {
+			err = tapi.WriteCenterContact(value.Tenant_id, value.Ext_id, value.Contact_name, value.Contact_phone, value.Contact_email, present[i])
+		}
 		if err != nil {
-			json.NewEncoder(w).Encode(err)
+			tapi.RecordSyncFailure(center, value.Ext_id, err)
+			results = append(results, err)
+			failed++
 		} else {
-			json.NewEncoder(w).Encode(record)
+			results = append(results, record)
 		}
 	}
 	log.Println(centers)
+	writeResults(w, results, failed)
 }
@@ -12,22 +12,29 @@ import (
 func CourseHandler(w http.ResponseWriter, r *http.Request) {
 	var courses []entity.Course
 	parsed_body, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		log.Println("there was an error parsing the body")
+	if err == nil {
+		err = json.Unmarshal(parsed_body, &courses)
 	}
-	err = json.Unmarshal(parsed_body, &courses)
 	if err != nil {
-		log.Println("there was an error unmarshalling the body")
+		badRecords(w, err)
+		return
 	}
-	for _, course := range courses {
+	present := tapi.PresentFields(parsed_body, len(courses))
+	var results []any
+	failed := 0
+	for i, course := range courses {
 		value := course.Value
-		_, err := tapi.WriteToDB(course.NewCourse(value.Url, value.Max_attendees, value.Address, value.Tenant_id, value.Ext_id, value.Name, value.Timezone, value.Mode, value.Center_id, value.Status, value.Created_at, value.Num_attendees, value.Product_id, value.Updated_at, value.Notes, value.Short_url))
+		record := course.NewCourse(value.Url, value.Max_attendees, value.Address, value.Tenant_id, value.Ext_id, value.Name, value.Timezone, value.Mode, value.Center_id, value.Status, value.Created_at, value.Num_attendees, value.Product_id, value.Updated_at, value.Notes, value.Short_url)
+		_, err := tapi.WriteToDB(record, present[i])
 		if err != nil {
-			json.NewEncoder(w).Encode(err)
+			tapi.RecordSyncFailure(record, value.Ext_id, err)
+			results = append(results, err)
+			failed++
 		} else {
-			json.NewEncoder(w).Encode(course)
+			results = append(results, course)
 		}
 	}
 	log.Println("this is what is being parsed:", courses)
+	writeResults(w, results, failed)
 
 }
@@ -12,23 +12,29 @@ import (
 func ProductHandler(w http.ResponseWriter, r *http.Request) {
 	var response []test_entity.Product
 	resp, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		log.Println("there was an error reading the body")
+	defer r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(resp, &response)
 	}
-	err = json.Unmarshal(resp, &response)
 	if err != nil {
-		log.Println("error in unmarshal process", err)
+		badRecords(w, err)
+		return
 	}
-	defer r.Body.Close()
 	log.Println("response:", string(resp))
-	for _, record := range response {
+	present := tapi.PresentFields(resp, len(response))
+	var results []any
+	failed := 0
+	for i, record := range response {
 		value := record.Value
-		_, err := tapi.WriteToDB(record.NewProduct(value.Updated_at, value.Created_at /*value.Is_deleted,*/, value.Format, value.Max_Attendees, value.Listing_Visibity, value.Event_Duration, value.Product, value.CType, value.Title, value.Name, value.TenantID, value.ExtID, value.Base_product_ext_id, value.Is_auto_approve))
+		product := record.NewProduct(value.Updated_at, value.Created_at /*value.Is_deleted,*/, value.Format, value.Max_Attendees, value.Listing_Visibity, value.Event_Duration, value.Product, value.CType, value.Title, value.Name, value.TenantID, value.ExtID, value.Base_product_ext_id, value.Is_auto_approve)
+		_, err := tapi.WriteToDB(product, present[i])
 		if err != nil {
-			json.NewEncoder(w).Encode(err)
+			tapi.RecordSyncFailure(product, value.ExtID, err)
+			results = append(results, err)
+			failed++
 		} else {
-			json.NewEncoder(w).Encode(record)
+			results = append(results, record)
 		}
 	}
-
+	writeResults(w, results, failed)
 }
@@ -0,0 +1,27 @@
+package sf_handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// writeResults writes the result of each record. The status is 500 if any
+// record failed to be written, so that the sender retries the batch; the
+// records already written are upserted again.
+func writeResults(w http.ResponseWriter, results []any, failed int) {
+	if failed > 0 {
+		log.Printf("%d of %d records failed to be written", failed, len(results))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	for _, result := range results {
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// badRecords reports a body that can not be read as records
+func badRecords(w http.ResponseWriter, err error) {
+	log.Println("unable to read the records", err)
+	w.WriteHeader(http.StatusBadRequest)
+	w.Write([]byte("Unable to read the records"))
+}
@@ -0,0 +1,30 @@
+package sf_handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeResults(t *testing.T) {
+	rr := httptest.NewRecorder()
+	writeResults(rr, []any{"ok", "ok"}, 0)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	writeResults(rr, []any{"ok", errors.New("write failed")}, 1)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"ok"`)
+}
+
+func Test_badRecords(t *testing.T) {
+	for _, h := range []http.HandlerFunc{CourseHandler, CenterHandler, ProductHandler, TimingHandler} {
+		rr := httptest.NewRecorder()
+		h(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{")))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	}
+}
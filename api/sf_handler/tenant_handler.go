@@ -21,7 +21,7 @@ func TenantHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 	for _, record := range tenants {
-		_, err := tapi.WriteToDB(&record)
+		_, err := tapi.WriteToDB(&record, nil)
 		if err == nil {
 			json.NewEncoder(w).Encode(record)
 			log.Println("insertion successful")
@@ -3,7 +3,6 @@ package sf_handler
 import (
 	"encoding/json"
 	"io/ioutil"
-	"log"
 	"net/http"
 	tapi "sudhagar/glad/api/tapi"
 	test_entity "sudhagar/glad/entity/sf_entity"
@@ -12,22 +11,26 @@ import (
 func TimingHandler(w http.ResponseWriter, r *http.Request) {
 	var response []test_entity.Timing
 	parse, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		log.Println("there was an error parsing the request body", err)
-	}
 	defer r.Body.Close()
-	err = json.Unmarshal(parse, &response)
+	if err == nil {
+		err = json.Unmarshal(parse, &response)
+	}
 	if err != nil {
-		log.Println("there was an error unmarshalling the request body", err)
+		badRecords(w, err)
+		return
 	}
-	for _, record := range response {
+	present := tapi.PresentFields(parse, len(response))
+	var results []any
+	failed := 0
+	for i, record := range response {
 		value := record.Value
-		_, err := tapi.WriteToDB(record.NewTiming(value.Course_id, value.Ext_id, value.Course_date, value.Start_time, value.End_time, value.Updated_at, value.Created_at))
+		_, err := tapi.WriteToDB(record.NewTiming(value.Course_id, value.Ext_id, value.Course_date, value.Start_time, value.End_time, value.Updated_at, value.Created_at), present[i])
 		if err == nil {
-			json.NewEncoder(w).Encode(record)
+			results = append(results, record)
 		} else {
-			json.NewEncoder(w).Encode(err)
+			results = append(results, err)
+			failed++
 		}
 	}
-	json.NewEncoder(w).Encode(response)
+	writeResults(w, append(results, response), failed)
 }
@@ -0,0 +1,447 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package syncer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+
+	"sudhagar/glad/api/presenter"
+	"sudhagar/glad/entity"
+	infra "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/metric"
+	"sudhagar/glad/repository"
+	"sudhagar/glad/usecase/control"
+)
+
+// inboundObjects sync object of the records of each inbound handler
+var inboundObjects = map[string]entity.SyncObject{
+	"account": entity.SyncObjectAccount,
+	"center":  entity.SyncObjectCenter,
+	"course":  entity.SyncObjectCourse,
+	"product": entity.SyncObjectProduct,
+	"timing":  entity.SyncObjectTiming,
+}
+
+// handlerOf returns the inbound handler name of the sync object
+func handlerOf(object entity.SyncObject) string {
+	for name, o := range inboundObjects {
+		if o == object {
+			return name
+		}
+	}
+	return string(object)
+}
+
+var (
+	controlOnce sync.Once
+	controlUC   control.UseCase
+	controlErr  error
+)
+
+// controlService returns the sync control usecase; replaced by tests
+var controlService = func() (control.UseCase, error) {
+	controlOnce.Do(func() {
+		sqlDB, err := infra.GetDB()
+		if err != nil {
+			controlErr = err
+			return
+		}
+		db, err := sqlDB.DB()
+		if err != nil {
+			controlErr = err
+			return
+		}
+		controlUC = control.NewService(repository.NewSyncControlPGSQL(db))
+	})
+	return controlUC, controlErr
+}
+
+// saveControlMetrics records the controls in effect and the held records
+func saveControlMetrics(s control.UseCase) {
+	metricService, err := metric.NewPrometheusService()
+	if err == nil {
+		err = control.SaveMetrics(s, metricService)
+	}
+	if err != nil {
+		log.Println("unable to record the sync controls", err)
+	}
+}
+
+// holdPaused holds the records whose inbound sync is paused, and passes the
+// others on to h. Records arriving while older ones of the same tenant and
+// object are held are held as well, so that they are applied in order.
+func holdPaused(object string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to read the records"))
+			return
+		}
+
+		apply, held, err := holdRecords(inboundObjects[object], body)
+		if err != nil {
+			log.Printf("unable to hold the paused %s records: %v", object, err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		if held > 0 {
+			log.Printf("held %d %s records while the sync is paused", held, object)
+		}
+		if apply == nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]int{"held": held})
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(apply))
+		h(w, r)
+	}
+}
+
+// holdRecords holds the paused records of the batch. Returns the records to
+// apply, nil if all were held, and the number held.
+func holdRecords(object entity.SyncObject, body []byte) ([]byte, int, error) {
+	var records []json.RawMessage
+	if err := json.Unmarshal(body, &records); err != nil || len(records) == 0 {
+		// Note: left to the handler to report
+		return body, 0, nil
+	}
+
+	s, err := controlService()
+	if err != nil {
+		return nil, 0, err
+	}
+	controls, err := s.ListControls()
+	if err != nil {
+		return nil, 0, err
+	}
+	stats, err := s.GetHeldStats()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(controls) == 0 && stats.Depth == 0 {
+		return body, 0, nil
+	}
+
+	// tenants with older records of the object still held
+	behind := map[entity.ID]bool{}
+	var apply []json.RawMessage
+	held := 0
+	for _, record := range records {
+		tenantID := recordTenant(record)
+		waiting, ok := behind[tenantID]
+		if !ok && stats.Depth > 0 {
+			items, err := s.ListHeld(tenantID, object, 1)
+			if err != nil && err != entity.ErrNotFound {
+				return nil, held, err
+			}
+			waiting = len(items) > 0
+			behind[tenantID] = waiting
+		}
+
+		if !waiting && !controls.For(tenantID, object, entity.SyncInbound).Paused {
+			apply = append(apply, record)
+			continue
+		}
+		if _, err := s.HoldInbound(tenantID, object, record); err != nil {
+			return nil, held, err
+		}
+		behind[tenantID] = true
+		held++
+	}
+	if held > 0 {
+		saveControlMetrics(s)
+	}
+
+	if len(apply) == 0 {
+		return nil, held, nil
+	}
+	if held == 0 {
+		return body, 0, nil
+	}
+	data, err := json.Marshal(apply)
+	return data, held, err
+}
+
+// recordTenant returns the tenant of an inbound record; IDInvalid if it has
+// none, e.g. a timing. Such records are held only by the controls of all the
+// tenants.
+func recordTenant(record []byte) entity.ID {
+	var r struct {
+		Value struct {
+			TenantID json.Number `json:"Tenant_id"`
+		} `json:"value"`
+	}
+	d := json.NewDecoder(bytes.NewReader(record))
+	d.UseNumber()
+	if err := d.Decode(&r); err != nil {
+		return entity.IDInvalid
+	}
+	id, err := entity.StringToID(r.Value.TenantID.String())
+	if err != nil {
+		return entity.IDInvalid
+	}
+	return id
+}
+
+// ReleaseHeld applies the held inbound records of the scope, oldest first.
+// Records still paused by another control are kept, and at most the
+// throttle of records of each object are applied. Stops at the first record
+// that fails to apply. Returns ErrReleaseBusy if another instance is
+// releasing them, otherwise the number of records applied.
+func ReleaseHeld(tenantID entity.ID, object entity.SyncObject) (int, error) {
+	unlock, err := lockRelease()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	return releaseHeld(tenantID, object)
+}
+
+// releaseHeld applies the held inbound records of the scope; the release
+// lock must be held
+func releaseHeld(tenantID entity.ID, object entity.SyncObject) (int, error) {
+	s, err := controlService()
+	if err != nil {
+		return 0, err
+	}
+	defer saveControlMetrics(s)
+
+	controls, err := s.ListControls()
+	if err != nil {
+		return 0, err
+	}
+	items, err := s.ListHeld(tenantID, object, 0)
+	if err == entity.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	taken := map[string]int{}
+	for _, i := range items {
+		key := i.TenantID.String() + "/" + string(i.Object)
+		c := controls.For(i.TenantID, i.Object, entity.SyncInbound)
+		if c.Paused || (c.Throttle > 0 && taken[key] >= c.Throttle) {
+			continue
+		}
+
+		records := append(append([]byte("["), i.Record...), ']')
+		if _, err := dispatch(inboundHandlers, handlerOf(i.Object), records); err != nil {
+			return released, fmt.Errorf("failed to apply held record %v: %w", i.ID, err)
+		}
+		if err := s.ReleaseHeld(i.ID); err != nil {
+			return released, fmt.Errorf("failed to release held record %v: %w", i.ID, err)
+		}
+		taken[key]++
+		released++
+	}
+	return released, nil
+}
+
+// controlRequest scope of a control request. Empty values select all the
+// tenants, objects or both directions.
+type controlRequest struct {
+	TenantID  string `json:"tenantId"`
+	Object    string `json:"object"`
+	Direction string `json:"direction"`
+	Reason    string `json:"reason"`
+	Limit     int    `json:"limit"`
+}
+
+// ControlScope the tenant, object and directions selected by the values of a
+// control request or command
+func ControlScope(tenant, object, direction string) (entity.ID, entity.SyncObject, []entity.SyncDirection, error) {
+	tenantID := entity.ID(entity.IDInvalid)
+	if tenant != "" {
+		id, err := entity.StringToID(tenant)
+		if err != nil {
+			return tenantID, "", nil, fmt.Errorf("invalid tenant %q: %w", tenant, entity.ErrInvalidEntity)
+		}
+		tenantID = id
+	}
+
+	syncObject := entity.SyncObjectAll
+	if object != "" {
+		syncObject = entity.SyncObject(object)
+	}
+
+	var directions []entity.SyncDirection
+	switch entity.SyncDirection(direction) {
+	case "":
+		directions = []entity.SyncDirection{entity.SyncInbound, entity.SyncOutbound}
+	case entity.SyncInbound, entity.SyncOutbound:
+		directions = []entity.SyncDirection{entity.SyncDirection(direction)}
+	default:
+		return tenantID, "", nil, fmt.Errorf("invalid direction %q: %w", direction, entity.ErrInvalidEntity)
+	}
+	return tenantID, syncObject, directions, nil
+}
+
+// ListControlsHandler returns the controls in effect and the held records
+func ListControlsHandler(w http.ResponseWriter, r *http.Request) {
+	s, err := controlService()
+	if err != nil {
+		writeControlError(w, err)
+		return
+	}
+	controls, err := s.ListControls()
+	if err != nil {
+		writeControlError(w, err)
+		return
+	}
+	stats, err := s.GetHeldStats()
+	if err != nil {
+		writeControlError(w, err)
+		return
+	}
+
+	toJ := presenter.SyncControls{
+		Controls:   []*presenter.SyncControl{},
+		Held:       stats.Depth,
+		OldestHeld: stats.Oldest,
+	}
+	for _, c := range controls {
+		var p presenter.SyncControl
+		p.CopyFrom(c)
+		toJ.Controls = append(toJ.Controls, &p)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toJ); err != nil {
+		log.Printf("Failed to encode the sync controls: %v", err)
+	}
+}
+
+// PauseHandler pauses the sync of the requested scope
+func PauseHandler(w http.ResponseWriter, r *http.Request) {
+	handleControl(w, r, func(s control.UseCase, req *controlRequest) error {
+		return forScope(req, func(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+			_, err := s.Pause(tenantID, object, direction, req.Reason)
+			return err
+		})
+	})
+}
+
+// ResumeHandler resumes the sync of the requested scope; the inbound records
+// held meanwhile are applied
+func ResumeHandler(w http.ResponseWriter, r *http.Request) {
+	handleControl(w, r, func(s control.UseCase, req *controlRequest) error {
+		err := forScope(req, func(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+			err := s.Resume(tenantID, object, direction)
+			if err == entity.ErrNotFound && req.Direction == "" {
+				// paused in one direction only
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return releaseScope(req)
+	})
+}
+
+// ThrottleHandler sets the throttle of the requested scope; 0 lifts it
+func ThrottleHandler(w http.ResponseWriter, r *http.Request) {
+	handleControl(w, r, func(s control.UseCase, req *controlRequest) error {
+		return forScope(req, func(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+			_, err := s.Throttle(tenantID, object, direction, req.Limit)
+			if err == entity.ErrNotFound && req.Limit == 0 {
+				// not throttled
+				return nil
+			}
+			return err
+		})
+	})
+}
+
+// ReleaseHandler applies the held inbound records of the requested scope
+// that are no longer paused, e.g. those left by a throttle
+func ReleaseHandler(w http.ResponseWriter, r *http.Request) {
+	handleControl(w, r, func(s control.UseCase, req *controlRequest) error {
+		return releaseScope(req)
+	})
+}
+
+func releaseScope(req *controlRequest) error {
+	tenantID, object, _, err := ControlScope(req.TenantID, req.Object, req.Direction)
+	if err != nil {
+		return err
+	}
+	released, err := ReleaseHeld(tenantID, object)
+	if errors.Is(err, ErrReleaseBusy) {
+		log.Println("Held records are released by the leader")
+		return nil
+	}
+	log.Printf("Released %d held records", released)
+	return err
+}
+
+func forScope(req *controlRequest,
+	apply func(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error,
+) error {
+	tenantID, object, directions, err := ControlScope(req.TenantID, req.Object, req.Direction)
+	if err != nil {
+		return err
+	}
+	for _, direction := range directions {
+		if err := apply(tenantID, object, direction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleControl decodes the control request, applies it and returns the
+// controls in effect
+func handleControl(w http.ResponseWriter, r *http.Request, apply func(s control.UseCase, req *controlRequest) error) {
+	var req controlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Unable to decode the control request"))
+		return
+	}
+	s, err := controlService()
+	if err != nil {
+		writeControlError(w, err)
+		return
+	}
+
+	err = apply(s, &req)
+	saveControlMetrics(s)
+	if err != nil {
+		writeControlError(w, err)
+		return
+	}
+	ListControlsHandler(w, r)
+}
+
+func writeControlError(w http.ResponseWriter, err error) {
+	log.Printf("Failed to control the sync: %v", err)
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, entity.ErrInvalidEntity):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	_, _ = w.Write([]byte(err.Error()))
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package syncer
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/control"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice entity.ID = 13790492210917015554
+	tenantBob   entity.ID = 13790492210917015555
+)
+
+func courseRecord(tenantID entity.ID, name string) string {
+	return `{"object": "Event__c", "value": {"Tenant_id": ` + tenantID.String() + `, "Name": "` + name + `"}}`
+}
+
+// withControls replaces the sync control usecase and the course handler;
+// returns the names of the applied courses
+func withControls(t *testing.T, s control.UseCase) *[]string {
+	var applied []string
+	service, lock, course := controlService, lockRelease, inboundHandlers["course"]
+	controlService = func() (control.UseCase, error) { return s, nil }
+	lockRelease = func() (func(), error) { return func() {}, nil }
+	inboundHandlers["course"] = func(w http.ResponseWriter, r *http.Request) {
+		var records []struct {
+			Value struct {
+				Name string
+			} `json:"value"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &records)
+		for _, rec := range records {
+			applied = append(applied, rec.Value.Name)
+		}
+	}
+	t.Cleanup(func() {
+		controlService, lockRelease, inboundHandlers["course"] = service, lock, course
+	})
+	return &applied
+}
+
+func Test_HoldAndRelease(t *testing.T) {
+	s := control.NewService(control.NewInmem())
+	applied := withControls(t, s)
+	h := holdPaused("course", inboundHandlers["course"])
+
+	_, err := s.Pause(tenantAlice, entity.SyncObjectCourse, entity.SyncInbound, "data fix")
+	assert.Nil(t, err)
+
+	rr := httptest.NewRecorder()
+	h(rr, httptest.NewRequest(http.MethodPost, "/course", strings.NewReader(
+		"["+courseRecord(tenantAlice, "a1")+","+courseRecord(tenantBob, "b1")+","+courseRecord(tenantAlice, "a2")+"]")))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"b1"}, *applied)
+
+	rr = httptest.NewRecorder()
+	h(rr, httptest.NewRequest(http.MethodPost, "/course", strings.NewReader("["+courseRecord(tenantAlice, "a3")+"]")))
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+
+	// still paused
+	released, err := ReleaseHeld(tenantAlice, entity.SyncObjectAll)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, released)
+
+	// resumed in order, a throttled release at a time
+	_, err = s.Throttle(tenantAlice, entity.SyncObjectCourse, entity.SyncInbound, 2)
+	assert.Nil(t, err)
+	err = s.Resume(tenantAlice, entity.SyncObjectCourse, entity.SyncInbound)
+	assert.Nil(t, err)
+	released, err = ReleaseHeld(tenantAlice, entity.SyncObjectAll)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, released)
+	assert.Equal(t, []string{"b1", "a1", "a2"}, *applied)
+
+	// held behind the record not yet released
+	rr = httptest.NewRecorder()
+	h(rr, httptest.NewRequest(http.MethodPost, "/course", strings.NewReader("["+courseRecord(tenantAlice, "a4")+"]")))
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+
+	released, err = ReleaseHeld(entity.IDInvalid, entity.SyncObjectAll)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, released)
+	assert.Equal(t, []string{"b1", "a1", "a2", "a3", "a4"}, *applied)
+
+	stats, _ := s.GetHeldStats()
+	assert.Equal(t, 0, stats.Depth)
+}
+
+func Test_recordTenant(t *testing.T) {
+	assert.Equal(t, tenantAlice, recordTenant([]byte(courseRecord(tenantAlice, "a1"))))
+	assert.Equal(t, tenantAlice, recordTenant([]byte(`{"value": {"Tenant_id": "13790492210917015554"}}`)))
+	assert.Equal(t, entity.ID(entity.IDInvalid), recordTenant([]byte(`{"value": {"Course_id": "a0B"}}`)))
+}
+
+func Test_ControlScope(t *testing.T) {
+	tenantID, object, directions, err := ControlScope("", "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ID(entity.IDInvalid), tenantID)
+	assert.Equal(t, entity.SyncObjectAll, object)
+	assert.Equal(t, 2, len(directions))
+
+	tenantID, object, directions, err = ControlScope(tenantAlice.String(), "course", "outbound")
+	assert.Nil(t, err)
+	assert.Equal(t, tenantAlice, tenantID)
+	assert.Equal(t, entity.SyncObjectCourse, object)
+	assert.Equal(t, []entity.SyncDirection{entity.SyncOutbound}, directions)
+
+	_, _, _, err = ControlScope("alice", "", "")
+	assert.ErrorIs(t, err, entity.ErrInvalidEntity)
+	_, _, _, err = ControlScope("", "", "sideways")
+	assert.ErrorIs(t, err, entity.ErrInvalidEntity)
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package syncer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	export "sudhagar/glad/api/rds_to_sf"
+	"sudhagar/glad/config"
+	"sudhagar/glad/entity"
+	infra "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/util"
+	service "sudhagar/glad/usecase/sf_export"
+)
+
+// advisory locks of the singleton sync jobs
+const (
+	listenerLock    = "sync-change-listener"
+	releaseHeldLock = "sync-release-held"
+)
+
+// ErrReleaseBusy the held records are being released by another instance
+var ErrReleaseBusy = errors.New("held records are being released by another instance")
+
+// lockRelease takes the lock of the release of the held records, which are
+// applied in order and so by one instance at a time; replaced by tests
+var lockRelease = func() (func(), error) {
+	db, err := openDB()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := infra.TryLock(context.Background(), db, releaseHeldLock)
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		return nil, ErrReleaseBusy
+	}
+	return lock.Release, nil
+}
+
+func openDB() (*sql.DB, error) {
+	gormDB, err := infra.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	return gormDB.DB()
+}
+
+// RunJobs runs the sync jobs until ctx is done. Every instance exports the
+// pending changes and deletes it claims; the change listener and the release
+// of the held records run on the leader only, elected with advisory locks.
+func RunJobs(ctx context.Context) error {
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	retry := time.Duration(util.GetIntEnvOrConfig("SYNC_LEADER_RETRY_SECONDS", config.SYNC_LEADER_RETRY_SECONDS)) * time.Second
+	interval := time.Duration(util.GetIntEnvOrConfig("SYNC_EXPORT_INTERVAL_SECONDS", config.SYNC_EXPORT_INTERVAL_SECONDS)) * time.Second
+
+	// capture changes made outside the API as export work
+	go func() {
+		_ = infra.RunAsLeader(ctx, db, listenerLock, retry, export.ListenForChanges)
+	}()
+	go func() {
+		_ = infra.RunAsLeader(ctx, db, releaseHeldLock, retry, func(ctx context.Context) error {
+			return every(ctx, retry, func() {
+				if _, err := releaseHeld(entity.IDInvalid, entity.SyncObjectAll); err != nil {
+					log.Println("unable to release the held records:", err)
+				}
+			})
+		})
+	}()
+
+	if interval <= 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return every(ctx, interval, func() {
+		sfService, err := service.NewSFExportService()
+		if err != nil {
+			log.Println("unable to export the pending changes:", err)
+			return
+		}
+		if _, err := sfService.ExportOutbox(0); err != nil {
+			log.Println("unable to export the pending changes:", err)
+		}
+		if _, err := sfService.ExportTombstones(0); err != nil {
+			log.Println("unable to export the pending deletes:", err)
+		}
+	})
+}
+
+// every runs job every interval until ctx is done
+func every(ctx context.Context, interval time.Duration, job func()) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			job()
+		}
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package syncer
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	export "sudhagar/glad/api/rds_to_sf"
+	handler "sudhagar/glad/api/sf_handler"
+
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+)
+
+// PathPrefix prefix of the sync endpoints when served from the API server
+const PathPrefix = "/sync"
+
+// inboundHandlers apply the records of each object
+var inboundHandlers = map[string]http.HandlerFunc{
+	"account": handler.AccountHandler,
+	"center":  handler.CenterHandler,
+	"course":  handler.CourseHandler,
+	"product": handler.ProductHandler,
+	"timing":  handler.TimingHandler,
+}
+
+// InboundHandlers inbound handlers per object; records whose inbound sync is
+// paused are held until resumed
+var InboundHandlers = func() map[string]http.HandlerFunc {
+	handlers := map[string]http.HandlerFunc{}
+	for object, h := range inboundHandlers {
+		handlers[object] = holdPaused(object, h)
+	}
+	return handlers
+}()
+
+// Dispatch applies the records of the object through its inbound handler, as
+// if SF had sent them; returns the handler response
+func Dispatch(object string, records []byte) (string, error) {
+	return dispatch(InboundHandlers, object, records)
+}
+
+func dispatch(handlers map[string]http.HandlerFunc, object string, records []byte) (string, error) {
+	h, ok := handlers[object]
+	if !ok {
+		return "", fmt.Errorf("import of %q is not supported", object)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/"+object, bytes.NewReader(records))
+	rr := httptest.NewRecorder()
+	h(rr, req)
+	if rr.Code >= http.StatusBadRequest {
+		return rr.Body.String(), fmt.Errorf("%s handler returned status %d", object, rr.Code)
+	}
+	return rr.Body.String(), nil
+}
+
+// MakeSyncHandlers make the inbound (SF to RDS), export and sync control url
+// handlers. The inbound handlers are served with the callout middleware,
+// which authenticates the SF org of a tenant; each applies the records of
+// that tenant only; the sync controls and the exports are served with the
+// operator middleware.
+func MakeSyncHandlers(r *mux.Router, callout, operator negroni.Negroni) {
+	handle := func(path, name string, h http.HandlerFunc) {
+		r.Handle(path, operator.With(
+			negroni.Wrap(h),
+		)).Name(name)
+	}
+	inbound := func(path, name, object string) {
+		r.Handle(path, callout.With(
+			negroni.Wrap(pinTenant(object, InboundHandlers[object])),
+		)).Name(name)
+	}
+
+	inbound("/account", "syncAccount", "account")
+	inbound("/course", "syncCourse", "course")
+	inbound("/product", "syncProduct", "product")
+	inbound("/timing", "syncTiming", "timing")
+	inbound("/center", "syncCenter", "center")
+
+	// pause, resume and throttle the sync
+	r.Handle("/controls", operator.With(
+		negroni.Wrap(http.HandlerFunc(ListControlsHandler)),
+	)).Methods("GET", "OPTIONS").Name("listSyncControls")
+	handle("/controls/pause", "pauseSync", PauseHandler)
+	handle("/controls/resume", "resumeSync", ResumeHandler)
+	handle("/controls/throttle", "throttleSync", ThrottleHandler)
+	handle("/controls/release", "releaseHeld", ReleaseHandler)
+
+	// Note: registered before {id} so that these are not taken as a course id
+	handle("/rds/export/tombstones", "exportTombstones", export.ExportTombstonesHandler)
+	handle("/rds/export/preview", "previewExport", export.PreviewHandler)
+	handle("/rds/export/outbox", "exportOutbox", export.ExportOutboxHandler)
+	handle("/rds/export/{id}", "exportCourse", export.ExportHandler)
+}
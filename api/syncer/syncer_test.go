@@ -0,0 +1,63 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package syncer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MakeSyncHandlers(t *testing.T) {
+	r := mux.NewRouter()
+	n := negroni.New()
+	MakeSyncHandlers(r.PathPrefix(PathPrefix).Subrouter(), *n, *n)
+
+	path, err := r.GetRoute("syncCourse").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/sync/course", path)
+
+	path, err = r.GetRoute("exportCourse").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/sync/rds/export/{id}", path)
+
+	path, err = r.GetRoute("pauseSync").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/sync/controls/pause", path)
+
+	// not taken as a course id
+	var match mux.RouteMatch
+	assert.True(t, r.Match(newRequest("/sync/rds/export/outbox"), &match))
+	assert.Equal(t, "exportOutbox", match.Route.GetName())
+}
+
+func Test_MakeSyncHandlersOperator(t *testing.T) {
+	r := mux.NewRouter()
+	n := negroni.New()
+	operator := negroni.New(negroni.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+	MakeSyncHandlers(r.PathPrefix(PathPrefix).Subrouter(), *n, *operator)
+
+	for _, path := range []string{"/sync/controls/pause", "/sync/controls/resume",
+		"/sync/controls/throttle", "/sync/controls/release", "/sync/rds/export/1",
+		"/sync/rds/export/preview", "/sync/rds/export/outbox", "/sync/rds/export/tombstones"} {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, newRequest(path))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, path)
+	}
+}
+
+func newRequest(path string) *http.Request {
+	req, _ := http.NewRequest("POST", path, nil)
+	return req
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package syncer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"sudhagar/glad/entity"
+	infra "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/common"
+	"sudhagar/glad/repository"
+)
+
+// courseTenant returns the tenant of a course, IDInvalid if there is no such
+// course; replaced by tests
+var courseTenant = func(courseID entity.ID) (entity.ID, error) {
+	sqlDB, err := infra.GetDB()
+	if err != nil {
+		return entity.IDInvalid, err
+	}
+	db, err := sqlDB.DB()
+	if err != nil {
+		return entity.IDInvalid, err
+	}
+	c, err := repository.NewCoursePGSQL(db).GetByID(courseID)
+	if err != nil || c == nil {
+		return entity.IDInvalid, err
+	}
+	return c.TenantID, nil
+}
+
+// pinTenant passes the records on to h only if all of them are of the tenant
+// of the request, as set by the callout middleware
+func pinTenant(object string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID, err := entity.StringToID(r.Header.Get(common.HttpHeaderTenantID))
+		if err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("Missing tenant"))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		var records []json.RawMessage
+		if err == nil {
+			err = json.Unmarshal(body, &records)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to read the records"))
+			return
+		}
+
+		for _, record := range records {
+			owner, err := ownerOf(object, record)
+			if err != nil {
+				log.Printf("unable to get the tenant of the %s record: %v", object, err)
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("Error reading the tenant of the records"))
+				return
+			}
+			if owner != tenantID {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte("Record doesn't belong to the tenant"))
+				return
+			}
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		h(w, r)
+	}
+}
+
+// ownerOf returns the tenant of an inbound record; a timing is of the tenant
+// of its course
+func ownerOf(object string, record []byte) (entity.ID, error) {
+	if inboundObjects[object] != entity.SyncObjectTiming {
+		return recordTenant(record), nil
+	}
+
+	var r struct {
+		Value struct {
+			CourseID json.Number `json:"Course_id"`
+		} `json:"value"`
+	}
+	d := json.NewDecoder(bytes.NewReader(record))
+	d.UseNumber()
+	if err := d.Decode(&r); err != nil {
+		return entity.IDInvalid, nil
+	}
+	courseID, err := entity.StringToID(r.Value.CourseID.String())
+	if err != nil {
+		return entity.IDInvalid, nil
+	}
+	return courseTenant(courseID)
+}
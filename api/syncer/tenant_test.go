@@ -0,0 +1,66 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package syncer
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pinTenant(t *testing.T) {
+	lookup := courseTenant
+	courseTenant = func(courseID entity.ID) (entity.ID, error) {
+		if courseID == 42 {
+			return tenantAlice, nil
+		}
+		return entity.IDInvalid, nil
+	}
+	t.Cleanup(func() { courseTenant = lookup })
+
+	var applied string
+	serve := func(object, tenant, body string) int {
+		applied = ""
+		h := pinTenant(object, func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			applied = string(data)
+		})
+		req := httptest.NewRequest(http.MethodPost, "/"+object, strings.NewReader(body))
+		if tenant != "" {
+			req.Header.Set(common.HttpHeaderTenantID, tenant)
+		}
+		rr := httptest.NewRecorder()
+		h(rr, req)
+		return rr.Code
+	}
+
+	body := "[" + courseRecord(tenantAlice, "a1") + "]"
+	assert.Equal(t, http.StatusOK, serve("course", tenantAlice.String(), body))
+	assert.Equal(t, body, applied)
+
+	// a batch with a record of another tenant is not applied
+	body = "[" + courseRecord(tenantAlice, "a1") + "," + courseRecord(tenantBob, "b1") + "]"
+	assert.Equal(t, http.StatusForbidden, serve("course", tenantAlice.String(), body))
+	assert.Empty(t, applied)
+	assert.Equal(t, http.StatusForbidden, serve("course", "", body))
+
+	// a timing is of the tenant of its course
+	timing := `[{"object": "Timing__c", "value": {"Course_id": 42}}]`
+	assert.Equal(t, http.StatusOK, serve("timing", tenantAlice.String(), timing))
+	assert.Equal(t, http.StatusForbidden, serve("timing", tenantBob.String(), timing))
+	assert.Equal(t, http.StatusForbidden, serve("timing", tenantAlice.String(),
+		`[{"object": "Timing__c", "value": {"Course_id": 43}}]`))
+
+	assert.Equal(t, http.StatusBadRequest, serve("course", tenantAlice.String(), "{"))
+}
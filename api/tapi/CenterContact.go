@@ -0,0 +1,67 @@
+package tapi
+
+import (
+	"fmt"
+	"time"
+
+	"sudhagar/glad/entity"
+	ops "sudhagar/glad/ops/db"
+)
+
+// center contact fields in SF, mapped to the primary contact of the center
+var centerContactFields = map[string]string{
+	"Contact_Name__c":  "name",
+	"Contact_Phone__c": "phone",
+	"Contact_Email__c": "email",
+}
+
+// WriteCenterContact applies the contact fields of a center of the tenant
+// received from SF to the primary contact of the center, creating it if
+// needed. Only the fields present in the payload are applied, as change
+// events carry the changed fields only; all of them if present is nil.
+// Fields owned locally are skipped.
+func WriteCenterContact(tenantID int, extID, name, phone, email string, present map[string]bool) error {
+	values := map[string]string{
+		"Contact_Name__c":  name,
+		"Contact_Phone__c": phone,
+		"Contact_Email__c": email,
+	}
+	rules := fieldOwnership()
+	updates := map[string]any{}
+	for field, v := range values {
+		if (present == nil || present[field]) && rules.Accepts(entity.SyncObjectCenter, field, entity.SyncInbound) {
+			updates[centerContactFields[field]] = v
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	db, err := ops.GetDB()
+	if err != nil {
+		return err
+	}
+	var centerIDs []int64
+	result := db.Table("center").Where("ext_id = ? AND tenant_id = ?", extID, tenantID).Limit(1).Pluck("id", &centerIDs)
+	if result.Error != nil {
+		return result.Error
+	}
+	if len(centerIDs) == 0 {
+		return fmt.Errorf("center %s not found", extID)
+	}
+
+	now := time.Now()
+	updates["updated_at"] = now
+	result = db.Table("center_contact").
+		Where("center_id = ? AND is_primary", centerIDs[0]).
+		Updates(updates)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+
+	updates["id"] = int64(entity.NewID())
+	updates["center_id"] = centerIDs[0]
+	updates["is_primary"] = true
+	updates["created_at"] = now
+	return db.Table("center_contact").Create(updates).Error
+}
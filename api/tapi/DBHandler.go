@@ -3,11 +3,52 @@ package tapi
 // todo: write the data to rds db
 //  todo: <entity> operations are done in rds,
 import (
+	"context"
+	"errors"
 	"log"
+	"reflect"
+	"sync"
+	"time"
+
 	ops "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/metric"
+
+	"gorm.io/gorm"
+)
+
+var (
+	syncMetric     metric.SyncService
+	syncMetricOnce sync.Once
 )
 
-func WriteToDB(record any) (string, error) {
+// saveInbound counts an inbound record of the given table
+func saveInbound(table, result string) {
+	syncMetricOnce.Do(func() {
+		s, err := metric.NewPrometheusService()
+		if err != nil {
+			log.Println("unable to create the metric service", err)
+			return
+		}
+		syncMetric = s
+	})
+	if syncMetric != nil {
+		syncMetric.SaveSyncRecord(metric.NewSyncRecord(table, "inbound", result))
+	}
+}
+
+// tableOf returns the table name of the gorm model
+func tableOf(db *gorm.DB, record any) string {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(record); err != nil {
+		return "unknown"
+	}
+	return stmt.Schema.Table
+}
+
+// WriteToDB writes a record received from SF. A record already imported is
+// updated with the fields present in the payload, keeping the fields we own;
+// all the fields if present is nil.
+func WriteToDB(record any, present map[string]bool) (string, error) {
 	db, err := ops.GetDB()
 	if err != nil {
 		log.Println("there is an error fetching the db", err)
@@ -15,11 +56,68 @@ func WriteToDB(record any) (string, error) {
 	if db == nil {
 		log.Println("db is nil")
 	}
+	table := tableOf(db, record)
+	saveInbound(table, metric.SyncReceived)
+	updated, err := updateExisting(db, table, record, present)
+	if err != nil {
+		log.Println("error occurred in the update process", err)
+		saveInbound(table, metric.SyncFailed)
+		return "", err
+	}
+	if updated {
+		saveInbound(table, metric.SyncApplied)
+		return "success", nil
+	}
+
 	log.Println("inserting record now:", record)
 	result := db.Create(record)
 	if result.Error != nil {
 		log.Println("error occurred in the write process", result.Error)
+		saveInbound(table, metric.SyncFailed)
 		return "", result.Error
 	}
+	saveInbound(table, metric.SyncApplied)
 	return "success", nil
 }
+
+// RecordSyncFailure marks the already imported record having the given ext
+// id, of the tenant of the record, as failed
+func RecordSyncFailure(record any, extID string, syncErr error) {
+	db, err := ops.GetDB()
+	if err != nil || db == nil {
+		log.Println("unable to record the sync failure, db is not available", err)
+		return
+	}
+	if err := markSyncFailed(db, record, extID, syncErr); err != nil {
+		log.Println("error occurred recording the sync failure", err)
+	}
+}
+
+// markSyncFailed marks the record of the ext id and the tenant as failed.
+// Note: ext ids are unique per SF org, so per tenant
+func markSyncFailed(db *gorm.DB, record any, extID string, syncErr error) error {
+	tenantID, ok := tenantOf(db, record)
+	if !ok {
+		return errors.New("the record has no tenant")
+	}
+	return db.Model(record).Where("ext_id = ? AND tenant_id = ?", extID, tenantID).Updates(map[string]any{
+		"last_synced_at":      time.Now(),
+		"last_sync_direction": "inbound",
+		"sync_status":         "failed",
+		"last_sync_error":     syncErr.Error(),
+	}).Error
+}
+
+// tenantOf returns the tenant id of the gorm model, if it has one
+func tenantOf(db *gorm.DB, record any) (any, bool) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(record); err != nil {
+		return nil, false
+	}
+	tenantField := stmt.Schema.LookUpField("tenant_id")
+	if tenantField == nil {
+		return nil, false
+	}
+	tenantID, zero := tenantField.ValueOf(context.Background(), reflect.Indirect(reflect.ValueOf(record)))
+	return tenantID, !zero
+}
@@ -0,0 +1,32 @@
+package tapi
+
+import (
+	"errors"
+	"testing"
+
+	sf_entity "sudhagar/glad/entity/sf_entity"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+func Test_markSyncFailed(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	assert.Nil(t, err)
+
+	// only the record of the tenant is marked
+	var stmt *gorm.Statement
+	_ = db.Callback().Update().After("gorm:update").Register("test:statement", func(tx *gorm.DB) {
+		stmt = tx.Statement
+	})
+	record := &sf_entity.Course_value{Tenant_id: 7, Ext_id: "a0B1"}
+	assert.Nil(t, markSyncFailed(db, record, "a0B1", errors.New("invalid status")))
+	assert.Contains(t, stmt.SQL.String(), "ext_id = ? AND tenant_id = ?")
+	assert.Contains(t, stmt.Vars, "a0B1")
+	assert.Contains(t, stmt.Vars, 7)
+
+	// not marked without a tenant
+	record = &sf_entity.Course_value{Ext_id: "a0B1"}
+	assert.NotNil(t, markSyncFailed(db, record, "a0B1", errors.New("invalid status")))
+}
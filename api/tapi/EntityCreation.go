@@ -5,7 +5,7 @@ import "log"
 type EntityType string
 
 func EntityCreationHandler(entity EntityType) (string, string) {
-	_, err := WriteToDB(entity)
+	_, err := WriteToDB(entity, nil)
 	if err != nil {
 		log.Println("there was an error writing the entity to the DB")
 		return "", ""
@@ -0,0 +1,189 @@
+package tapi
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+
+	"sudhagar/glad/config"
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/util"
+	"sudhagar/glad/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	ownership     entity.FieldOwnership
+	ownershipOnce sync.Once
+)
+
+// fieldOwnership returns the configured field ownership rules
+func fieldOwnership() entity.FieldOwnership {
+	ownershipOnce.Do(func() {
+		o, err := entity.ParseFieldOwnership(util.GetStrEnvOrConfig("SF_FIELD_OWNERS", config.SF_FIELD_OWNERS))
+		if err != nil {
+			log.Println("invalid field ownership rules, all fields are shared", err)
+			o = entity.FieldOwnership{}
+		}
+		ownership = o
+	})
+	return ownership
+}
+
+// updateExisting applies the record over the local record of the same ext id
+// and tenant, if any, and reports whether there was one. Fields owned locally
+// keep their value; a different inbound value is recorded as a conflict.
+// Only the fields present in the payload are applied, as change events carry
+// the changed fields only; all of them if present is nil. The sync columns
+// are always applied, marking the record synced.
+func updateExisting(db *gorm.DB, table string, record any, present map[string]bool) (bool, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(record); err != nil {
+		return false, err
+	}
+	extField := stmt.Schema.LookUpField("ext_id")
+	if extField == nil {
+		return false, nil
+	}
+	ctx := context.Background()
+	rv := reflect.Indirect(reflect.ValueOf(record))
+	extID, zero := extField.ValueOf(ctx, rv)
+	if zero {
+		return false, nil
+	}
+	// Note: ext ids are unique per SF org, so per tenant
+	scoped := func() *gorm.DB {
+		q := db.Table(table).Where("ext_id = ?", extID)
+		if tenantField := stmt.Schema.LookUpField("tenant_id"); tenantField != nil {
+			tenantID, _ := tenantField.ValueOf(ctx, rv)
+			q = q.Where("tenant_id = ?", tenantID)
+		}
+		return q
+	}
+
+	var existing map[string]any
+	result := scoped().Limit(1).Find(&existing)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	object := entity.SyncObject(table)
+	rules := fieldOwnership()
+	updates := map[string]any{}
+	for _, f := range stmt.Schema.Fields {
+		if f.DBName == "" || f.DBName == "ext_id" || f.DBName == "tenant_id" || f.DBName == "created_at" {
+			continue
+		}
+		v, _ := f.ValueOf(ctx, rv)
+		name := jsonName(f)
+		if name == "-" {
+			updates[f.DBName] = v
+			continue
+		}
+		if present != nil && !present[name] {
+			continue
+		}
+		if rules.Accepts(object, name, entity.SyncInbound) {
+			updates[f.DBName] = v
+			continue
+		}
+		if !sameValue(existing[f.DBName], v) {
+			recordConflict(db, entity.NewSyncConflict(object, idOf(existing["id"]), fmt.Sprint(extID), name,
+				entity.SyncInbound, rules.Owner(object, name), valueString(v), valueString(existing[f.DBName])))
+		}
+	}
+	if len(updates) == 0 {
+		return true, nil
+	}
+	return true, scoped().Updates(updates).Error
+}
+
+// PresentFields returns the SF fields present in the value of each of the n
+// records of the batch; nil, i.e. all the fields, for the records that can
+// not be read
+func PresentFields(body []byte, n int) []map[string]bool {
+	fields := make([]map[string]bool, n)
+	var records []struct {
+		Value map[string]json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(body, &records); err != nil {
+		return fields
+	}
+	for i := 0; i < n && i < len(records); i++ {
+		if records[i].Value == nil {
+			continue
+		}
+		fields[i] = map[string]bool{}
+		for k := range records[i].Value {
+			fields[i][k] = true
+		}
+	}
+	return fields
+}
+
+// recordConflict logs and saves an inbound write refused by the rules
+func recordConflict(db *gorm.DB, c *entity.SyncConflict) {
+	log.Printf("sync conflict: %s %v %s is owned by %s, not importing %q", c.Object, c.EntityID, c.Field, c.Owner, c.Attempted)
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Println("unable to record the sync conflict", err)
+		return
+	}
+	if err := repository.NewConflictPGSQL(sqlDB).Create(c); err != nil {
+		log.Println("unable to record the sync conflict", err)
+	}
+}
+
+// jsonName returns the SF field name of the model field
+func jsonName(f *schema.Field) string {
+	name, _, _ := strings.Cut(f.StructField.Tag.Get("json"), ",")
+	return name
+}
+
+// idOf converts a scanned id column
+func idOf(v any) entity.ID {
+	switch id := v.(type) {
+	case int64:
+		return entity.ID(id)
+	case int32:
+		return entity.ID(id)
+	}
+	return 0
+}
+
+// valueString returns the value as stored in the database
+func valueString(v any) string {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if dv, err := valuer.Value(); err == nil {
+			v = dv
+		}
+	}
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
+
+// sameValue compares a scanned column with a model value; JSON columns are
+// compared decoded
+func sameValue(stored, v any) bool {
+	a, b := valueString(stored), valueString(v)
+	if a == b {
+		return true
+	}
+	var ja, jb any
+	if json.Unmarshal([]byte(a), &ja) == nil && json.Unmarshal([]byte(b), &jb) == nil {
+		return reflect.DeepEqual(ja, jb)
+	}
+	return false
+}
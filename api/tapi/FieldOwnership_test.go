@@ -0,0 +1,22 @@
+package tapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PresentFields(t *testing.T) {
+	body := []byte(`[
+		{"object": "Event__c", "value": {"Id": "a0B1", "Notes__c": "", "Max_attendees__c": 0}},
+		{"object": "Event__c"}
+	]`)
+	fields := PresentFields(body, 2)
+	assert.Len(t, fields, 2)
+	assert.Equal(t, map[string]bool{"Id": true, "Notes__c": true, "Max_attendees__c": true}, fields[0])
+	assert.Nil(t, fields[1])
+
+	// all the fields of the records that can not be read
+	fields = PresentFields([]byte(`{`), 1)
+	assert.Equal(t, []map[string]bool{nil}, fields)
+}
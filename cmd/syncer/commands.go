@@ -0,0 +1,453 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"sudhagar/glad/api/middleware"
+	"sudhagar/glad/api/syncer"
+	"sudhagar/glad/config"
+	"sudhagar/glad/entity"
+	infra "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/cometd"
+	"sudhagar/glad/pkg/metric"
+	"sudhagar/glad/pkg/util"
+	"sudhagar/glad/repository"
+	"sudhagar/glad/usecase/center"
+	"sudhagar/glad/usecase/control"
+	"sudhagar/glad/usecase/course"
+	"sudhagar/glad/usecase/outbox"
+	"sudhagar/glad/usecase/product"
+	service "sudhagar/glad/usecase/sf_export"
+	"sudhagar/glad/usecase/stream"
+	"sudhagar/glad/usecase/tombstone"
+
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// serve runs the inbound server until interrupted
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	port := fs.Int("port", 4001, "port to listen on")
+	_ = fs.Parse(args)
+
+	metricService, err := metric.NewPrometheusService()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	r := mux.NewRouter()
+	secrets, err := middleware.ParseCalloutSecrets(
+		util.GetStrEnvOrConfig("SYNC_CALLOUT_SECRETS", config.SYNC_CALLOUT_SECRETS))
+	if err != nil {
+		return err
+	}
+	callout := negroni.New(
+		negroni.HandlerFunc(middleware.Metrics(metricService)),
+		negroni.HandlerFunc(middleware.AuthenticateCallout(secrets)),
+		negroni.NewLogger(),
+	)
+	operator := negroni.New(
+		negroni.HandlerFunc(middleware.Metrics(metricService)),
+		negroni.HandlerFunc(middleware.AuthenticateOperator(
+			util.GetStrEnvOrConfig("SYNC_OPERATOR_TOKEN", config.SYNC_OPERATOR_TOKEN))),
+		negroni.NewLogger(),
+	)
+	syncer.MakeSyncHandlers(r, *callout, *operator)
+	r.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		if err := syncer.RunJobs(ctx); err != nil && err != context.Canceled {
+			log.Println("sync jobs stopped:", err)
+		}
+	}()
+
+	srv := &http.Server{
+		Addr:    ":" + strconv.Itoa(*port),
+		Handler: r,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Println("now listening at port", *port)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// subscribe applies the SF streaming events of a tenant until interrupted;
+// with several instances, the one holding the lock of the tenant subscribes
+// and another takes over if it stops
+func subscribe(args []string) error {
+	fs := flag.NewFlagSet("subscribe", flag.ExitOnError)
+	tenant := fs.String("tenant", "", "tenant id the events are applied to")
+	channels := fs.String("channels",
+		util.GetStrEnvOrConfig("SF_STREAM_CHANNELS", config.SF_STREAM_CHANNELS),
+		"comma separated change data capture or platform event channels")
+	replayFrom := fs.Int64("replay-from", cometd.ReplayNew,
+		"replay id of the channels without a saved position: -1 new events, -2 all retained events")
+	_ = fs.Parse(args)
+
+	tenantID, err := entity.StringToID(*tenant)
+	if err != nil {
+		return fmt.Errorf("invalid tenant %q", *tenant)
+	}
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := &cometd.Client{
+		URL: cometd.NewURL(util.GetStrEnvOrConfig("SF_INSTANCE_URL", config.SF_INSTANCE_URL),
+			util.GetStrEnvOrConfig("SF_API_VERSION", config.SF_API_VERSION)),
+		Token: util.GenerateTokens,
+	}
+	dispatch := func(object string, records []byte) error {
+		_, err := syncer.Dispatch(object, records)
+		return err
+	}
+	s := stream.NewSubscriber(tenantID, strings.Split(*channels, ","), client,
+		repository.NewReplayPGSQL(db), dispatch, *replayFrom)
+
+	retry := time.Duration(util.GetIntEnvOrConfig("SYNC_LEADER_RETRY_SECONDS", config.SYNC_LEADER_RETRY_SECONDS)) * time.Second
+	err = infra.RunAsLeader(ctx, db, "sync-subscribe:"+tenantID.String(), retry, func(ctx context.Context) error {
+		log.Println("subscribed to", *channels)
+		return s.Run(ctx)
+	})
+	if err != context.Canceled {
+		return err
+	}
+	return nil
+}
+
+// export exports courses or the pending queues
+func export(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	object := fs.String("object", string(entity.SyncObjectCourse), "object to export")
+	tenant := fs.String("tenant", "", "tenant id; exports all the objects of the tenant")
+	id := fs.String("id", "", "id of the object to export")
+	dryRun := fs.Bool("dry-run", false, "print the payloads instead of sending them")
+	pending := fs.Bool("pending", false, "export the pending changes and deletes")
+	limit := fs.Int("limit", 0, "max pending changes and deletes to export; 0 for all")
+	bulk := fs.Bool("bulk", false, "export all the courses or accounts of the tenant with the Bulk API")
+	_ = fs.Parse(args)
+
+	sfService, err := service.NewSFExportService()
+	if err != nil {
+		return err
+	}
+
+	if *pending {
+		changes, err := sfService.ExportOutbox(*limit)
+		if err != nil {
+			return err
+		}
+		deletes, err := sfService.ExportTombstones(*limit)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("exported %d changes and %d deletes\n", changes, deletes)
+		return nil
+	}
+
+	if *bulk {
+		return exportBulk(sfService, entity.SyncObject(*object), *tenant, *dryRun)
+	}
+
+	if entity.SyncObject(*object) != entity.SyncObjectCourse {
+		return fmt.Errorf("export of %s is not supported", *object)
+	}
+
+	var ids []entity.ID
+	switch {
+	case *id != "":
+		courseID, err := entity.StringToID(*id)
+		if err != nil {
+			return fmt.Errorf("invalid id %q", *id)
+		}
+		if !*dryRun {
+			return sfService.ExportToSF(courseID)
+		}
+		ids = append(ids, courseID)
+	case *tenant != "":
+		tenantID, err := entity.StringToID(*tenant)
+		if err != nil {
+			return fmt.Errorf("invalid tenant %q", *tenant)
+		}
+		if !*dryRun {
+			count, err := sfService.ExportCourses(tenantID)
+			fmt.Printf("exported %d courses\n", count)
+			return err
+		}
+		ids, err = courseIDs(tenantID)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("one of -id, -tenant or -pending is required")
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sfService.PreviewCourses(ids))
+}
+
+// exportBulk exports the records of the tenant with Bulk API jobs
+func exportBulk(sfService *service.SFExportService, object entity.SyncObject, tenant string, dryRun bool) error {
+	tenantID, err := entity.StringToID(tenant)
+	if err != nil {
+		return fmt.Errorf("invalid tenant %q", tenant)
+	}
+	result, err := sfService.ExportBulk(context.Background(), object, tenantID, dryRun)
+	if result == nil {
+		return err
+	}
+
+	if dryRun {
+		fmt.Printf("%d %s records to send, %d invalid\n", result.Sent, object, len(result.Failed))
+	} else {
+		fmt.Printf("jobs %v: %d of %d %s records applied\n", result.Jobs, result.Applied, result.Sent, object)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for id, msg := range result.Failed {
+		fmt.Fprintf(w, "%v\t%s\n", id, msg)
+	}
+	w.Flush()
+	return err
+}
+
+// courseIDs lists the ids of the courses of the tenant
+func courseIDs(tenantID entity.ID) ([]entity.ID, error) {
+	db, err := openDB()
+	if err != nil {
+		return nil, err
+	}
+	courses, err := repository.NewCoursePGSQL(db).List(tenantID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	var ids []entity.ID
+	for _, c := range courses {
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
+
+// importFile applies a file of SF records through the inbound handlers
+func importFile(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	object := fs.String("object", "", "object of the records: account, center, course, product or timing")
+	file := fs.String("file", "", "JSON file with the records as sent by SF")
+	_ = fs.Parse(args)
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	out, err := syncer.Dispatch(*object, data)
+	fmt.Println(out)
+	return err
+}
+
+// reconcile finds the drifted courses of a tenant; skipped if another
+// instance is reconciling it
+func reconcile(args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	tenant := fs.String("tenant", "", "tenant id")
+	dryRun := fs.Bool("dry-run", false, "report the drift without queueing the exports")
+	_ = fs.Parse(args)
+
+	tenantID, err := entity.StringToID(*tenant)
+	if err != nil {
+		return fmt.Errorf("invalid tenant %q", *tenant)
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	lock, err := infra.TryLock(context.Background(), db, "sync-reconcile:"+tenantID.String())
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		fmt.Println("the tenant is being reconciled by another instance")
+		return nil
+	}
+	defer lock.Release()
+
+	sfService, err := service.NewSFExportService()
+	if err != nil {
+		return err
+	}
+	results, err := sfService.Reconcile(context.Background(), tenantID, *dryRun)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		fmt.Printf("%s: %d checked, %d drifted\n", r.Object, r.Checked, len(r.Drifted))
+		for _, id := range r.Drifted {
+			fmt.Println("  ", id)
+		}
+	}
+	return nil
+}
+
+// replay queues the failed exports again
+func replay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	id := fs.String("id", "", "id of the failed item; all failed items if not set")
+	limit := fs.Int("limit", 0, "max failed items to replay; 0 for all")
+	_ = fs.Parse(args)
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	outboxService := outbox.NewService(repository.NewOutboxPGSQL(db), service.OutboxRetry())
+
+	var items []*entity.OutboxItem
+	if *id != "" {
+		itemID, err := entity.StringToID(*id)
+		if err != nil {
+			return fmt.Errorf("invalid id %q", *id)
+		}
+		item, err := outboxService.GetOutboxItem(itemID)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	} else {
+		items, err = outboxService.ListFailedOutbox(*limit)
+		if err == entity.ErrNotFound {
+			fmt.Println("nothing to replay")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, item := range items {
+		if _, err := outboxService.ReplayOutboxItem(item.ID); err != nil {
+			return fmt.Errorf("failed to replay %v: %w", item.ID, err)
+		}
+	}
+	fmt.Printf("replayed %d items\n", len(items))
+	return nil
+}
+
+// status prints the sync queues, the sync controls and the sync status of a
+// tenant
+func status(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	tenant := fs.String("tenant", "", "tenant id; shows the sync status of its records")
+	_ = fs.Parse(args)
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	outboxService := outbox.NewService(repository.NewOutboxPGSQL(db), service.OutboxRetry())
+	tombstoneService := tombstone.NewService(repository.NewTombstonePGSQL(db), nil)
+	controlService := control.NewService(repository.NewSyncControlPGSQL(db))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "QUEUE\tPENDING\tOLDEST\tFAILED")
+	stats, err := outboxService.GetPendingStats()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "outbox\t%d\t%s\t%d\n", stats.Depth, formatTime(stats.Oldest), stats.Failed)
+	stats, err = tombstoneService.GetPendingStats()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "tombstone\t%d\t%s\t-\n", stats.Depth, formatTime(stats.Oldest))
+	stats, err = controlService.GetHeldStats()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "inbox\t%d\t%s\t-\n", stats.Depth, formatTime(stats.Oldest))
+	if err := printControls(w, controlService); err != nil {
+		return err
+	}
+
+	if *tenant == "" {
+		return nil
+	}
+	tenantID, err := entity.StringToID(*tenant)
+	if err != nil {
+		return fmt.Errorf("invalid tenant %q", *tenant)
+	}
+
+	courseService := course.NewService(repository.NewCoursePGSQL(db), nil, nil, nil)
+	centerService := center.NewService(repository.NewCenterPGSQL(db), nil)
+	productService := product.NewService(repository.NewProductPGSQL(db), nil)
+
+	fmt.Fprintln(w, "\nOBJECT\tPENDING\tSYNCED\tFAILED")
+	for _, object := range []entity.SyncObject{
+		entity.SyncObjectCourse,
+		entity.SyncObjectCenter,
+		entity.SyncObjectProduct,
+	} {
+		var counts map[entity.SyncStatus]int
+		switch object {
+		case entity.SyncObjectCourse:
+			counts, err = courseService.CountCoursesBySyncStatus(tenantID)
+		case entity.SyncObjectCenter:
+			counts, err = centerService.CountCentersBySyncStatus(tenantID)
+		case entity.SyncObjectProduct:
+			counts, err = productService.CountProductsBySyncStatus(tenantID)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, object)
+		for _, st := range []entity.SyncStatus{entity.SyncPending, entity.SyncSynced, entity.SyncFailed} {
+			fmt.Fprintf(w, "\t%d", counts[st])
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/repository"
+	"sudhagar/glad/usecase/connector"
+	"sudhagar/glad/usecase/connector/file"
+	service "sudhagar/glad/usecase/sf_export"
+)
+
+// newConnector returns the connector of the named system
+func newConnector(system, dir string) (connector.Connector, error) {
+	switch system {
+	case entity.ExternalSystemFile:
+		if dir == "" {
+			return nil, fmt.Errorf("-dir is required by the %s connector", system)
+		}
+		return file.New(dir), nil
+	case entity.ExternalSystemSalesforce:
+		sfService, err := service.NewSFExportService()
+		if err != nil {
+			return nil, err
+		}
+		return sfService.Connector(), nil
+	}
+	return nil, fmt.Errorf("unknown connector %q", system)
+}
+
+// tenantEntity entity of a tenant to push
+type tenantEntity struct {
+	id entity.ID
+	v  any
+}
+
+// listEntities lists the entities of the object of the tenant
+func listEntities(db *sql.DB, object entity.SyncObject, tenantID entity.ID) ([]tenantEntity, error) {
+	var list []tenantEntity
+	switch object {
+	case entity.SyncObjectCourse:
+		courses, err := repository.NewCoursePGSQL(db).List(tenantID, 0, 0)
+		for _, e := range courses {
+			list = append(list, tenantEntity{e.ID, e})
+		}
+		return list, err
+	case entity.SyncObjectCenter:
+		centers, err := repository.NewCenterPGSQL(db).List(tenantID, 0, 0)
+		for _, e := range centers {
+			list = append(list, tenantEntity{e.ID, e})
+		}
+		return list, err
+	case entity.SyncObjectProduct:
+		products, err := repository.NewProductPGSQL(db).List(tenantID, 0, 0)
+		for _, e := range products {
+			list = append(list, tenantEntity{e.ID, e})
+		}
+		return list, err
+	case entity.SyncObjectAccount:
+		accounts, err := repository.NewAccountPGSQL(db).List(tenantID, 0, 0, "")
+		for _, e := range accounts {
+			list = append(list, tenantEntity{e.ID, e})
+		}
+		return list, err
+	}
+	return nil, fmt.Errorf("push of %s is not supported", object)
+}
+
+// push pushes the entities of a tenant through a connector
+func push(args []string) error {
+	fs := flag.NewFlagSet("push", flag.ExitOnError)
+	system := fs.String("connector", entity.ExternalSystemFile, "connector: file or salesforce")
+	dir := fs.String("dir", "", "drop directory of the file connector")
+	object := fs.String("object", string(entity.SyncObjectCourse), "object to push")
+	tenant := fs.String("tenant", "", "tenant id")
+	_ = fs.Parse(args)
+
+	tenantID, err := entity.StringToID(*tenant)
+	if err != nil {
+		return fmt.Errorf("invalid tenant %q", *tenant)
+	}
+	c, err := newConnector(*system, *dir)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	entities, err := listEntities(db, entity.SyncObject(*object), tenantID)
+	if err != nil {
+		return err
+	}
+
+	connectorService := connector.NewService(repository.NewExternalRefPGSQL(db))
+	pushed := 0
+	for _, e := range entities {
+		_, err := connectorService.Push(ctx, c, tenantID, entity.SyncObject(*object), e.id, e.v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to push %s %v: %v\n", *object, e.id, err)
+			continue
+		}
+		pushed++
+	}
+	fmt.Printf("pushed %d of %d %s records to %s\n", pushed, len(entities), *object, c.System())
+	return nil
+}
+
+// pull prints the records changed in an external system
+func pull(args []string) error {
+	fs := flag.NewFlagSet("pull", flag.ExitOnError)
+	system := fs.String("connector", entity.ExternalSystemFile, "connector: file or salesforce")
+	dir := fs.String("dir", "", "drop directory of the file connector")
+	object := fs.String("object", string(entity.SyncObjectCourse), "object to pull")
+	cursor := fs.String("cursor", "", "cursor returned by the previous pull")
+	_ = fs.Parse(args)
+
+	c, err := newConnector(*system, *dir)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	connectorService := connector.NewService(repository.NewExternalRefPGSQL(db))
+	records, next, err := connectorService.Pull(ctx, c, entity.SyncObject(*object), *cursor)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(records); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "next cursor:", next)
+	return nil
+}
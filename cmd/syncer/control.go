@@ -0,0 +1,177 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"sudhagar/glad/api/syncer"
+	"sudhagar/glad/entity"
+	"sudhagar/glad/repository"
+	"sudhagar/glad/usecase/control"
+)
+
+// scopeFlags flags selecting the tenant, object and directions to control
+type scopeFlags struct {
+	tenant    *string
+	object    *string
+	direction *string
+}
+
+func newScopeFlags(fs *flag.FlagSet) *scopeFlags {
+	return &scopeFlags{
+		tenant:    fs.String("tenant", "", "tenant id; all the tenants if not set"),
+		object:    fs.String("object", "", "object, e.g. course or course_timing; all the objects if not set"),
+		direction: fs.String("direction", "", "inbound or outbound; both if not set"),
+	}
+}
+
+// apply applies fn to each direction of the scope
+func (f *scopeFlags) apply(fn func(s control.UseCase,
+	tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+) error) error {
+	tenantID, object, directions, err := syncer.ControlScope(*f.tenant, *f.object, *f.direction)
+	if err != nil {
+		return err
+	}
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	s := control.NewService(repository.NewSyncControlPGSQL(db))
+	for _, direction := range directions {
+		if err := fn(s, tenantID, object, direction); err != nil {
+			return fmt.Errorf("%s: %w", direction, err)
+		}
+	}
+	return nil
+}
+
+// pause pauses the sync; the work stays queued until resumed
+func pause(args []string) error {
+	fs := flag.NewFlagSet("pause", flag.ExitOnError)
+	scope := newScopeFlags(fs)
+	reason := fs.String("reason", "", "why the sync is paused, e.g. the SF maintenance window")
+	_ = fs.Parse(args)
+
+	return scope.apply(func(s control.UseCase, tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+		if _, err := s.Pause(tenantID, object, direction, *reason); err != nil {
+			return err
+		}
+		fmt.Printf("paused %s sync of %s\n", direction, scopeName(tenantID, object))
+		return nil
+	})
+}
+
+// resume resumes the sync and applies the inbound records held meanwhile;
+// outbound work is exported by the next export run
+func resume(args []string) error {
+	fs := flag.NewFlagSet("resume", flag.ExitOnError)
+	scope := newScopeFlags(fs)
+	_ = fs.Parse(args)
+
+	err := scope.apply(func(s control.UseCase, tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+		err := s.Resume(tenantID, object, direction)
+		if err == entity.ErrNotFound && *scope.direction == "" {
+			// paused in one direction only
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("resumed %s sync of %s\n", direction, scopeName(tenantID, object))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return releaseHeld(scope)
+}
+
+// throttle limits the records synced per run
+func throttle(args []string) error {
+	fs := flag.NewFlagSet("throttle", flag.ExitOnError)
+	scope := newScopeFlags(fs)
+	limit := fs.Int("limit", 0, "max records of each object per export run or release; 0 lifts the limit")
+	_ = fs.Parse(args)
+
+	return scope.apply(func(s control.UseCase, tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+		_, err := s.Throttle(tenantID, object, direction, *limit)
+		if err == entity.ErrNotFound && *limit == 0 {
+			// not throttled
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("throttled %s sync of %s to %d records\n", direction, scopeName(tenantID, object), *limit)
+		return nil
+	})
+}
+
+// release applies the held inbound records that are no longer paused
+func release(args []string) error {
+	fs := flag.NewFlagSet("release", flag.ExitOnError)
+	scope := newScopeFlags(fs)
+	_ = fs.Parse(args)
+
+	return releaseHeld(scope)
+}
+
+func releaseHeld(scope *scopeFlags) error {
+	if *scope.direction == string(entity.SyncOutbound) {
+		return nil
+	}
+	tenantID, object, _, err := syncer.ControlScope(*scope.tenant, *scope.object, *scope.direction)
+	if err != nil {
+		return err
+	}
+	released, err := syncer.ReleaseHeld(tenantID, object)
+	if errors.Is(err, syncer.ErrReleaseBusy) {
+		fmt.Println("the held records are released by the leader instance")
+		return nil
+	}
+	fmt.Printf("applied %d held records\n", released)
+	return err
+}
+
+// printControls prints the controls in effect
+func printControls(w io.Writer, s control.UseCase) error {
+	controls, err := s.ListControls()
+	if err != nil {
+		return err
+	}
+	if len(controls) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w, "\nTENANT\tOBJECT\tDIRECTION\tPAUSED\tTHROTTLE\tREASON")
+	for _, c := range controls {
+		tenant := "*"
+		if c.TenantID != entity.IDInvalid {
+			tenant = c.TenantID.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%d\t%s\n", tenant, c.Object, c.Direction, c.Paused, c.Throttle, c.Reason)
+	}
+	return nil
+}
+
+func scopeName(tenantID entity.ID, object entity.SyncObject) string {
+	tenant := "all the tenants"
+	if tenantID != entity.IDInvalid {
+		tenant = "tenant " + tenantID.String()
+	}
+	if object == entity.SyncObjectAll {
+		return "all the objects of " + tenant
+	}
+	return string(object) + " of " + tenant
+}
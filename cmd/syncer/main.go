@@ -0,0 +1,90 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	infra "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/metric"
+)
+
+const usage = `usage: syncer <command> [flags]
+
+commands:
+  serve      run the inbound (SF to RDS) server and the change listener
+  export     export courses, accounts in bulk, or the pending changes and deletes, to SF
+  import     import SF records from a file as the inbound server does
+  subscribe  apply the SF change data capture and platform events of a tenant
+  push       push the records of a tenant to an external system
+  pull       show the records changed in an external system
+  reconcile  find courses that drifted from SF and queue them for export
+  replay     queue the failed (dead-lettered) exports again
+  pause      pause the sync of a tenant or object; the work stays queued
+  resume     resume the sync and apply the inbound records held meanwhile
+  throttle   limit the records synced per run of a tenant or object
+  release    apply the held inbound records that are no longer paused
+  status     show the sync queues, the paused syncs and the sync status of a tenant
+
+Run 'syncer <command> -h' for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	commands := map[string]func(args []string) error{
+		"serve":     serve,
+		"export":    export,
+		"import":    importFile,
+		"subscribe": subscribe,
+		"push":      push,
+		"pull":      pull,
+		"reconcile": reconcile,
+		"replay":    replay,
+		"pause":     pause,
+		"resume":    resume,
+		"throttle":  throttle,
+		"release":   release,
+		"status":    status,
+	}
+	name := os.Args[1]
+	run, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", name, usage)
+		os.Exit(2)
+	}
+
+	metricService, err := metric.NewPrometheusService()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	appMetric := metric.NewCLI("syncer-" + name)
+	appMetric.Started()
+	err = run(os.Args[2:])
+	appMetric.Finished()
+	if mErr := metricService.SaveCLI(appMetric); mErr != nil {
+		log.Println("unable to push the metrics:", mErr)
+	}
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// openDB the database shared with the inbound path
+func openDB() (*sql.DB, error) {
+	gormDB, err := infra.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	return gormDB.DB()
+}
@@ -1,21 +0,0 @@
-/*
- * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
- * All rights reserved.
- * This code may not be used, copied, modified, or distributed without explicit permission.
- */
-
-package main
-
-import (
-	"os"
-	"os/signal"
-	"syscall"
-)
-
-func main() {
-
-	// Listen to Ctrl+C (you can also do something else that prevents the program from exiting)
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	<-c
-}
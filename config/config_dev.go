@@ -23,6 +23,90 @@ const (
 	// API port
 	API_PORT = 8080
 
+	// Bearer authentication; the tokens are validated against the key set of
+	// the issuer, <issuer>/.well-known/jwks.json, unless AUTH_JWKS_URL or a
+	// local AUTH_JWKS_FILE is set. The public paths are comma separated.
+	AUTH_ENABLED              = false
+	AUTH_ISSUER               = ""
+	AUTH_AUDIENCE             = ""
+	AUTH_JWKS_URL             = ""
+	AUTH_JWKS_FILE            = ""
+	AUTH_JWKS_REFRESH_SECONDS = 300
+	AUTH_PUBLIC_PATHS         = "/v1/config"
+
+	// Client configuration served at /v1/config; the endpoints are comma
+	// separated <type>=<url>, e.g. auth=https://auth.example.org
+	CONFIG_TIMEZONES = "EST,CST,MST,PST"
+	CONFIG_ENDPOINTS = ""
+
+	// Course change notifications: "smtp", "file" or "" to not send them;
+	// the file sender writes the messages to NOTIFY_FILE, stderr if not set
+	NOTIFY_SENDER = "file"
+	NOTIFY_FILE   = ""
+	NOTIFY_FROM   = "noreply@glad.example.org"
+	SMTP_HOST     = "127.0.0.1"
+	SMTP_PORT     = 587
+	SMTP_USERNAME = ""
+	SMTP_PASSWORD = ""
+	// the connection to the SMTP server and each message time out after
+	SMTP_TIMEOUT_SECONDS = 10
+	// the changed courses are notified every interval; 0 disables it
+	NOTIFY_INTERVAL_SECONDS = 10
+
+	// Serve the Salesforce sync endpoints under /sync of the API server
+	SYNC_ENABLED = true
+	// Shared secrets of the Salesforce callouts to the inbound sync endpoints,
+	// comma separated "<tenant id>:<secret>"; sent in the X-GLAD-SyncSecret
+	// header. The org of a tenant writes the records of that tenant only.
+	SYNC_CALLOUT_SECRETS = "5306526529902621696:dev-sync-secret"
+	// Token of the operator requests (sync controls and exports) to the
+	// standalone syncer, sent in the X-GLAD-OperatorToken header; without it
+	// the operator routes reject every request.
+	SYNC_OPERATOR_TOKEN = "dev-operator-token"
+
+	// Sync jobs; every instance exports the pending changes it claims for the
+	// lease, the leader runs the singleton jobs
+	SYNC_EXPORT_INTERVAL_SECONDS = 30 /* 0 disables the export job */
+	SYNC_CLAIM_LEASE_SECONDS     = 300
+	SYNC_LEADER_RETRY_SECONDS    = 15
+
+	// Retry of the failed exports; the backoff doubles on every attempt and
+	// the item is left as a dead letter after the max attempts
+	SYNC_RETRY_BACKOFF_SECONDS     = 60
+	SYNC_RETRY_MAX_BACKOFF_SECONDS = 3600
+	SYNC_RETRY_MAX_ATTEMPTS        = 8
+
 	// Metrics
 	PROMETHEUS_PUSHGATEWAY = "http://localhost:9091/"
+
+	// Salesforce delete propagation per object: "delete" or "cancel"
+	SF_DELETE_ACTION_COURSE  = "cancel"
+	SF_DELETE_ACTION_CENTER  = "delete"
+	SF_DELETE_ACTION_PRODUCT = "delete"
+	SF_DELETE_ACTION_ACCOUNT = "delete"
+
+	// Salesforce API limits per org
+	SF_RATE_LIMIT              = 5   /* requests per second */
+	SF_RATE_BURST              = 10  /* requests */
+	SF_MAX_CONCURRENT          = 5   /* requests in flight */
+	SF_QUOTA_PAUSE_PERCENT     = 80  /* of the daily quota; non-urgent exports pause above */
+	SF_QUOTA_USAGE_TTL_SECONDS = 600 /* the reported usage is unknown after; a request is let through to refresh it */
+
+	// Salesforce Bulk API 2.0; the external id field holds our record id on
+	// the mapped objects
+	SF_INSTANCE_URL           = "https://aol-dev--awspoc.sandbox.my.salesforce.com"
+	SF_ORG                    = "aol-dev--awspoc" /* org of the instance; its API limits are shared by all its clients */
+	SF_API_VERSION            = "v60.0"
+	SF_BULK_EXTERNAL_ID_FIELD = "Glad_Id__c"
+	SF_BULK_POLL_SECONDS      = 5
+
+	// Salesforce streaming channels applied by the subscriber
+	SF_STREAM_CHANNELS = "/data/ChangeEvents"
+
+	// Field ownership, <object>:<SF field>=<salesforce|local|shared>; other
+	// fields are shared and the last sync wins
+	SF_FIELD_OWNERS = "course:Status__c=salesforce,course:Number_Of_Students__c=salesforce," +
+		"course:Notes__c=local,course:Address=local," +
+		"course:Street_Address_1__c=local,course:Street_Address_2__c=local,course:City__c=local," +
+		"course:State__c=local,course:Zip_Postal_Code__c=local,course:Country__c=local"
 )
@@ -19,9 +19,20 @@ const (
 	AccountOrganizer        AccountType = "organizer"
 	AccountMember           AccountType = "member"
 	AccountUser             AccountType = "user"
+	AccountAdmin            AccountType = "admin"
 	// Add new types here
 )
 
+// AccountTypes all the account types
+var AccountTypes = []AccountType{
+	AccountTeacher,
+	AccountAssistantTeacher,
+	AccountOrganizer,
+	AccountMember,
+	AccountUser,
+	AccountAdmin,
+}
+
 // Account data
 type Account struct {
 	ID        ID
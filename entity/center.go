@@ -53,6 +53,11 @@ type Center struct {
 	IsNationalCenter bool
 	IsEnabled        bool
 
+	Contacts []*CenterContact
+
+	// sync meta data
+	Sync SyncState
+
 	// meta data
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -136,6 +141,7 @@ func NewCenter(tenantID ID,
 		WebPage:          webPage,
 		IsNationalCenter: isNationalCenter,
 		IsEnabled:        isEnabled,
+		Sync:             SyncState{Status: SyncPending},
 		CreatedAt:        time.Now(),
 	}
 	err := c.Validate()
@@ -152,3 +158,45 @@ func (c *Center) Validate() error {
 	}
 	return nil
 }
+
+// Center contact
+type CenterContact struct {
+	ID       ID
+	CenterID ID
+	Name     string
+	Phone    string
+	Email    string
+	// the contact of the center in Salesforce; one per center at most
+	IsPrimary bool
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCenterContact create a new center contact
+func NewCenterContact(centerID ID, name, phone, email string, isPrimary bool) (*CenterContact, error) {
+	c := &CenterContact{
+		ID:        NewID(),
+		CenterID:  centerID,
+		Name:      name,
+		Phone:     phone,
+		Email:     email,
+		IsPrimary: isPrimary,
+		CreatedAt: time.Now(),
+	}
+	err := c.Validate()
+	if err != nil {
+		return nil, ErrInvalidEntity
+	}
+	return c, nil
+}
+
+// Validate validates center contact; a contact has a name and a phone or
+// an email
+func (c *CenterContact) Validate() error {
+	if c.CenterID == IDInvalid || c.Name == "" || (c.Phone == "" && c.Email == "") {
+		return ErrInvalidEntity
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Config endpoint type
+type ConfigEndpointType string
+
+const (
+	ConfigEndpointAuth        ConfigEndpointType = "auth"
+	ConfigEndpointMediaImages ConfigEndpointType = "media-images"
+	ConfigEndpointMediaVideos ConfigEndpointType = "media-videos"
+	ConfigEndpointAnalytics   ConfigEndpointType = "analytics"
+	ConfigEndpointLogging     ConfigEndpointType = "logging"
+	// Add new types here
+)
+
+// IsValid checks whether the endpoint type is a known value
+func (t ConfigEndpointType) IsValid() bool {
+	switch t {
+	case ConfigEndpointAuth, ConfigEndpointMediaImages, ConfigEndpointMediaVideos,
+		ConfigEndpointAnalytics, ConfigEndpointLogging:
+		return true
+	}
+	return false
+}
+
+// ConfigEndpoint a service endpoint of the clients
+type ConfigEndpoint struct {
+	Type ConfigEndpointType
+	URL  string
+}
+
+// ParseConfigEndpoints parses comma separated <type>=<url> endpoints
+func ParseConfigEndpoints(s string) ([]ConfigEndpoint, error) {
+	var endpoints []ConfigEndpoint
+	for _, e := range strings.Split(s, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		t, url, ok := strings.Cut(e, "=")
+		if !ok || url == "" || !ConfigEndpointType(t).IsValid() {
+			return nil, fmt.Errorf("invalid config endpoint %q", e)
+		}
+		endpoints = append(endpoints, ConfigEndpoint{Type: ConfigEndpointType(t), URL: url})
+	}
+	return endpoints, nil
+}
+
+// ConfigTenant the tenant settings of the clients
+type ConfigTenant struct {
+	ID      ID
+	Name    string
+	Country string
+}
+
+// Config the configuration of the clients of a tenant; Version changes
+// whenever any of the rest changes
+type Config struct {
+	Version int64
+
+	Tenant         ConfigTenant
+	Timezones      []string
+	CourseStatuses []CourseStatus
+	CourseModes    []CourseMode
+	AccountTypes   []AccountType
+	ProductFormats []ProductFormat
+	Endpoints      []ConfigEndpoint
+}
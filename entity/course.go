@@ -7,6 +7,8 @@
 package entity
 
 import (
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -19,6 +21,12 @@ const (
 	// Add new types here
 )
 
+// CourseModes all the course modes
+var CourseModes = []CourseMode{
+	CourseInPerson,
+	CourseOnline,
+}
+
 // Course status
 type CourseStatus string
 
@@ -37,6 +45,54 @@ const (
 	// Add new types here
 )
 
+// CourseStatuses all the course statuses
+var CourseStatuses = []CourseStatus{
+	CourseDraft,
+	CourseArchived,
+	CourseOpen,
+	CourseExpenseSubmitted,
+	CourseExpenseDeclined,
+	CourseClosed,
+	CourseActive,
+	CourseDeclined,
+	CourseSubmitted,
+	CourseCanceled,
+	CoursedInactive,
+}
+
+// IsValid checks whether the course status is a known value
+func (s CourseStatus) IsValid() bool {
+	return slices.Contains(CourseStatuses, s)
+}
+
+// Course role of an account
+type CourseRole string
+
+const (
+	CourseRoleTeacher   CourseRole = "teacher"
+	CourseRoleOrganizer CourseRole = "organizer"
+	CourseRoleContact   CourseRole = "contact"
+	CourseRoleNotify    CourseRole = "notify"
+	// Add new types here
+)
+
+// CourseRoles all the roles of an account in a course
+var CourseRoles = []CourseRole{
+	CourseRoleTeacher,
+	CourseRoleOrganizer,
+	CourseRoleContact,
+	CourseRoleNotify,
+}
+
+// IsValid checks whether the course role is a known value
+func (r CourseRole) IsValid() bool {
+	switch r {
+	case CourseRoleTeacher, CourseRoleOrganizer, CourseRoleContact, CourseRoleNotify:
+		return true
+	}
+	return false
+}
+
 // Course Address
 type CourseAddress struct {
 	Street1 string
@@ -76,6 +132,9 @@ type Course struct {
 	MaxAttendees int32
 	NumAttendees int32
 
+	// sync meta data
+	Sync SyncState
+
 	// meta data
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -112,6 +171,17 @@ func (l *CourseAddress) Validate() error {
 	return nil
 }
 
+// String formats the address on one line, skipping the empty parts
+func (l CourseAddress) String() string {
+	var parts []string
+	for _, p := range []string{l.Street1, l.Street2, l.City, l.State, l.Zip, l.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // NewCourse create a new course
 func NewCourse(tenantID ID,
 	extID *string,
@@ -140,6 +210,7 @@ func NewCourse(tenantID ID,
 		Mode:         mode,
 		MaxAttendees: maxAttendees,
 		NumAttendees: numAttendees,
+		Sync:         SyncState{Status: SyncPending},
 		CreatedAt:    time.Now(),
 	}
 	err := c.Validate()
@@ -156,3 +227,58 @@ func (c *Course) Validate() error {
 	}
 	return nil
 }
+
+// max accounts per course in each role
+const (
+	MaxCourseTeachers   = 3
+	MaxCourseOrganizers = 3
+	MaxCourseContacts   = 1
+)
+
+// Course teacher
+type CourseTeacher struct {
+	ID        ID
+	IsPrimary bool
+}
+
+// CourseAccounts the teachers, organizers and contacts of a course; all of
+// them are optional
+type CourseAccounts struct {
+	CourseID ID
+
+	Teachers   []CourseTeacher
+	Organizers []ID
+	Contacts   []ID
+}
+
+// Validate validates the number of accounts in each role; an account is
+// assigned once per role and a course has one primary teacher at most
+func (a *CourseAccounts) Validate() error {
+	if len(a.Teachers) > MaxCourseTeachers ||
+		len(a.Organizers) > MaxCourseOrganizers ||
+		len(a.Contacts) > MaxCourseContacts {
+		return ErrInvalidEntity
+	}
+
+	primary := 0
+	var teachers []ID
+	for _, t := range a.Teachers {
+		if t.IsPrimary {
+			primary++
+		}
+		teachers = append(teachers, t.ID)
+	}
+	if primary > 1 {
+		return ErrInvalidEntity
+	}
+	for _, ids := range [][]ID{teachers, a.Organizers, a.Contacts} {
+		seen := map[ID]bool{}
+		for _, id := range ids {
+			if id == IDInvalid || seen[id] {
+				return ErrInvalidEntity
+			}
+			seen[id] = true
+		}
+	}
+	return nil
+}
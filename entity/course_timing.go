@@ -0,0 +1,104 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// course date/time formats
+const (
+	CourseDateLayout = "2006-01-02"
+	CourseTimeLayout = "15:04:05"
+	// seconds are optional
+	courseShortTimeLayout = "15:04"
+)
+
+// CourseTiming a date of a course with its start and end time
+type CourseTiming struct {
+	ID       ID
+	CourseID ID
+
+	ExtID *string
+
+	DateTime CourseDateTime
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCourseTiming create a new course timing
+func NewCourseTiming(courseID ID, extID *string, dateTime CourseDateTime) (*CourseTiming, error) {
+	t := &CourseTiming{
+		ID:        NewID(),
+		CourseID:  courseID,
+		ExtID:     extID,
+		DateTime:  dateTime,
+		CreatedAt: time.Now(),
+	}
+	err := t.Validate()
+	if err != nil {
+		return nil, ErrInvalidEntity
+	}
+	return t, nil
+}
+
+// Validate validate course timing
+func (t *CourseTiming) Validate() error {
+	if t.CourseID == IDInvalid {
+		return ErrInvalidEntity
+	}
+	return t.DateTime.Validate()
+}
+
+// Validate validates the date, and the start and end time if set; the
+// course must end after it starts
+func (dt *CourseDateTime) Validate() error {
+	if _, err := time.Parse(CourseDateLayout, dt.Date); err != nil {
+		return ErrInvalidEntity
+	}
+	start, err := parseCourseTime(dt.StartTime)
+	if err != nil {
+		return ErrInvalidEntity
+	}
+	end, err := parseCourseTime(dt.EndTime)
+	if err != nil {
+		return ErrInvalidEntity
+	}
+	if start != nil && end != nil && !end.After(*start) {
+		return ErrInvalidEntity
+	}
+	return nil
+}
+
+// String formats the date and the times, e.g. "2024-05-02 09:00-12:00"
+func (dt CourseDateTime) String() string {
+	s := dt.Date
+	if dt.StartTime != "" {
+		s += " " + dt.StartTime
+		if dt.EndTime != "" {
+			s += "-" + dt.EndTime
+		}
+	}
+	return s
+}
+
+// parseCourseTime parses a time in HH:MM:SS or HH:MM format; nil if not set
+func parseCourseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(CourseTimeLayout, s)
+	if err != nil {
+		t, err = time.Parse(courseShortTimeLayout, s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
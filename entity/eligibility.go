@@ -0,0 +1,66 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// Teaching eligibility type
+type EligibilityType string
+
+const (
+	// teaches as the primary teacher or as an assistant
+	EligibilityPrimary EligibilityType = "primary"
+	// teaches as an assistant only
+	EligibilityAssistant EligibilityType = "assistant"
+)
+
+// IsValid checks whether the eligibility type is known
+func (t EligibilityType) IsValid() bool {
+	return t == EligibilityPrimary || t == EligibilityAssistant
+}
+
+// TeacherEligibility a product a teacher can teach
+type TeacherEligibility struct {
+	ProductID ID
+	TeacherID ID
+	Type      EligibilityType
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewTeacherEligibility create a new teacher eligibility
+func NewTeacherEligibility(productID ID, teacherID ID, t EligibilityType) (*TeacherEligibility, error) {
+	e := &TeacherEligibility{
+		ProductID: productID,
+		TeacherID: teacherID,
+		Type:      t,
+		CreatedAt: time.Now(),
+	}
+	err := e.Validate()
+	if err != nil {
+		return nil, ErrInvalidEntity
+	}
+	return e, nil
+}
+
+// Validate validate teacher eligibility
+func (e *TeacherEligibility) Validate() error {
+	if e.ProductID == IDInvalid || e.TeacherID == IDInvalid || !e.Type.IsValid() {
+		return ErrInvalidEntity
+	}
+	return nil
+}
+
+// Allows checks whether the teacher can teach the product as the primary
+// teacher or as an assistant
+func (e *TeacherEligibility) Allows(isPrimary bool) bool {
+	return !isPrimary || e.Type == EligibilityPrimary
+}
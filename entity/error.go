@@ -14,6 +14,16 @@ var ErrNotFound = errors.New("not found")
 // ErrInvalidEntity invalid entity
 var ErrInvalidEntity = errors.New("invalid entity")
 
+// ErrDeletePending delete is waiting for the confirmation from Salesforce
+var ErrDeletePending = errors.New("delete pending confirmation from salesforce")
+
+// ErrClaimLost the claim of a queued item expired and was taken by another
+// syncer instance
+var ErrClaimLost = errors.New("claim lost to another syncer instance")
+
+// ErrForbidden the account isn't allowed to do it
+var ErrForbidden = errors.New("forbidden")
+
 // ErrTokenMismatch auth token invalid (error)
 var ErrTokenMismatch = errors.New("auth token invalid")
 
@@ -0,0 +1,43 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// ExportSnapshot the last payload accepted by Salesforce for a record
+type ExportSnapshot struct {
+	Object   SyncObject
+	EntityID ID
+	// JSON encoded SFPayload list as built, including the fields withheld
+	// by the ownership rules
+	Payload    []byte
+	ExportedAt time.Time
+}
+
+// SFFieldDiff a field that changed since the last export
+type SFFieldDiff struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// SFPreview the payload an export would send, without sending it
+type SFPreview struct {
+	Object   SyncObject `json:"object"`
+	EntityID ID         `json:"entityId"`
+	// changed fields only; empty when nothing would be sent
+	Payload []SFPayload `json:"payload"`
+	// mapping problems; the export would be rejected
+	Errors []string `json:"errors,omitempty"`
+	// fields not sent; owned by Salesforce
+	Withheld []string `json:"withheld,omitempty"`
+	// nil if the record was never exported
+	LastExportedAt *time.Time    `json:"lastExportedAt,omitempty"`
+	Diff           []SFFieldDiff `json:"diff,omitempty"`
+}
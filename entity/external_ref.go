@@ -0,0 +1,67 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// External systems
+const (
+	ExternalSystemSalesforce = "salesforce"
+	ExternalSystemFile       = "file"
+	// Add new systems here
+)
+
+// ExternalRef link of an entity to its record in an external system. An
+// entity has at most one record per system.
+// Note: the ext_id columns remain the Salesforce id
+type ExternalRef struct {
+	ID       ID
+	TenantID ID
+
+	Object   SyncObject
+	EntityID ID
+
+	System string
+	ExtID  string
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewExternalRef create a new external ref
+func NewExternalRef(tenantID ID,
+	object SyncObject,
+	entityID ID,
+	system string,
+	extID string,
+) (*ExternalRef, error) {
+	r := &ExternalRef{
+		ID:        NewID(),
+		TenantID:  tenantID,
+		Object:    object,
+		EntityID:  entityID,
+		System:    system,
+		ExtID:     extID,
+		CreatedAt: time.Now(),
+	}
+	err := r.Validate()
+	if err != nil {
+		return nil, ErrInvalidEntity
+	}
+	return r, nil
+}
+
+// Validate validate external ref
+func (r *ExternalRef) Validate() error {
+	if r.Object == "" || r.EntityID == IDInvalid || r.System == "" || r.ExtID == "" {
+		return ErrInvalidEntity
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import "time"
+
+// Course change type
+type CourseChangeType string
+
+const (
+	CourseChangeStatus   CourseChangeType = "status"
+	CourseChangeTiming   CourseChangeType = "timing"
+	CourseChangeLocation CourseChangeType = "location"
+	// Add new types here
+)
+
+// CourseChange a change of a course notified to the accounts subscribed to
+// the course; From and To are the values before and after the change as
+// shown to the subscribers
+type CourseChange struct {
+	CourseID ID
+	Type     CourseChangeType
+	From     string
+	To       string
+}
+
+// NewCourseChange create a new course change; nil if nothing changed
+func NewCourseChange(courseID ID, t CourseChangeType, from, to string) *CourseChange {
+	if from == to {
+		return nil
+	}
+	return &CourseChange{
+		CourseID: courseID,
+		Type:     t,
+		From:     from,
+		To:       to,
+	}
+}
+
+// CourseNotifyState what the subscribers of a course were last notified of;
+// the changes of the course are found against it
+type CourseNotifyState struct {
+	CourseID ID
+	Status   string
+	Location string
+	Timing   string
+
+	UpdatedAt time.Time
+}
+
+// Changes lists the changes from the state to next; none from a nil state
+func (s *CourseNotifyState) Changes(next *CourseNotifyState) []*CourseChange {
+	if s == nil {
+		return nil
+	}
+	var changes []*CourseChange
+	for _, c := range []*CourseChange{
+		NewCourseChange(next.CourseID, CourseChangeStatus, s.Status, next.Status),
+		NewCourseChange(next.CourseID, CourseChangeTiming, s.Timing, next.Timing),
+		NewCourseChange(next.CourseID, CourseChangeLocation, s.Location, next.Location),
+	} {
+		if c != nil {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
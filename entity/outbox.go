@@ -0,0 +1,135 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// Sync operation - change made to a record
+type SyncOperation string
+
+const (
+	SyncInsert SyncOperation = "insert"
+	SyncUpdate SyncOperation = "update"
+	SyncDelete SyncOperation = "delete"
+	// Add new types here
+)
+
+// IsValid checks whether the sync operation is a known value
+func (o SyncOperation) IsValid() bool {
+	switch o {
+	case SyncInsert, SyncUpdate, SyncDelete:
+		return true
+	}
+	return false
+}
+
+// Sync change origin
+const (
+	// Written by the inbound (SF to RDS) path; not exported back
+	SyncOriginSalesforce = "salesforce"
+	// Anything else: REST API, manual SQL, ...
+	SyncOriginLocal = "local"
+)
+
+// SyncChange change event emitted by the database triggers
+type SyncChange struct {
+	Table     string        `json:"table"`
+	ID        string        `json:"id"`
+	TenantID  string        `json:"tenant_id"`
+	Operation SyncOperation `json:"operation"`
+	Origin    string        `json:"origin"`
+}
+
+// OutboxItem a change to be exported to Salesforce
+type OutboxItem struct {
+	ID ID
+	// IDInvalid if unknown
+	TenantID ID
+
+	Object    SyncObject
+	EntityID  ID
+	Operation SyncOperation
+
+	// pending until exported, synced once accepted by Salesforce
+	Status    SyncStatus
+	Attempts  int32
+	LastError string
+
+	// syncer instance exporting the item, until the claim expires
+	ClaimedBy    string
+	ClaimedAt    *time.Time
+	ClaimedUntil *time.Time
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ChangedSinceClaim checks whether a change was folded into the item while
+// it was being exported
+func (o *OutboxItem) ChangedSinceClaim() bool {
+	return o.ClaimedAt != nil && o.UpdatedAt.After(*o.ClaimedAt)
+}
+
+// NewOutboxItem create a new outbox item
+func NewOutboxItem(tenantID ID, object SyncObject, entityID ID, op SyncOperation) (*OutboxItem, error) {
+	o := &OutboxItem{
+		ID:        NewID(),
+		TenantID:  tenantID,
+		Object:    object,
+		EntityID:  entityID,
+		Operation: op,
+		Status:    SyncPending,
+		CreatedAt: time.Now(),
+	}
+	err := o.Validate()
+	if err != nil {
+		return nil, ErrInvalidEntity
+	}
+	return o, nil
+}
+
+// Validate validate outbox item
+func (o *OutboxItem) Validate() error {
+	if o.Object == "" || o.EntityID == IDInvalid || !o.Operation.IsValid() {
+		return ErrInvalidEntity
+	}
+	return nil
+}
+
+// OutboxRetry retry of the failed items. An item is retried Backoff after
+// the failed attempt, doubled on every attempt up to MaxBackoff, until it
+// failed MaxAttempts times; then it is left as a dead letter.
+type OutboxRetry struct {
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	MaxAttempts int32
+}
+
+// RetryAt gives when the failed item is retried; nil if it isn't
+func (r OutboxRetry) RetryAt(o *OutboxItem) *time.Time {
+	if o.Status != SyncFailed || o.Attempts >= r.MaxAttempts {
+		return nil
+	}
+	backoff := r.Backoff
+	for i := int32(1); i < o.Attempts && backoff < r.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	at := o.UpdatedAt.Add(min(backoff, r.MaxBackoff))
+	return &at
+}
+
+// QueueStats pending items of a sync queue
+type QueueStats struct {
+	Depth int
+	// nil if the queue is empty
+	Oldest *time.Time
+	// Failed failed items; counted by the outbox only
+	Failed int
+}
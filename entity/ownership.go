@@ -0,0 +1,119 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Field owner - side whose writes to a field are applied
+type FieldOwner string
+
+const (
+	// Written by Salesforce only; never exported
+	FieldOwnerSalesforce FieldOwner = "salesforce"
+	// Written through our API only; never imported over a local record
+	FieldOwnerLocal FieldOwner = "local"
+	// Written by both; the last sync wins
+	FieldOwnerShared FieldOwner = "shared"
+	// Add new types here
+)
+
+// IsValid checks whether the field owner is a known value
+func (o FieldOwner) IsValid() bool {
+	switch o {
+	case FieldOwnerSalesforce, FieldOwnerLocal, FieldOwnerShared:
+		return true
+	}
+	return false
+}
+
+// FieldOwnership owner per object and SF field name; fields without a rule
+// are shared
+type FieldOwnership map[SyncObject]map[string]FieldOwner
+
+// ParseFieldOwnership parses comma separated <object>:<SF field>=<owner> rules
+func ParseFieldOwnership(s string) (FieldOwnership, error) {
+	o := FieldOwnership{}
+	for _, rule := range strings.Split(s, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		field, owner, ok := strings.Cut(rule, "=")
+		object, field, ok2 := strings.Cut(field, ":")
+		if !ok || !ok2 || field == "" || !FieldOwner(owner).IsValid() {
+			return nil, fmt.Errorf("invalid field ownership rule %q", rule)
+		}
+		if o[SyncObject(object)] == nil {
+			o[SyncObject(object)] = map[string]FieldOwner{}
+		}
+		o[SyncObject(object)][field] = FieldOwner(owner)
+	}
+	return o, nil
+}
+
+// Owner returns the owner of the field of the object
+func (o FieldOwnership) Owner(object SyncObject, field string) FieldOwner {
+	if owner, ok := o[object][field]; ok {
+		return owner
+	}
+	return FieldOwnerShared
+}
+
+// Accepts checks whether a write to the field coming from the given direction
+// is applied
+func (o FieldOwnership) Accepts(object SyncObject, field string, direction SyncDirection) bool {
+	switch o.Owner(object, field) {
+	case FieldOwnerSalesforce:
+		return direction == SyncInbound
+	case FieldOwnerLocal:
+		return direction == SyncOutbound
+	}
+	return true
+}
+
+// SyncConflict write to a field refused by the ownership rules
+type SyncConflict struct {
+	ID        ID
+	Object    SyncObject
+	EntityID  ID
+	ExtID     string
+	Field     string
+	Direction SyncDirection
+	Owner     FieldOwner
+	// Attempted value refused; Kept value left in place
+	Attempted string
+	Kept      string
+	CreatedAt time.Time
+}
+
+// NewSyncConflict create a new sync conflict
+func NewSyncConflict(object SyncObject,
+	entityID ID,
+	extID string,
+	field string,
+	direction SyncDirection,
+	owner FieldOwner,
+	attempted string,
+	kept string,
+) *SyncConflict {
+	return &SyncConflict{
+		ID:        NewID(),
+		Object:    object,
+		EntityID:  entityID,
+		ExtID:     extID,
+		Field:     field,
+		Direction: direction,
+		Owner:     owner,
+		Attempted: attempted,
+		Kept:      kept,
+		CreatedAt: time.Now(),
+	}
+}
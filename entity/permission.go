@@ -0,0 +1,75 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import "slices"
+
+// Permission to act on a kind of resource of the tenant
+type Permission string
+
+const (
+	// Read any resource
+	PermissionRead Permission = "read"
+	// Subscribe oneself to the notifications of a course
+	PermissionSubscribe Permission = "course:subscribe"
+	// Create courses
+	PermissionCreateCourse Permission = "course:create"
+	// Edit the courses one teaches or organizes
+	PermissionEditOwnCourse Permission = "course:edit-own"
+	// Edit any course
+	PermissionEditAnyCourse Permission = "course:edit-any"
+	// Create, edit and delete centers and their contacts
+	PermissionManageCenters Permission = "center:manage"
+	// Create, edit and delete accounts and their eligibility
+	PermissionManageAccounts Permission = "account:manage"
+	// Create, edit and delete products
+	PermissionManageProducts Permission = "product:manage"
+	// Create, edit and delete tenants
+	PermissionManageTenants Permission = "tenant:manage"
+	// Pause, resume and throttle the Salesforce sync and run its exports
+	PermissionManageSync Permission = "sync:manage"
+	// Add new permissions here
+)
+
+// AccountPermissions the permissions of each account type; admins have all
+// of them
+var AccountPermissions = map[AccountType][]Permission{
+	AccountOrganizer: {
+		PermissionRead,
+		PermissionSubscribe,
+		PermissionCreateCourse,
+		PermissionEditOwnCourse,
+		PermissionManageCenters,
+	},
+	AccountTeacher: {
+		PermissionRead,
+		PermissionSubscribe,
+		PermissionCreateCourse,
+		PermissionEditOwnCourse,
+	},
+	AccountAssistantTeacher: {
+		PermissionRead,
+		PermissionSubscribe,
+		PermissionEditOwnCourse,
+	},
+	AccountMember: {
+		PermissionRead,
+		PermissionSubscribe,
+	},
+	AccountUser: {
+		PermissionRead,
+		PermissionSubscribe,
+	},
+}
+
+// Can checks whether the accounts of the type have the permission
+func (t AccountType) Can(p Permission) bool {
+	if t == AccountAdmin {
+		return true
+	}
+	return slices.Contains(AccountPermissions[t], p)
+}
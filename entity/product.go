@@ -25,6 +25,13 @@ const (
 	ProductFormatDestination ProductFormat = "Destination Retreats"
 )
 
+// ProductFormats all the product formats
+var ProductFormats = []ProductFormat{
+	ProductFormatInPerson,
+	ProductFormatOnline,
+	ProductFormatDestination,
+}
+
 // Product represents a product entity
 type Product struct {
 	ID               ID
@@ -41,6 +48,9 @@ type Product struct {
 
 	IsAutoApprove bool
 
+	// sync meta data
+	Sync SyncState
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -71,6 +81,7 @@ func NewProduct(tenantID ID,
 		MaxAttendees:     maxAttendees,
 		Format:           format,
 		IsAutoApprove:    isAutoApprove,
+		Sync:             SyncState{Status: SyncPending},
 		CreatedAt:        time.Now(),
 	}
 
@@ -0,0 +1,19 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// ReplayPosition last streaming event applied from a Salesforce channel
+type ReplayPosition struct {
+	TenantID  ID
+	Channel   string
+	ReplayID  int64
+	UpdatedAt time.Time
+}
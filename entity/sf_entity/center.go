@@ -20,6 +20,12 @@ type Center_value struct {
 	Is_enabled         bool        `json:"Is_enable__c" gorm:"column:is_enabled"`
 	Created_at         string      `json:"CreatedDate" gorm:"column:created_at"`
 	Updated_at         string      `json:"UpdatedDate" gorm:"column:updated_at"`
+	SyncColumns        `json:"-" gorm:"embedded"`
+
+	// primary contact of the center; kept in center_contact
+	Contact_name  string `json:"Contact_Name__c" gorm:"-"`
+	Contact_phone string `json:"Contact_Phone__c" gorm:"-"`
+	Contact_email string `json:"Contact_Email__c" gorm:"-"`
 }
 
 func (*Center_value) TableName() string {
@@ -38,5 +44,5 @@ func (*Center) NewCenter(Ext_id string,
 	Is_enabled bool,
 	Created_at string,
 	Updated_at string) *Center_value {
-	return &Center_value{Ext_id: Ext_id, Tenant_id: Tenant_id, Ext_name: Ext_name, Address: Address, Geo_Location: Geo_Location, Capacity: Capacity, Mode: Mode, Webpage: Webpage, Is_national_center: Is_national_center, Is_enabled: Is_enabled, Created_at: Created_at, Updated_at: Updated_at}
+	return &Center_value{Ext_id: Ext_id, Tenant_id: Tenant_id, Ext_name: Ext_name, Address: Address, Geo_Location: Geo_Location, Capacity: Capacity, Mode: Mode, Webpage: Webpage, Is_national_center: Is_national_center, Is_enabled: Is_enabled, Created_at: Created_at, Updated_at: Updated_at, SyncColumns: NewInboundSync(Updated_at)}
 }
@@ -23,6 +23,7 @@ type Course_value struct {
 	Updated_at    string   `json:"LastModifiedDate" gorm:"updated_at"`
 	Notes         string   `json:"Notes__c" gorm:"notes"`
 	Short_url     string   `json:"Short_url" gorm:"short_url"`
+	SyncColumns   `json:"-" gorm:"embedded"`
 }
 
 func (*Course_value) TableName() string {
@@ -46,5 +47,5 @@ func (*Course) NewCourse(Url string,
 	Notes string,
 	Short_url string,
 ) *Course_value {
-	return &Course_value{Url: Url, Max_attendees: Max_attendees, Address: Address, Tenant_id: Tenant_id, Ext_id: Ext_id, Name: Name, Timezone: Timezone, Mode: Mode, Center_id: Center_id, Status: Status, Created_at: Created_at, Num_attendees: Num_attendees, Product_id: Product_id, Updated_at: Updated_at, Notes: Notes, Short_url: Short_url}
+	return &Course_value{Url: Url, Max_attendees: Max_attendees, Address: Address, Tenant_id: Tenant_id, Ext_id: Ext_id, Name: Name, Timezone: Timezone, Mode: Mode, Center_id: Center_id, Status: Status, Created_at: Created_at, Num_attendees: Num_attendees, Product_id: Product_id, Updated_at: Updated_at, Notes: Notes, Short_url: Short_url, SyncColumns: NewInboundSync(Updated_at)}
 }
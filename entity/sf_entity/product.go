@@ -22,6 +22,7 @@ type Product_value struct {
 	ExtID               string `json:"Id" gorm:"column:ext_id"`
 	Base_product_ext_id string `json:"base_product_ext_id" gorm:"base_product_ext_id"`
 	Is_auto_approve     bool   `json:"Auto_Approve_Event__c" gorm:"column:is_auto_approve"`
+	SyncColumns         `json:"-" gorm:"embedded"`
 }
 
 func (*Product_value) TableName() string {
@@ -43,5 +44,5 @@ func (*Product) NewProduct(Updated_at string,
 	ExtID string,
 	Base_product_ext_id string,
 	Is_auto_approve bool) *Product_value {
-	return &Product_value{Updated_at: Updated_at, Created_at: Created_at /*Is_deleted: Is_deleted,*/, Format: Format, Max_Attendees: Max_Attendees, Listing_Visibity: Listing_Visibity, Event_Duration: Event_Duration, Product: Product, CType: CType, Title: Title, Name: Name, TenantID: TenantID, ExtID: ExtID, Base_product_ext_id: Base_product_ext_id, Is_auto_approve: Is_auto_approve}
+	return &Product_value{Updated_at: Updated_at, Created_at: Created_at /*Is_deleted: Is_deleted,*/, Format: Format, Max_Attendees: Max_Attendees, Listing_Visibity: Listing_Visibity, Event_Duration: Event_Duration, Product: Product, CType: CType, Title: Title, Name: Name, TenantID: TenantID, ExtID: ExtID, Base_product_ext_id: Base_product_ext_id, Is_auto_approve: Is_auto_approve, SyncColumns: NewInboundSync(Updated_at)}
 }
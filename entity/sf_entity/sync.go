@@ -0,0 +1,32 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import "time"
+
+// SyncColumns sync meta data columns written by the inbound (SF to RDS) path;
+// a successful import clears the last sync error
+type SyncColumns struct {
+	Last_synced_at      time.Time `json:"-" gorm:"column:last_synced_at"`
+	Last_sync_direction string    `json:"-" gorm:"column:last_sync_direction"`
+	Sync_status         string    `json:"-" gorm:"column:sync_status"`
+	Last_sync_error     *string   `json:"-" gorm:"column:last_sync_error"`
+	Sf_last_modified    *string   `json:"-" gorm:"column:sf_last_modified"`
+}
+
+// NewInboundSync returns the sync columns of a record received from SF just now
+func NewInboundSync(lastModified string) SyncColumns {
+	sc := SyncColumns{
+		Last_synced_at:      time.Now(),
+		Last_sync_direction: "inbound",
+		Sync_status:         "synced",
+	}
+	if lastModified != "" {
+		sc.Sf_last_modified = &lastModified
+	}
+	return sc
+}
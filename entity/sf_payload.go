@@ -1,13 +1,34 @@
 package entity
 
+// Salesforce object names accepted by the apexrest endpoint
+const (
+	SFObjectEvent   = "Event__c"
+	SFObjectCenter  = "Center__c"
+	SFObjectProduct = "Product__c"
+	SFObjectAccount = "Account"
+)
+
+// Salesforce record operations
+const (
+	SFOperationInsert = "Insert"
+	SFOperationUpdate = "Update"
+	SFOperationDelete = "Delete"
+)
+
 type SFPayload struct {
 	Object string     `json:"object"`
 	Items  []SFRecord `json:"items"`
 }
 
 type SFRecord struct {
-	Operation string      `json:"operation"`
-	Value     SFEventData `json:"value"`
+	Operation string `json:"operation"`
+	Value     any    `json:"value"`
+}
+
+// SFResult the outcome of a record sent to the apexrest endpoint, in the
+// order of the records sent; Id is set by Salesforce on an insert
+type SFResult struct {
+	Id string `json:"Id"`
 }
 
 type SFEventData struct {
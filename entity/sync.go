@@ -0,0 +1,80 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// Sync object - local table name of a record mirrored in Salesforce
+type SyncObject string
+
+const (
+	SyncObjectCourse  SyncObject = "course"
+	SyncObjectCenter  SyncObject = "center"
+	SyncObjectProduct SyncObject = "product"
+	SyncObjectAccount SyncObject = "account"
+	SyncObjectTiming  SyncObject = "course_timing"
+	// Add new types here
+)
+
+// Sync direction
+type SyncDirection string
+
+const (
+	// Salesforce to RDS
+	SyncInbound SyncDirection = "inbound"
+	// RDS to Salesforce
+	SyncOutbound SyncDirection = "outbound"
+	// Add new types here
+)
+
+// Sync status
+type SyncStatus string
+
+const (
+	SyncPending SyncStatus = "pending"
+	SyncSynced  SyncStatus = "synced"
+	SyncFailed  SyncStatus = "failed"
+	// Add new types here
+)
+
+// IsValid checks whether the sync status is a known value
+func (s SyncStatus) IsValid() bool {
+	switch s {
+	case SyncPending, SyncSynced, SyncFailed:
+		return true
+	}
+	return false
+}
+
+// SyncState sync meta data of a record that is mirrored in Salesforce
+type SyncState struct {
+	LastSyncedAt      *time.Time
+	LastSyncDirection SyncDirection
+	Status            SyncStatus
+	LastError         string
+
+	// LastModifiedDate of the record as reported by Salesforce
+	SFLastModified *time.Time
+}
+
+// NewSyncState creates the sync state for a sync attempt made now. A non-nil
+// err marks the attempt as failed.
+func NewSyncState(direction SyncDirection, err error) *SyncState {
+	now := time.Now()
+	s := &SyncState{
+		LastSyncedAt:      &now,
+		LastSyncDirection: direction,
+		Status:            SyncSynced,
+	}
+	if err != nil {
+		s.Status = SyncFailed
+		s.LastError = err.Error()
+	}
+	return s
+}
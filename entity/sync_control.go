@@ -0,0 +1,127 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// SyncObjectAll matches every object in a sync control
+const SyncObjectAll SyncObject = "*"
+
+// SyncControl pauses or throttles the sync of an object of a tenant in one
+// direction. Paused work stays queued and is resumed in order.
+type SyncControl struct {
+	// IDInvalid for all the tenants
+	TenantID ID
+	// SyncObjectAll for all the objects
+	Object    SyncObject
+	Direction SyncDirection
+
+	Paused bool
+	// max records of each object per export run or release of held records;
+	// 0 for no limit
+	Throttle int
+	Reason   string
+
+	UpdatedAt time.Time
+}
+
+// NewSyncControl create a new sync control
+func NewSyncControl(tenantID ID,
+	object SyncObject,
+	direction SyncDirection,
+	paused bool,
+	throttle int,
+	reason string,
+) (*SyncControl, error) {
+	c := &SyncControl{
+		TenantID:  tenantID,
+		Object:    object,
+		Direction: direction,
+		Paused:    paused,
+		Throttle:  throttle,
+		Reason:    reason,
+		UpdatedAt: time.Now(),
+	}
+	err := c.Validate()
+	if err != nil {
+		return nil, ErrInvalidEntity
+	}
+	return c, nil
+}
+
+// Validate validate sync control
+func (c *SyncControl) Validate() error {
+	if c.Object == "" || c.Throttle < 0 {
+		return ErrInvalidEntity
+	}
+	if c.Direction != SyncInbound && c.Direction != SyncOutbound {
+		return ErrInvalidEntity
+	}
+	return nil
+}
+
+// Matches checks whether the control applies to the object of the tenant
+func (c *SyncControl) Matches(tenantID ID, object SyncObject, direction SyncDirection) bool {
+	return c.Direction == direction &&
+		(c.TenantID == IDInvalid || c.TenantID == tenantID) &&
+		(c.Object == SyncObjectAll || c.Object == object)
+}
+
+// SyncControls the sync controls in effect
+type SyncControls []*SyncControl
+
+// For merges the controls that apply to the object of the tenant: it is
+// paused if any of them is, and throttled to the lowest limit.
+func (cs SyncControls) For(tenantID ID, object SyncObject, direction SyncDirection) SyncControl {
+	merged := SyncControl{
+		TenantID:  tenantID,
+		Object:    object,
+		Direction: direction,
+	}
+	for _, c := range cs {
+		if !c.Matches(tenantID, object, direction) {
+			continue
+		}
+		if c.Paused {
+			merged.Paused = true
+			merged.Reason = c.Reason
+		}
+		if c.Throttle > 0 && (merged.Throttle == 0 || c.Throttle < merged.Throttle) {
+			merged.Throttle = c.Throttle
+		}
+	}
+	return merged
+}
+
+// InboxItem an inbound record held while its sync is paused
+type InboxItem struct {
+	ID ID
+	// IDInvalid if the record has no tenant
+	TenantID ID
+	Object   SyncObject
+	// the record as received from Salesforce
+	Record []byte
+
+	CreatedAt time.Time
+}
+
+// NewInboxItem create a new inbox item
+func NewInboxItem(tenantID ID, object SyncObject, record []byte) (*InboxItem, error) {
+	i := &InboxItem{
+		ID:        NewID(),
+		TenantID:  tenantID,
+		Object:    object,
+		Record:    record,
+		CreatedAt: time.Now(),
+	}
+	if i.Object == "" || len(i.Record) == 0 {
+		return nil, ErrInvalidEntity
+	}
+	return i, nil
+}
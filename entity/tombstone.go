@@ -0,0 +1,91 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+)
+
+// Tombstone action - how a local delete is propagated to Salesforce
+type TombstoneAction string
+
+const (
+	// Delete the record in Salesforce
+	TombstoneDelete TombstoneAction = "delete"
+	// Keep the record in Salesforce, but mark it as canceled/inactive
+	TombstoneCancel TombstoneAction = "cancel"
+	// Add new types here
+)
+
+// IsValid checks whether the tombstone action is a known value
+func (a TombstoneAction) IsValid() bool {
+	switch a {
+	case TombstoneDelete, TombstoneCancel:
+		return true
+	}
+	return false
+}
+
+// Tombstone data - a deleted record that is yet to be removed from Salesforce.
+// The local record is retained until Salesforce confirms the delete.
+type Tombstone struct {
+	ID       ID
+	TenantID ID
+
+	Object   SyncObject
+	EntityID ID
+	ExtID    string
+
+	Action TombstoneAction
+	// pending until sent, synced once confirmed by Salesforce
+	Status    SyncStatus
+	Attempts  int32
+	LastError string
+
+	// syncer instance sending the delete, until the claim expires
+	ClaimedBy    string
+	ClaimedUntil *time.Time
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewTombstone create a new tombstone
+func NewTombstone(tenantID ID,
+	object SyncObject,
+	entityID ID,
+	extID string,
+	action TombstoneAction,
+) (*Tombstone, error) {
+	t := &Tombstone{
+		ID:        NewID(),
+		TenantID:  tenantID,
+		Object:    object,
+		EntityID:  entityID,
+		ExtID:     extID,
+		Action:    action,
+		Status:    SyncPending,
+		CreatedAt: time.Now(),
+	}
+	err := t.Validate()
+	if err != nil {
+		return nil, ErrInvalidEntity
+	}
+	return t, nil
+}
+
+// Validate validate tombstone
+func (t *Tombstone) Validate() error {
+	if t.Object == "" || t.EntityID == IDInvalid || t.ExtID == "" {
+		return ErrInvalidEntity
+	}
+	if !t.Action.IsValid() {
+		return ErrInvalidEntity
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package infra
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"sync"
 	"time"
@@ -14,12 +16,30 @@ var (
 	once sync.Once
 )
 
+// connectionString default; replaced by Init. todo: move to .env
+var connectionString = "host=localhost user=postgres password=1234 port=5432 dbname=glad sslmode=disable"
+
+// Init shares the given connection pool with the sync handlers; dsn is used
+// by the change listener. Must be called before the first GetDB.
+func Init(dsn string, sqlDB *sql.DB) error {
+	applied := false
+	var err error
+	once.Do(func() {
+		applied = true
+		connectionString = dsn
+		db, err = gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	})
+	if !applied {
+		return errors.New("db is already initialized")
+	}
+	return err
+}
+
 func GetDB() (*gorm.DB, error) {
 	var err error
 	once.Do(func() {
 		log.Println("db creation initiated")
-		connection_str := "host=localhost user=postgres password=1234 port=5432 dbname=glad sslmode=disable" // todo: move to .env
-		db, err = gorm.Open(postgres.Open(connection_str), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(connectionString), &gorm.Config{})
 		log.Println("in function", db)
 		if err != nil {
 			log.Println("there was an error with the database", err)
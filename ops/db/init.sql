@@ -37,7 +37,8 @@ CREATE TYPE timezone_type AS ENUM ('EST'
     , 'MST'
     , 'PST'
     );
-CREATE TYPE account_type AS ENUM ('assistant-teacher'
+CREATE TYPE account_type AS ENUM ('admin'
+    , 'assistant-teacher'
     , 'member'
     , 'organizer'
     , 'student'
@@ -50,6 +51,24 @@ CREATE TYPE center_mode AS ENUM ('in-person'
 CREATE TYPE teaching_eligibility_type AS ENUM ('primary'
     , 'assistant'
     );
+CREATE TYPE sync_direction AS ENUM ('inbound'
+    , 'outbound'
+    );
+CREATE TYPE sync_status AS ENUM ('pending'
+    , 'synced'
+    , 'failed'
+    );
+CREATE TYPE tombstone_action AS ENUM ('delete'
+    , 'cancel'
+    );
+CREATE TYPE sync_operation AS ENUM ('insert'
+    , 'update'
+    , 'delete'
+    );
+CREATE TYPE field_owner AS ENUM ('salesforce'
+    , 'local'
+    , 'shared'
+    );
 
 
 -- Create tables
@@ -103,11 +122,20 @@ CREATE TABLE IF NOT EXISTS product (
     is_auto_approve BOOLEAN DEFAULT FALSE,
     -- is_deleted is an internal field in Salesforce. Hence, need not be synced
 
+    -- Sync meta data (maintained by the Salesforce import and export paths)
+    last_synced_at TIMESTAMP,
+    last_sync_direction sync_direction,
+    sync_status sync_status NOT NULL DEFAULT 'pending',
+    last_sync_error TEXT,
+    -- LastModifiedDate of the record in Salesforce
+    sf_last_modified TIMESTAMP,
+
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 CREATE INDEX idx_product_ext_id ON product(ext_id);
 CREATE INDEX idx_product_tenant_id ON product(tenant_id);
+CREATE INDEX idx_product_sync_status ON product(sync_status);
 CREATE INDEX idx_product_name ON product(ext_name);
 CREATE INDEX idx_product_title ON product(title);
 
@@ -139,22 +167,36 @@ CREATE TABLE IF NOT EXISTS center (
     is_national_center BOOLEAN DEFAULT FALSE,
     is_enabled BOOLEAN,
 
+    -- Sync meta data (maintained by the Salesforce import and export paths)
+    last_synced_at TIMESTAMP,
+    last_sync_direction sync_direction,
+    sync_status sync_status NOT NULL DEFAULT 'pending',
+    last_sync_error TEXT,
+    -- LastModifiedDate of the record in Salesforce
+    sf_last_modified TIMESTAMP,
+
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 CREATE INDEX idx_center_ext_id ON center(ext_id);
 CREATE INDEX idx_center_tenant_id ON center(tenant_id);
+CREATE INDEX idx_center_sync_status ON center(sync_status);
 CREATE INDEX idx_center_name ON center(name);
 CREATE INDEX idx_center_ext_name ON center(ext_name);
 
 CREATE TABLE IF NOT EXISTS center_contact (
+    id BIGSERIAL PRIMARY KEY,
     center_id INT NOT NULL REFERENCES center(id) ON DELETE CASCADE,
     name VARCHAR(255),
     phone VARCHAR(32),
     email VARCHAR(80),
+    -- Note: the primary contact is the contact of the center in Salesforce
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 CREATE INDEX idx_center_contact_center_id ON center_contact(center_id);
+CREATE UNIQUE INDEX idx_center_contact_primary ON center_contact(center_id) WHERE is_primary;
 
 -- COURSE entity
 CREATE TABLE IF NOT EXISTS course (
@@ -185,11 +227,20 @@ CREATE TABLE IF NOT EXISTS course (
 
     -- is_auto_approve does not make sense here. In Salesforce this seems like copied from Master (Product)
 
+    -- Sync meta data (maintained by the Salesforce import and export paths)
+    last_synced_at TIMESTAMP,
+    last_sync_direction sync_direction,
+    sync_status sync_status NOT NULL DEFAULT 'pending',
+    last_sync_error TEXT,
+    -- LastModifiedDate of the record in Salesforce
+    sf_last_modified TIMESTAMP,
+
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 CREATE INDEX idx_course_tenant_id ON course(tenant_id);
 CREATE INDEX idx_course_product_id ON course(product_id);
+CREATE INDEX idx_course_sync_status ON course(sync_status);
 
 -- ACCOUNT entity
 CREATE TABLE IF NOT EXISTS account (
@@ -230,7 +281,9 @@ CREATE TABLE IF NOT EXISTS teacher_eligibility (
     type teaching_eligibility_type,
 
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Note: A teacher has one eligibility type per product
+    UNIQUE(product_id, teacher_id)
 );
 CREATE INDEX idx_teacher_eligibility_product_id ON teacher_eligibility(product_id);
 CREATE INDEX idx_teacher_eligibility_teacher_id ON teacher_eligibility(teacher_id);
@@ -288,6 +341,278 @@ CREATE INDEX idx_course_timings_course_id ON course_timing(course_id);
 CREATE TABLE IF NOT EXISTS course_notify (
     course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
     notify_id BIGINT NOT NULL REFERENCES account(id) ON DELETE RESTRICT,
-    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(course_id, notify_id)
 );
 CREATE INDEX idx_course_notify_course_id ON course_notify(course_id);
+
+-- COURSE NOTIFY QUEUE: Courses changed since their subscribers were last notified
+-- Note: Queued by triggers, so that the changes of every writer are notified, the
+-- inbound Salesforce ones included
+CREATE TABLE IF NOT EXISTS course_notify_queue (
+    course_id BIGINT PRIMARY KEY,
+    queued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- COURSE NOTIFY STATE: What the subscribers of a course were last notified of
+-- Note: The changes of the course are found against it
+CREATE TABLE IF NOT EXISTS course_notify_state (
+    course_id BIGINT PRIMARY KEY REFERENCES course(id) ON DELETE CASCADE,
+    status VARCHAR(32) NOT NULL,
+    location TEXT NOT NULL,
+    timing TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE OR REPLACE FUNCTION queue_course_notify() RETURNS TRIGGER AS $$
+DECLARE
+    changed_course BIGINT;
+BEGIN
+    IF TG_TABLE_NAME = 'course' THEN
+        changed_course := NEW.id;
+    ELSIF TG_OP = 'DELETE' THEN
+        changed_course := OLD.course_id;
+    ELSE
+        changed_course := NEW.course_id;
+    END IF;
+
+    INSERT INTO course_notify_queue (course_id, queued_at)
+    VALUES (changed_course, clock_timestamp())
+    ON CONFLICT (course_id) DO NOTHING;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Note: Only the columns notified queue the course
+CREATE TRIGGER trg_course_notify AFTER INSERT OR UPDATE OF status, address ON course
+    FOR EACH ROW EXECUTE FUNCTION queue_course_notify();
+CREATE TRIGGER trg_course_timing_notify AFTER INSERT OR UPDATE OR DELETE ON course_timing
+    FOR EACH ROW EXECUTE FUNCTION queue_course_notify();
+
+-- SYNC TOMBSTONE: Deletes of synced records that are yet to be propagated to Salesforce
+-- Note: The local record is deleted only after Salesforce confirms the delete
+CREATE TABLE IF NOT EXISTS sync_tombstone (
+    id BIGINT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id),
+
+    -- Note: object is the table name of the deleted record (course, center, product, account)
+    object VARCHAR(32) NOT NULL,
+    -- Note: Not a foreign key as the record is removed once the delete is confirmed
+    entity_id BIGINT NOT NULL,
+    -- Note: ext_id is salesforce id
+    ext_id VARCHAR(32) NOT NULL,
+
+    action tombstone_action NOT NULL DEFAULT 'delete',
+    status sync_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+
+    -- Note: The syncer instance sending the delete; others skip it until claimed_until
+    claimed_by VARCHAR(255),
+    claimed_until TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(object, entity_id)
+);
+CREATE INDEX idx_sync_tombstone_tenant_id ON sync_tombstone(tenant_id);
+CREATE INDEX idx_sync_tombstone_status ON sync_tombstone(status);
+
+-- SYNC EXPORT SNAPSHOT: Last payload accepted by Salesforce per record
+-- Note: Used to preview the changes of the next export
+CREATE TABLE IF NOT EXISTS sync_export_snapshot (
+    object VARCHAR(32) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    exported_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (object, entity_id)
+);
+
+-- SYNC OUTBOX: Changes to be exported to Salesforce
+-- Note: Filled by the enqueue_sync_change trigger and the syncer listener of its sync_change notifications
+-- Note: Failed items are retried with a backoff up to the max attempts, then left as dead letters
+CREATE TABLE IF NOT EXISTS sync_outbox (
+    id BIGINT PRIMARY KEY,
+    -- Note: 0 if the tenant is unknown
+    tenant_id BIGINT NOT NULL DEFAULT 0,
+
+    -- Note: object is the table name of the changed record
+    object VARCHAR(32) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    operation sync_operation NOT NULL,
+
+    status sync_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+
+    -- Note: The syncer instance exporting the item; others skip it until claimed_until
+    claimed_by VARCHAR(255),
+    claimed_at TIMESTAMP,
+    claimed_until TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- Note: At most one pending item per record; later changes are folded into it
+CREATE UNIQUE INDEX idx_sync_outbox_pending ON sync_outbox(object, entity_id) WHERE status = 'pending';
+CREATE INDEX idx_sync_outbox_status ON sync_outbox(status);
+
+-- SYNC CHANGE CAPTURE: NOTIFY sync_change on every change, whoever made it
+-- Payload: {"table": ..., "id": ..., "tenant_id": ..., "operation": insert|update|delete, "origin": local|salesforce}
+-- Note: The syncer listener queues the changes of the other records; those of courses are queued here,
+--       in the transaction of the change, so that none is lost while the listener is down
+-- Note: A change of a timing is a change of its course; deletes of courses are propagated by the tombstones
+-- Note: Updates of the sync meta data alone are not a change of the record
+-- Note: origin is salesforce when the inbound path stamped the sync meta data; not exported back
+CREATE SEQUENCE IF NOT EXISTS sync_outbox_seq;
+
+-- Note: Same layout as pkg/uid: milliseconds, shard and sequence
+CREATE OR REPLACE FUNCTION next_sync_outbox_id() RETURNS BIGINT AS $$
+    SELECT ((floor(extract(epoch FROM clock_timestamp()) * 1000)::BIGINT << 23)
+        | (floor(random() * 1024)::BIGINT << 10)
+        | (nextval('sync_outbox_seq') % 1024)) & ~(1::BIGINT << 63);
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION enqueue_sync_change() RETURNS trigger AS $$
+DECLARE
+    rec JSONB;
+    old_rec JSONB;
+    origin TEXT := 'local';
+    tenant BIGINT;
+    changed_course BIGINT;
+    op sync_operation := 'update';
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        rec := to_jsonb(OLD);
+    ELSE
+        rec := to_jsonb(NEW);
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        old_rec := to_jsonb(OLD);
+        -- ext_id is assigned by Salesforce when an export creates the record
+        IF (rec - 'last_synced_at' - 'last_sync_direction' - 'sync_status'
+                - 'last_sync_error' - 'sf_last_modified' - 'updated_at' - 'ext_id')
+            = (old_rec - 'last_synced_at' - 'last_sync_direction' - 'sync_status'
+                - 'last_sync_error' - 'sf_last_modified' - 'updated_at' - 'ext_id') THEN
+            RETURN NULL;
+        END IF;
+    END IF;
+
+    IF TG_OP <> 'DELETE' AND rec->>'last_sync_direction' = 'inbound'
+        AND (TG_OP = 'INSERT' OR rec->'last_synced_at' IS DISTINCT FROM old_rec->'last_synced_at') THEN
+        origin := 'salesforce';
+    END IF;
+
+    IF TG_TABLE_NAME = 'course_timing' THEN
+        changed_course := (rec->>'course_id')::BIGINT;
+        -- Note: Not found when the timings are deleted along with their course
+        SELECT c.tenant_id INTO tenant FROM course c WHERE c.id = changed_course;
+    ELSE
+        tenant := (rec->>'tenant_id')::BIGINT;
+        IF TG_TABLE_NAME = 'course' AND TG_OP <> 'DELETE' THEN
+            changed_course := (rec->>'id')::BIGINT;
+            op := lower(TG_OP)::sync_operation;
+        END IF;
+    END IF;
+
+    PERFORM pg_notify('sync_change', json_build_object(
+        'table', TG_TABLE_NAME,
+        'id', rec->>'id',
+        'tenant_id', tenant::TEXT,
+        'operation', lower(TG_OP),
+        'origin', origin)::text);
+
+    IF changed_course IS NULL OR tenant IS NULL OR origin = 'salesforce' THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO sync_outbox (id, tenant_id, object, entity_id, operation, status, created_at, updated_at)
+    VALUES (next_sync_outbox_id(), tenant, 'course', changed_course, op, 'pending', clock_timestamp(), clock_timestamp())
+    ON CONFLICT (object, entity_id) WHERE status = 'pending'
+    DO UPDATE SET operation = EXCLUDED.operation, updated_at = EXCLUDED.updated_at;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_course_sync_change AFTER INSERT OR UPDATE OR DELETE ON course
+    FOR EACH ROW EXECUTE FUNCTION enqueue_sync_change();
+CREATE TRIGGER trg_center_sync_change AFTER INSERT OR UPDATE OR DELETE ON center
+    FOR EACH ROW EXECUTE FUNCTION enqueue_sync_change();
+CREATE TRIGGER trg_product_sync_change AFTER INSERT OR UPDATE OR DELETE ON product
+    FOR EACH ROW EXECUTE FUNCTION enqueue_sync_change();
+CREATE TRIGGER trg_account_sync_change AFTER INSERT OR UPDATE OR DELETE ON account
+    FOR EACH ROW EXECUTE FUNCTION enqueue_sync_change();
+CREATE TRIGGER trg_course_timing_sync_change AFTER INSERT OR UPDATE OR DELETE ON course_timing
+    FOR EACH ROW EXECUTE FUNCTION enqueue_sync_change();
+
+-- SYNC REPLAY: Last Salesforce streaming event applied per tenant and channel
+-- Note: The subscriber resumes from here after a restart
+CREATE TABLE IF NOT EXISTS sync_replay (
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id),
+    channel VARCHAR(255) NOT NULL,
+    replay_id BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, channel)
+);
+
+-- SYNC CONFLICT: Writes to a field refused by the field ownership rules
+-- Note: field is the Salesforce field name
+CREATE TABLE IF NOT EXISTS sync_conflict (
+    id BIGINT PRIMARY KEY,
+    object VARCHAR(32) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    ext_id VARCHAR(32),
+    field VARCHAR(255) NOT NULL,
+    direction sync_direction NOT NULL,
+    owner field_owner NOT NULL,
+    attempted TEXT,
+    kept TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_sync_conflict_entity ON sync_conflict(object, entity_id);
+
+-- EXTERNAL REF: Link of an entity to its record in an external system
+-- Note: The ext_id columns remain the Salesforce id
+CREATE TABLE IF NOT EXISTS external_ref (
+    id BIGINT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id),
+
+    object VARCHAR(32) NOT NULL,
+    entity_id BIGINT NOT NULL,
+
+    system VARCHAR(32) NOT NULL,
+    ext_id VARCHAR(255) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (object, entity_id, system),
+    UNIQUE (system, object, ext_id)
+);
+
+-- SYNC CONTROL: Pause and throttle of the sync of an object of a tenant
+-- Note: tenant_id 0 and object '*' apply to all the tenants and objects
+-- Note: Paused outbound work stays pending in the outbox and the tombstones
+CREATE TABLE IF NOT EXISTS sync_control (
+    tenant_id BIGINT NOT NULL DEFAULT 0,
+    object VARCHAR(32) NOT NULL,
+    direction sync_direction NOT NULL,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Note: max records of each object per export run or release of held records; 0 for no limit
+    throttle INTEGER NOT NULL DEFAULT 0,
+    reason TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, object, direction)
+);
+
+-- SYNC INBOX: Inbound records held while their sync is paused
+-- Note: Released oldest first once resumed; record is as received from Salesforce
+CREATE TABLE IF NOT EXISTS sync_inbox (
+    id BIGINT PRIMARY KEY,
+    -- Note: 0 if the record has no tenant
+    tenant_id BIGINT NOT NULL DEFAULT 0,
+    object VARCHAR(32) NOT NULL,
+    record JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_sync_inbox_created ON sync_inbox(tenant_id, object, created_at);
@@ -0,0 +1,45 @@
+package infra
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Listen calls handle with the payload of every NOTIFY on channel until ctx
+// is done. The connection is re-established on failure; notifications sent
+// while disconnected are lost.
+func Listen(ctx context.Context, channel string, handle func(payload string)) error {
+	listener := pq.NewListener(connectionString, 10*time.Second, time.Minute,
+		func(ev pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Println("listener error on", channel, err)
+			}
+		})
+	defer listener.Close()
+
+	if err := listener.Listen(channel); err != nil {
+		return err
+	}
+	log.Println("listening for notifications on", channel)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n := <-listener.Notify:
+			// nil after a reconnect
+			if n == nil {
+				log.Println("listener reconnected on", channel)
+				continue
+			}
+			handle(n.Extra)
+		case <-time.After(90 * time.Second):
+			if err := listener.Ping(); err != nil {
+				log.Println("listener ping failed on", channel, err)
+			}
+		}
+	}
+}
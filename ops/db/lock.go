@@ -0,0 +1,106 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Instance identifies this process in the claims of the sync queues
+var Instance = instanceName()
+
+func instanceName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// Lock a session advisory lock held on a dedicated connection
+type Lock struct {
+	name string
+	conn *sql.Conn
+}
+
+// TryLock takes the session advisory lock of name on a dedicated connection
+// of db. Returns nil if another session holds it; otherwise Release must be
+// called to unlock it and return the connection to the pool.
+func TryLock(ctx context.Context, db *sql.DB, name string) (*Lock, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ok bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1));`, name).Scan(&ok)
+	if err != nil || !ok {
+		conn.Close()
+		return nil, err
+	}
+	return &Lock{name: name, conn: conn}, nil
+}
+
+// Held checks that the session holding the lock is alive
+func (l *Lock) Held(ctx context.Context) bool {
+	var one int
+	return l.conn.QueryRowContext(ctx, `SELECT 1;`).Scan(&one) == nil
+}
+
+// Release unlocks the lock and returns its connection to the pool
+func (l *Lock) Release() {
+	// Note: the lock is released with the session if this fails
+	_, _ = l.conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1));`, l.name)
+	l.conn.Close()
+}
+
+// RunAsLeader runs job while this instance holds the advisory lock of name;
+// the other instances wait and one of them takes over once it is released
+// or the session of the leader is lost. The job is canceled if the session
+// is lost, and run again if it fails. Returns when ctx is done.
+func RunAsLeader(ctx context.Context, db *sql.DB, name string, retry time.Duration, job func(ctx context.Context) error) error {
+	for {
+		lock, err := TryLock(ctx, db, name)
+		if err != nil {
+			log.Println("unable to take the lock of", name, err)
+		}
+		if lock != nil {
+			log.Println(Instance, "is the leader of", name)
+			err = lock.lead(ctx, retry, job)
+			lock.Release()
+			if err != nil && ctx.Err() == nil {
+				log.Println(name, "stopped:", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retry):
+		}
+	}
+}
+
+// lead runs job until it returns or the session of the lock is lost
+func (l *Lock) lead(ctx context.Context, check time.Duration, job func(ctx context.Context) error) error {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- job(jobCtx) }()
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(check):
+			if !l.Held(ctx) {
+				log.Println(Instance, "lost the lock of", l.name)
+				cancel()
+				return <-done
+			}
+		}
+	}
+}
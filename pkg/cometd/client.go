@@ -0,0 +1,217 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+// Package cometd is a long-polling Bayeux client of the Salesforce streaming
+// API, with the replay extension
+package cometd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"sync"
+)
+
+// Meta channels
+const (
+	ChannelHandshake  = "/meta/handshake"
+	ChannelSubscribe  = "/meta/subscribe"
+	ChannelConnect    = "/meta/connect"
+	ChannelDisconnect = "/meta/disconnect"
+)
+
+// Reconnect advice
+const (
+	ReconnectRetry     = "retry"
+	ReconnectHandshake = "handshake"
+	ReconnectNone      = "none"
+)
+
+const (
+	connectionType = "long-polling"
+	bayeuxVersion  = "1.0"
+)
+
+// Replay ids of channels without a stored position
+const (
+	ReplayNew int64 = -1 // events after the subscription
+	ReplayAll int64 = -2 // all the retained events
+)
+
+// Advice reconnect advice of the server
+type Advice struct {
+	Reconnect string `json:"reconnect,omitempty"`
+	Interval  int    `json:"interval,omitempty"`
+	Timeout   int    `json:"timeout,omitempty"`
+}
+
+// Message Bayeux message
+type Message struct {
+	Channel                  string          `json:"channel"`
+	ClientID                 string          `json:"clientId,omitempty"`
+	Version                  string          `json:"version,omitempty"`
+	SupportedConnectionTypes []string        `json:"supportedConnectionTypes,omitempty"`
+	ConnectionType           string          `json:"connectionType,omitempty"`
+	Subscription             string          `json:"subscription,omitempty"`
+	Successful               bool            `json:"successful,omitempty"`
+	Error                    string          `json:"error,omitempty"`
+	Advice                   *Advice         `json:"advice,omitempty"`
+	Ext                      map[string]any  `json:"ext,omitempty"`
+	Data                     json.RawMessage `json:"data,omitempty"`
+}
+
+// ErrRehandshake the client id is no longer valid; handshake and subscribe
+// again
+var ErrRehandshake = errors.New("cometd: handshake required")
+
+// Client streaming client; not safe for concurrent Connect calls
+type Client struct {
+	// e.g. https://<org>.my.salesforce.com/cometd/60.0
+	URL string
+	// Token returns the access token of the org
+	Token func() (string, error)
+	HTTP  *http.Client
+
+	once     sync.Once
+	clientID string
+}
+
+// NewURL returns the streaming endpoint of the org for the API version
+func NewURL(instanceURL, version string) string {
+	return instanceURL + "/cometd/" + strings.TrimPrefix(version, "v")
+}
+
+// Handshake gets a new client id
+func (c *Client) Handshake(ctx context.Context) error {
+	replies, err := c.send(ctx, Message{
+		Channel:                  ChannelHandshake,
+		Version:                  bayeuxVersion,
+		SupportedConnectionTypes: []string{connectionType},
+		Ext:                      map[string]any{"replay": true},
+	})
+	if err != nil {
+		return err
+	}
+	reply, err := replyOf(replies, ChannelHandshake)
+	if err != nil {
+		return err
+	}
+	c.clientID = reply.ClientID
+	return nil
+}
+
+// Subscribe subscribes to the channel from the event after replayID
+func (c *Client) Subscribe(ctx context.Context, channel string, replayID int64) error {
+	replies, err := c.send(ctx, Message{
+		Channel:      ChannelSubscribe,
+		ClientID:     c.clientID,
+		Subscription: channel,
+		Ext:          map[string]any{"replay": map[string]int64{channel: replayID}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = replyOf(replies, ChannelSubscribe)
+	return err
+}
+
+// Connect long-polls for events; returns the event messages received
+func (c *Client) Connect(ctx context.Context) ([]Message, error) {
+	replies, err := c.send(ctx, Message{
+		Channel:        ChannelConnect,
+		ClientID:       c.clientID,
+		ConnectionType: connectionType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Message
+	for _, m := range replies {
+		if !strings.HasPrefix(m.Channel, "/meta/") {
+			events = append(events, m)
+		}
+	}
+	if _, err := replyOf(replies, ChannelConnect); err != nil {
+		return events, err
+	}
+	return events, nil
+}
+
+// Disconnect ends the session
+func (c *Client) Disconnect(ctx context.Context) error {
+	_, err := c.send(ctx, Message{Channel: ChannelDisconnect, ClientID: c.clientID})
+	c.clientID = ""
+	return err
+}
+
+// replyOf finds the reply of the meta channel
+func replyOf(replies []Message, channel string) (*Message, error) {
+	for i := range replies {
+		m := &replies[i]
+		if m.Channel != channel {
+			continue
+		}
+		if m.Successful {
+			return m, nil
+		}
+		if m.Advice != nil && m.Advice.Reconnect == ReconnectHandshake {
+			return nil, fmt.Errorf("%w: %s", ErrRehandshake, m.Error)
+		}
+		return nil, fmt.Errorf("cometd: %s failed: %s", channel, m.Error)
+	}
+	return nil, fmt.Errorf("cometd: no %s reply", channel)
+}
+
+func (c *Client) send(ctx context.Context, msg Message) ([]Message, error) {
+	c.once.Do(func() {
+		if c.HTTP == nil {
+			// Note: SF ties the session to its cookies
+			jar, _ := cookiejar.New(nil)
+			c.HTTP = &http.Client{Jar: jar}
+		}
+	})
+
+	body, err := json.Marshal([]Message{msg})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token, err := c.Token()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w: status %d", ErrRehandshake, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("cometd: status %d: %s", resp.StatusCode, msg)
+	}
+
+	var replies []Message
+	if err := json.NewDecoder(resp.Body).Decode(&replies); err != nil {
+		return nil, err
+	}
+	return replies, nil
+}
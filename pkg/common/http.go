@@ -8,4 +8,8 @@ package common
 
 const (
 	HttpHeaderTenantID = "X-GLAD-TenantID"
+	// HttpHeaderSyncSecret shared secret of the Salesforce callouts
+	HttpHeaderSyncSecret = "X-GLAD-SyncSecret"
+	// HttpHeaderOperatorToken token of the operator requests to the syncer
+	HttpHeaderOperatorToken = "X-GLAD-OperatorToken"
 )
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package jwt
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Keys gives the public key a token was signed with
+type Keys interface {
+	Key(kid string) (*rsa.PublicKey, error)
+}
+
+// jwk is a JSON web key; only the RSA signing keys are used
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// ParseJWKS parses a JSON web key set, as served by Cognito at
+// <issuer>/.well-known/jwks.json
+func ParseJWKS(data []byte) (StaticKeys, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := StaticKeys{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k.Kid, err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key %s: invalid exponent", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA signing keys in the key set")
+	}
+	return keys, nil
+}
+
+// LoadJWKS loads a JSON web key set from a file
+func LoadJWKS(path string) (StaticKeys, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+// StaticKeys is a fixed key set, by key id
+type StaticKeys map[string]*rsa.PublicKey
+
+// Key gives the key with the id
+func (k StaticKeys) Key(kid string) (*rsa.PublicKey, error) {
+	key, ok := k[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// RemoteKeys is a key set fetched from a URL. It is fetched again when a
+// token is signed with an unknown key, to pick up rotated keys, but not more
+// often than every refresh interval; a failed fetch is not retried sooner
+// either. The fetch is made without the lock, so the known keys are served
+// meanwhile, and the concurrent lookups wait for the one fetch in flight.
+type RemoteKeys struct {
+	url     string
+	client  *http.Client
+	refresh time.Duration
+
+	mu        sync.Mutex
+	keys      StaticKeys
+	fetchedAt time.Time
+	// error of the last fetch
+	err error
+	// closed when the fetch in flight is done; nil without one
+	fetching chan struct{}
+
+	// replaced by tests
+	now func() time.Time
+}
+
+// NewRemoteKeys create a key set fetched from url
+func NewRemoteKeys(url string, refresh time.Duration) *RemoteKeys {
+	return &RemoteKeys{
+		url:     url,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		refresh: refresh,
+		now:     time.Now,
+	}
+}
+
+// Key gives the key with the id
+func (k *RemoteKeys) Key(kid string) (*rsa.PublicKey, error) {
+	k.mu.Lock()
+	for {
+		if key, ok := k.keys[kid]; ok {
+			k.mu.Unlock()
+			return key, nil
+		}
+		if k.fetching == nil {
+			break
+		}
+		done := k.fetching
+		k.mu.Unlock()
+		<-done
+		k.mu.Lock()
+		if k.err != nil {
+			err := k.err
+			k.mu.Unlock()
+			return nil, err
+		}
+	}
+	if !k.fetchedAt.IsZero() && k.now().Sub(k.fetchedAt) < k.refresh {
+		defer k.mu.Unlock()
+		if k.keys == nil {
+			return nil, k.err
+		}
+		return nil, ErrUnknownKey
+	}
+	done := make(chan struct{})
+	k.fetching = done
+	k.fetchedAt = k.now()
+	k.mu.Unlock()
+
+	keys, err := k.fetch()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.err = err
+	if err == nil {
+		k.keys = keys
+	}
+	k.fetching = nil
+	close(done)
+	if err != nil {
+		return nil, err
+	}
+	return k.keys.Key(kid)
+}
+
+// fetch fetches the key set
+func (k *RemoteKeys) fetch() (StaticKeys, error) {
+	resp, err := k.client.Get(k.url)
+	if err != nil {
+		return nil, fmt.Errorf("fetching the key set: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching the key set: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("fetching the key set: %w", err)
+	}
+	return ParseJWKS(data)
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+// Package jwt validates the RS256 JSON web tokens issued by Cognito or any
+// other OpenID provider publishing its keys as a JSON web key set
+package jwt
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Validation errors
+var (
+	ErrMalformed   = errors.New("malformed token")
+	ErrAlgorithm   = errors.New("unsupported signing algorithm")
+	ErrUnknownKey  = errors.New("unknown signing key")
+	ErrSignature   = errors.New("invalid signature")
+	ErrExpired     = errors.New("token expired")
+	ErrNotYetValid = errors.New("token not yet valid")
+	ErrIssuer      = errors.New("invalid issuer")
+	ErrAudience    = errors.New("invalid audience")
+	ErrTokenUse    = errors.New("invalid token use")
+)
+
+// leeway allowed for the clock skew with the issuer
+const leeway = time.Minute
+
+// Audience is the aud claim, a string or an array of strings
+type Audience []string
+
+// UnmarshalJSON unmarshals a string or an array of strings
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+	var l []string
+	if err := json.Unmarshal(data, &l); err != nil {
+		return err
+	}
+	*a = l
+	return nil
+}
+
+// Claims of a token. Cognito id tokens carry the app client in aud, access
+// tokens in client_id.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  Audience `json:"aud"`
+	ClientID  string   `json:"client_id"`
+	TokenUse  string   `json:"token_use"`
+	Email     string   `json:"email"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	IssuedAt  int64    `json:"iat"`
+}
+
+// Validator validates tokens signed with its keys
+type Validator struct {
+	keys     Keys
+	issuer   string
+	audience string
+
+	// replaced by tests
+	now func() time.Time
+}
+
+// NewValidator create a new validator; the issuer and the audience are not
+// checked if empty
+func NewValidator(keys Keys, issuer, audience string) *Validator {
+	return &Validator{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
+	}
+}
+
+// Validate checks the signature and the claims of the token
+func (v *Validator) Validate(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "RS256" {
+		return nil, ErrAlgorithm
+	}
+	key, err := v.keys.Key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return nil, ErrSignature
+	}
+
+	var c Claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, err
+	}
+	if err := v.check(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// check checks the time, issuer, audience and use of the token
+func (v *Validator) check(c *Claims) error {
+	now := v.now()
+	if c.Subject == "" || c.ExpiresAt == 0 {
+		return ErrMalformed
+	}
+	if now.After(time.Unix(c.ExpiresAt, 0).Add(leeway)) {
+		return ErrExpired
+	}
+	if c.NotBefore != 0 && now.Add(leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return ErrNotYetValid
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return ErrIssuer
+	}
+	switch c.TokenUse {
+	case "", "id", "access":
+	default:
+		return ErrTokenUse
+	}
+	if v.audience == "" {
+		return nil
+	}
+	if c.ClientID == v.audience {
+		return nil
+	}
+	for _, a := range c.Audience {
+		if a == v.audience {
+			return nil
+		}
+	}
+	return ErrAudience
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of the token
+func decodeSegment(s string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ErrMalformed
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return ErrMalformed
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	issuer   = "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_abc"
+	clientID = "client1"
+)
+
+func newKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	return key
+}
+
+func jwks(keys map[string]*rsa.PrivateKey) string {
+	var l []string
+	for kid, k := range keys {
+		l = append(l, fmt.Sprintf(`{"kid":%q,"kty":"RSA","alg":"RS256","use":"sig","n":%q,"e":%q}`, kid,
+			base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())))
+	}
+	return `{"keys":[` + strings.Join(l, ",") + `]}`
+}
+
+func TestValidate(t *testing.T) {
+	key, other := newKey(t), newKey(t)
+	keys, err := ParseJWKS([]byte(jwks(map[string]*rsa.PrivateKey{"k1": key})))
+	assert.Nil(t, err)
+	now := time.Now()
+	v := NewValidator(keys, issuer, clientID)
+	v.now = func() time.Time { return now }
+
+	valid := func() *Claims {
+		return &Claims{
+			Subject:   "sub1",
+			Issuer:    issuer,
+			Audience:  Audience{clientID},
+			TokenUse:  "id",
+			ExpiresAt: now.Add(time.Hour).Unix(),
+			IssuedAt:  now.Unix(),
+		}
+	}
+	token, _ := Sign(key, "k1", valid())
+	c, err := v.Validate(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "sub1", c.Subject)
+
+	// access tokens carry the client in client_id
+	access := valid()
+	access.Audience, access.ClientID, access.TokenUse = nil, clientID, "access"
+	token, _ = Sign(key, "k1", access)
+	_, err = v.Validate(token)
+	assert.Nil(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		update func(c *Claims)
+		err    error
+	}{
+		{"expired", func(c *Claims) { c.ExpiresAt = now.Add(-2 * time.Minute).Unix() }, ErrExpired},
+		{"not yet valid", func(c *Claims) { c.NotBefore = now.Add(2 * time.Minute).Unix() }, ErrNotYetValid},
+		{"issuer", func(c *Claims) { c.Issuer = "https://example.org" }, ErrIssuer},
+		{"audience", func(c *Claims) { c.Audience = Audience{"client2"} }, ErrAudience},
+		{"token use", func(c *Claims) { c.TokenUse = "refresh" }, ErrTokenUse},
+		{"subject", func(c *Claims) { c.Subject = "" }, ErrMalformed},
+	} {
+		c := valid()
+		tc.update(c)
+		token, _ := Sign(key, "k1", c)
+		_, err := v.Validate(token)
+		assert.Equal(t, tc.err, err, tc.name)
+	}
+
+	// within the leeway
+	c = valid()
+	c.ExpiresAt = now.Add(-30 * time.Second).Unix()
+	token, _ = Sign(key, "k1", c)
+	_, err = v.Validate(token)
+	assert.Nil(t, err)
+
+	token, _ = Sign(other, "k1", valid())
+	_, err = v.Validate(token)
+	assert.Equal(t, ErrSignature, err)
+	token, _ = Sign(key, "k2", valid())
+	_, err = v.Validate(token)
+	assert.Equal(t, ErrUnknownKey, err)
+
+	token, _ = Sign(key, "k1", valid())
+	parts := strings.Split(token, ".")
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"k1"}`))
+	_, err = v.Validate(none + "." + parts[1] + ".")
+	assert.Equal(t, ErrAlgorithm, err)
+	_, err = v.Validate("abc")
+	assert.Equal(t, ErrMalformed, err)
+}
+
+func TestAudience(t *testing.T) {
+	var c Claims
+	assert.Nil(t, json.Unmarshal([]byte(`{"aud":"a"}`), &c))
+	assert.Equal(t, Audience{"a"}, c.Audience)
+	assert.Nil(t, json.Unmarshal([]byte(`{"aud":["a","b"]}`), &c))
+	assert.Equal(t, Audience{"a", "b"}, c.Audience)
+}
+
+func TestRemoteKeys(t *testing.T) {
+	key1, key2 := newKey(t), newKey(t)
+	set := map[string]*rsa.PrivateKey{"k1": key1}
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_, _ = w.Write([]byte(jwks(set)))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	k := NewRemoteKeys(srv.URL, time.Minute)
+	k.now = func() time.Time { return now }
+
+	pub, err := k.Key("k1")
+	assert.Nil(t, err)
+	assert.Equal(t, key1.N, pub.N)
+	_, _ = k.Key("k1")
+	assert.Equal(t, 1, fetches)
+
+	// rotated keys are picked up after the refresh interval
+	set["k2"] = key2
+	_, err = k.Key("k2")
+	assert.Equal(t, ErrUnknownKey, err)
+	assert.Equal(t, 1, fetches)
+	now = now.Add(2 * time.Minute)
+	pub, err = k.Key("k2")
+	assert.Nil(t, err)
+	assert.Equal(t, key2.N, pub.N)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestRemoteKeysUnavailable(t *testing.T) {
+	key1 := newKey(t)
+	fetches, up := 0, false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(jwks(map[string]*rsa.PrivateKey{"k1": key1})))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	k := NewRemoteKeys(srv.URL, time.Minute)
+	k.now = func() time.Time { return now }
+
+	// the failed fetch is not retried within the refresh interval
+	_, err := k.Key("k1")
+	assert.NotNil(t, err)
+	_, err = k.Key("k1")
+	assert.NotNil(t, err)
+	assert.NotEqual(t, ErrUnknownKey, err)
+	assert.Equal(t, 1, fetches)
+
+	up = true
+	now = now.Add(2 * time.Minute)
+	pub, err := k.Key("k1")
+	assert.Nil(t, err)
+	assert.Equal(t, key1.N, pub.N)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestRemoteKeysFetchUnlocked(t *testing.T) {
+	key1, key2 := newKey(t), newKey(t)
+	set := map[string]*rsa.PrivateKey{"k1": key1}
+	var fetches atomic.Int32
+	fetching, release := make(chan struct{}, 1), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) > 1 {
+			fetching <- struct{}{}
+			<-release
+		}
+		_, _ = w.Write([]byte(jwks(set)))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	k := NewRemoteKeys(srv.URL, time.Minute)
+	k.now = func() time.Time { return now }
+	_, err := k.Key("k1")
+	assert.Nil(t, err)
+
+	set["k2"] = key2
+	now = now.Add(2 * time.Minute)
+	var wg sync.WaitGroup
+	lookup := func() {
+		defer wg.Done()
+		pub, err := k.Key("k2")
+		assert.Nil(t, err)
+		assert.Equal(t, key2.N, pub.N)
+	}
+	wg.Add(1)
+	go lookup()
+	<-fetching
+
+	// the known keys are served during the fetch
+	pub, err := k.Key("k1")
+	assert.Nil(t, err)
+	assert.Equal(t, key1.N, pub.N)
+
+	// a concurrent lookup waits for the fetch in flight
+	wg.Add(1)
+	go lookup()
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestParseJWKS(t *testing.T) {
+	_, err := ParseJWKS([]byte(`{"keys":[{"kid":"k1","kty":"EC","crv":"P-256"}]}`))
+	assert.NotNil(t, err)
+	_, err = ParseJWKS([]byte(`not json`))
+	assert.NotNil(t, err)
+}
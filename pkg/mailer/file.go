@@ -0,0 +1,37 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package mailer
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// File writes the messages to a file or a log instead of sending them; for
+// development and tests
+type File struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+// NewFile create a new file sender
+func NewFile(w io.Writer, from string) *File {
+	return &File{
+		w:    w,
+		from: from,
+	}
+}
+
+// Send writes the message followed by a blank line
+func (f *File) Send(m *Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, err := f.w.Write(append(format(f.from, m, time.Now()), "\r\n\r\n"...))
+	return err
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package mailer
+
+import (
+	"bytes"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_File(t *testing.T) {
+	var b bytes.Buffer
+	s := NewFile(&b, "glad@example.org")
+	err := s.Send(&Message{
+		To:      []string{"alice@example.org"},
+		Subject: "Course canceled",
+		Body:    "Hi Alice,\nthe course is canceled.",
+	})
+	assert.Nil(t, err)
+	out := b.String()
+	assert.True(t, strings.HasPrefix(out, "From: glad@example.org\r\nTo: alice@example.org\r\nSubject: Course canceled\r\n"))
+	assert.Contains(t, out, "\r\n\r\nHi Alice,\r\nthe course is canceled.")
+}
+
+func Test_SMTP(t *testing.T) {
+	s := NewSMTP("smtp.example.org", 587, "glad", "secret", "glad@example.org", time.Second)
+	var sent struct {
+		addr, from string
+		to         []string
+		msg        []byte
+	}
+	s.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		assert.NotNil(t, a)
+		sent.addr, sent.from, sent.to, sent.msg = addr, from, to, msg
+		return nil
+	}
+
+	err := s.Send(&Message{To: []string{"alice@example.org", "bob@example.org"}, Subject: "Hi", Body: "Hello"})
+	assert.Nil(t, err)
+	assert.Equal(t, "smtp.example.org:587", sent.addr)
+	assert.Equal(t, "glad@example.org", sent.from)
+	assert.Equal(t, []string{"alice@example.org", "bob@example.org"}, sent.to)
+	assert.Contains(t, string(sent.msg), "To: alice@example.org, bob@example.org\r\n")
+
+	err = s.Send(&Message{Subject: "Hi"})
+	assert.NotNil(t, err)
+}
+
+func Test_SMTPTimeout(t *testing.T) {
+	// a server that accepts the connection and never answers
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	s := NewSMTP("127.0.0.1", addr.Port, "", "", "glad@example.org", 50*time.Millisecond)
+	start := time.Now()
+	err = s.Send(&Message{To: []string{"alice@example.org"}, Subject: "Hi", Body: "Hello"})
+	assert.NotNil(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -68,3 +68,96 @@ type Service interface {
 	SaveCLI(c *CLI) error
 	SaveHTTP(h *HTTP)
 }
+
+// Sync record results
+const (
+	SyncReceived = "received"
+	SyncApplied  = "applied"
+	SyncFailed   = "failed"
+)
+
+// SyncRecord a record moved between RDS and Salesforce
+type SyncRecord struct {
+	Object    string
+	Direction string
+	Result    string
+}
+
+// NewSyncRecord create a new sync record
+func NewSyncRecord(object, direction, result string) *SyncRecord {
+	return &SyncRecord{
+		Object:    object,
+		Direction: direction,
+		Result:    result,
+	}
+}
+
+// Callout an outbound request to Salesforce
+type Callout struct {
+	Object     string
+	StatusCode string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Duration   float64
+}
+
+// NewCallout create a new callout
+func NewCallout(object string) *Callout {
+	return &Callout{
+		Object: object,
+	}
+}
+
+// Started start monitoring the callout
+func (c *Callout) Started() {
+	c.StartedAt = time.Now()
+}
+
+// Finished callout finished
+func (c *Callout) Finished() {
+	c.FinishedAt = time.Now()
+	c.Duration = time.Since(c.StartedAt).Seconds()
+}
+
+// Queue pending sync work
+type Queue struct {
+	Name  string
+	Depth int
+	// age of the oldest pending item in seconds; 0 if empty
+	OldestAge float64
+}
+
+// NewQueue create a new queue snapshot
+func NewQueue(name string, depth int, oldest *time.Time) *Queue {
+	q := &Queue{
+		Name:  name,
+		Depth: depth,
+	}
+	if oldest != nil {
+		q.OldestAge = time.Since(*oldest).Seconds()
+	}
+	return q
+}
+
+// Control pause and throttle of the sync of an object of a tenant
+type Control struct {
+	Tenant    string
+	Object    string
+	Direction string
+	Paused    bool
+	// max records per run; 0 for no limit
+	Throttle int
+}
+
+// SyncService sync metrics
+type SyncService interface {
+	SaveSyncRecord(r *SyncRecord)
+	SaveCallout(c *Callout)
+	SaveQueue(q *Queue)
+	// SaveTokenRefresh result is success or failed
+	SaveTokenRefresh(result string)
+	// SaveDrift records drifted records found by reconciliation
+	SaveDrift(object string, count int)
+	// SaveControls replaces the recorded sync controls with the ones in effect
+	SaveControls(c []*Control)
+}
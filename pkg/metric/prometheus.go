@@ -13,10 +13,32 @@ import (
 	"github.com/prometheus/client_golang/prometheus/push"
 )
 
-// service implements Service interface
+// service implements Service and SyncService interfaces
 type service struct {
 	pHistogram           *prometheus.HistogramVec
 	httpRequestHistogram *prometheus.HistogramVec
+
+	// sync
+	syncRecords      *prometheus.CounterVec
+	calloutHistogram *prometheus.HistogramVec
+	queueDepth       *prometheus.GaugeVec
+	queueOldestAge   *prometheus.GaugeVec
+	tokenRefreshes   *prometheus.CounterVec
+	drift            *prometheus.CounterVec
+	paused           *prometheus.GaugeVec
+	throttle         *prometheus.GaugeVec
+}
+
+// register registers the collector; returns the registered one if a
+// collector with the same description exists already
+func register[T prometheus.Collector](c T) (T, error) {
+	err := prometheus.Register(c)
+	if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		if existing, ok := are.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+	return c, err
 }
 
 // NewPrometheusService create a new prometheus service
@@ -33,17 +55,78 @@ func NewPrometheusService() (*service, error) {
 		Help:      "The latency of the HTTP requests.",
 		Buckets:   prometheus.DefBuckets,
 	}, []string{"handler", "method", "code"})
+	records := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sync",
+		Name:      "records_total",
+		Help:      "Records received, applied and failed per object and direction.",
+	}, []string{"object", "direction", "result"})
+	callout := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "sync",
+		Name:      "callout_duration_seconds",
+		Help:      "The latency of the Salesforce callouts.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"object", "code"})
+	depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "sync",
+		Name:      "queue_depth",
+		Help:      "Pending items in the sync queue.",
+	}, []string{"queue"})
+	age := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "sync",
+		Name:      "queue_oldest_age_seconds",
+		Help:      "Age of the oldest pending item in the sync queue.",
+	}, []string{"queue"})
+	tokens := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sync",
+		Name:      "token_refresh_total",
+		Help:      "Salesforce access token refreshes.",
+	}, []string{"result"})
+	drift := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sync",
+		Name:      "reconcile_drift_total",
+		Help:      "Records found out of sync by reconciliation.",
+	}, []string{"object"})
+	paused := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "sync",
+		Name:      "paused",
+		Help:      "1 while the sync of the object of the tenant is paused.",
+	}, []string{"tenant", "object", "direction"})
+	throttle := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "sync",
+		Name:      "throttle_limit",
+		Help:      "Max records synced per run of the object of the tenant.",
+	}, []string{"tenant", "object", "direction"})
 
-	s := &service{
-		pHistogram:           cli,
-		httpRequestHistogram: http,
+	s := &service{}
+	var err error
+	if s.pHistogram, err = register(cli); err != nil {
+		return nil, err
+	}
+	if s.httpRequestHistogram, err = register(http); err != nil {
+		return nil, err
 	}
-	err := prometheus.Register(s.pHistogram)
-	if err != nil && err.Error() != "duplicate metrics collector registration attempted" {
+	if s.syncRecords, err = register(records); err != nil {
 		return nil, err
 	}
-	err = prometheus.Register(s.httpRequestHistogram)
-	if err != nil && err.Error() != "duplicate metrics collector registration attempted" {
+	if s.calloutHistogram, err = register(callout); err != nil {
+		return nil, err
+	}
+	if s.queueDepth, err = register(depth); err != nil {
+		return nil, err
+	}
+	if s.queueOldestAge, err = register(age); err != nil {
+		return nil, err
+	}
+	if s.tokenRefreshes, err = register(tokens); err != nil {
+		return nil, err
+	}
+	if s.drift, err = register(drift); err != nil {
+		return nil, err
+	}
+	if s.paused, err = register(paused); err != nil {
+		return nil, err
+	}
+	if s.throttle, err = register(throttle); err != nil {
 		return nil, err
 	}
 	return s, nil
@@ -60,3 +143,45 @@ func (s *service) SaveCLI(c *CLI) error {
 func (s *service) SaveHTTP(h *HTTP) {
 	s.httpRequestHistogram.WithLabelValues(h.Handler, h.Method, h.StatusCode).Observe(h.Duration)
 }
+
+// SaveSyncRecord counts a synced record
+func (s *service) SaveSyncRecord(r *SyncRecord) {
+	s.syncRecords.WithLabelValues(r.Object, r.Direction, r.Result).Inc()
+}
+
+// SaveCallout records the latency and status of a callout
+func (s *service) SaveCallout(c *Callout) {
+	s.calloutHistogram.WithLabelValues(c.Object, c.StatusCode).Observe(c.Duration)
+}
+
+// SaveQueue records the depth and age of a queue
+func (s *service) SaveQueue(q *Queue) {
+	s.queueDepth.WithLabelValues(q.Name).Set(float64(q.Depth))
+	s.queueOldestAge.WithLabelValues(q.Name).Set(q.OldestAge)
+}
+
+// SaveTokenRefresh counts a token refresh
+func (s *service) SaveTokenRefresh(result string) {
+	s.tokenRefreshes.WithLabelValues(result).Inc()
+}
+
+// SaveDrift counts drifted records
+func (s *service) SaveDrift(object string, count int) {
+	s.drift.WithLabelValues(object).Add(float64(count))
+}
+
+// SaveControls records the sync controls; resumed ones are dropped
+func (s *service) SaveControls(controls []*Control) {
+	s.paused.Reset()
+	s.throttle.Reset()
+	for _, c := range controls {
+		paused := 0.0
+		if c.Paused {
+			paused = 1
+		}
+		s.paused.WithLabelValues(c.Tenant, c.Object, c.Direction).Set(paused)
+		if c.Throttle > 0 {
+			s.throttle.WithLabelValues(c.Tenant, c.Object, c.Direction).Set(float64(c.Throttle))
+		}
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HeaderLimitInfo Salesforce response header reporting the API usage,
+// e.g. "api-usage=25/15000"
+const HeaderLimitInfo = "Sforce-Limit-Info"
+
+// ErrPaused non-urgent request refused as the API usage crossed the threshold
+var ErrPaused = errors.New("salesforce api usage above the pause threshold")
+
+// Config limits of an org
+type Config struct {
+	// requests per second; 0 disables the rate limit
+	Rate float64
+	// requests allowed at once after being idle
+	Burst int
+	// requests in flight; 0 disables the concurrency limit
+	MaxConcurrent int
+	// fraction of the daily quota after which non-urgent requests are
+	// refused; 0 disables pausing
+	PauseThreshold float64
+	// the reported usage is unknown once older, so that a request is let
+	// through to refresh it and the pause lifts once the quota is reset;
+	// 0 keeps it until the next report
+	UsageTTL time.Duration
+}
+
+// Limiter client side limits of the requests to a Salesforce org
+type Limiter struct {
+	cfg Config
+	sem chan struct{}
+	now func() time.Time
+
+	mu         sync.Mutex
+	tokens     float64
+	last       time.Time
+	used       int
+	max        int
+	observedAt time.Time
+}
+
+// New create a new limiter
+func New(cfg Config) *Limiter {
+	if cfg.Burst < 1 {
+		cfg.Burst = 1
+	}
+	l := &Limiter{
+		cfg:    cfg,
+		now:    time.Now,
+		tokens: float64(cfg.Burst),
+	}
+	l.last = l.now()
+	if cfg.MaxConcurrent > 0 {
+		l.sem = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	return l
+}
+
+// Acquire waits for a request slot. release must be called once the request
+// is done. Returns ErrPaused for non-urgent requests while paused.
+func (l *Limiter) Acquire(ctx context.Context, urgent bool) (release func(), err error) {
+	if !urgent && l.Paused() {
+		return nil, ErrPaused
+	}
+	if err := l.wait(ctx); err != nil {
+		return nil, err
+	}
+	if l.sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.sem <- struct{}{}:
+		return func() { <-l.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// wait waits for a token of the bucket
+func (l *Limiter) wait(ctx context.Context) error {
+	if l.cfg.Rate <= 0 {
+		return nil
+	}
+	for {
+		d := l.reserve()
+		if d == 0 {
+			return nil
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token; returns the time to wait if there is none
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = min(float64(l.cfg.Burst), l.tokens+now.Sub(l.last).Seconds()*l.cfg.Rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.cfg.Rate * float64(time.Second))
+}
+
+// Observe tracks the API usage reported in the response headers
+func (l *Limiter) Observe(h http.Header) {
+	used, max, ok := ParseLimitInfo(h.Get(HeaderLimitInfo))
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	l.used, l.max = used, max
+	l.observedAt = l.now()
+	l.mu.Unlock()
+}
+
+// Usage gets the last reported API usage; max is 0 if not known yet or the
+// report expired
+func (l *Limiter) Usage() (used, max int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cfg.UsageTTL > 0 && l.now().Sub(l.observedAt) >= l.cfg.UsageTTL {
+		return 0, 0
+	}
+	return l.used, l.max
+}
+
+// Paused checks whether the last reported API usage crossed the pause
+// threshold
+func (l *Limiter) Paused() bool {
+	used, max := l.Usage()
+	return l.cfg.PauseThreshold > 0 && max > 0 &&
+		float64(used) >= l.cfg.PauseThreshold*float64(max)
+}
+
+// ParseLimitInfo parses the api-usage of a Sforce-Limit-Info header value
+func ParseLimitInfo(v string) (used, max int, ok bool) {
+	for _, part := range strings.Split(v, ",") {
+		usage, found := strings.CutPrefix(strings.TrimSpace(part), "api-usage=")
+		if !found {
+			continue
+		}
+		u, m, found := strings.Cut(usage, "/")
+		if !found {
+			return 0, 0, false
+		}
+		used, err := strconv.Atoi(u)
+		if err != nil {
+			return 0, 0, false
+		}
+		max, err := strconv.Atoi(m)
+		if err != nil {
+			return 0, 0, false
+		}
+		return used, max, true
+	}
+	return 0, 0, false
+}
+
+var (
+	orgs   = map[string]*Limiter{}
+	orgsMu sync.Mutex
+)
+
+// ForOrg gets the limiter shared by all the clients of the org; cfg is used
+// when the limiter is created
+func ForOrg(org string, cfg Config) *Limiter {
+	orgsMu.Lock()
+	defer orgsMu.Unlock()
+
+	l, ok := orgs[org]
+	if !ok {
+		l = New(cfg)
+		orgs[org] = l
+	}
+	return l
+}
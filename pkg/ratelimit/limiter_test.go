@@ -0,0 +1,117 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimitInfo(t *testing.T) {
+	used, max, ok := ParseLimitInfo("api-usage=25/15000")
+	assert.True(t, ok)
+	assert.Equal(t, 25, used)
+	assert.Equal(t, 15000, max)
+
+	used, max, ok = ParseLimitInfo("per-app-api-usage=2/250(appName=sync), api-usage=30/100")
+	assert.True(t, ok)
+	assert.Equal(t, 30, used)
+	assert.Equal(t, 100, max)
+
+	_, _, ok = ParseLimitInfo("")
+	assert.False(t, ok)
+	_, _, ok = ParseLimitInfo("api-usage=25")
+	assert.False(t, ok)
+}
+
+func TestRate(t *testing.T) {
+	l := New(Config{Rate: 1, Burst: 2})
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	// bucket is empty
+	assert.Equal(t, time.Second, l.reserve())
+
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve())
+}
+
+func TestConcurrency(t *testing.T) {
+	l := New(Config{MaxConcurrent: 1})
+
+	release, err := l.Acquire(context.Background(), true)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, true)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	release()
+	release, err = l.Acquire(context.Background(), true)
+	assert.Nil(t, err)
+	release()
+}
+
+func TestPause(t *testing.T) {
+	l := New(Config{PauseThreshold: 0.8})
+	h := http.Header{}
+
+	h.Set(HeaderLimitInfo, "api-usage=79/100")
+	l.Observe(h)
+	assert.False(t, l.Paused())
+
+	h.Set(HeaderLimitInfo, "api-usage=80/100")
+	l.Observe(h)
+	assert.True(t, l.Paused())
+
+	_, err := l.Acquire(context.Background(), false)
+	assert.Equal(t, ErrPaused, err)
+	release, err := l.Acquire(context.Background(), true)
+	assert.Nil(t, err)
+	release()
+}
+
+func TestPauseExpires(t *testing.T) {
+	now := time.Now()
+	l := New(Config{PauseThreshold: 0.8, UsageTTL: 10 * time.Minute})
+	l.now = func() time.Time { return now }
+	h := http.Header{}
+
+	h.Set(HeaderLimitInfo, "api-usage=90/100")
+	l.Observe(h)
+	assert.True(t, l.Paused())
+
+	// unknown once expired; a request is let through to refresh it
+	now = now.Add(10 * time.Minute)
+	assert.False(t, l.Paused())
+	release, err := l.Acquire(context.Background(), false)
+	assert.Nil(t, err)
+	release()
+
+	h.Set(HeaderLimitInfo, "api-usage=10/100")
+	l.Observe(h)
+	used, max := l.Usage()
+	assert.Equal(t, 10, used)
+	assert.Equal(t, 100, max)
+}
+
+func TestForOrg(t *testing.T) {
+	l1 := ForOrg("org1", Config{Rate: 1})
+	l2 := ForOrg("org1", Config{Rate: 2})
+	l3 := ForOrg("org2", Config{Rate: 1})
+
+	assert.Same(t, l1, l2)
+	assert.NotSame(t, l1, l3)
+}
@@ -0,0 +1,380 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+// Package sfbulk is a client of the Salesforce Bulk API 2.0 ingest and query
+// jobs
+package sfbulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"sudhagar/glad/pkg/ratelimit"
+)
+
+// Operation of an ingest job
+type Operation string
+
+const (
+	OpInsert Operation = "insert"
+	OpUpdate Operation = "update"
+	OpUpsert Operation = "upsert"
+	OpDelete Operation = "delete"
+)
+
+// Job state
+type State string
+
+const (
+	StateOpen           State = "Open"
+	StateUploadComplete State = "UploadComplete"
+	StateInProgress     State = "InProgress"
+	StateJobComplete    State = "JobComplete"
+	StateFailed         State = "Failed"
+	StateAborted        State = "Aborted"
+)
+
+// Done checks whether the job finished
+func (s State) Done() bool {
+	return s == StateJobComplete || s == StateFailed || s == StateAborted
+}
+
+// Result columns added by Salesforce
+const (
+	ColumnID      = "sf__Id"
+	ColumnCreated = "sf__Created"
+	ColumnError   = "sf__Error"
+)
+
+// JobRequest ingest job to create
+type JobRequest struct {
+	Object              string    `json:"object"`
+	Operation           Operation `json:"operation"`
+	ExternalIDFieldName string    `json:"externalIdFieldName,omitempty"`
+	ContentType         string    `json:"contentType"`
+	LineEnding          string    `json:"lineEnding"`
+}
+
+// Job ingest job
+type Job struct {
+	ID                     string    `json:"id"`
+	Object                 string    `json:"object"`
+	Operation              Operation `json:"operation"`
+	State                  State     `json:"state"`
+	NumberRecordsProcessed int       `json:"numberRecordsProcessed"`
+	NumberRecordsFailed    int       `json:"numberRecordsFailed"`
+	ErrorMessage           string    `json:"errorMessage,omitempty"`
+}
+
+// Result per row result of a finished job; rows keep the uploaded columns
+type Result struct {
+	Job        *Job
+	Successful []map[string]string
+	Failed     []map[string]string
+}
+
+// Client Bulk API 2.0 client of a Salesforce org
+type Client struct {
+	// e.g. https://<org>.my.salesforce.com
+	InstanceURL string
+	// e.g. v60.0
+	Version string
+	// Token returns the access token of the org
+	Token func() (string, error)
+	// Limiter optional; bulk requests are not urgent
+	Limiter *ratelimit.Limiter
+	// PollInterval between job status checks
+	PollInterval time.Duration
+	HTTP         *http.Client
+}
+
+// Job resources
+const (
+	ingestPath = "/jobs/ingest"
+	queryPath  = "/jobs/query"
+)
+
+// ErrJobFailed the job did not complete
+var ErrJobFailed = errors.New("bulk job failed")
+
+// Run creates a job for the CSV, uploads it, waits for the job to finish and
+// fetches the per row results
+func (c *Client) Run(ctx context.Context, req JobRequest, data []byte) (*Result, error) {
+	job, err := c.CreateJob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Upload(ctx, job.ID, data); err != nil {
+		return nil, err
+	}
+	if err := c.SetState(ctx, job.ID, StateUploadComplete); err != nil {
+		return nil, err
+	}
+	job, err = c.Wait(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job.State != StateJobComplete {
+		return &Result{Job: job}, fmt.Errorf("%w: %s %s", ErrJobFailed, job.State, job.ErrorMessage)
+	}
+
+	result := &Result{Job: job}
+	if result.Successful, err = c.Results(ctx, job.ID, "successfulResults"); err != nil {
+		return nil, err
+	}
+	if result.Failed, err = c.Results(ctx, job.ID, "failedResults"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateJob creates an ingest job
+func (c *Client) CreateJob(ctx context.Context, req JobRequest) (*Job, error) {
+	if req.ContentType == "" {
+		req.ContentType = "CSV"
+	}
+	if req.LineEnding == "" {
+		req.LineEnding = "LF"
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var job Job
+	err = c.do(ctx, http.MethodPost, ingestPath, "application/json", body, http.StatusOK, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk job: %w", err)
+	}
+	return &job, nil
+}
+
+// Upload uploads the CSV data of the job
+func (c *Client) Upload(ctx context.Context, jobID string, data []byte) error {
+	err := c.do(ctx, http.MethodPut, ingestPath+"/"+jobID+"/batches", "text/csv", data, http.StatusCreated, nil)
+	if err != nil {
+		return fmt.Errorf("failed to upload bulk job data: %w", err)
+	}
+	return nil
+}
+
+// SetState sets the job state to UploadComplete or Aborted
+func (c *Client) SetState(ctx context.Context, jobID string, state State) error {
+	body, _ := json.Marshal(map[string]State{"state": state})
+	err := c.do(ctx, http.MethodPatch, ingestPath+"/"+jobID, "application/json", body, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set bulk job state: %w", err)
+	}
+	return nil
+}
+
+// GetJob gets the job status
+func (c *Client) GetJob(ctx context.Context, jobID string) (*Job, error) {
+	return c.getJob(ctx, ingestPath, jobID)
+}
+
+func (c *Client) getJob(ctx context.Context, resource, jobID string) (*Job, error) {
+	var job Job
+	err := c.do(ctx, http.MethodGet, resource+"/"+jobID, "", nil, http.StatusOK, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk job: %w", err)
+	}
+	return &job, nil
+}
+
+// Wait polls the job until it is done
+func (c *Client) Wait(ctx context.Context, jobID string) (*Job, error) {
+	return c.wait(ctx, ingestPath, jobID)
+}
+
+func (c *Client) wait(ctx context.Context, resource, jobID string) (*Job, error) {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	for {
+		job, err := c.getJob(ctx, resource, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.State.Done() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Results gets the successfulResults or failedResults rows of the job
+func (c *Client) Results(ctx context.Context, jobID, kind string) ([]map[string]string, error) {
+	var data bytes.Buffer
+	err := c.do(ctx, http.MethodGet, ingestPath+"/"+jobID+"/"+kind, "", nil, http.StatusOK, &data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk job %s: %w", kind, err)
+	}
+	return ReadCSV(&data)
+}
+
+// Query runs a query job of the SOQL query and returns the rows keyed by
+// field name
+func (c *Client) Query(ctx context.Context, soql string) ([]map[string]string, error) {
+	body, err := json.Marshal(map[string]string{"operation": "query", "query": soql})
+	if err != nil {
+		return nil, err
+	}
+	var job Job
+	err = c.do(ctx, http.MethodPost, queryPath, "application/json", body, http.StatusOK, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk query job: %w", err)
+	}
+	done, err := c.wait(ctx, queryPath, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if done.State != StateJobComplete {
+		return nil, fmt.Errorf("%w: %s %s", ErrJobFailed, done.State, done.ErrorMessage)
+	}
+
+	// Note: large results are split in pages chained by a locator
+	var rows []map[string]string
+	page := resultPage{}
+	for {
+		path := queryPath + "/" + job.ID + "/results"
+		if page.locator != "" {
+			path += "?locator=" + url.QueryEscape(page.locator)
+		}
+		page = resultPage{}
+		err := c.do(ctx, http.MethodGet, path, "", nil, http.StatusOK, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bulk query results: %w", err)
+		}
+		data, err := ReadCSV(&page.data)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, data...)
+		if page.locator == "" || page.locator == "null" {
+			return rows, nil
+		}
+	}
+}
+
+// resultPage a page of query results and the locator of the next one
+type resultPage struct {
+	data    bytes.Buffer
+	locator string
+}
+
+// do sends the request; out is decoded as JSON unless it is a buffer or a
+// result page
+func (c *Client) do(ctx context.Context,
+	method, path, contentType string,
+	body []byte,
+	want int,
+	out any,
+) error {
+	if c.Limiter != nil {
+		release, err := c.Limiter.Acquire(ctx, false)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	endpoint := c.InstanceURL + "/services/data/" + c.Version + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	token, err := c.Token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if c.Limiter != nil {
+		c.Limiter.Observe(resp.Header)
+	}
+
+	if resp.StatusCode != want {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("SF returned status %d: %s", resp.StatusCode, msg)
+	}
+	switch o := out.(type) {
+	case nil:
+		return nil
+	case *bytes.Buffer:
+		_, err = o.ReadFrom(resp.Body)
+		return err
+	case *resultPage:
+		o.locator = resp.Header.Get("Sforce-Locator")
+		_, err = o.data.ReadFrom(resp.Body)
+		return err
+	default:
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+}
+
+// WriteCSV encodes the rows with the header
+func WriteCSV(header []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadCSV decodes the rows keyed by the header
+func ReadCSV(r io.Reader) ([]map[string]string, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(record) {
+				row[h] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
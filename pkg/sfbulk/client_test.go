@@ -0,0 +1,111 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sfbulk_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sudhagar/glad/pkg/sfbulk"
+	"sudhagar/glad/pkg/sfbulk/sffake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(s *sffake.Server) *sfbulk.Client {
+	return &sfbulk.Client{
+		InstanceURL:  s.URL,
+		Version:      "v60.0",
+		Token:        func() (string, error) { return "token", nil },
+		PollInterval: time.Millisecond,
+	}
+}
+
+func TestRun_Upsert(t *testing.T) {
+	s := sffake.NewServer()
+	defer s.Close()
+	s.Reject = func(object string, row map[string]string) string {
+		if row["Name"] == "" {
+			return "REQUIRED_FIELD_MISSING:Required fields are missing: [Name]"
+		}
+		return ""
+	}
+	c := newClient(s)
+	req := sfbulk.JobRequest{
+		Object:              "Event__c",
+		Operation:           sfbulk.OpUpsert,
+		ExternalIDFieldName: "Glad_Id__c",
+	}
+
+	data, err := sfbulk.WriteCSV([]string{"Glad_Id__c", "Name"}, [][]string{
+		{"1", "Happiness Program"},
+		{"2", ""},
+		{"3", "Sahaj Samadhi"},
+	})
+	assert.Nil(t, err)
+	result, err := c.Run(context.Background(), req, data)
+	assert.Nil(t, err)
+	assert.Equal(t, sfbulk.StateJobComplete, result.Job.State)
+	assert.Equal(t, 3, result.Job.NumberRecordsProcessed)
+	assert.Equal(t, 1, result.Job.NumberRecordsFailed)
+	assert.Len(t, result.Successful, 2)
+	assert.Equal(t, "1", result.Successful[0]["Glad_Id__c"])
+	assert.Equal(t, "true", result.Successful[0][sfbulk.ColumnCreated])
+	assert.NotEmpty(t, result.Successful[0][sfbulk.ColumnID])
+	assert.Len(t, result.Failed, 1)
+	assert.Equal(t, "2", result.Failed[0]["Glad_Id__c"])
+	assert.Contains(t, result.Failed[0][sfbulk.ColumnError], "REQUIRED_FIELD_MISSING")
+	assert.Len(t, s.Records("Event__c"), 2)
+
+	// re-export updates the same records
+	data, _ = sfbulk.WriteCSV([]string{"Glad_Id__c", "Name"}, [][]string{{"1", "Art of Living Part 1"}})
+	result, err = c.Run(context.Background(), req, data)
+	assert.Nil(t, err)
+	assert.Equal(t, "false", result.Successful[0][sfbulk.ColumnCreated])
+	assert.Len(t, s.Records("Event__c"), 2)
+}
+
+func TestRun_Failed(t *testing.T) {
+	s := sffake.NewServer()
+	defer s.Close()
+	c := newClient(s)
+
+	_, err := c.Run(context.Background(), sfbulk.JobRequest{Object: "Event__c", Operation: sfbulk.OpUpsert}, nil)
+	assert.NotNil(t, err)
+
+	result, err := c.Run(context.Background(), sfbulk.JobRequest{Object: "Event__c", Operation: sfbulk.OpInsert}, nil)
+	assert.ErrorIs(t, err, sfbulk.ErrJobFailed)
+	assert.Equal(t, sfbulk.StateFailed, result.Job.State)
+}
+
+func TestQuery(t *testing.T) {
+	s := sffake.NewServer()
+	defer s.Close()
+	s.PageSize = 2
+	c := newClient(s)
+
+	data, _ := sfbulk.WriteCSV([]string{"Name"}, [][]string{{"a"}, {"b"}, {"c"}})
+	result, err := c.Run(context.Background(), sfbulk.JobRequest{Object: "Event__c", Operation: sfbulk.OpInsert}, data)
+	assert.Nil(t, err)
+	assert.Len(t, result.Successful, 3)
+
+	// results are read across the pages
+	rows, err := c.Query(context.Background(), "SELECT Id, Name FROM Event__c")
+	assert.Nil(t, err)
+	assert.Len(t, rows, 3)
+
+	id := result.Successful[1][sfbulk.ColumnID]
+	rows, err = c.Query(context.Background(), "SELECT Id, Name FROM Event__c WHERE Id IN ('"+id+"')")
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, id, rows[0]["Id"])
+	assert.Equal(t, "b", rows[0]["Name"])
+
+	_, err = c.Query(context.Background(), "DELETE FROM Event__c")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,377 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+// Package sffake is a local fake of the Salesforce Bulk API 2.0 ingest and
+// query jobs for tests and local runs of the syncer
+package sffake
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sudhagar/glad/pkg/sfbulk"
+)
+
+// Server fake Salesforce org
+type Server struct {
+	*httptest.Server
+
+	// Reject returns an error message to fail a row, empty to accept it
+	Reject func(object string, row map[string]string) string
+	// PageSize rows per page of query results; all the rows if not set
+	PageSize int
+	// Now the SystemModstamp of the records written
+	Now func() time.Time
+
+	mu      sync.Mutex
+	nextID  int
+	jobs    map[string]*job
+	records map[string]map[string]map[string]string
+}
+
+type job struct {
+	sfbulk.Job
+	external string
+	data     bytes.Buffer
+	success  [][]string
+	failed   [][]string
+	header   []string
+	// rows of a query job
+	rows [][]string
+}
+
+// The queries served: the fields of an object, optionally of a list of ids
+// or modified after a time
+var (
+	soqlPattern     = regexp.MustCompile(`(?i)^SELECT\s+(.+?)\s+FROM\s+(\w+)(?:\s+WHERE\s+(.+?))?\s*$`)
+	idsPattern      = regexp.MustCompile(`(?i)^Id\s+IN\s*\((.*)\)$`)
+	modifiedPattern = regexp.MustCompile(`(?i)^SystemModstamp\s*>\s*(\S+)$`)
+)
+
+// modstampFormat SystemModstamp as returned by SF
+const modstampFormat = "2006-01-02T15:04:05.000Z"
+
+// NewServer starts a fake org; Close it when done
+func NewServer() *Server {
+	s := &Server{
+		Now:     time.Now,
+		jobs:    map[string]*job{},
+		records: map[string]map[string]map[string]string{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Records returns the stored records of the object keyed by Id
+func (s *Server) Records(object string) map[string]map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := map[string]map[string]string{}
+	for id, r := range s.records[object] {
+		out[id] = r
+	}
+	return out
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		http.Error(w, "INVALID_SESSION_ID", http.StatusUnauthorized)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := strings.Index(r.URL.Path, "/jobs/query"); i >= 0 {
+		s.serveQuery(w, r, strings.Split(strings.Trim(r.URL.Path[i+len("/jobs/query"):], "/"), "/"))
+		return
+	}
+	i := strings.Index(r.URL.Path, "/jobs/ingest")
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path[i+len("/jobs/ingest"):], "/"), "/")
+
+	if parts[0] == "" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.create(w, r)
+		return
+	}
+	j, ok := s.jobs[parts[0]]
+	if !ok {
+		http.Error(w, "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(j.Job)
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		s.setState(w, r, j)
+	case len(parts) == 2 && parts[1] == "batches" && r.Method == http.MethodPut:
+		if j.State != sfbulk.StateOpen {
+			http.Error(w, "job is not open", http.StatusConflict)
+			return
+		}
+		j.data.ReadFrom(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	case len(parts) == 2 && parts[1] == "successfulResults":
+		writeResults(w, append([]string{sfbulk.ColumnID, sfbulk.ColumnCreated}, j.header...), j.success)
+	case len(parts) == 2 && parts[1] == "failedResults":
+		writeResults(w, append([]string{sfbulk.ColumnID, sfbulk.ColumnError}, j.header...), j.failed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveQuery serves the query jobs; they complete when created
+func (s *Server) serveQuery(w http.ResponseWriter, r *http.Request, parts []string) {
+	if parts[0] == "" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.createQuery(w, r)
+		return
+	}
+	j, ok := s.jobs[parts[0]]
+	if !ok || j.Operation != "query" {
+		http.Error(w, "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(j.Job)
+	case len(parts) == 2 && parts[1] == "results" && r.Method == http.MethodGet:
+		start, _ := strconv.Atoi(r.URL.Query().Get("locator"))
+		end := len(j.rows)
+		if s.PageSize > 0 && start+s.PageSize < end {
+			end = start + s.PageSize
+		}
+		if start > end {
+			start = end
+		}
+		locator := "null"
+		if end < len(j.rows) {
+			locator = strconv.Itoa(end)
+		}
+		w.Header().Set("Sforce-Locator", locator)
+		writeResults(w, j.header, j.rows[start:end])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) createQuery(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Operation string `json:"operation"`
+		Query     string `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Operation != "query" {
+		http.Error(w, "INVALIDJOB", http.StatusBadRequest)
+		return
+	}
+	m := soqlPattern.FindStringSubmatch(strings.TrimSpace(req.Query))
+	if m == nil {
+		http.Error(w, "MALFORMED_QUERY", http.StatusBadRequest)
+		return
+	}
+
+	s.nextID++
+	j := &job{}
+	j.Job = sfbulk.Job{
+		ID:        fmt.Sprintf("750%015d", s.nextID),
+		Object:    m[2],
+		Operation: "query",
+		State:     sfbulk.StateJobComplete,
+	}
+	for _, f := range strings.Split(m[1], ",") {
+		j.header = append(j.header, strings.TrimSpace(f))
+	}
+	var ids map[string]bool
+	var after *time.Time
+	if where := m[3]; where != "" {
+		if in := idsPattern.FindStringSubmatch(where); in != nil {
+			ids = map[string]bool{}
+			for _, id := range strings.Split(in[1], ",") {
+				ids[strings.Trim(strings.TrimSpace(id), "'")] = true
+			}
+		} else if mod := modifiedPattern.FindStringSubmatch(where); mod != nil {
+			t, err := time.Parse(time.RFC3339, mod[1])
+			if err != nil {
+				http.Error(w, "MALFORMED_QUERY: invalid datetime", http.StatusBadRequest)
+				return
+			}
+			after = &t
+		} else {
+			http.Error(w, "MALFORMED_QUERY", http.StatusBadRequest)
+			return
+		}
+	}
+	for id, record := range s.records[j.Object] {
+		if ids != nil && !ids[id] {
+			continue
+		}
+		if after != nil {
+			if t, err := time.Parse(time.RFC3339, record["SystemModstamp"]); err != nil || !t.After(*after) {
+				continue
+			}
+		}
+		row := make([]string, len(j.header))
+		for i, h := range j.header {
+			row[i] = record[h]
+		}
+		j.rows = append(j.rows, row)
+	}
+	j.NumberRecordsProcessed = len(j.rows)
+	s.jobs[j.ID] = j
+	json.NewEncoder(w).Encode(j.Job)
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	var req sfbulk.JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Object == "" {
+		http.Error(w, "INVALIDJOB", http.StatusBadRequest)
+		return
+	}
+	if req.Operation == sfbulk.OpUpsert && req.ExternalIDFieldName == "" {
+		http.Error(w, "INVALIDJOB: externalIdFieldName is required", http.StatusBadRequest)
+		return
+	}
+	s.nextID++
+	j := &job{external: req.ExternalIDFieldName}
+	j.Job = sfbulk.Job{
+		ID:        fmt.Sprintf("750%015d", s.nextID),
+		Object:    req.Object,
+		Operation: req.Operation,
+		State:     sfbulk.StateOpen,
+	}
+	s.jobs[j.ID] = j
+	json.NewEncoder(w).Encode(j.Job)
+}
+
+// setState processes the uploaded rows synchronously on UploadComplete
+func (s *Server) setState(w http.ResponseWriter, r *http.Request, j *job) {
+	var req struct {
+		State sfbulk.State `json:"state"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "INVALID_STATE", http.StatusBadRequest)
+		return
+	}
+	switch req.State {
+	case sfbulk.StateAborted:
+		j.State = sfbulk.StateAborted
+	case sfbulk.StateUploadComplete:
+		s.process(j)
+	default:
+		http.Error(w, "INVALID_STATE", http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(j.Job)
+}
+
+func (s *Server) process(j *job) {
+	records, err := csv.NewReader(&j.data).ReadAll()
+	if err != nil || len(records) == 0 {
+		j.State = sfbulk.StateFailed
+		j.ErrorMessage = "InvalidBatch: unable to parse CSV"
+		return
+	}
+	j.header = records[0]
+	store := s.records[j.Object]
+	if store == nil {
+		store = map[string]map[string]string{}
+		s.records[j.Object] = store
+	}
+
+	for _, record := range records[1:] {
+		row := map[string]string{}
+		for i, h := range j.header {
+			if i < len(record) {
+				row[h] = record[i]
+			}
+		}
+		j.NumberRecordsProcessed++
+
+		if s.Reject != nil {
+			if msg := s.Reject(j.Object, row); msg != "" {
+				j.NumberRecordsFailed++
+				j.failed = append(j.failed, append([]string{"", msg}, record...))
+				continue
+			}
+		}
+		id, created, msg := s.match(j, store, row)
+		if msg != "" {
+			j.NumberRecordsFailed++
+			j.failed = append(j.failed, append([]string{"", msg}, record...))
+			continue
+		}
+		if j.Operation == sfbulk.OpDelete {
+			delete(store, id)
+		} else {
+			if store[id] == nil {
+				store[id] = map[string]string{"Id": id}
+			}
+			for k, v := range row {
+				store[id][k] = v
+			}
+			store[id]["SystemModstamp"] = s.Now().UTC().Format(modstampFormat)
+		}
+		j.success = append(j.success, append([]string{id, fmt.Sprint(created)}, record...))
+	}
+	j.State = sfbulk.StateJobComplete
+}
+
+// match finds the record of the row, or a new Id when the row creates one
+func (s *Server) match(j *job, store map[string]map[string]string, row map[string]string) (string, bool, string) {
+	switch j.Operation {
+	case sfbulk.OpInsert:
+		return s.newID(j.Object), true, ""
+	case sfbulk.OpUpsert:
+		key := row[j.external]
+		if key == "" {
+			return "", false, "MISSING_ARGUMENT:" + j.external + " not specified"
+		}
+		for id, r := range store {
+			if r[j.external] == key {
+				return id, false, ""
+			}
+		}
+		return s.newID(j.Object), true, ""
+	default:
+		id := row["Id"]
+		if _, ok := store[id]; !ok {
+			return "", false, "ENTITY_IS_DELETED:entity is deleted"
+		}
+		return id, false, ""
+	}
+}
+
+func (s *Server) newID(object string) string {
+	s.nextID++
+	return fmt.Sprintf("a0%s%013d", strings.ToUpper(object[:1]), s.nextID)
+}
+
+func writeResults(w http.ResponseWriter, header []string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	cw.WriteAll(rows)
+}
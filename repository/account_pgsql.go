@@ -82,11 +82,17 @@ func (r *AccountPGSQL) GetByName(tenantID entity.ID, username string) (*entity.A
 // Update updates an account
 func (r *AccountPGSQL) Update(e *entity.Account) error {
 	e.UpdatedAt = time.Now()
-	_, err := r.db.Exec(`UPDATE account SET username = $1, type = $2, cognito_id = $3, updated_at = $4 WHERE id = $5;`,
-		e.Username, e.Type, e.CognitoID, e.UpdatedAt.Format("2006-01-02"), e.ID)
+	res, err := r.db.Exec(`UPDATE account SET username = $1, type = $2, cognito_id = $3, updated_at = $4
+		WHERE id = $5 AND tenant_id = $6;`,
+		e.Username, e.Type, e.CognitoID, e.UpdatedAt.Format("2006-01-02"), e.ID, e.TenantID)
 	if err != nil {
 		return err
 	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
 	return nil
 }
 
@@ -134,9 +140,9 @@ func (r *AccountPGSQL) List(tenantID entity.ID, page, limit int, at entity.Accou
 	return r.scanRows(rows)
 }
 
-// Delete deletes an account
-func (r *AccountPGSQL) Delete(id entity.ID) error {
-	res, err := r.db.Exec(`DELETE FROM account WHERE id = $1;`, id)
+// Delete deletes an account of the tenant
+func (r *AccountPGSQL) Delete(tenantID, id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM account WHERE tenant_id = $1 AND id = $2;`, tenantID, id)
 	if err != nil {
 		return err
 	}
@@ -177,12 +183,12 @@ func (r *AccountPGSQL) GetCount(tenantID entity.ID) (int, error) {
 	return count, nil
 }
 
-// Get retrieves an account
-func (r *AccountPGSQL) Get(id entity.ID) (*entity.Account, error) {
+// Get retrieves an account of the tenant
+func (r *AccountPGSQL) Get(tenantID, id entity.ID) (*entity.Account, error) {
 	stmt, err := r.db.Prepare(`
 		SELECT id, tenant_id, ext_id, username, first_name, last_name,
 			phone, email, type, created_at
-		FROM account WHERE id = $1;`)
+		FROM account WHERE tenant_id = $1 AND id = $2;`)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +196,7 @@ func (r *AccountPGSQL) Get(id entity.ID) (*entity.Account, error) {
 	var a entity.Account
 	var ext_id, first_name, last_name, phone, email, accountType sql.NullString
 
-	err = stmt.QueryRow(id).Scan(
+	err = stmt.QueryRow(tenantID, id).Scan(
 		&a.ID,
 		&a.TenantID,
 		&ext_id,
@@ -219,6 +225,33 @@ func (r *AccountPGSQL) Get(id entity.ID) (*entity.Account, error) {
 	return &a, nil
 }
 
+// GetByCognitoID retrieves an account using the Cognito user id; the ids
+// are unique across the tenants of the user pool
+func (r *AccountPGSQL) GetByCognitoID(cognitoID string) (*entity.Account, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT id, tenant_id, ext_id, username, first_name, last_name,
+			phone, email, type, created_at
+		FROM account WHERE cognito_id = $1;`)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.Query(cognitoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts, err := r.scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+	accounts[0].CognitoID = cognitoID
+	return accounts[0], nil
+}
+
 // Search searches accounts
 func (r *AccountPGSQL) Search(tenantID entity.ID, q string, page, limit int, at entity.AccountType) ([]*entity.Account, error) {
 	// OR LOWER(first_name) LIKE LOWER($2)
@@ -59,11 +59,12 @@ func (r *CenterPGSQL) Create(e *entity.Center) (entity.ID, error) {
 	return e.ID, nil
 }
 
-// Get retrieves a center
+// Get retrieves a center of the tenant
 // Not all fields are required for v1
-func (r *CenterPGSQL) Get(id entity.ID) (*entity.Center, error) {
+func (r *CenterPGSQL) Get(tenantID, id entity.ID) (*entity.Center, error) {
 	stmt, err := r.db.Prepare(`
-		SELECT id, tenant_id, ext_id, ext_name, name, mode, created_at FROM center WHERE id = $1;`)
+		SELECT id, tenant_id, ext_id, ext_name, name, mode, created_at, ` + syncColumns + `
+		FROM center WHERE tenant_id = $1 AND id = $2;`)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +73,9 @@ func (r *CenterPGSQL) Get(id entity.ID) (*entity.Center, error) {
 	var extName sql.NullString
 	var name sql.NullString
 	var mode sql.NullString
-	err = stmt.QueryRow(id).Scan(&c.ID, &c.TenantID, &extID, &extName, &name, &mode, &c.CreatedAt)
+	var syncState nullSyncState
+	dest := []any{&c.ID, &c.TenantID, &extID, &extName, &name, &mode, &c.CreatedAt}
+	err = stmt.QueryRow(tenantID, id).Scan(append(dest, syncState.dest()...)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -83,6 +86,7 @@ func (r *CenterPGSQL) Get(id entity.ID) (*entity.Center, error) {
 	c.Name = name.String
 	c.ExtName = extName.String
 	c.Mode = entity.CenterMode(mode.String)
+	syncState.copyTo(&c.Sync)
 
 	return &c, nil
 }
@@ -90,12 +94,17 @@ func (r *CenterPGSQL) Get(id entity.ID) (*entity.Center, error) {
 // Update updates a center
 func (r *CenterPGSQL) Update(e *entity.Center) error {
 	e.UpdatedAt = time.Now()
-	_, err := r.db.Exec(`
-		UPDATE center SET name = $1, mode = $2, updated_at = $3 WHERE id = $4;`,
-		e.Name, e.Mode, e.UpdatedAt.Format("2006-01-02"), e.ID)
+	res, err := r.db.Exec(`
+		UPDATE center SET name = $1, mode = $2, updated_at = $3 WHERE id = $4 AND tenant_id = $5;`,
+		e.Name, e.Mode, e.UpdatedAt.Format("2006-01-02"), e.ID, e.TenantID)
 	if err != nil {
 		return err
 	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
 	return nil
 }
 
@@ -104,7 +113,7 @@ func (r *CenterPGSQL) Search(tenantID entity.ID,
 	q string, page, limit int,
 ) ([]*entity.Center, error) {
 	query := `
-		SELECT id, tenant_id, ext_id, ext_name, name, capacity, mode, created_at
+		SELECT id, tenant_id, ext_id, ext_name, name, capacity, mode, created_at, ` + syncColumns + `
 		FROM center
 		WHERE is_enabled = TRUE
 			AND tenant_id = $1
@@ -146,7 +155,7 @@ func (r *CenterPGSQL) Search(tenantID entity.ID,
 // List lists centers
 func (r *CenterPGSQL) List(tenantID entity.ID, page, limit int) ([]*entity.Center, error) {
 	query := `
-		SELECT id, tenant_id, ext_id, ext_name, name, capacity, mode, created_at
+		SELECT id, tenant_id, ext_id, ext_name, name, capacity, mode, created_at, ` + syncColumns + `
 		FROM center
 		WHERE is_enabled = TRUE AND tenant_id = $1
 	`
@@ -180,9 +189,58 @@ func (r *CenterPGSQL) List(tenantID entity.ID, page, limit int) ([]*entity.Cente
 	return r.scanRows(rows)
 }
 
-// Delete deletes a center
-func (r *CenterPGSQL) Delete(id entity.ID) error {
-	res, err := r.db.Exec(`DELETE FROM center WHERE id = $1;`, id)
+// CountBySyncStatus counts the centers of the tenant per sync status
+func (r *CenterPGSQL) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	return countBySyncStatus(r.db, "center", tenantID)
+}
+
+// ListBySyncStatus lists centers with the given sync status
+func (r *CenterPGSQL) ListBySyncStatus(tenantID entity.ID,
+	status entity.SyncStatus, page, limit int,
+) ([]*entity.Center, error) {
+	query := `
+		SELECT id, tenant_id, ext_id, ext_name, name, capacity, mode, created_at, ` + syncColumns + `
+		FROM center
+		WHERE tenant_id = $1 AND sync_status = $2
+	`
+	if page > 0 && limit > 0 {
+		offset := (page - 1) * limit
+		query += ` LIMIT $3 OFFSET $4;`
+		stmt, err := r.db.Prepare(query)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := stmt.Query(tenantID, status, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		return r.scanRows(rows)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(tenantID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	return r.scanRows(rows)
+}
+
+// UpdateSyncState updates the sync meta data of a center
+func (r *CenterPGSQL) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	return updateSyncState(r.db, "center", id, s)
+}
+
+// Delete deletes a center of the tenant
+func (r *CenterPGSQL) Delete(tenantID, id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM center WHERE tenant_id = $1 AND id = $2;`, tenantID, id)
 	if err != nil {
 		return err
 	}
@@ -215,8 +273,9 @@ func (r *CenterPGSQL) scanRows(rows *sql.Rows) ([]*entity.Center, error) {
 		var center entity.Center
 		var ext_id, ext_name, name sql.NullString
 		var capacity sql.NullInt32
+		var syncState nullSyncState
 
-		err := rows.Scan(
+		dest := []any{
 			&center.ID,
 			&center.TenantID,
 			&ext_id,
@@ -225,7 +284,8 @@ func (r *CenterPGSQL) scanRows(rows *sql.Rows) ([]*entity.Center, error) {
 			&capacity,
 			&center.Mode,
 			&center.CreatedAt,
-		)
+		}
+		err := rows.Scan(append(dest, syncState.dest()...)...)
 
 		if err != nil {
 			return nil, err
@@ -235,9 +295,103 @@ func (r *CenterPGSQL) scanRows(rows *sql.Rows) ([]*entity.Center, error) {
 		center.ExtName = ext_name.String
 		center.Name = name.String
 		center.Capacity = capacity.Int32
+		syncState.copyTo(&center.Sync)
 
 		centers = append(centers, &center)
 
 	}
 	return centers, nil
 }
+
+const centerContactColumns = `id, center_id, COALESCE(name, ''), COALESCE(phone, ''), COALESCE(email, ''),
+		is_primary, created_at, updated_at`
+
+// centerOfTenant restricts a center contact query to the centers of the
+// tenant given as $1
+const centerOfTenant = `center_id IN (SELECT id FROM center WHERE tenant_id = $1)`
+
+// GetContact gets a center contact of a center of the tenant
+func (r *CenterPGSQL) GetContact(tenantID, id entity.ID) (*entity.CenterContact, error) {
+	rows, err := r.db.Query(`
+		SELECT `+centerContactColumns+` FROM center_contact
+		WHERE `+centerOfTenant+` AND id = $2;`, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts, err := scanCenterContacts(rows)
+	if err != nil || len(contacts) == 0 {
+		return nil, err
+	}
+	return contacts[0], nil
+}
+
+// ListContacts lists the contacts of a center, the primary contact first
+func (r *CenterPGSQL) ListContacts(centerID entity.ID) ([]*entity.CenterContact, error) {
+	rows, err := r.db.Query(`
+		SELECT `+centerContactColumns+` FROM center_contact
+		WHERE center_id = $1 ORDER BY is_primary DESC, created_at;`, centerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCenterContacts(rows)
+}
+
+// CreateContact creates a center contact
+func (r *CenterPGSQL) CreateContact(e *entity.CenterContact) (entity.ID, error) {
+	_, err := r.db.Exec(`
+		INSERT INTO center_contact (id, center_id, name, phone, email, is_primary, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $7);`,
+		e.ID, e.CenterID, e.Name, nullString(e.Phone), nullString(e.Email), e.IsPrimary, e.CreatedAt)
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// UpdateContact updates a center contact of a center of the tenant
+func (r *CenterPGSQL) UpdateContact(tenantID entity.ID, e *entity.CenterContact) error {
+	res, err := r.db.Exec(`
+		UPDATE center_contact SET name = $2, phone = $3, email = $4, is_primary = $5, updated_at = $6
+		WHERE `+centerOfTenant+` AND id = $7;`,
+		tenantID, e.Name, nullString(e.Phone), nullString(e.Email), e.IsPrimary, e.UpdatedAt, e.ID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteContact deletes a center contact of a center of the tenant
+func (r *CenterPGSQL) DeleteContact(tenantID, id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM center_contact WHERE `+centerOfTenant+` AND id = $2;`, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanCenterContacts(rows *sql.Rows) ([]*entity.CenterContact, error) {
+	var contacts []*entity.CenterContact
+	for rows.Next() {
+		var c entity.CenterContact
+		err := rows.Scan(&c.ID, &c.CenterID, &c.Name, &c.Phone, &c.Email,
+			&c.IsPrimary, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, &c)
+	}
+	return contacts, rows.Err()
+}
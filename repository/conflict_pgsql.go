@@ -0,0 +1,77 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"sudhagar/glad/entity"
+)
+
+// ConflictPGSQL postgres repo
+type ConflictPGSQL struct {
+	db *sql.DB
+}
+
+// NewConflictPGSQL create new repository
+func NewConflictPGSQL(db *sql.DB) *ConflictPGSQL {
+	return &ConflictPGSQL{
+		db: db,
+	}
+}
+
+// Create a sync conflict
+func (r *ConflictPGSQL) Create(e *entity.SyncConflict) error {
+	var extID sql.NullString
+	if e.ExtID != "" {
+		extID = sql.NullString{String: e.ExtID, Valid: true}
+	}
+	_, err := r.db.Exec(`
+		INSERT INTO sync_conflict (id, object, entity_id, ext_id, field, direction,
+			owner, attempted, kept, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`,
+		e.ID, e.Object, e.EntityID, extID, e.Field, e.Direction,
+		e.Owner, e.Attempted, e.Kept, e.CreatedAt)
+	return err
+}
+
+// List the latest conflicts of an object, newest first
+func (r *ConflictPGSQL) List(object entity.SyncObject, limit int) ([]*entity.SyncConflict, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT id, object, entity_id, ext_id, field, direction, owner,
+			attempted, kept, created_at
+		FROM sync_conflict
+		WHERE object = $1
+		ORDER BY created_at DESC
+		LIMIT $2;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(object, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conflicts []*entity.SyncConflict
+	for rows.Next() {
+		var c entity.SyncConflict
+		var extID, attempted, kept sql.NullString
+		err := rows.Scan(&c.ID, &c.Object, &c.EntityID, &extID, &c.Field, &c.Direction,
+			&c.Owner, &attempted, &kept, &c.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		c.ExtID = extID.String
+		c.Attempted = attempted.String
+		c.Kept = kept.String
+		conflicts = append(conflicts, &c)
+	}
+	return conflicts, rows.Err()
+}
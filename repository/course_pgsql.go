@@ -9,9 +9,13 @@ package repository
 import (
 	"database/sql"
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"sudhagar/glad/entity"
+
+	"github.com/lib/pq"
 )
 
 // CoursePGSQL mysql repo
@@ -69,21 +73,33 @@ func (r *CoursePGSQL) Create(e *entity.Course) (entity.ID, error) {
 	return e.ID, nil
 }
 
-// Get retrieves a course
-func (r *CoursePGSQL) Get(id entity.ID) (*entity.Course, error) {
+// Get retrieves a course of the tenant
+func (r *CoursePGSQL) Get(tenantID, id entity.ID) (*entity.Course, error) {
+	return r.get(`WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+}
+
+// GetByID retrieves a course of any tenant, for the sync jobs and the
+// notifications, which have no tenant to scope the read by
+func (r *CoursePGSQL) GetByID(id entity.ID) (*entity.Course, error) {
+	return r.get(`WHERE id = $1`, id)
+}
+
+func (r *CoursePGSQL) get(where string, args ...any) (*entity.Course, error) {
 	stmt, err := r.db.Prepare(`
 		SELECT id, tenant_id, ext_id, center_id, product_id, name, notes, timezone, address,
-		status, mode, max_attendees, num_attendees, created_at
+		status, mode, max_attendees, num_attendees, created_at, ` + syncColumns + `
 		FROM course
-		WHERE id = $1;`)
+		` + where + `;`)
 	if err != nil {
 		return nil, err
 	}
 	var c entity.Course
 	var ext_id sql.NullString
 	var name, notes, timezone, address_json, status, mode sql.NullString
-	err = stmt.QueryRow(id).Scan(&c.ID, &c.TenantID, &ext_id, &c.CenterID, &c.ProductID, &name, &notes, &timezone,
-		&address_json, &status, &mode, &c.MaxAttendees, &c.NumAttendees, &c.CreatedAt)
+	var syncState nullSyncState
+	dest := []any{&c.ID, &c.TenantID, &ext_id, &c.CenterID, &c.ProductID, &name, &notes, &timezone,
+		&address_json, &status, &mode, &c.MaxAttendees, &c.NumAttendees, &c.CreatedAt}
+	err = stmt.QueryRow(args...).Scan(append(dest, syncState.dest()...)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -98,12 +114,15 @@ func (r *CoursePGSQL) Get(id entity.ID) (*entity.Course, error) {
 		}
 	}
 
-	c.ExtID = &ext_id.String
+	if ext_id.Valid {
+		c.ExtID = &ext_id.String
+	}
 	c.Name = name.String
 	c.Notes = notes.String
 	c.Timezone = timezone.String
 	c.Status = entity.CourseStatus(status.String)
 	c.Mode = entity.CourseMode(mode.String)
+	syncState.copyTo(&c.Sync)
 
 	return &c, nil
 }
@@ -116,18 +135,23 @@ func (r *CoursePGSQL) Update(e *entity.Course) error {
 		return err
 	}
 
-	_, err = r.db.Exec(`
+	res, err := r.db.Exec(`
 		UPDATE course SET center_id = $1, name = $2, notes = $3, timezone = $4, address = $5,
 			status = $6, mode = $7, max_attendees = $8, num_attendees = $9,
 			updated_at = $10, product_id = $11
-		WHERE id = $12;
+		WHERE id = $12 AND tenant_id = $13;
 		`,
 		e.CenterID, e.Name, e.Notes, e.Timezone, string(addressJSON), (e.Status), (e.Mode),
 		e.MaxAttendees, e.NumAttendees, e.UpdatedAt.Format("2006-01-02"), e.ProductID,
-		e.ID)
+		e.ID, e.TenantID)
 	if err != nil {
 		return err
 	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
 	return nil
 }
 
@@ -135,7 +159,7 @@ func (r *CoursePGSQL) Update(e *entity.Course) error {
 func (r *CoursePGSQL) Search(tenantID entity.ID, q string, page, limit int) ([]*entity.Course, error) {
 	query := `
 		SELECT id, tenant_id, ext_id, center_id, product_id, name, notes, timezone, address,
-		status, mode, max_attendees, num_attendees, created_at
+		status, mode, max_attendees, num_attendees, created_at, ` + syncColumns + `
 		FROM course
 		WHERE tenant_id = $1 AND name LIKE $2
 	`
@@ -175,7 +199,7 @@ func (r *CoursePGSQL) Search(tenantID entity.ID, q string, page, limit int) ([]*
 func (r *CoursePGSQL) List(tenantID entity.ID, page, limit int) ([]*entity.Course, error) {
 	query := `
 		SELECT id, tenant_id, ext_id, center_id, product_id, name, notes, timezone, address,
-		status, mode, max_attendees, num_attendees, created_at
+		status, mode, max_attendees, num_attendees, created_at, ` + syncColumns + `
 		FROM course
 		WHERE tenant_id = $1`
 
@@ -210,9 +234,132 @@ func (r *CoursePGSQL) List(tenantID entity.ID, page, limit int) ([]*entity.Cours
 	return r.scanRows(rows)
 }
 
-// Delete deletes a course
-func (r *CoursePGSQL) Delete(id entity.ID) error {
-	res, err := r.db.Exec(`DELETE FROM course WHERE id = $1;`, id)
+// CountBySyncStatus counts the courses of the tenant per sync status
+func (r *CoursePGSQL) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	return countBySyncStatus(r.db, "course", tenantID)
+}
+
+// ListBySyncStatus lists courses with the given sync status
+func (r *CoursePGSQL) ListBySyncStatus(tenantID entity.ID,
+	status entity.SyncStatus, page, limit int,
+) ([]*entity.Course, error) {
+	query := `
+		SELECT id, tenant_id, ext_id, center_id, product_id, name, notes, timezone, address,
+		status, mode, max_attendees, num_attendees, created_at, ` + syncColumns + `
+		FROM course
+		WHERE tenant_id = $1 AND sync_status = $2`
+
+	if page > 0 && limit > 0 {
+		offset := (page - 1) * limit
+		query += ` LIMIT $3 OFFSET $4;`
+		stmt, err := r.db.Prepare(query)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := stmt.Query(tenantID, status, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		defer rows.Close()
+		return r.scanRows(rows)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(tenantID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	return r.scanRows(rows)
+}
+
+// courseRoleTables the table and the account column of each course role
+var courseRoleTables = map[entity.CourseRole][2]string{
+	entity.CourseRoleTeacher:   {"course_teacher", "teacher_id"},
+	entity.CourseRoleOrganizer: {"course_organizer", "organizer_id"},
+	entity.CourseRoleContact:   {"course_contact", "contact_id"},
+	entity.CourseRoleNotify:    {"course_notify", "notify_id"},
+}
+
+// FindByAccounts lists the courses in which any of the accounts has any of
+// the roles, latest first
+func (r *CoursePGSQL) FindByAccounts(tenantID entity.ID,
+	accountIDs []entity.ID,
+	roles []entity.CourseRole,
+	statuses []entity.CourseStatus,
+	page, limit int,
+) ([]*entity.Course, error) {
+	ids := make([]int64, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		ids = append(ids, int64(id))
+	}
+
+	var relations []string
+	for _, role := range roles {
+		t, ok := courseRoleTables[role]
+		if !ok {
+			return nil, entity.ErrInvalidEntity
+		}
+		relations = append(relations,
+			`SELECT course_id FROM `+t[0]+` WHERE `+t[1]+` = ANY($2)`)
+	}
+	if len(relations) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, tenant_id, ext_id, center_id, product_id, name, notes, timezone, address,
+		status, mode, max_attendees, num_attendees, created_at, ` + syncColumns + `
+		FROM course
+		WHERE tenant_id = $1 AND id IN (` + strings.Join(relations, " UNION ") + `)`
+	args := []any{tenantID, pq.Array(ids)}
+
+	if len(statuses) > 0 {
+		names := make([]string, 0, len(statuses))
+		for _, st := range statuses {
+			names = append(names, string(st))
+		}
+		args = append(args, pq.Array(names))
+		query += ` AND status = ANY($3)`
+	}
+	query += ` ORDER BY created_at DESC, id`
+
+	if page > 0 && limit > 0 {
+		n := len(args)
+		args = append(args, limit, (page-1)*limit)
+		query += ` LIMIT $` + strconv.Itoa(n+1) + ` OFFSET $` + strconv.Itoa(n+2)
+	}
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	return r.scanRows(rows)
+}
+
+// UpdateSyncState updates the sync meta data of a course
+func (r *CoursePGSQL) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	return updateSyncState(r.db, "course", id, s)
+}
+
+// UpdateExtID sets the SF id of a course created in SF by an export
+func (r *CoursePGSQL) UpdateExtID(id entity.ID, extID string) error {
+	_, err := r.db.Exec(`UPDATE course SET ext_id = $1 WHERE id = $2 AND ext_id IS NULL;`, extID, id)
+	return err
+}
+
+// Delete deletes a course of the tenant
+func (r *CoursePGSQL) Delete(tenantID, id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM course WHERE tenant_id = $1 AND id = $2;`, tenantID, id)
 	if err != nil {
 		return err
 	}
@@ -245,7 +392,8 @@ func (r *CoursePGSQL) scanRows(rows *sql.Rows) ([]*entity.Course, error) {
 	for rows.Next() {
 		var course entity.Course
 		var ext_id, name, notes, timezone, address_json, status, mode sql.NullString
-		err := rows.Scan(
+		var syncState nullSyncState
+		dest := []any{
 			&course.ID,
 			&course.TenantID,
 			&ext_id,
@@ -260,17 +408,21 @@ func (r *CoursePGSQL) scanRows(rows *sql.Rows) ([]*entity.Course, error) {
 			&course.MaxAttendees,
 			&course.NumAttendees,
 			&course.CreatedAt,
-		)
+		}
+		err := rows.Scan(append(dest, syncState.dest()...)...)
 		if err != nil {
 			return nil, err
 		}
 
-		course.ExtID = &ext_id.String
+		if ext_id.Valid {
+			course.ExtID = &ext_id.String
+		}
 		course.Name = name.String
 		course.Notes = notes.String
 		course.Timezone = timezone.String
 		course.Status = entity.CourseStatus(status.String)
 		course.Mode = entity.CourseMode(mode.String)
+		syncState.copyTo(&course.Sync)
 
 		if address_json.Valid && address_json.String != "" {
 			err = json.Unmarshal([]byte(address_json.String), &course.Address)
@@ -283,3 +435,102 @@ func (r *CoursePGSQL) scanRows(rows *sql.Rows) ([]*entity.Course, error) {
 	}
 	return courses, nil
 }
+
+// GetAccounts gets the teachers, organizers and contacts of a course
+func (r *CoursePGSQL) GetAccounts(courseID entity.ID) (*entity.CourseAccounts, error) {
+	a := &entity.CourseAccounts{CourseID: courseID}
+
+	rows, err := r.db.Query(`
+		SELECT teacher_id, COALESCE(is_primary, FALSE) FROM course_teacher
+		WHERE course_id = $1 ORDER BY is_primary DESC, updated_at;`, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t entity.CourseTeacher
+		if err := rows.Scan(&t.ID, &t.IsPrimary); err != nil {
+			return nil, err
+		}
+		a.Teachers = append(a.Teachers, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	a.Organizers, err = r.listAccountIDs(`
+		SELECT organizer_id FROM course_organizer WHERE course_id = $1 ORDER BY updated_at;`, courseID)
+	if err != nil {
+		return nil, err
+	}
+	a.Contacts, err = r.listAccountIDs(`
+		SELECT contact_id FROM course_contact WHERE course_id = $1 ORDER BY updated_at;`, courseID)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *CoursePGSQL) listAccountIDs(query string, courseID entity.ID) ([]entity.ID, error) {
+	rows, err := r.db.Query(query, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []entity.ID
+	for rows.Next() {
+		var id entity.ID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// UpdateAccounts replaces the teachers, organizers and contacts of a course
+// in a transaction
+func (r *CoursePGSQL) UpdateAccounts(a *entity.CourseAccounts) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, table := range []string{"course_teacher", "course_organizer", "course_contact"} {
+		_, err = tx.Exec(`DELETE FROM `+table+` WHERE course_id = $1;`, a.CourseID)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for _, t := range a.Teachers {
+		_, err = tx.Exec(`
+			INSERT INTO course_teacher (course_id, teacher_id, is_primary, updated_at)
+			VALUES($1, $2, $3, $4);`, a.CourseID, t.ID, t.IsPrimary, now)
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range a.Organizers {
+		_, err = tx.Exec(`
+			INSERT INTO course_organizer (course_id, organizer_id, updated_at)
+			VALUES($1, $2, $3);`, a.CourseID, id, now)
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range a.Contacts {
+		_, err = tx.Exec(`
+			INSERT INTO course_contact (course_id, contact_id, updated_at)
+			VALUES($1, $2, $3);`, a.CourseID, id, now)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"sudhagar/glad/entity"
+)
+
+// EligibilityPGSQL teacher eligibility repo
+type EligibilityPGSQL struct {
+	db *sql.DB
+}
+
+// NewEligibilityPGSQL create new repository
+func NewEligibilityPGSQL(db *sql.DB) *EligibilityPGSQL {
+	return &EligibilityPGSQL{
+		db: db,
+	}
+}
+
+// Get retrieves the eligibility of a teacher for a product
+func (r *EligibilityPGSQL) Get(teacherID, productID entity.ID) (*entity.TeacherEligibility, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT product_id, teacher_id, type, created_at, updated_at
+		FROM teacher_eligibility
+		WHERE teacher_id = $1 AND product_id = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(teacherID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	eligibility, err := r.scanRows(rows)
+	if err != nil || len(eligibility) == 0 {
+		return nil, err
+	}
+	return eligibility[0], nil
+}
+
+// ListByTeacher lists the eligibility of a teacher
+func (r *EligibilityPGSQL) ListByTeacher(teacherID entity.ID) ([]*entity.TeacherEligibility, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT product_id, teacher_id, type, created_at, updated_at
+		FROM teacher_eligibility
+		WHERE teacher_id = $1
+		ORDER BY product_id;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(teacherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// Save creates the eligibility or updates its type
+func (r *EligibilityPGSQL) Save(e *entity.TeacherEligibility) error {
+	_, err := r.db.Exec(`
+		INSERT INTO teacher_eligibility (product_id, teacher_id, type, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $4)
+		ON CONFLICT (product_id, teacher_id)
+		DO UPDATE SET type = EXCLUDED.type, updated_at = CURRENT_TIMESTAMP;`,
+		e.ProductID, e.TeacherID, e.Type, e.CreatedAt)
+	return err
+}
+
+// Delete deletes the eligibility of a teacher for a product
+func (r *EligibilityPGSQL) Delete(teacherID, productID entity.ID) error {
+	res, err := r.db.Exec(`
+		DELETE FROM teacher_eligibility WHERE teacher_id = $1 AND product_id = $2;`,
+		teacherID, productID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *EligibilityPGSQL) scanRows(rows *sql.Rows) ([]*entity.TeacherEligibility, error) {
+	var eligibility []*entity.TeacherEligibility
+	for rows.Next() {
+		var e entity.TeacherEligibility
+		var t sql.NullString
+		err := rows.Scan(&e.ProductID, &e.TeacherID, &t, &e.CreatedAt, &e.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		e.Type = entity.EligibilityType(t.String)
+		eligibility = append(eligibility, &e)
+	}
+	return eligibility, rows.Err()
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"sudhagar/glad/entity"
+)
+
+// ExportSnapshotPGSQL postgres repo
+type ExportSnapshotPGSQL struct {
+	db *sql.DB
+}
+
+// NewExportSnapshotPGSQL create new repository
+func NewExportSnapshotPGSQL(db *sql.DB) *ExportSnapshotPGSQL {
+	return &ExportSnapshotPGSQL{
+		db: db,
+	}
+}
+
+// Get the last exported snapshot of a record
+func (r *ExportSnapshotPGSQL) Get(object entity.SyncObject, entityID entity.ID) (*entity.ExportSnapshot, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT object, entity_id, payload, exported_at
+		FROM sync_export_snapshot WHERE object = $1 AND entity_id = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var s entity.ExportSnapshot
+	err = stmt.QueryRow(object, entityID).Scan(&s.Object, &s.EntityID, &s.Payload, &s.ExportedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Save creates or replaces the snapshot of a record
+func (r *ExportSnapshotPGSQL) Save(e *entity.ExportSnapshot) error {
+	_, err := r.db.Exec(`
+		INSERT INTO sync_export_snapshot (object, entity_id, payload, exported_at)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT (object, entity_id)
+		DO UPDATE SET payload = EXCLUDED.payload, exported_at = EXCLUDED.exported_at;`,
+		e.Object, e.EntityID, e.Payload, e.ExportedAt)
+	return err
+}
+
+// Delete the snapshot of a record
+func (r *ExportSnapshotPGSQL) Delete(object entity.SyncObject, entityID entity.ID) error {
+	_, err := r.db.Exec(`DELETE FROM sync_export_snapshot WHERE object = $1 AND entity_id = $2;`,
+		object, entityID)
+	return err
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// ExternalRefPGSQL postgres repo
+type ExternalRefPGSQL struct {
+	db *sql.DB
+}
+
+// NewExternalRefPGSQL create new repository
+func NewExternalRefPGSQL(db *sql.DB) *ExternalRefPGSQL {
+	return &ExternalRefPGSQL{
+		db: db,
+	}
+}
+
+// Create an external ref
+func (r *ExternalRefPGSQL) Create(e *entity.ExternalRef) (entity.ID, error) {
+	stmt, err := r.db.Prepare(`
+		INSERT INTO external_ref (id, tenant_id, object, entity_id, system, ext_id, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7)`)
+	if err != nil {
+		return e.ID, err
+	}
+	_, err = stmt.Exec(
+		e.ID,
+		e.TenantID,
+		e.Object,
+		e.EntityID,
+		e.System,
+		e.ExtID,
+		e.CreatedAt,
+	)
+	if err != nil {
+		return e.ID, err
+	}
+	err = stmt.Close()
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// GetByEntity gets the ref of an entity in a system
+func (r *ExternalRefPGSQL) GetByEntity(system string, object entity.SyncObject, entityID entity.ID) (*entity.ExternalRef, error) {
+	return r.getOne(`
+		SELECT id, tenant_id, object, entity_id, system, ext_id, created_at, updated_at
+		FROM external_ref WHERE system = $1 AND object = $2 AND entity_id = $3;`,
+		system, object, entityID)
+}
+
+// GetByExtID gets the ref of a record of a system
+func (r *ExternalRefPGSQL) GetByExtID(system string, object entity.SyncObject, extID string) (*entity.ExternalRef, error) {
+	return r.getOne(`
+		SELECT id, tenant_id, object, entity_id, system, ext_id, created_at, updated_at
+		FROM external_ref WHERE system = $1 AND object = $2 AND ext_id = $3;`,
+		system, object, extID)
+}
+
+// List lists the refs of an entity in all the systems
+func (r *ExternalRefPGSQL) List(object entity.SyncObject, entityID entity.ID) ([]*entity.ExternalRef, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT id, tenant_id, object, entity_id, system, ext_id, created_at, updated_at
+		FROM external_ref WHERE object = $1 AND entity_id = $2 ORDER BY system;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(object, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// Update the ext id of a ref
+func (r *ExternalRefPGSQL) Update(e *entity.ExternalRef) error {
+	e.UpdatedAt = time.Now()
+	res, err := r.db.Exec(`
+		UPDATE external_ref SET ext_id = $1, updated_at = $2 WHERE id = $3;`,
+		e.ExtID, e.UpdatedAt, e.ID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete an external ref
+func (r *ExternalRefPGSQL) Delete(id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM external_ref WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// getOne gets the ref matched by the query; nil if none
+func (r *ExternalRefPGSQL) getOne(query string, args ...any) (*entity.ExternalRef, error) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refs, err := r.scanRows(rows)
+	if err != nil || len(refs) == 0 {
+		return nil, err
+	}
+	return refs[0], nil
+}
+
+func (r *ExternalRefPGSQL) scanRows(rows *sql.Rows) ([]*entity.ExternalRef, error) {
+	var refs []*entity.ExternalRef
+
+	for rows.Next() {
+		var e entity.ExternalRef
+		err := rows.Scan(
+			&e.ID,
+			&e.TenantID,
+			&e.Object,
+			&e.EntityID,
+			&e.System,
+			&e.ExtID,
+			&e.CreatedAt,
+			&e.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, &e)
+	}
+
+	return refs, rows.Err()
+}
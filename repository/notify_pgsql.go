@@ -0,0 +1,155 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// NotifyPGSQL course notification subscription repo
+type NotifyPGSQL struct {
+	db *sql.DB
+}
+
+// NewNotifyPGSQL create new repository
+func NewNotifyPGSQL(db *sql.DB) *NotifyPGSQL {
+	return &NotifyPGSQL{
+		db: db,
+	}
+}
+
+// ListSubscribers lists the accounts subscribed to the course
+func (r *NotifyPGSQL) ListSubscribers(courseID entity.ID) ([]entity.ID, error) {
+	rows, err := r.db.Query(`
+		SELECT notify_id FROM course_notify WHERE course_id = $1 ORDER BY updated_at;`, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []entity.ID
+	for rows.Next() {
+		var id entity.ID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Subscribe subscribes the account to the course
+func (r *NotifyPGSQL) Subscribe(courseID, accountID entity.ID) error {
+	_, err := r.db.Exec(`
+		INSERT INTO course_notify (course_id, notify_id, updated_at)
+		VALUES($1, $2, $3)
+		ON CONFLICT (course_id, notify_id) DO NOTHING;`, courseID, accountID, time.Now())
+	return err
+}
+
+// Unsubscribe unsubscribes the account from the course
+func (r *NotifyPGSQL) Unsubscribe(courseID, accountID entity.ID) error {
+	res, err := r.db.Exec(`
+		DELETE FROM course_notify WHERE course_id = $1 AND notify_id = $2;`, courseID, accountID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetState gets what the subscribers of the course were last notified of
+func (r *NotifyPGSQL) GetState(courseID entity.ID) (*entity.CourseNotifyState, error) {
+	var s entity.CourseNotifyState
+	err := r.db.QueryRow(`
+		SELECT course_id, status, location, timing, updated_at
+		FROM course_notify_state WHERE course_id = $1;`, courseID).
+		Scan(&s.CourseID, &s.Status, &s.Location, &s.Timing, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SaveState saves what the subscribers of the course were notified of
+func (r *NotifyPGSQL) SaveState(s *entity.CourseNotifyState) error {
+	_, err := r.db.Exec(`
+		INSERT INTO course_notify_state (course_id, status, location, timing, updated_at)
+		VALUES($1, $2, $3, $4, $5)
+		ON CONFLICT (course_id) DO UPDATE SET status = EXCLUDED.status, location = EXCLUDED.location,
+			timing = EXCLUDED.timing, updated_at = EXCLUDED.updated_at;`,
+		s.CourseID, s.Status, s.Location, s.Timing, s.UpdatedAt)
+	return err
+}
+
+// Queue queues the course to notify its changes
+func (r *NotifyPGSQL) Queue(courseID entity.ID) error {
+	_, err := r.db.Exec(`
+		INSERT INTO course_notify_queue (course_id, queued_at)
+		VALUES($1, $2)
+		ON CONFLICT (course_id) DO NOTHING;`, courseID, time.Now())
+	return err
+}
+
+// Dequeue takes up to limit queued courses, oldest first. Rows being taken
+// by another instance are skipped rather than waited for.
+func (r *NotifyPGSQL) Dequeue(limit int) ([]entity.ID, error) {
+	query := `
+		DELETE FROM course_notify_queue
+		WHERE course_id IN (
+			SELECT course_id FROM course_notify_queue ORDER BY queued_at`
+	var args []any
+	if limit > 0 {
+		query += ` LIMIT $1`
+		args = append(args, limit)
+	}
+	query += `
+			FOR UPDATE SKIP LOCKED)
+		RETURNING course_id, queued_at;`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type queued struct {
+		id entity.ID
+		at time.Time
+	}
+	var courses []queued
+	for rows.Next() {
+		var q queued
+		if err := rows.Scan(&q.id, &q.at); err != nil {
+			return nil, err
+		}
+		courses = append(courses, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(courses, func(i, j int) bool {
+		return courses[i].at.Before(courses[j].at)
+	})
+
+	ids := make([]entity.ID, 0, len(courses))
+	for _, q := range courses {
+		ids = append(ids, q.id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,320 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// OutboxPGSQL postgres repo
+type OutboxPGSQL struct {
+	db *sql.DB
+}
+
+// NewOutboxPGSQL create new repository
+func NewOutboxPGSQL(db *sql.DB) *OutboxPGSQL {
+	return &OutboxPGSQL{
+		db: db,
+	}
+}
+
+// Enqueue an outbox item; folds it into the pending item of the same record.
+// Note: updated_at by the database clock, as the claims
+func (r *OutboxPGSQL) Enqueue(e *entity.OutboxItem) (entity.ID, error) {
+	var id entity.ID
+	err := r.db.QueryRow(`
+		INSERT INTO sync_outbox (id, tenant_id, object, entity_id, operation, status, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, now())
+		ON CONFLICT (object, entity_id) WHERE status = 'pending'
+		DO UPDATE SET operation = EXCLUDED.operation, updated_at = EXCLUDED.updated_at
+		RETURNING id;`,
+		e.ID, e.TenantID, e.Object, e.EntityID, e.Operation, e.Status, e.CreatedAt).Scan(&id)
+	if err != nil {
+		return e.ID, err
+	}
+	return id, nil
+}
+
+// Get an outbox item
+func (r *OutboxPGSQL) Get(id entity.ID) (*entity.OutboxItem, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT ` + outboxColumns + `
+		FROM sync_outbox WHERE id = $1;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items, err := r.scanRows(rows)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+// outboxNotPaused leaves out the items whose outbound sync is paused, so
+// that they do not hold up the others
+const outboxNotPaused = `
+		AND NOT EXISTS (
+			SELECT 1 FROM sync_control c
+			WHERE c.paused AND c.direction = 'outbound'
+			AND c.tenant_id IN (0, o.tenant_id) AND c.object IN ('*', o.object))`
+
+// outboxColumns columns read by scanRows
+const outboxColumns = `id, tenant_id, object, entity_id, operation, status, attempts, last_error,
+		claimed_by, claimed_at, claimed_until, created_at, updated_at`
+
+// ListPending lists pending outbox items, oldest first; paused items are
+// left out
+func (r *OutboxPGSQL) ListPending(limit int) ([]*entity.OutboxItem, error) {
+	return r.listByStatus(entity.SyncPending, outboxNotPaused, limit)
+}
+
+// Claim claims pending outbox items, and failed ones due for a retry, oldest
+// first. Rows being claimed by another instance are skipped rather than
+// waited for. The claim is timed by the database clock, which also times the
+// changes folded into the items.
+func (r *OutboxPGSQL) Claim(owner string,
+	limit int,
+	lease time.Duration,
+	retry entity.OutboxRetry,
+) ([]*entity.OutboxItem, error) {
+	// Note: same backoff as entity.OutboxRetry.RetryAt
+	query := `
+		UPDATE sync_outbox SET claimed_by = $1, claimed_at = now(),
+			claimed_until = now() + $2 * interval '1 second'
+		WHERE id IN (
+			SELECT id FROM sync_outbox o
+			WHERE (status = 'pending' OR (status = 'failed' AND attempts < $3
+				AND updated_at + LEAST($4 * power(2, GREATEST(attempts - 1, 0)), $5) * interval '1 second' < now()))
+			AND (claimed_until IS NULL OR claimed_until < now())` + outboxNotPaused + `
+			ORDER BY created_at`
+	args := []any{owner, lease.Seconds(), retry.MaxAttempts, retry.Backoff.Seconds(), retry.MaxBackoff.Seconds()}
+	if limit > 0 {
+		query += ` LIMIT $6`
+		args = append(args, limit)
+	}
+	query += `
+			FOR UPDATE SKIP LOCKED)
+		RETURNING ` + outboxColumns
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items, err := r.scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	return items, nil
+}
+
+// ListFailed lists failed outbox items, oldest first
+func (r *OutboxPGSQL) ListFailed(limit int) ([]*entity.OutboxItem, error) {
+	return r.listByStatus(entity.SyncFailed, "", limit)
+}
+
+func (r *OutboxPGSQL) listByStatus(status entity.SyncStatus, filter string, limit int) ([]*entity.OutboxItem, error) {
+	query := `
+		SELECT ` + outboxColumns + `
+		FROM sync_outbox o WHERE status = $1` + filter + ` ORDER BY created_at`
+	args := []any{status}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// Update an outbox item
+func (r *OutboxPGSQL) Update(e *entity.OutboxItem) error {
+	e.UpdatedAt = time.Now()
+	var lastError sql.NullString
+	if e.LastError != "" {
+		lastError = sql.NullString{String: e.LastError, Valid: true}
+	}
+
+	var claimedBy sql.NullString
+	if e.ClaimedBy != "" {
+		claimedBy = sql.NullString{String: e.ClaimedBy, Valid: true}
+	}
+
+	// Note: updated_at by the database clock, as the claims
+	res, err := r.db.Exec(`
+		UPDATE sync_outbox SET status = $1, attempts = $2, last_error = $3,
+			claimed_by = $4, claimed_at = $5, claimed_until = $6, updated_at = now()
+		WHERE id = $7;`,
+		e.Status, e.Attempts, lastError, claimedBy, e.ClaimedAt, e.ClaimedUntil, e.ID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// claimed runs the update of an item claimed by owner; entity.ErrClaimLost
+// if owner no longer holds the claim
+func (r *OutboxPGSQL) claimed(query string, args ...any) error {
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return entity.ErrClaimLost
+	}
+
+	return nil
+}
+
+// Renew extends the claim of owner on the item to now+lease
+func (r *OutboxPGSQL) Renew(id entity.ID, owner string, lease time.Duration) error {
+	return r.claimed(`
+		UPDATE sync_outbox SET claimed_until = now() + $3 * interval '1 second'
+		WHERE id = $1 AND claimed_by = $2;`,
+		id, owner, lease.Seconds())
+}
+
+// Release drops the claim of owner on the item
+func (r *OutboxPGSQL) Release(id entity.ID, owner string) error {
+	return r.claimed(`
+		UPDATE sync_outbox SET claimed_by = NULL, claimed_at = NULL, claimed_until = NULL
+		WHERE id = $1 AND claimed_by = $2;`,
+		id, owner)
+}
+
+// Complete marks the item claimed by owner as synced and drops the claim.
+// An item changed since it was claimed is left pending instead; both times
+// are of the database clock.
+func (r *OutboxPGSQL) Complete(id entity.ID, owner string) error {
+	return r.claimed(`
+		UPDATE sync_outbox SET
+			status = CASE WHEN updated_at <= claimed_at THEN 'synced' ELSE status END,
+			attempts = CASE WHEN updated_at <= claimed_at THEN attempts + 1 ELSE attempts END,
+			last_error = CASE WHEN updated_at <= claimed_at THEN NULL ELSE last_error END,
+			updated_at = CASE WHEN updated_at <= claimed_at THEN now() ELSE updated_at END,
+			claimed_by = NULL, claimed_at = NULL, claimed_until = NULL
+		WHERE id = $1 AND claimed_by = $2;`,
+		id, owner)
+}
+
+// Fail marks the item claimed by owner as failed
+func (r *OutboxPGSQL) Fail(id entity.ID, owner string, cause string) error {
+	var lastError sql.NullString
+	if cause != "" {
+		lastError = sql.NullString{String: cause, Valid: true}
+	}
+	return r.claimed(`
+		UPDATE sync_outbox SET status = 'failed', attempts = attempts + 1, last_error = $3,
+			claimed_by = NULL, claimed_at = NULL, claimed_until = NULL, updated_at = now()
+		WHERE id = $1 AND claimed_by = $2;`,
+		id, owner, lastError)
+}
+
+// Delete an outbox item
+func (r *OutboxPGSQL) Delete(id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM sync_outbox WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// PendingStats gets the depth, the oldest pending item and the failed count
+func (r *OutboxPGSQL) PendingStats() (*entity.QueueStats, error) {
+	var stats entity.QueueStats
+	var oldest sql.NullTime
+	err := r.db.QueryRow(`
+		SELECT count(*) FILTER (WHERE status = 'pending'),
+			min(created_at) FILTER (WHERE status = 'pending'),
+			count(*) FILTER (WHERE status = 'failed')
+		FROM sync_outbox;`).Scan(&stats.Depth, &oldest, &stats.Failed)
+	if err != nil {
+		return nil, err
+	}
+	if oldest.Valid {
+		stats.Oldest = &oldest.Time
+	}
+	return &stats, nil
+}
+
+func (r *OutboxPGSQL) scanRows(rows *sql.Rows) ([]*entity.OutboxItem, error) {
+	var items []*entity.OutboxItem
+
+	for rows.Next() {
+		var o entity.OutboxItem
+		var lastError, claimedBy sql.NullString
+		var claimedAt, claimedUntil sql.NullTime
+		err := rows.Scan(
+			&o.ID,
+			&o.TenantID,
+			&o.Object,
+			&o.EntityID,
+			&o.Operation,
+			&o.Status,
+			&o.Attempts,
+			&lastError,
+			&claimedBy,
+			&claimedAt,
+			&claimedUntil,
+			&o.CreatedAt,
+			&o.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		o.LastError = lastError.String
+		o.ClaimedBy = claimedBy.String
+		if claimedAt.Valid {
+			o.ClaimedAt = &claimedAt.Time
+		}
+		if claimedUntil.Valid {
+			o.ClaimedUntil = &claimedUntil.Time
+		}
+		items = append(items, &o)
+	}
+
+	return items, rows.Err()
+}
@@ -53,12 +53,13 @@ func (r *ProductPGSQL) Create(e *entity.Product) (entity.ID, error) {
 	return e.ID, nil
 }
 
-// Get retrieves a product
-func (r *ProductPGSQL) Get(id entity.ID) (*entity.Product, error) {
+// Get retrieves a product of the tenant
+func (r *ProductPGSQL) Get(tenantID, id entity.ID) (*entity.Product, error) {
 	stmt, err := r.db.Prepare(`
-		SELECT id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id, 
-			duration_days, visibility, max_attendees, format, is_auto_approve, created_at 
-		FROM product WHERE id = $1;`)
+		SELECT id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id,
+			duration_days, visibility, max_attendees, format, is_auto_approve, created_at,
+			` + syncColumns + `
+		FROM product WHERE tenant_id = $1 AND id = $2;`)
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +67,9 @@ func (r *ProductPGSQL) Get(id entity.ID) (*entity.Product, error) {
 	var p entity.Product
 	var ext_id, base_product_ext_id, visibility, format sql.NullString
 	var duration_days, max_attendees sql.NullInt32
+	var syncState nullSyncState
 
-	err = stmt.QueryRow(id).Scan(
+	dest := []any{
 		&p.ID,
 		&p.TenantID,
 		&ext_id,
@@ -81,7 +83,8 @@ func (r *ProductPGSQL) Get(id entity.ID) (*entity.Product, error) {
 		&format,
 		&p.IsAutoApprove,
 		&p.CreatedAt,
-	)
+	}
+	err = stmt.QueryRow(tenantID, id).Scan(append(dest, syncState.dest()...)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -95,6 +98,7 @@ func (r *ProductPGSQL) Get(id entity.ID) (*entity.Product, error) {
 	p.Visibility = entity.ProductVisibility(visibility.String)
 	p.MaxAttendees = max_attendees.Int32
 	p.Format = entity.ProductFormat(format.String)
+	syncState.copyTo(&p.Sync)
 
 	return &p, nil
 }
@@ -102,12 +106,12 @@ func (r *ProductPGSQL) Get(id entity.ID) (*entity.Product, error) {
 // Update updates a product
 func (r *ProductPGSQL) Update(e *entity.Product) error {
 	e.UpdatedAt = time.Now()
-	_, err := r.db.Exec(`
+	res, err := r.db.Exec(`
 		UPDATE product 
 		SET ext_name = $1, title = $2, ctype = $3, base_product_ext_id = $4,
 			duration_days = $5, visibility = $6, max_attendees = $7,
 			format = $8,  is_auto_approve = $9, updated_at = $10
-		WHERE id = $11;`,
+		WHERE id = $11 AND tenant_id = $12;`,
 		e.ExtName,
 		e.Title,
 		e.CType,
@@ -119,10 +123,16 @@ func (r *ProductPGSQL) Update(e *entity.Product) error {
 		e.IsAutoApprove,
 		e.UpdatedAt.Format("2006-01-02"),
 		e.ID,
+		e.TenantID,
 	)
 	if err != nil {
 		return err
 	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
 	return nil
 }
 
@@ -130,7 +140,8 @@ func (r *ProductPGSQL) Update(e *entity.Product) error {
 func (r *ProductPGSQL) Search(tenantID entity.ID, q string, page, limit int) ([]*entity.Product, error) {
 	query := `
 		SELECT id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id,
-			duration_days, visibility, max_attendees, format, is_auto_approve, created_at
+			duration_days, visibility, max_attendees, format, is_auto_approve, created_at,
+			` + syncColumns + `
 		FROM product 
 		WHERE tenant_id = $1 AND (LOWER(ext_name) LIKE LOWER($2) OR LOWER(title) LIKE LOWER($2))
 	`
@@ -169,7 +180,8 @@ func (r *ProductPGSQL) Search(tenantID entity.ID, q string, page, limit int) ([]
 func (r *ProductPGSQL) List(tenantID entity.ID, page, limit int) ([]*entity.Product, error) {
 	query := `
 		SELECT id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id,
-			duration_days, visibility, max_attendees, format, is_auto_approve, created_at
+			duration_days, visibility, max_attendees, format, is_auto_approve, created_at,
+			` + syncColumns + `
 		FROM product 
 		WHERE tenant_id = $1
 	`
@@ -204,9 +216,59 @@ func (r *ProductPGSQL) List(tenantID entity.ID, page, limit int) ([]*entity.Prod
 	return r.scanRows(rows)
 }
 
-// Delete deletes a product
-func (r *ProductPGSQL) Delete(id entity.ID) error {
-	res, err := r.db.Exec(`DELETE FROM product WHERE id = $1;`, id)
+// CountBySyncStatus counts the products of the tenant per sync status
+func (r *ProductPGSQL) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	return countBySyncStatus(r.db, "product", tenantID)
+}
+
+// ListBySyncStatus lists products with the given sync status
+func (r *ProductPGSQL) ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Product, error) {
+	query := `
+		SELECT id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id,
+			duration_days, visibility, max_attendees, format, is_auto_approve, created_at,
+			` + syncColumns + `
+		FROM product
+		WHERE tenant_id = $1 AND sync_status = $2
+	`
+
+	// Add pagination if specified
+	if page > 0 && limit > 0 {
+		offset := (page - 1) * limit
+		query += ` LIMIT $3 OFFSET $4;`
+		stmt, err := r.db.Prepare(query)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := stmt.Query(tenantID, status, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		return r.scanRows(rows)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(tenantID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	return r.scanRows(rows)
+}
+
+// UpdateSyncState updates the sync meta data of a product
+func (r *ProductPGSQL) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	return updateSyncState(r.db, "product", id, s)
+}
+
+// Delete deletes a product of the tenant
+func (r *ProductPGSQL) Delete(tenantID, id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM product WHERE tenant_id = $1 AND id = $2;`, tenantID, id)
 	if err != nil {
 		return err
 	}
@@ -246,8 +308,9 @@ func (r *ProductPGSQL) scanRows(rows *sql.Rows) ([]*entity.Product, error) {
 		var p entity.Product
 		var ext_id, base_product_ext_id, visibility, format sql.NullString
 		var duration_days, max_attendees sql.NullInt32
+		var syncState nullSyncState
 
-		err := rows.Scan(
+		dest := []any{
 			&p.ID,
 			&p.TenantID,
 			&ext_id,
@@ -261,7 +324,8 @@ func (r *ProductPGSQL) scanRows(rows *sql.Rows) ([]*entity.Product, error) {
 			&format,
 			&p.IsAutoApprove,
 			&p.CreatedAt,
-		)
+		}
+		err := rows.Scan(append(dest, syncState.dest()...)...)
 		if err != nil {
 			return nil, err
 		}
@@ -272,6 +336,7 @@ func (r *ProductPGSQL) scanRows(rows *sql.Rows) ([]*entity.Product, error) {
 		p.Visibility = entity.ProductVisibility(visibility.String)
 		p.MaxAttendees = max_attendees.Int32
 		p.Format = entity.ProductFormat(format.String)
+		syncState.copyTo(&p.Sync)
 
 		products = append(products, &p)
 	}
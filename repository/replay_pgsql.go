@@ -0,0 +1,57 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"sudhagar/glad/entity"
+)
+
+// ReplayPGSQL postgres repo
+type ReplayPGSQL struct {
+	db *sql.DB
+}
+
+// NewReplayPGSQL create new repository
+func NewReplayPGSQL(db *sql.DB) *ReplayPGSQL {
+	return &ReplayPGSQL{
+		db: db,
+	}
+}
+
+// Get the replay position of a channel of the tenant
+func (r *ReplayPGSQL) Get(tenantID entity.ID, channel string) (*entity.ReplayPosition, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT tenant_id, channel, replay_id, updated_at
+		FROM sync_replay WHERE tenant_id = $1 AND channel = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var p entity.ReplayPosition
+	err = stmt.QueryRow(tenantID, channel).Scan(&p.TenantID, &p.Channel, &p.ReplayID, &p.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Save creates or moves the replay position of a channel
+func (r *ReplayPGSQL) Save(e *entity.ReplayPosition) error {
+	_, err := r.db.Exec(`
+		INSERT INTO sync_replay (tenant_id, channel, replay_id, updated_at)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, channel)
+		DO UPDATE SET replay_id = EXCLUDED.replay_id, updated_at = EXCLUDED.updated_at;`,
+		e.TenantID, e.Channel, e.ReplayID, e.UpdatedAt)
+	return err
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"sudhagar/glad/entity"
+)
+
+// syncColumns sync meta data columns; keep in sync with nullSyncState.dest()
+const syncColumns = `last_synced_at, last_sync_direction, sync_status, last_sync_error, sf_last_modified`
+
+// nullSyncState scan target for the sync meta data columns
+type nullSyncState struct {
+	lastSyncedAt   sql.NullTime
+	direction      sql.NullString
+	status         sql.NullString
+	lastError      sql.NullString
+	sfLastModified sql.NullTime
+}
+
+// dest returns the scan destinations in the order of syncColumns
+func (n *nullSyncState) dest() []any {
+	return []any{&n.lastSyncedAt, &n.direction, &n.status, &n.lastError, &n.sfLastModified}
+}
+
+// copyTo copies the scanned values into the entity sync state
+func (n *nullSyncState) copyTo(s *entity.SyncState) {
+	s.LastSyncedAt = nil
+	if n.lastSyncedAt.Valid {
+		t := n.lastSyncedAt.Time
+		s.LastSyncedAt = &t
+	}
+	s.LastSyncDirection = entity.SyncDirection(n.direction.String)
+	s.Status = entity.SyncStatus(n.status.String)
+	s.LastError = n.lastError.String
+	s.SFLastModified = nil
+	if n.sfLastModified.Valid {
+		t := n.sfLastModified.Time
+		s.SFLastModified = &t
+	}
+}
+
+// updateSyncState updates the sync meta data of a record in the given table
+func updateSyncState(db *sql.DB, table string, id entity.ID, s *entity.SyncState) error {
+	var lastError sql.NullString
+	if s.LastError != "" {
+		lastError = sql.NullString{String: s.LastError, Valid: true}
+	}
+	var direction sql.NullString
+	if s.LastSyncDirection != "" {
+		direction = sql.NullString{String: string(s.LastSyncDirection), Valid: true}
+	}
+
+	// Note: table name is never user supplied
+	res, err := db.Exec(`
+		UPDATE `+table+`
+		SET last_synced_at = $1, last_sync_direction = $2, sync_status = $3,
+			last_sync_error = $4, sf_last_modified = COALESCE($5, sf_last_modified)
+		WHERE id = $6;`,
+		s.LastSyncedAt, direction, s.Status, lastError, s.SFLastModified, id)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// countBySyncStatus counts the records of the tenant in the given table per
+// sync status
+func countBySyncStatus(db *sql.DB, table string, tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	// Note: table name is never user supplied
+	rows, err := db.Query(`
+		SELECT sync_status, count(*) FROM `+table+`
+		WHERE tenant_id = $1
+		GROUP BY sync_status;`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[entity.SyncStatus]int{}
+	for rows.Next() {
+		var status sql.NullString
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[entity.SyncStatus(status.String)] += count
+	}
+	return counts, rows.Err()
+}
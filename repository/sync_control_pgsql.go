@@ -0,0 +1,225 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"sudhagar/glad/entity"
+)
+
+// SyncControlPGSQL postgres repo of the sync controls and the held inbound
+// records
+type SyncControlPGSQL struct {
+	db *sql.DB
+}
+
+// NewSyncControlPGSQL create new repository
+func NewSyncControlPGSQL(db *sql.DB) *SyncControlPGSQL {
+	return &SyncControlPGSQL{
+		db: db,
+	}
+}
+
+// GetControl gets the control of the scope
+func (r *SyncControlPGSQL) GetControl(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+) (*entity.SyncControl, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT tenant_id, object, direction, paused, throttle, reason, updated_at
+		FROM sync_control WHERE tenant_id = $1 AND object = $2 AND direction = $3;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(tenantID, object, direction)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	controls, err := r.scanControls(rows)
+	if err != nil || len(controls) == 0 {
+		return nil, err
+	}
+	return controls[0], nil
+}
+
+// ListControls lists the controls
+func (r *SyncControlPGSQL) ListControls() ([]*entity.SyncControl, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT tenant_id, object, direction, paused, throttle, reason, updated_at
+		FROM sync_control ORDER BY tenant_id, object, direction;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanControls(rows)
+}
+
+// SaveControl creates or replaces the control of its scope
+func (r *SyncControlPGSQL) SaveControl(e *entity.SyncControl) error {
+	var reason sql.NullString
+	if e.Reason != "" {
+		reason = sql.NullString{String: e.Reason, Valid: true}
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO sync_control (tenant_id, object, direction, paused, throttle, reason, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id, object, direction)
+		DO UPDATE SET paused = EXCLUDED.paused, throttle = EXCLUDED.throttle,
+			reason = EXCLUDED.reason, updated_at = EXCLUDED.updated_at;`,
+		e.TenantID, e.Object, e.Direction, e.Paused, e.Throttle, reason, e.UpdatedAt)
+	return err
+}
+
+// DeleteControl deletes the control of the scope
+func (r *SyncControlPGSQL) DeleteControl(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+) error {
+	res, err := r.db.Exec(`
+		DELETE FROM sync_control WHERE tenant_id = $1 AND object = $2 AND direction = $3;`,
+		tenantID, object, direction)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Hold an inbound record
+func (r *SyncControlPGSQL) Hold(e *entity.InboxItem) (entity.ID, error) {
+	stmt, err := r.db.Prepare(`
+		INSERT INTO sync_inbox (id, tenant_id, object, record, created_at)
+		VALUES($1, $2, $3, $4, $5)`)
+	if err != nil {
+		return e.ID, err
+	}
+	_, err = stmt.Exec(
+		e.ID,
+		e.TenantID,
+		e.Object,
+		string(e.Record),
+		e.CreatedAt,
+	)
+	if err != nil {
+		return e.ID, err
+	}
+	err = stmt.Close()
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// ListHeld lists the held records of the scope, oldest first
+func (r *SyncControlPGSQL) ListHeld(tenantID entity.ID, object entity.SyncObject, limit int) ([]*entity.InboxItem, error) {
+	query := `
+		SELECT id, tenant_id, object, record, created_at
+		FROM sync_inbox WHERE ($1 = 0 OR tenant_id = $1) AND ($2 = '*' OR object = $2)
+		ORDER BY created_at, id`
+	args := []any{tenantID, object}
+	if limit > 0 {
+		query += ` LIMIT $3`
+		args = append(args, limit)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*entity.InboxItem
+	for rows.Next() {
+		var i entity.InboxItem
+		var record string
+		err := rows.Scan(&i.ID, &i.TenantID, &i.Object, &record, &i.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		i.Record = []byte(record)
+		items = append(items, &i)
+	}
+	return items, rows.Err()
+}
+
+// HeldStats gets the depth and the oldest held record
+func (r *SyncControlPGSQL) HeldStats() (*entity.QueueStats, error) {
+	var stats entity.QueueStats
+	var oldest sql.NullTime
+	err := r.db.QueryRow(`
+		SELECT count(*), min(created_at) FROM sync_inbox;`).Scan(&stats.Depth, &oldest)
+	if err != nil {
+		return nil, err
+	}
+	if oldest.Valid {
+		stats.Oldest = &oldest.Time
+	}
+	return &stats, nil
+}
+
+// DeleteHeld deletes a held record
+func (r *SyncControlPGSQL) DeleteHeld(id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM sync_inbox WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *SyncControlPGSQL) scanControls(rows *sql.Rows) ([]*entity.SyncControl, error) {
+	var controls []*entity.SyncControl
+
+	for rows.Next() {
+		var c entity.SyncControl
+		var reason sql.NullString
+		err := rows.Scan(
+			&c.TenantID,
+			&c.Object,
+			&c.Direction,
+			&c.Paused,
+			&c.Throttle,
+			&reason,
+			&c.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Reason = reason.String
+		controls = append(controls, &c)
+	}
+
+	return controls, rows.Err()
+}
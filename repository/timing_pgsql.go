@@ -2,10 +2,19 @@ package repository
 
 import (
 	"database/sql"
+	"time"
+
 	"sudhagar/glad/entity"
 	sf_entity "sudhagar/glad/entity/sf_entity"
 )
 
+// the date and times are read in the formats of the API
+const timingColumns = `id, course_id, ext_id,
+	COALESCE(to_char(course_date, 'YYYY-MM-DD'), ''),
+	COALESCE(to_char(start_time, 'HH24:MI:SS'), ''),
+	COALESCE(to_char(end_time, 'HH24:MI:SS'), ''),
+	created_at, updated_at`
+
 type TimingPGSQL struct {
 	db *sql.DB
 }
@@ -43,3 +52,155 @@ func (r *TimingPGSQL) GetByCourseID(courseID entity.ID) ([]*sf_entity.Timing_val
 
 	return timings, nil
 }
+
+// Create creates a course timing
+func (r *TimingPGSQL) Create(e *entity.CourseTiming) (entity.ID, error) {
+	err := insertTiming(r.db, e)
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// execer runs the statements on the db or in a transaction
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func insertTiming(db execer, e *entity.CourseTiming) error {
+	_, err := db.Exec(`
+		INSERT INTO course_timing (id, course_id, ext_id, course_date, start_time, end_time, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $7);`,
+		e.ID, e.CourseID, e.ExtID, e.DateTime.Date, nullString(e.DateTime.StartTime),
+		nullString(e.DateTime.EndTime), e.CreatedAt)
+	return err
+}
+
+// nullString stores empty strings as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// courseOfTenant restricts a course timing query to the courses of the
+// tenant given as $1
+const courseOfTenant = `course_id IN (SELECT id FROM course WHERE tenant_id = $1)`
+
+// Get retrieves a timing of a course of the tenant
+func (r *TimingPGSQL) Get(tenantID, id entity.ID) (*entity.CourseTiming, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT ` + timingColumns + `
+		FROM course_timing WHERE ` + courseOfTenant + ` AND id = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	timings, err := r.scanRows(rows)
+	if err != nil || len(timings) == 0 {
+		return nil, err
+	}
+	return timings[0], nil
+}
+
+// ListByCourse lists the timings of a course, earliest first
+func (r *TimingPGSQL) ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT ` + timingColumns + `
+		FROM course_timing WHERE course_id = $1
+		ORDER BY course_date, start_time;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// Update updates a timing of a course of the tenant
+func (r *TimingPGSQL) Update(tenantID entity.ID, e *entity.CourseTiming) error {
+	e.UpdatedAt = time.Now()
+	res, err := r.db.Exec(`
+		UPDATE course_timing SET ext_id = $2, course_date = $3, start_time = $4, end_time = $5,
+			updated_at = $6
+		WHERE `+courseOfTenant+` AND id = $7;`,
+		tenantID, e.ExtID, e.DateTime.Date, nullString(e.DateTime.StartTime), nullString(e.DateTime.EndTime),
+		e.UpdatedAt, e.ID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete deletes a timing of a course of the tenant
+func (r *TimingPGSQL) Delete(tenantID, id entity.ID) error {
+	res, err := r.db.Exec(`DELETE FROM course_timing WHERE `+courseOfTenant+` AND id = $2;`, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ReplaceByCourse replaces all the timings of a course in a transaction
+func (r *TimingPGSQL) ReplaceByCourse(courseID entity.ID, timings []*entity.CourseTiming) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`DELETE FROM course_timing WHERE course_id = $1;`, courseID)
+	if err != nil {
+		return err
+	}
+	for _, t := range timings {
+		err = insertTiming(tx, t)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *TimingPGSQL) scanRows(rows *sql.Rows) ([]*entity.CourseTiming, error) {
+	var timings []*entity.CourseTiming
+	for rows.Next() {
+		var t entity.CourseTiming
+		var extID sql.NullString
+		var updatedAt sql.NullTime
+		err := rows.Scan(&t.ID, &t.CourseID, &extID, &t.DateTime.Date, &t.DateTime.StartTime,
+			&t.DateTime.EndTime, &t.CreatedAt, &updatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if extID.Valid {
+			t.ExtID = &extID.String
+		}
+		t.UpdatedAt = updatedAt.Time
+		timings = append(timings, &t)
+	}
+	return timings, rows.Err()
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// TombstonePGSQL postgres repo
+type TombstonePGSQL struct {
+	db *sql.DB
+}
+
+// NewTombstonePGSQL create new repository
+func NewTombstonePGSQL(db *sql.DB) *TombstonePGSQL {
+	return &TombstonePGSQL{
+		db: db,
+	}
+}
+
+// Create a tombstone
+func (r *TombstonePGSQL) Create(e *entity.Tombstone) (entity.ID, error) {
+	stmt, err := r.db.Prepare(`
+		INSERT INTO sync_tombstone (id, tenant_id, object, entity_id, ext_id, action, status, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)`)
+	if err != nil {
+		return e.ID, err
+	}
+	_, err = stmt.Exec(
+		e.ID,
+		e.TenantID,
+		e.Object,
+		e.EntityID,
+		e.ExtID,
+		e.Action,
+		e.Status,
+		e.CreatedAt,
+	)
+	if err != nil {
+		return e.ID, err
+	}
+	err = stmt.Close()
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// Get a tombstone
+func (r *TombstonePGSQL) Get(id entity.ID) (*entity.Tombstone, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT ` + tombstoneColumns + `
+		FROM sync_tombstone WHERE id = $1;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tombstones, err := r.scanRows(rows)
+	if err != nil || len(tombstones) == 0 {
+		return nil, err
+	}
+	return tombstones[0], nil
+}
+
+// GetByEntity gets the tombstone of a deleted record
+func (r *TombstonePGSQL) GetByEntity(object entity.SyncObject, entityID entity.ID) (*entity.Tombstone, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT ` + tombstoneColumns + `
+		FROM sync_tombstone WHERE object = $1 AND entity_id = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(object, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tombstones, err := r.scanRows(rows)
+	if err != nil || len(tombstones) == 0 {
+		return nil, err
+	}
+	return tombstones[0], nil
+}
+
+// tombstoneNotPaused leaves out the tombstones whose outbound sync is paused
+const tombstoneNotPaused = `
+		AND NOT EXISTS (
+			SELECT 1 FROM sync_control c
+			WHERE c.paused AND c.direction = 'outbound'
+			AND c.tenant_id IN (0, t.tenant_id) AND c.object IN ('*', t.object))`
+
+// tombstoneColumns columns read by scanRows
+const tombstoneColumns = `id, tenant_id, object, entity_id, ext_id, action, status, attempts, last_error,
+		claimed_by, claimed_until, created_at, updated_at`
+
+// ListPending lists pending and failed tombstones, oldest first; paused
+// tombstones are left out
+func (r *TombstonePGSQL) ListPending(limit int) ([]*entity.Tombstone, error) {
+	query := `
+		SELECT ` + tombstoneColumns + `
+		FROM sync_tombstone t WHERE status IN ('pending', 'failed')` + tombstoneNotPaused + `
+		ORDER BY created_at`
+	args := []any{}
+	if limit > 0 {
+		query += ` LIMIT $1`
+		args = append(args, limit)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// Claim claims pending and failed tombstones, oldest first. Rows being
+// claimed by another instance are skipped rather than waited for.
+func (r *TombstonePGSQL) Claim(owner string, limit int, now time.Time, lease time.Duration) ([]*entity.Tombstone, error) {
+	query := `
+		UPDATE sync_tombstone SET claimed_by = $1, claimed_until = $3
+		WHERE id IN (
+			SELECT id FROM sync_tombstone t
+			WHERE status IN ('pending', 'failed') AND (claimed_until IS NULL OR claimed_until < $2)` + tombstoneNotPaused + `
+			ORDER BY created_at`
+	args := []any{owner, now, now.Add(lease)}
+	if limit > 0 {
+		query += ` LIMIT $4`
+		args = append(args, limit)
+	}
+	query += `
+			FOR UPDATE SKIP LOCKED)
+		RETURNING ` + tombstoneColumns
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tombstones, err := r.scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tombstones, func(i, j int) bool {
+		return tombstones[i].CreatedAt.Before(tombstones[j].CreatedAt)
+	})
+	return tombstones, nil
+}
+
+// Update a tombstone
+func (r *TombstonePGSQL) Update(e *entity.Tombstone) error {
+	e.UpdatedAt = time.Now()
+	var lastError sql.NullString
+	if e.LastError != "" {
+		lastError = sql.NullString{String: e.LastError, Valid: true}
+	}
+
+	var claimedBy sql.NullString
+	if e.ClaimedBy != "" {
+		claimedBy = sql.NullString{String: e.ClaimedBy, Valid: true}
+	}
+
+	res, err := r.db.Exec(`
+		UPDATE sync_tombstone SET status = $1, attempts = $2, last_error = $3,
+			claimed_by = $4, claimed_until = $5, updated_at = $6, ext_id = $7, action = $8
+		WHERE id = $9;`,
+		e.Status, e.Attempts, lastError, claimedBy, e.ClaimedUntil, e.UpdatedAt, e.ExtID, e.Action, e.ID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// claimed runs the update of a tombstone claimed by owner;
+// entity.ErrClaimLost if owner no longer holds the claim
+func (r *TombstonePGSQL) claimed(query string, args ...any) error {
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return entity.ErrClaimLost
+	}
+
+	return nil
+}
+
+// Renew extends the claim of owner on the tombstone to now+lease
+func (r *TombstonePGSQL) Renew(id entity.ID, owner string, now time.Time, lease time.Duration) error {
+	return r.claimed(`
+		UPDATE sync_tombstone SET claimed_until = $3
+		WHERE id = $1 AND claimed_by = $2;`,
+		id, owner, now.Add(lease))
+}
+
+// Release drops the claim of owner on the tombstone
+func (r *TombstonePGSQL) Release(id entity.ID, owner string) error {
+	return r.claimed(`
+		UPDATE sync_tombstone SET claimed_by = NULL, claimed_until = NULL
+		WHERE id = $1 AND claimed_by = $2;`,
+		id, owner)
+}
+
+// Confirm marks the tombstone claimed by owner as synced
+func (r *TombstonePGSQL) Confirm(id entity.ID, owner string, now time.Time) error {
+	return r.claimed(`
+		UPDATE sync_tombstone SET status = 'synced', attempts = attempts + 1, last_error = NULL,
+			claimed_by = NULL, claimed_until = NULL, updated_at = $3
+		WHERE id = $1 AND claimed_by = $2;`,
+		id, owner, now)
+}
+
+// Fail marks the tombstone claimed by owner as failed
+func (r *TombstonePGSQL) Fail(id entity.ID, owner string, now time.Time, cause string) error {
+	var lastError sql.NullString
+	if cause != "" {
+		lastError = sql.NullString{String: cause, Valid: true}
+	}
+	return r.claimed(`
+		UPDATE sync_tombstone SET status = 'failed', attempts = attempts + 1, last_error = $3,
+			claimed_by = NULL, claimed_until = NULL, updated_at = $4
+		WHERE id = $1 AND claimed_by = $2;`,
+		id, owner, lastError, now)
+}
+
+// PendingStats gets the depth and the oldest pending item
+func (r *TombstonePGSQL) PendingStats() (*entity.QueueStats, error) {
+	var stats entity.QueueStats
+	var oldest sql.NullTime
+	err := r.db.QueryRow(`
+		SELECT count(*), min(created_at) FROM sync_tombstone WHERE status IN ('pending', 'failed');`).Scan(&stats.Depth, &oldest)
+	if err != nil {
+		return nil, err
+	}
+	if oldest.Valid {
+		stats.Oldest = &oldest.Time
+	}
+	return &stats, nil
+}
+
+func (r *TombstonePGSQL) scanRows(rows *sql.Rows) ([]*entity.Tombstone, error) {
+	var tombstones []*entity.Tombstone
+
+	for rows.Next() {
+		var t entity.Tombstone
+		var lastError, claimedBy sql.NullString
+		var claimedUntil sql.NullTime
+		err := rows.Scan(
+			&t.ID,
+			&t.TenantID,
+			&t.Object,
+			&t.EntityID,
+			&t.ExtID,
+			&t.Action,
+			&t.Status,
+			&t.Attempts,
+			&lastError,
+			&claimedBy,
+			&claimedUntil,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		t.LastError = lastError.String
+		t.ClaimedBy = claimedBy.String
+		if claimedUntil.Valid {
+			t.ClaimedUntil = &claimedUntil.Time
+		}
+		tombstones = append(tombstones, &t)
+	}
+
+	return tombstones, rows.Err()
+}
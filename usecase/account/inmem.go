@@ -31,9 +31,9 @@ func (r *inmem) Create(e *entity.Account) error {
 }
 
 // Get retrieves an account
-func (r *inmem) Get(id entity.ID) (*entity.Account, error) {
+func (r *inmem) Get(tenantID, id entity.ID) (*entity.Account, error) {
 	for _, j := range r.m {
-		if j.ID == id {
+		if j.ID == id && j.TenantID == tenantID {
 			return r.m[j.ID], nil
 		}
 	}
@@ -52,10 +52,21 @@ func (r *inmem) GetByName(tenantID entity.ID, username string) (*entity.Account,
 	return nil, entity.ErrNotFound
 }
 
+// GetByCognitoID retrieves an account using the Cognito user id
+func (r *inmem) GetByCognitoID(cognitoID string) (*entity.Account, error) {
+	for _, j := range r.m {
+		if j.CognitoID == cognitoID {
+			return r.m[j.ID], nil
+		}
+	}
+
+	return nil, entity.ErrNotFound
+}
+
 // Update an account
 func (r *inmem) Update(e *entity.Account) error {
 	account := r.m[e.ID]
-	if account == nil {
+	if account == nil || account.TenantID != e.TenantID {
 		return entity.ErrNotFound
 	}
 
@@ -73,8 +84,9 @@ func (r *inmem) Update(e *entity.Account) error {
 func (r *inmem) List(tenantID entity.ID, page, limit int, at entity.AccountType) ([]*entity.Account, error) {
 	var d []*entity.Account
 	for _, j := range r.m {
-		// TenantID check removed
-		d = append(d, j)
+		if j.TenantID == tenantID {
+			d = append(d, j)
+		}
 	}
 	if page > 0 && limit > 0 {
 		start := (page - 1) * limit
@@ -91,8 +103,8 @@ func (r *inmem) List(tenantID entity.ID, page, limit int, at entity.AccountType)
 }
 
 // Delete deletes an account
-func (r *inmem) Delete(id entity.ID) error {
-	account, err := r.Get(id)
+func (r *inmem) Delete(tenantID, id entity.ID) error {
+	account, err := r.Get(tenantID, id)
 	if err != nil {
 		return err
 	}
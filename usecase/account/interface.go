@@ -13,7 +13,9 @@ import (
 // Reader interface
 type Reader interface {
 	GetByName(tenantID entity.ID, username string) (*entity.Account, error)
-	Get(id entity.ID) (*entity.Account, error)
+	GetByCognitoID(cognitoID string) (*entity.Account, error)
+	// Get gets an account of the tenant
+	Get(tenantID, id entity.ID) (*entity.Account, error)
 	List(tenantID entity.ID, page, limit int, at entity.AccountType) ([]*entity.Account, error)
 	Search(tenantID entity.ID, query string, page, limit int, at entity.AccountType) ([]*entity.Account, error)
 	GetCount(tenantId entity.ID) (int, error)
@@ -22,8 +24,9 @@ type Reader interface {
 // Writer interface
 type Writer interface {
 	Create(e *entity.Account) error
+	// Update updates the account if it is of its tenant
 	Update(e *entity.Account) error
-	Delete(id entity.ID) error
+	Delete(tenantID, id entity.ID) error
 	DeleteByName(tenantID entity.ID, username string) error
 }
 
@@ -45,11 +48,12 @@ type UseCase interface {
 		phone string,
 		email string,
 		at entity.AccountType) error
-	GetAccount(id entity.ID) (*entity.Account, error)
+	GetAccount(tenantID, id entity.ID) (*entity.Account, error)
 	GetAccountByName(tenantID entity.ID, username string) (*entity.Account, error)
+	GetAccountByCognitoID(cognitoID string) (*entity.Account, error)
 	ListAccounts(tenantID entity.ID, page, limit int, at entity.AccountType) ([]*entity.Account, error)
 	UpdateAccount(e *entity.Account) error
-	DeleteAccount(id entity.ID) error
+	DeleteAccount(tenantID, id entity.ID) error
 	DeleteAccountByName(tenantID entity.ID, username string) error
 	GetCount(tenantId entity.ID) int
 	SearchAccounts(tenantID entity.ID, query string, page, limit int, at entity.AccountType) ([]*entity.Account, error)
@@ -35,18 +35,33 @@ func (m *MockReader) EXPECT() *MockReaderMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockReader) Get(id entity.ID) (*entity.Account, error) {
+func (m *MockReader) Get(tenantID, id entity.ID) (*entity.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
 	ret0, _ := ret[0].(*entity.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockReaderMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, id)
+}
+
+// GetByCognitoID mocks base method.
+func (m *MockReader) GetByCognitoID(cognitoID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCognitoID", cognitoID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCognitoID indicates an expected call of GetByCognitoID.
+func (mr *MockReaderMockRecorder) GetByCognitoID(cognitoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCognitoID", reflect.TypeOf((*MockReader)(nil).GetByCognitoID), cognitoID)
 }
 
 // GetByName mocks base method.
@@ -147,17 +162,17 @@ func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockWriter) Delete(id entity.ID) error {
+func (m *MockWriter) Delete(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockWriterMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, id)
 }
 
 // DeleteByName mocks base method.
@@ -226,17 +241,17 @@ func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(id entity.ID) error {
+func (m *MockRepository) Delete(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, id)
 }
 
 // DeleteByName mocks base method.
@@ -254,18 +269,33 @@ func (mr *MockRepositoryMockRecorder) DeleteByName(tenantID, username interface{
 }
 
 // Get mocks base method.
-func (m *MockRepository) Get(id entity.ID) (*entity.Account, error) {
+func (m *MockRepository) Get(tenantID, id entity.ID) (*entity.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
 	ret0, _ := ret[0].(*entity.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, id)
+}
+
+// GetByCognitoID mocks base method.
+func (m *MockRepository) GetByCognitoID(cognitoID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCognitoID", cognitoID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCognitoID indicates an expected call of GetByCognitoID.
+func (mr *MockRepositoryMockRecorder) GetByCognitoID(cognitoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCognitoID", reflect.TypeOf((*MockRepository)(nil).GetByCognitoID), cognitoID)
 }
 
 // GetByName mocks base method.
@@ -380,17 +410,17 @@ func (mr *MockUseCaseMockRecorder) CreateAccount(tenantID, extID, cognitoID, use
 }
 
 // DeleteAccount mocks base method.
-func (m *MockUseCase) DeleteAccount(id entity.ID) error {
+func (m *MockUseCase) DeleteAccount(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", id)
+	ret := m.ctrl.Call(m, "DeleteAccount", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockUseCaseMockRecorder) DeleteAccount(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteAccount(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUseCase)(nil).DeleteAccount), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUseCase)(nil).DeleteAccount), tenantID, id)
 }
 
 // DeleteAccountByName mocks base method.
@@ -408,18 +438,33 @@ func (mr *MockUseCaseMockRecorder) DeleteAccountByName(tenantID, username interf
 }
 
 // GetAccount mocks base method.
-func (m *MockUseCase) GetAccount(id entity.ID) (*entity.Account, error) {
+func (m *MockUseCase) GetAccount(tenantID, id entity.ID) (*entity.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", id)
+	ret := m.ctrl.Call(m, "GetAccount", tenantID, id)
 	ret0, _ := ret[0].(*entity.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccount indicates an expected call of GetAccount.
-func (mr *MockUseCaseMockRecorder) GetAccount(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) GetAccount(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockUseCase)(nil).GetAccount), tenantID, id)
+}
+
+// GetAccountByCognitoID mocks base method.
+func (m *MockUseCase) GetAccountByCognitoID(cognitoID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByCognitoID", cognitoID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByCognitoID indicates an expected call of GetAccountByCognitoID.
+func (mr *MockUseCaseMockRecorder) GetAccountByCognitoID(cognitoID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockUseCase)(nil).GetAccount), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByCognitoID", reflect.TypeOf((*MockUseCase)(nil).GetAccountByCognitoID), cognitoID)
 }
 
 // GetAccountByName mocks base method.
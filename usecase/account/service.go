@@ -10,17 +10,21 @@ import (
 	"time"
 
 	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/tombstone"
 )
 
 // Service account usecase
 type Service struct {
-	repo Repository
+	repo      Repository
+	tombstone tombstone.UseCase
 }
 
-// NewService create new service
-func NewService(r Repository) *Service {
+// NewService create new service. Deletes of accounts synced to Salesforce
+// are held back until tb confirms them; a nil tb deletes right away.
+func NewService(r Repository, tb tombstone.UseCase) *Service {
 	return &Service{
-		repo: r,
+		repo:      r,
+		tombstone: tb,
 	}
 }
 
@@ -51,9 +55,9 @@ func (s *Service) CreateAccount(
 	return s.repo.Create(account)
 }
 
-// GetAccount retrieves an account
-func (s *Service) GetAccount(id entity.ID) (*entity.Account, error) {
-	account, err := s.repo.Get(id)
+// GetAccount retrieves an account of the tenant
+func (s *Service) GetAccount(tenantID, id entity.ID) (*entity.Account, error) {
+	account, err := s.repo.Get(tenantID, id)
 	if account == nil {
 		return nil, entity.ErrNotFound
 	}
@@ -77,6 +81,22 @@ func (s *Service) GetAccountByName(tenantID entity.ID, username string) (*entity
 	return account, nil
 }
 
+// GetAccountByCognitoID retrieves the account of a Cognito user
+func (s *Service) GetAccountByCognitoID(cognitoID string) (*entity.Account, error) {
+	if cognitoID == "" {
+		return nil, entity.ErrNotFound
+	}
+	account, err := s.repo.GetByCognitoID(cognitoID)
+	if account == nil {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
 // ListAccounts list accounts
 func (s *Service) ListAccounts(tenantID entity.ID, page, limit int, at entity.AccountType) ([]*entity.Account, error) {
 	accounts, err := s.repo.List(tenantID, page, limit, at)
@@ -95,13 +115,17 @@ func (s *Service) UpdateAccount(t *entity.Account) error {
 	if err != nil {
 		return err
 	}
+	_, err = s.GetAccount(t.TenantID, t.ID)
+	if err != nil {
+		return err
+	}
 	t.UpdatedAt = time.Now()
 	return s.repo.Update(t)
 }
 
-// DeleteAccount Deletes an account
-func (s *Service) DeleteAccount(id entity.ID) error {
-	account, err := s.GetAccount(id)
+// DeleteAccount Deletes an account of the tenant
+func (s *Service) DeleteAccount(tenantID, id entity.ID) error {
+	account, err := s.GetAccount(tenantID, id)
 	if account == nil {
 		return entity.ErrNotFound
 	}
@@ -109,7 +133,11 @@ func (s *Service) DeleteAccount(id entity.ID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if s.holdDelete(account) {
+		return s.tombstoneAccount(account)
+	}
+
+	return s.repo.Delete(tenantID, id)
 }
 
 // DeleteAccount Deletes an account using username
@@ -122,9 +150,28 @@ func (s *Service) DeleteAccountByName(tenantID entity.ID, username string) error
 		return err
 	}
 
+	if s.holdDelete(account) {
+		return s.tombstoneAccount(account)
+	}
+
 	return s.repo.DeleteByName(tenantID, username)
 }
 
+// holdDelete checks whether the delete must wait for Salesforce
+func (s *Service) holdDelete(account *entity.Account) bool {
+	return s.tombstone != nil && account.ExtID != ""
+}
+
+// tombstoneAccount records the delete to be propagated to Salesforce
+func (s *Service) tombstoneAccount(account *entity.Account) error {
+	_, err := s.tombstone.CreateTombstone(account.TenantID,
+		entity.SyncObjectAccount, account.ID, account.ExtID)
+	if err != nil {
+		return err
+	}
+	return entity.ErrDeletePending
+}
+
 // GetCount gets total account count
 func (s *Service) GetCount(tenantID entity.ID) int {
 	count, err := s.repo.GetCount(tenantID)
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/tombstone"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -28,8 +29,7 @@ const (
 	aliceCognitoID  = "aws:cognito:alice"
 	alice2CognitoID = "aws:cognito:alice2"
 
-	// todo: add multi-tenant support
-	// tenantBob entity.ID = 13790492210917015555
+	tenantBob entity.ID = 13790492210917015555
 )
 
 func newFixtureAccount() *entity.Account {
@@ -50,7 +50,7 @@ func newFixtureAccount() *entity.Account {
 
 func Test_Create(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	account := newFixtureAccount()
 	err := m.CreateAccount(tenantAlice,
 		account.ExtID,
@@ -68,7 +68,7 @@ func Test_Create(t *testing.T) {
 
 func Test_SearchAndFind(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	account1 := newFixtureAccount()
 	account2 := newFixtureAccount()
 	account2.ID = accountID2Alice
@@ -110,13 +110,23 @@ func Test_SearchAndFind(t *testing.T) {
 		assert.Equal(t, account1.Type, saved.Type)
 		assert.Equal(t, account1.Username, saved.Username)
 	})
+
+	t.Run("get by cognito id", func(t *testing.T) {
+		saved, err := m.GetAccountByCognitoID(alice2CognitoID)
+		assert.Nil(t, err)
+		assert.Equal(t, account2.Username, saved.Username)
+		_, err = m.GetAccountByCognitoID("aws:cognito:bob")
+		assert.Equal(t, entity.ErrNotFound, err)
+		_, err = m.GetAccountByCognitoID("")
+		assert.Equal(t, entity.ErrNotFound, err)
+	})
 }
 
 // It's unlikely that the update will be called in this entity model.
 // Perhaps a human readable name can be given for customer to reference.
 func Test_Update(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	account := newFixtureAccount()
 	err := m.CreateAccount(tenantAlice,
 		account.ExtID,
@@ -144,7 +154,7 @@ func Test_Update(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 
 	account1 := newFixtureAccount()
 
@@ -172,3 +182,65 @@ func TestDelete(t *testing.T) {
 	_, err = m.GetAccountByName(tenantAlice, account2.Username)
 	assert.Equal(t, entity.ErrNotFound, err)
 }
+
+func Test_OtherTenant(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, nil)
+	account := newFixtureAccount()
+	err := m.CreateAccount(tenantAlice,
+		account.ExtID,
+		account.CognitoID,
+		account.Username,
+		account.FirstName,
+		account.LastName,
+		account.Phone,
+		account.Email,
+		account.Type,
+	)
+	assert.Nil(t, err)
+	a, _ := m.GetAccountByName(tenantAlice, account.Username)
+
+	_, err = m.GetAccount(tenantBob, a.ID)
+	assert.Equal(t, entity.ErrNotFound, err)
+	_, err = m.ListAccounts(tenantBob, 0, 10, "")
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	other := *a
+	other.TenantID = tenantBob
+	other.FirstName = "Mallory"
+	assert.Equal(t, entity.ErrNotFound, m.UpdateAccount(&other))
+	assert.Equal(t, entity.ErrNotFound, m.DeleteAccount(tenantBob, a.ID))
+
+	saved, err := m.GetAccount(tenantAlice, a.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, account.FirstName, saved.FirstName)
+}
+
+func TestDelete_Pending(t *testing.T) {
+	repo := newInmem()
+	tb := tombstone.NewService(tombstone.NewInmem(), nil)
+	m := NewService(repo, tb)
+
+	account := newFixtureAccount()
+	_ = m.CreateAccount(tenantAlice,
+		account.ExtID,
+		account.CognitoID,
+		account.Username,
+		account.FirstName,
+		account.LastName,
+		account.Phone,
+		account.Email,
+		account.Type,
+	)
+
+	err := m.DeleteAccountByName(tenantAlice, account.Username)
+	assert.Equal(t, entity.ErrDeletePending, err)
+	_, err = m.GetAccountByName(tenantAlice, account.Username)
+	assert.Nil(t, err)
+
+	tombstones, err := tb.ListPendingTombstones(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tombstones))
+	assert.Equal(t, entity.SyncObjectAccount, tombstones[0].Object)
+	assert.Equal(t, aliceExtID, tombstones[0].ExtID)
+}
@@ -7,6 +7,7 @@
 package center
 
 import (
+	"sort"
 	"strings"
 
 	"sudhagar/glad/entity"
@@ -14,14 +15,16 @@ import (
 
 // inmem in memory repo
 type inmem struct {
-	m map[entity.ID]*entity.Center
+	m        map[entity.ID]*entity.Center
+	contacts map[entity.ID]*entity.CenterContact
 }
 
 // newInmem create new repository
 func newInmem() *inmem {
 	var m = map[entity.ID]*entity.Center{}
 	return &inmem{
-		m: m,
+		m:        m,
+		contacts: map[entity.ID]*entity.CenterContact{},
 	}
 }
 
@@ -32,8 +35,8 @@ func (r *inmem) Create(e *entity.Center) (entity.ID, error) {
 }
 
 // Get a center
-func (r *inmem) Get(id entity.ID) (*entity.Center, error) {
-	if r.m[id] == nil {
+func (r *inmem) Get(tenantID, id entity.ID) (*entity.Center, error) {
+	if r.m[id] == nil || r.m[id].TenantID != tenantID {
 		return nil, entity.ErrNotFound
 	}
 	return r.m[id], nil
@@ -41,7 +44,7 @@ func (r *inmem) Get(id entity.ID) (*entity.Center, error) {
 
 // Update a center
 func (r *inmem) Update(e *entity.Center) error {
-	_, err := r.Get(e.ID)
+	_, err := r.Get(e.TenantID, e.ID)
 	if err != nil {
 		return err
 	}
@@ -102,13 +105,65 @@ func (r *inmem) List(tenantID entity.ID, page, limit int) ([]*entity.Center, err
 	return centers, nil
 }
 
-// Delete a center
-func (r *inmem) Delete(id entity.ID) error {
+// ListBySyncStatus lists centers with the given sync status
+func (r *inmem) ListBySyncStatus(tenantID entity.ID,
+	status entity.SyncStatus,
+	page, limit int,
+) ([]*entity.Center, error) {
+	var centers []*entity.Center
+	for _, j := range r.m {
+		if j.TenantID == tenantID && j.Sync.Status == status {
+			centers = append(centers, j)
+		}
+	}
+
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(centers) {
+			return []*entity.Center{}, nil
+		}
+
+		if end > len(centers) {
+			end = len(centers)
+		}
+		return centers[start:end], nil
+	}
+	return centers, nil
+}
+
+// CountBySyncStatus counts the centers of the tenant per sync status
+func (r *inmem) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	counts := map[entity.SyncStatus]int{}
+	for _, j := range r.m {
+		if j.TenantID == tenantID {
+			counts[j.Sync.Status]++
+		}
+	}
+	return counts, nil
+}
+
+// UpdateSyncState updates the sync meta data of a center
+func (r *inmem) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
 	if r.m[id] == nil {
 		return entity.ErrNotFound
 	}
+	r.m[id].Sync = *s
+	return nil
+}
+
+// Delete a center
+func (r *inmem) Delete(tenantID, id entity.ID) error {
+	if r.m[id] == nil || r.m[id].TenantID != tenantID {
+		return entity.ErrNotFound
+	}
 	r.m[id] = nil
 	delete(r.m, id)
+	for cid, c := range r.contacts {
+		if c.CenterID == id {
+			delete(r.contacts, cid)
+		}
+	}
 	return nil
 }
 
@@ -122,3 +177,56 @@ func (r *inmem) GetCount(tenantID entity.ID) (int, error) {
 	}
 	return count, nil
 }
+
+// GetContact gets a contact of a center of the tenant
+func (r *inmem) GetContact(tenantID, id entity.ID) (*entity.CenterContact, error) {
+	c := r.contacts[id]
+	if c == nil || r.m[c.CenterID] == nil || r.m[c.CenterID].TenantID != tenantID {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// ListContacts lists the contacts of a center, the primary contact first
+func (r *inmem) ListContacts(centerID entity.ID) ([]*entity.CenterContact, error) {
+	var contacts []*entity.CenterContact
+	for _, c := range r.contacts {
+		if c.CenterID == centerID {
+			contacts = append(contacts, c)
+		}
+	}
+	sort.SliceStable(contacts, func(i, j int) bool {
+		if contacts[i].IsPrimary != contacts[j].IsPrimary {
+			return contacts[i].IsPrimary
+		}
+		return contacts[i].CreatedAt.Before(contacts[j].CreatedAt)
+	})
+	return contacts, nil
+}
+
+// CreateContact creates a center contact
+func (r *inmem) CreateContact(e *entity.CenterContact) (entity.ID, error) {
+	if r.m[e.CenterID] == nil {
+		return entity.IDInvalid, entity.ErrNotFound
+	}
+	r.contacts[e.ID] = e
+	return e.ID, nil
+}
+
+// UpdateContact updates a contact of a center of the tenant
+func (r *inmem) UpdateContact(tenantID entity.ID, e *entity.CenterContact) error {
+	if c, _ := r.GetContact(tenantID, e.ID); c == nil {
+		return entity.ErrNotFound
+	}
+	r.contacts[e.ID] = e
+	return nil
+}
+
+// DeleteContact deletes a contact of a center of the tenant
+func (r *inmem) DeleteContact(tenantID, id entity.ID) error {
+	if c, _ := r.GetContact(tenantID, id); c == nil {
+		return entity.ErrNotFound
+	}
+	delete(r.contacts, id)
+	return nil
+}
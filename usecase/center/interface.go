@@ -12,17 +12,33 @@ import (
 
 // Reader interface
 type Reader interface {
-	Get(id entity.ID) (*entity.Center, error)
+	// Get gets a center of the tenant
+	Get(tenantID, id entity.ID) (*entity.Center, error)
 	Search(tenantID entity.ID, query string, page, limit int) ([]*entity.Center, error)
 	List(tenantID entity.ID, page, limit int) ([]*entity.Center, error)
 	GetCount(id entity.ID) (int, error)
+	ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Center, error)
+	// CountBySyncStatus counts the centers of the tenant per sync status
+	CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error)
+	// GetContact gets a contact of a center of the tenant
+	GetContact(tenantID, id entity.ID) (*entity.CenterContact, error)
+	ListContacts(centerID entity.ID) ([]*entity.CenterContact, error)
 }
 
 // Writer center writer
 type Writer interface {
 	Create(e *entity.Center) (entity.ID, error)
+	// Update updates the center if it is of its tenant
 	Update(e *entity.Center) error
-	Delete(id entity.ID) error
+	Delete(tenantID, id entity.ID) error
+	// UpdateSyncState is not scoped by tenant: only the sync paths call it,
+	// with the id of a record they read, and it is not in the UseCase
+	UpdateSyncState(id entity.ID, s *entity.SyncState) error
+	CreateContact(e *entity.CenterContact) (entity.ID, error)
+	// UpdateContact updates a contact of a center of the tenant
+	UpdateContact(tenantID entity.ID, e *entity.CenterContact) error
+	// DeleteContact deletes a contact of a center of the tenant
+	DeleteContact(tenantID, id entity.ID) error
 }
 
 // Repository interface
@@ -33,11 +49,18 @@ type Repository interface {
 
 // UseCase interface
 type UseCase interface {
-	GetCenter(id entity.ID) (*entity.Center, error)
+	GetCenter(tenantID, id entity.ID) (*entity.Center, error)
 	SearchCenters(tenantID entity.ID, query string, page, limit int) ([]*entity.Center, error)
 	ListCenters(tenantID entity.ID, page, limit int) ([]*entity.Center, error)
+	ListCentersBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Center, error)
+	CountCentersBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error)
 	CreateCenter(tenantID entity.ID, extID, extName, name string, mode entity.CenterMode, isEnabled bool) (entity.ID, error)
 	UpdateCenter(e *entity.Center) error
-	DeleteCenter(id entity.ID) error
+	DeleteCenter(tenantID, id entity.ID) error
 	GetCount(id entity.ID) int
+	GetContact(tenantID, id entity.ID) (*entity.CenterContact, error)
+	ListContacts(centerID entity.ID) ([]*entity.CenterContact, error)
+	CreateContact(tenantID, centerID entity.ID, name, phone, email string, isPrimary bool) (entity.ID, error)
+	UpdateContact(tenantID entity.ID, e *entity.CenterContact) error
+	DeleteContact(tenantID, id entity.ID) error
 }
@@ -34,19 +34,49 @@ func (m *MockReader) EXPECT() *MockReaderMockRecorder {
 	return m.recorder
 }
 
+// CountBySyncStatus mocks base method.
+func (m *MockReader) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySyncStatus indicates an expected call of CountBySyncStatus.
+func (mr *MockReaderMockRecorder) CountBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySyncStatus", reflect.TypeOf((*MockReader)(nil).CountBySyncStatus), tenantID)
+}
+
 // Get mocks base method.
-func (m *MockReader) Get(id entity.ID) (*entity.Center, error) {
+func (m *MockReader) Get(tenantID, id entity.ID) (*entity.Center, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
 	ret0, _ := ret[0].(*entity.Center)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockReaderMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, id)
+}
+
+// GetContact mocks base method.
+func (m *MockReader) GetContact(tenantID, id entity.ID) (*entity.CenterContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContact", tenantID, id)
+	ret0, _ := ret[0].(*entity.CenterContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContact indicates an expected call of GetContact.
+func (mr *MockReaderMockRecorder) GetContact(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContact", reflect.TypeOf((*MockReader)(nil).GetContact), tenantID, id)
 }
 
 // GetCount mocks base method.
@@ -79,6 +109,36 @@ func (mr *MockReaderMockRecorder) List(tenantID, page, limit interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), tenantID, page, limit)
 }
 
+// ListBySyncStatus mocks base method.
+func (m *MockReader) ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Center, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Center)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySyncStatus indicates an expected call of ListBySyncStatus.
+func (mr *MockReaderMockRecorder) ListBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySyncStatus", reflect.TypeOf((*MockReader)(nil).ListBySyncStatus), tenantID, status, page, limit)
+}
+
+// ListContacts mocks base method.
+func (m *MockReader) ListContacts(centerID entity.ID) ([]*entity.CenterContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContacts", centerID)
+	ret0, _ := ret[0].([]*entity.CenterContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContacts indicates an expected call of ListContacts.
+func (mr *MockReaderMockRecorder) ListContacts(centerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContacts", reflect.TypeOf((*MockReader)(nil).ListContacts), centerID)
+}
+
 // Search mocks base method.
 func (m *MockReader) Search(tenantID entity.ID, query string, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -132,18 +192,47 @@ func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWriter)(nil).Create), e)
 }
 
+// CreateContact mocks base method.
+func (m *MockWriter) CreateContact(e *entity.CenterContact) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContact", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContact indicates an expected call of CreateContact.
+func (mr *MockWriterMockRecorder) CreateContact(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContact", reflect.TypeOf((*MockWriter)(nil).CreateContact), e)
+}
+
 // Delete mocks base method.
-func (m *MockWriter) Delete(id entity.ID) error {
+func (m *MockWriter) Delete(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockWriterMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, id)
+}
+
+// DeleteContact mocks base method.
+func (m *MockWriter) DeleteContact(tenantID, id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContact", tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContact indicates an expected call of DeleteContact.
+func (mr *MockWriterMockRecorder) DeleteContact(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockWriter)(nil).DeleteContact), tenantID, id)
 }
 
 // Update mocks base method.
@@ -160,6 +249,34 @@ func (mr *MockWriterMockRecorder) Update(e interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), e)
 }
 
+// UpdateContact mocks base method.
+func (m *MockWriter) UpdateContact(tenantID entity.ID, e *entity.CenterContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContact", tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContact indicates an expected call of UpdateContact.
+func (mr *MockWriterMockRecorder) UpdateContact(tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockWriter)(nil).UpdateContact), tenantID, e)
+}
+
+// UpdateSyncState mocks base method.
+func (m *MockWriter) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncState", id, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncState indicates an expected call of UpdateSyncState.
+func (mr *MockWriterMockRecorder) UpdateSyncState(id, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncState", reflect.TypeOf((*MockWriter)(nil).UpdateSyncState), id, s)
+}
+
 // MockRepository is a mock of Repository interface.
 type MockRepository struct {
 	ctrl     *gomock.Controller
@@ -183,6 +300,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountBySyncStatus mocks base method.
+func (m *MockRepository) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySyncStatus indicates an expected call of CountBySyncStatus.
+func (mr *MockRepositoryMockRecorder) CountBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySyncStatus", reflect.TypeOf((*MockRepository)(nil).CountBySyncStatus), tenantID)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(e *entity.Center) (entity.ID, error) {
 	m.ctrl.T.Helper()
@@ -198,33 +330,77 @@ func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), e)
 }
 
+// CreateContact mocks base method.
+func (m *MockRepository) CreateContact(e *entity.CenterContact) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContact", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContact indicates an expected call of CreateContact.
+func (mr *MockRepositoryMockRecorder) CreateContact(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContact", reflect.TypeOf((*MockRepository)(nil).CreateContact), e)
+}
+
 // Delete mocks base method.
-func (m *MockRepository) Delete(id entity.ID) error {
+func (m *MockRepository) Delete(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, id)
+}
+
+// DeleteContact mocks base method.
+func (m *MockRepository) DeleteContact(tenantID, id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContact", tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContact indicates an expected call of DeleteContact.
+func (mr *MockRepositoryMockRecorder) DeleteContact(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockRepository)(nil).DeleteContact), tenantID, id)
 }
 
 // Get mocks base method.
-func (m *MockRepository) Get(id entity.ID) (*entity.Center, error) {
+func (m *MockRepository) Get(tenantID, id entity.ID) (*entity.Center, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
 	ret0, _ := ret[0].(*entity.Center)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, id)
+}
+
+// GetContact mocks base method.
+func (m *MockRepository) GetContact(tenantID, id entity.ID) (*entity.CenterContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContact", tenantID, id)
+	ret0, _ := ret[0].(*entity.CenterContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContact indicates an expected call of GetContact.
+func (mr *MockRepositoryMockRecorder) GetContact(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContact", reflect.TypeOf((*MockRepository)(nil).GetContact), tenantID, id)
 }
 
 // GetCount mocks base method.
@@ -257,6 +433,36 @@ func (mr *MockRepositoryMockRecorder) List(tenantID, page, limit interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), tenantID, page, limit)
 }
 
+// ListBySyncStatus mocks base method.
+func (m *MockRepository) ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Center, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Center)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySyncStatus indicates an expected call of ListBySyncStatus.
+func (mr *MockRepositoryMockRecorder) ListBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySyncStatus", reflect.TypeOf((*MockRepository)(nil).ListBySyncStatus), tenantID, status, page, limit)
+}
+
+// ListContacts mocks base method.
+func (m *MockRepository) ListContacts(centerID entity.ID) ([]*entity.CenterContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContacts", centerID)
+	ret0, _ := ret[0].([]*entity.CenterContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContacts indicates an expected call of ListContacts.
+func (mr *MockRepositoryMockRecorder) ListContacts(centerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContacts", reflect.TypeOf((*MockRepository)(nil).ListContacts), centerID)
+}
+
 // Search mocks base method.
 func (m *MockRepository) Search(tenantID entity.ID, query string, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -286,6 +492,34 @@ func (mr *MockRepositoryMockRecorder) Update(e interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), e)
 }
 
+// UpdateContact mocks base method.
+func (m *MockRepository) UpdateContact(tenantID entity.ID, e *entity.CenterContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContact", tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContact indicates an expected call of UpdateContact.
+func (mr *MockRepositoryMockRecorder) UpdateContact(tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockRepository)(nil).UpdateContact), tenantID, e)
+}
+
+// UpdateSyncState mocks base method.
+func (m *MockRepository) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncState", id, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncState indicates an expected call of UpdateSyncState.
+func (mr *MockRepositoryMockRecorder) UpdateSyncState(id, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncState", reflect.TypeOf((*MockRepository)(nil).UpdateSyncState), id, s)
+}
+
 // MockUseCase is a mock of UseCase interface.
 type MockUseCase struct {
 	ctrl     *gomock.Controller
@@ -309,6 +543,21 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// CountCentersBySyncStatus mocks base method.
+func (m *MockUseCase) CountCentersBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCentersBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCentersBySyncStatus indicates an expected call of CountCentersBySyncStatus.
+func (mr *MockUseCaseMockRecorder) CountCentersBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCentersBySyncStatus", reflect.TypeOf((*MockUseCase)(nil).CountCentersBySyncStatus), tenantID)
+}
+
 // CreateCenter mocks base method.
 func (m *MockUseCase) CreateCenter(tenantID entity.ID, extID, extName, name string, mode entity.CenterMode, isEnabled bool) (entity.ID, error) {
 	m.ctrl.T.Helper()
@@ -324,33 +573,77 @@ func (mr *MockUseCaseMockRecorder) CreateCenter(tenantID, extID, extName, name,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCenter", reflect.TypeOf((*MockUseCase)(nil).CreateCenter), tenantID, extID, extName, name, mode, isEnabled)
 }
 
+// CreateContact mocks base method.
+func (m *MockUseCase) CreateContact(tenantID, centerID entity.ID, name, phone, email string, isPrimary bool) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContact", tenantID, centerID, name, phone, email, isPrimary)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContact indicates an expected call of CreateContact.
+func (mr *MockUseCaseMockRecorder) CreateContact(tenantID, centerID, name, phone, email, isPrimary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContact", reflect.TypeOf((*MockUseCase)(nil).CreateContact), tenantID, centerID, name, phone, email, isPrimary)
+}
+
 // DeleteCenter mocks base method.
-func (m *MockUseCase) DeleteCenter(id entity.ID) error {
+func (m *MockUseCase) DeleteCenter(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCenter", id)
+	ret := m.ctrl.Call(m, "DeleteCenter", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCenter indicates an expected call of DeleteCenter.
-func (mr *MockUseCaseMockRecorder) DeleteCenter(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteCenter(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCenter", reflect.TypeOf((*MockUseCase)(nil).DeleteCenter), tenantID, id)
+}
+
+// DeleteContact mocks base method.
+func (m *MockUseCase) DeleteContact(tenantID, id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContact", tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContact indicates an expected call of DeleteContact.
+func (mr *MockUseCaseMockRecorder) DeleteContact(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCenter", reflect.TypeOf((*MockUseCase)(nil).DeleteCenter), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockUseCase)(nil).DeleteContact), tenantID, id)
 }
 
 // GetCenter mocks base method.
-func (m *MockUseCase) GetCenter(id entity.ID) (*entity.Center, error) {
+func (m *MockUseCase) GetCenter(tenantID, id entity.ID) (*entity.Center, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCenter", id)
+	ret := m.ctrl.Call(m, "GetCenter", tenantID, id)
 	ret0, _ := ret[0].(*entity.Center)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCenter indicates an expected call of GetCenter.
-func (mr *MockUseCaseMockRecorder) GetCenter(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) GetCenter(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCenter", reflect.TypeOf((*MockUseCase)(nil).GetCenter), tenantID, id)
+}
+
+// GetContact mocks base method.
+func (m *MockUseCase) GetContact(tenantID, id entity.ID) (*entity.CenterContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContact", tenantID, id)
+	ret0, _ := ret[0].(*entity.CenterContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContact indicates an expected call of GetContact.
+func (mr *MockUseCaseMockRecorder) GetContact(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCenter", reflect.TypeOf((*MockUseCase)(nil).GetCenter), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContact", reflect.TypeOf((*MockUseCase)(nil).GetContact), tenantID, id)
 }
 
 // GetCount mocks base method.
@@ -382,6 +675,36 @@ func (mr *MockUseCaseMockRecorder) ListCenters(tenantID, page, limit interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCenters", reflect.TypeOf((*MockUseCase)(nil).ListCenters), tenantID, page, limit)
 }
 
+// ListCentersBySyncStatus mocks base method.
+func (m *MockUseCase) ListCentersBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Center, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCentersBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Center)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCentersBySyncStatus indicates an expected call of ListCentersBySyncStatus.
+func (mr *MockUseCaseMockRecorder) ListCentersBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCentersBySyncStatus", reflect.TypeOf((*MockUseCase)(nil).ListCentersBySyncStatus), tenantID, status, page, limit)
+}
+
+// ListContacts mocks base method.
+func (m *MockUseCase) ListContacts(centerID entity.ID) ([]*entity.CenterContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContacts", centerID)
+	ret0, _ := ret[0].([]*entity.CenterContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContacts indicates an expected call of ListContacts.
+func (mr *MockUseCaseMockRecorder) ListContacts(centerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContacts", reflect.TypeOf((*MockUseCase)(nil).ListContacts), centerID)
+}
+
 // SearchCenters mocks base method.
 func (m *MockUseCase) SearchCenters(tenantID entity.ID, query string, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -410,3 +733,17 @@ func (mr *MockUseCaseMockRecorder) UpdateCenter(e interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCenter", reflect.TypeOf((*MockUseCase)(nil).UpdateCenter), e)
 }
+
+// UpdateContact mocks base method.
+func (m *MockUseCase) UpdateContact(tenantID entity.ID, e *entity.CenterContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContact", tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContact indicates an expected call of UpdateContact.
+func (mr *MockUseCaseMockRecorder) UpdateContact(tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockUseCase)(nil).UpdateContact), tenantID, e)
+}
@@ -11,17 +11,21 @@ import (
 	"time"
 
 	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/tombstone"
 )
 
 // Service center usecase
 type Service struct {
-	repo Repository
+	repo      Repository
+	tombstone tombstone.UseCase
 }
 
-// NewService create new service
-func NewService(r Repository) *Service {
+// NewService create new service. Deletes of records synced to Salesforce
+// are held back until tb confirms them; a nil tb deletes right away.
+func NewService(r Repository, tb tombstone.UseCase) *Service {
 	return &Service{
-		repo: r,
+		repo:      r,
+		tombstone: tb,
 	}
 }
 
@@ -41,15 +45,19 @@ func (s *Service) CreateCenter(tenantID entity.ID,
 	return s.repo.Create(c)
 }
 
-// GetCenter retrieves a center
-func (s *Service) GetCenter(id entity.ID) (*entity.Center, error) {
-	t, err := s.repo.Get(id)
+// GetCenter retrieves a center of the tenant
+func (s *Service) GetCenter(tenantID, id entity.ID) (*entity.Center, error) {
+	t, err := s.repo.Get(tenantID, id)
 	if t == nil {
 		return nil, entity.ErrNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
+	t.Contacts, err = s.repo.ListContacts(id)
+	if err != nil {
+		return nil, err
+	}
 
 	return t, nil
 }
@@ -80,9 +88,31 @@ func (s *Service) ListCenters(tenantID entity.ID, page, limit int) ([]*entity.Ce
 	return centers, nil
 }
 
-// DeleteCenter Delete a center
-func (s *Service) DeleteCenter(id entity.ID) error {
-	t, err := s.GetCenter(id)
+// ListCentersBySyncStatus list centers with the given sync status
+func (s *Service) ListCentersBySyncStatus(tenantID entity.ID,
+	status entity.SyncStatus, page, limit int,
+) ([]*entity.Center, error) {
+	if !status.IsValid() {
+		return nil, entity.ErrInvalidEntity
+	}
+	centers, err := s.repo.ListBySyncStatus(tenantID, status, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(centers) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return centers, nil
+}
+
+// CountCentersBySyncStatus counts the centers of the tenant per sync status
+func (s *Service) CountCentersBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	return s.repo.CountBySyncStatus(tenantID)
+}
+
+// DeleteCenter Delete a center of the tenant
+func (s *Service) DeleteCenter(tenantID, id entity.ID) error {
+	t, err := s.GetCenter(tenantID, id)
 	if t == nil {
 		return entity.ErrNotFound
 	}
@@ -90,15 +120,38 @@ func (s *Service) DeleteCenter(id entity.ID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if s.tombstone != nil && t.ExtID != "" {
+		_, err = s.tombstone.CreateTombstone(t.TenantID, entity.SyncObjectCenter, id, t.ExtID)
+		if err != nil {
+			return err
+		}
+		err = s.repo.UpdateSyncState(id, &entity.SyncState{
+			LastSyncedAt:      t.Sync.LastSyncedAt,
+			LastSyncDirection: t.Sync.LastSyncDirection,
+			Status:            entity.SyncPending,
+		})
+		if err != nil {
+			return err
+		}
+		return entity.ErrDeletePending
+	}
+
+	return s.repo.Delete(tenantID, id)
 }
 
-// UpdateCenter Update a center
+// UpdateCenter Update a center of its tenant
 func (s *Service) UpdateCenter(c *entity.Center) error {
 	err := c.Validate()
 	if err != nil {
 		return err
 	}
+	saved, err := s.repo.Get(c.TenantID, c.ID)
+	if saved == nil {
+		return entity.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
 	c.UpdatedAt = time.Now()
 	return s.repo.Update(c)
 }
@@ -112,3 +165,83 @@ func (s *Service) GetCount(tenantID entity.ID) int {
 
 	return count
 }
+
+// GetContact retrieves a contact of a center of the tenant
+func (s *Service) GetContact(tenantID, id entity.ID) (*entity.CenterContact, error) {
+	c, err := s.repo.GetContact(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, entity.ErrNotFound
+	}
+	return c, nil
+}
+
+// ListContacts lists the contacts of a center, the primary contact first
+func (s *Service) ListContacts(centerID entity.ID) ([]*entity.CenterContact, error) {
+	return s.repo.ListContacts(centerID)
+}
+
+// CreateContact creates a contact of a center of the tenant; a primary
+// contact replaces the current primary contact, which stays as a contact
+func (s *Service) CreateContact(tenantID, centerID entity.ID, name, phone, email string, isPrimary bool) (entity.ID, error) {
+	c, err := entity.NewCenterContact(centerID, name, phone, email, isPrimary)
+	if err != nil {
+		return entity.IDInvalid, err
+	}
+	if _, err := s.GetCenter(tenantID, centerID); err != nil {
+		return entity.IDInvalid, err
+	}
+	if err := s.demotePrimary(tenantID, c); err != nil {
+		return entity.IDInvalid, err
+	}
+	return s.repo.CreateContact(c)
+}
+
+// UpdateContact updates a contact of a center of the tenant
+func (s *Service) UpdateContact(tenantID entity.ID, c *entity.CenterContact) error {
+	err := c.Validate()
+	if err != nil {
+		return err
+	}
+	if _, err := s.GetContact(tenantID, c.ID); err != nil {
+		return err
+	}
+	if err := s.demotePrimary(tenantID, c); err != nil {
+		return err
+	}
+	c.UpdatedAt = time.Now()
+	return s.repo.UpdateContact(tenantID, c)
+}
+
+// DeleteContact deletes a contact of a center of the tenant
+func (s *Service) DeleteContact(tenantID, id entity.ID) error {
+	c, err := s.GetContact(tenantID, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteContact(tenantID, c.ID)
+}
+
+// demotePrimary unsets the primary contact of the center if c becomes the
+// primary contact
+func (s *Service) demotePrimary(tenantID entity.ID, c *entity.CenterContact) error {
+	if !c.IsPrimary {
+		return nil
+	}
+	contacts, err := s.repo.ListContacts(c.CenterID)
+	if err != nil {
+		return err
+	}
+	for _, e := range contacts {
+		if e.IsPrimary && e.ID != c.ID {
+			e.IsPrimary = false
+			e.UpdatedAt = time.Now()
+			if err := s.repo.UpdateContact(tenantID, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
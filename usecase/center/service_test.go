@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/tombstone"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -20,9 +21,8 @@ const (
 	tenantAlice   entity.ID = 13790492210917015554
 	aliceExtID              = "000aliceExtID"
 
-	// todo: add multi-tenant support
-	// tenantBob    entity.ID = 13790492210917015555
-	bobExtID = "000bobExtID"
+	tenantBob entity.ID = 13790492210917015555
+	bobExtID            = "000bobExtID"
 )
 
 func newFixtureCenter() *entity.Center {
@@ -40,7 +40,7 @@ func newFixtureCenter() *entity.Center {
 
 func Test_Create(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	tmpl := newFixtureCenter()
 	_, err := m.CreateCenter(tmpl.TenantID, tmpl.ExtID, tmpl.ExtName, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
 	assert.Nil(t, err)
@@ -49,7 +49,7 @@ func Test_Create(t *testing.T) {
 
 func Test_SearchAndFind(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	tmpl1 := newFixtureCenter()
 	tmpl2 := newFixtureCenter()
 	tmpl2.Name = "default2"
@@ -81,7 +81,7 @@ func Test_SearchAndFind(t *testing.T) {
 	})
 
 	t.Run("get", func(t *testing.T) {
-		saved, err := m.GetCenter(tID)
+		saved, err := m.GetCenter(tenantAlice, tID)
 		assert.Nil(t, err)
 		assert.Equal(t, tmpl1.TenantID, saved.TenantID)
 		assert.Equal(t, tmpl1.ExtID, saved.ExtID)
@@ -92,34 +92,135 @@ func Test_SearchAndFind(t *testing.T) {
 
 func Test_Update(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	tmpl := newFixtureCenter()
 	id, err := m.CreateCenter(tmpl.TenantID, tmpl.ExtID, tmpl.ExtName, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
 	assert.Nil(t, err)
 
-	saved, _ := m.GetCenter(id)
+	saved, _ := m.GetCenter(tenantAlice, id)
 	saved.Mode = entity.CenterOnline
 	assert.Nil(t, m.UpdateCenter(saved))
 
-	updated, err := m.GetCenter(id)
+	updated, err := m.GetCenter(tenantAlice, id)
 	assert.Nil(t, err)
 	assert.Equal(t, entity.CenterOnline, updated.Mode)
 }
 
 func TestDelete(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 
 	tmpl1 := newFixtureCenter()
 	tmpl2 := newFixtureCenter()
 	tmpl2.ExtID = bobExtID
 	t2ID, _ := m.CreateCenter(tmpl2.TenantID, tmpl2.ExtID, tmpl2.ExtName, tmpl2.Name, tmpl2.Mode, tmpl2.IsEnabled)
 
-	err := m.DeleteCenter(tmpl1.ID)
+	err := m.DeleteCenter(tenantAlice, tmpl1.ID)
 	assert.Equal(t, entity.ErrNotFound, err)
 
-	err = m.DeleteCenter(t2ID)
+	err = m.DeleteCenter(tenantAlice, t2ID)
 	assert.Nil(t, err)
-	_, err = m.GetCenter(t2ID)
+	_, err = m.GetCenter(tenantAlice, t2ID)
 	assert.Equal(t, entity.ErrNotFound, err)
 }
+
+func Test_OtherTenant(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, nil)
+	tmpl := newFixtureCenter()
+	id, err := m.CreateCenter(tmpl.TenantID, tmpl.ExtID, tmpl.ExtName, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
+	assert.Nil(t, err)
+
+	_, err = m.GetCenter(tenantBob, id)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	c, _ := m.GetCenter(tenantAlice, id)
+	other := *c
+	other.TenantID = tenantBob
+	other.Name = "Renamed"
+	assert.Equal(t, entity.ErrNotFound, m.UpdateCenter(&other))
+	assert.Equal(t, entity.ErrNotFound, m.DeleteCenter(tenantBob, id))
+
+	saved, err := m.GetCenter(tenantAlice, id)
+	assert.Nil(t, err)
+	assert.Equal(t, tmpl.Name, saved.Name)
+}
+
+func TestDelete_Pending(t *testing.T) {
+	repo := newInmem()
+	tb := tombstone.NewService(tombstone.NewInmem(), nil)
+	m := NewService(repo, tb)
+
+	tmpl := newFixtureCenter()
+	id, _ := m.CreateCenter(tmpl.TenantID, tmpl.ExtID, tmpl.ExtName, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
+
+	err := m.DeleteCenter(tenantAlice, id)
+	assert.Equal(t, entity.ErrDeletePending, err)
+	c, err := m.GetCenter(tenantAlice, id)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.SyncPending, c.Sync.Status)
+
+	tombstones, err := tb.ListPendingTombstones(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tombstones))
+	assert.Equal(t, entity.SyncObjectCenter, tombstones[0].Object)
+	assert.Equal(t, id, tombstones[0].EntityID)
+}
+
+func Test_Contacts(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, nil)
+	tmpl := newFixtureCenter()
+	centerID, _ := m.CreateCenter(tmpl.TenantID, tmpl.ExtID, tmpl.ExtName, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
+
+	c, err := m.GetCenter(tenantAlice, centerID)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(c.Contacts))
+
+	alice, err := m.CreateContact(tenantAlice, centerID, "Alice", "+1 650 555 0100", "", false)
+	assert.Nil(t, err)
+	bob, err := m.CreateContact(tenantAlice, centerID, "Bob", "", "bob@example.org", true)
+	assert.Nil(t, err)
+
+	// primary first
+	c, _ = m.GetCenter(tenantAlice, centerID)
+	assert.Equal(t, 2, len(c.Contacts))
+	assert.Equal(t, bob, c.Contacts[0].ID)
+	assert.True(t, c.Contacts[0].IsPrimary)
+
+	// a new primary replaces the primary
+	contact, _ := m.GetContact(tenantAlice, alice)
+	contact.IsPrimary = true
+	assert.Nil(t, m.UpdateContact(tenantAlice, contact))
+	contacts, _ := m.ListContacts(centerID)
+	assert.Equal(t, alice, contacts[0].ID)
+	assert.True(t, contacts[0].IsPrimary)
+	assert.False(t, contacts[1].IsPrimary)
+
+	_, err = m.CreateContact(tenantAlice, centerID, "Carol", "", "", false)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+	contact.Name = ""
+	assert.Equal(t, entity.ErrInvalidEntity, m.UpdateContact(tenantAlice, contact))
+
+	assert.Nil(t, m.DeleteContact(tenantAlice, bob))
+	_, err = m.GetContact(tenantAlice, bob)
+	assert.Equal(t, entity.ErrNotFound, err)
+	assert.Equal(t, entity.ErrNotFound, m.DeleteContact(tenantAlice, bob))
+
+	// the contacts of another tenant's center don't exist for the tenant
+	_, err = m.CreateContact(tenantBob, centerID, "Dave", "", "dave@example.org", false)
+	assert.Equal(t, entity.ErrNotFound, err)
+	_, err = m.GetContact(tenantBob, alice)
+	assert.Equal(t, entity.ErrNotFound, err)
+	dave := *contact
+	dave.Name = "Dave"
+	assert.Equal(t, entity.ErrNotFound, m.UpdateContact(tenantBob, &dave))
+	assert.Equal(t, entity.ErrNotFound, m.DeleteContact(tenantBob, alice))
+	contacts, _ = m.ListContacts(centerID)
+	assert.Equal(t, 1, len(contacts))
+
+	// deleted with the center
+	assert.Nil(t, m.DeleteCenter(tenantAlice, centerID))
+	contacts, _ = m.ListContacts(centerID)
+	assert.Equal(t, 0, len(contacts))
+}
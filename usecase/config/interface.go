@@ -0,0 +1,18 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package config
+
+import (
+	"sudhagar/glad/entity"
+)
+
+// UseCase interface
+type UseCase interface {
+	// GetConfig returns the config of the tenant; nil if version is the
+	// current version
+	GetConfig(tenantID entity.ID, version int64) (*entity.Config, error)
+}
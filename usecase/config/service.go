@@ -0,0 +1,78 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"hash/fnv"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/tenant"
+)
+
+// Service client config usecase
+type Service struct {
+	tenants   tenant.UseCase
+	timezones []string
+	endpoints []entity.ConfigEndpoint
+}
+
+// NewService create new service; the config of a tenant is assembled from
+// its settings, the enum lists of the entities, and the given timezones and
+// endpoints
+func NewService(tenants tenant.UseCase, timezones []string, endpoints []entity.ConfigEndpoint) *Service {
+	return &Service{
+		tenants:   tenants,
+		timezones: timezones,
+		endpoints: endpoints,
+	}
+}
+
+// GetConfig returns the config of the tenant; nil if version is the current
+// version
+func (s *Service) GetConfig(tenantID entity.ID, version int64) (*entity.Config, error) {
+	t, err := s.tenants.GetTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &entity.Config{
+		Tenant: entity.ConfigTenant{
+			ID:      t.ID,
+			Name:    t.Name,
+			Country: t.Country,
+		},
+		Timezones:      s.timezones,
+		CourseStatuses: entity.CourseStatuses,
+		CourseModes:    entity.CourseModes,
+		AccountTypes:   entity.AccountTypes,
+		ProductFormats: entity.ProductFormats,
+		Endpoints:      s.endpoints,
+	}
+	c.Version, err = configVersion(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Version == version {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// configVersion hashes the content of the config, so that the version
+// changes with any change of a tenant setting or of a list without being
+// bumped by hand
+func configVersion(c *entity.Config) (int64, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	// positive for the clients storing it in a signed integer
+	return int64(h.Sum64() >> 1), nil
+}
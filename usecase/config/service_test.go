@@ -0,0 +1,61 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"sudhagar/glad/entity"
+	tenantmock "sudhagar/glad/usecase/tenant/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice entity.ID = 13790492210917015554
+)
+
+func Test_GetConfig(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	tenants := tenantmock.NewMockUseCase(controller)
+	alice := &entity.Tenant{ID: tenantAlice, Name: "alice", Country: "USA"}
+	tenants.EXPECT().GetTenant(tenantAlice).Return(alice, nil).AnyTimes()
+	tenants.EXPECT().GetTenant(tenantAlice+1).Return(nil, entity.ErrNotFound)
+
+	endpoints, err := entity.ParseConfigEndpoints("auth=https://auth.example.org, logging=https://log.example.org")
+	assert.Nil(t, err)
+	m := NewService(tenants, []string{"EST", "PST"}, endpoints)
+
+	c, err := m.GetConfig(tenantAlice, 0)
+	assert.Nil(t, err)
+	assert.NotZero(t, c.Version)
+	assert.Equal(t, entity.ConfigTenant{ID: tenantAlice, Name: "alice", Country: "USA"}, c.Tenant)
+	assert.Equal(t, []string{"EST", "PST"}, c.Timezones)
+	assert.Equal(t, entity.CourseStatuses, c.CourseStatuses)
+	assert.Equal(t, entity.AccountTypes, c.AccountTypes)
+	assert.Equal(t, 2, len(c.Endpoints))
+
+	// current
+	current, err := m.GetConfig(tenantAlice, c.Version)
+	assert.Nil(t, err)
+	assert.Nil(t, current)
+
+	// a changed setting changes the version
+	alice.Country = "India"
+	changed, err := m.GetConfig(tenantAlice, c.Version)
+	assert.Nil(t, err)
+	assert.NotEqual(t, c.Version, changed.Version)
+	assert.Equal(t, "India", changed.Tenant.Country)
+
+	_, err = m.GetConfig(tenantAlice+1, 0)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	_, err = entity.ParseConfigEndpoints("ftp=ftp://example.org")
+	assert.NotNil(t, err)
+}
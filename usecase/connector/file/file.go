@@ -0,0 +1,194 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+// Package file is a connector of a drop directory. Pushed records are
+// written to out/<object>/<id>.json; records are pulled from the JSON and
+// CSV files dropped in in/<object>, in file name order.
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/connector"
+)
+
+// IDField field holding the id of a record in the files
+const IDField = "ID"
+
+// Connector drop directory connector
+type Connector struct {
+	dir string
+}
+
+// New create new connector of the directory
+func New(dir string) *Connector {
+	return &Connector{
+		dir: dir,
+	}
+}
+
+// System name of the system
+func (c *Connector) System() string {
+	return entity.ExternalSystemFile
+}
+
+// Authenticate checks that the directory is usable
+func (c *Connector) Authenticate(ctx context.Context) error {
+	info, err := os.Stat(c.dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", c.dir)
+	}
+	return os.MkdirAll(filepath.Join(c.dir, "out"), 0o755)
+}
+
+// Map maps an entity to its fields as encoded in JSON
+func (c *Connector) Map(object entity.SyncObject, v any) (*connector.Record, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := decode(data, &fields); err != nil {
+		return nil, fmt.Errorf("%s is not a JSON object: %w", object, err)
+	}
+	return &connector.Record{Object: object, Fields: fields}, nil
+}
+
+// Push writes a file per record; new records keep their local id
+func (c *Connector) Push(ctx context.Context, records []*connector.Record) ([]connector.PushResult, error) {
+	results := make([]connector.PushResult, len(records))
+	for i, r := range records {
+		extID := r.ExtID
+		if extID == "" {
+			extID = fmt.Sprint(r.Fields[IDField])
+		}
+		if extID == "" || extID == "<nil>" || strings.ContainsAny(extID, `/\`) {
+			results[i].Err = fmt.Errorf("invalid record id %q", extID)
+			continue
+		}
+
+		dir := filepath.Join(c.dir, "out", string(r.Object))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(r.Fields, "", "  ")
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, extID+".json"), data, 0o644); err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].ExtID = extID
+	}
+	return results, nil
+}
+
+// Pull reads the files dropped for the object with names after the cursor;
+// the cursor is the last file name read
+func (c *Connector) Pull(ctx context.Context, object entity.SyncObject, cursor string) ([]*connector.Record, string, error) {
+	dir := filepath.Join(c.dir, "in", string(object))
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, cursor, nil
+	}
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if !e.IsDir() && (ext == ".json" || ext == ".csv") && e.Name() > cursor {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var records []*connector.Record
+	for _, name := range names {
+		var rows []map[string]any
+		if filepath.Ext(name) == ".csv" {
+			rows, err = readCSV(filepath.Join(dir, name))
+		} else {
+			rows, err = readJSON(filepath.Join(dir, name))
+		}
+		if err != nil {
+			return nil, cursor, fmt.Errorf("unable to read %s: %w", name, err)
+		}
+		for _, row := range rows {
+			r := &connector.Record{Object: object, Fields: row}
+			if id, ok := row[IDField]; ok {
+				r.ExtID = fmt.Sprint(id)
+			}
+			records = append(records, r)
+		}
+		cursor = name
+	}
+	return records, cursor, nil
+}
+
+// readJSON reads a file of a record or a list of records
+func readJSON(path string) ([]map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]any
+	if err := decode(data, &rows); err == nil {
+		return rows, nil
+	}
+	var row map[string]any
+	if err := decode(data, &row); err != nil {
+		return nil, err
+	}
+	return []map[string]any{row}, nil
+}
+
+// decode decodes JSON keeping the numbers, e.g. ids, as written
+func decode(data []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// readCSV reads a file of records with a header row
+func readCSV(path string) ([]map[string]any, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	lines, err := csv.NewReader(f).ReadAll()
+	if err != nil || len(lines) == 0 {
+		return nil, err
+	}
+	rows := make([]map[string]any, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		row := make(map[string]any, len(line))
+		for i, h := range lines[0] {
+			if i < len(line) {
+				row[h] = line[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package connector
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// Inmem in memory repo
+type Inmem struct {
+	m map[entity.ID]*entity.ExternalRef
+}
+
+// NewInmem create new repository
+func NewInmem() *Inmem {
+	var m = map[entity.ID]*entity.ExternalRef{}
+	return &Inmem{
+		m: m,
+	}
+}
+
+// Create an external ref
+func (r *Inmem) Create(e *entity.ExternalRef) (entity.ID, error) {
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// GetByEntity gets the ref of an entity in a system
+func (r *Inmem) GetByEntity(system string, object entity.SyncObject, entityID entity.ID) (*entity.ExternalRef, error) {
+	for _, j := range r.m {
+		if j.System == system && j.Object == object && j.EntityID == entityID {
+			return j, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetByExtID gets the ref of a record of a system
+func (r *Inmem) GetByExtID(system string, object entity.SyncObject, extID string) (*entity.ExternalRef, error) {
+	for _, j := range r.m {
+		if j.System == system && j.Object == object && j.ExtID == extID {
+			return j, nil
+		}
+	}
+	return nil, nil
+}
+
+// List lists the refs of an entity
+func (r *Inmem) List(object entity.SyncObject, entityID entity.ID) ([]*entity.ExternalRef, error) {
+	var refs []*entity.ExternalRef
+	for _, j := range r.m {
+		if j.Object == object && j.EntityID == entityID {
+			refs = append(refs, j)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].System < refs[j].System })
+	return refs, nil
+}
+
+// Update an external ref
+func (r *Inmem) Update(e *entity.ExternalRef) error {
+	if r.m[e.ID] == nil {
+		return sql.ErrNoRows
+	}
+	e.UpdatedAt = time.Now()
+	r.m[e.ID] = e
+	return nil
+}
+
+// Delete an external ref
+func (r *Inmem) Delete(id entity.ID) error {
+	if r.m[id] == nil {
+		return sql.ErrNoRows
+	}
+	r.m[id] = nil
+	delete(r.m, id)
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package connector
+
+import (
+	"context"
+	"errors"
+
+	"sudhagar/glad/entity"
+)
+
+// ErrNotSupported the connector does not support the operation or object
+var ErrNotSupported = errors.New("not supported by the connector")
+
+// Record record of an external system
+type Record struct {
+	Object entity.SyncObject
+	// id in the external system; empty for records not there yet
+	ExtID     string
+	Operation entity.SyncOperation
+	// fields named as in the external system
+	Fields map[string]any
+}
+
+// PushResult outcome of a pushed record
+type PushResult struct {
+	// id in the external system; set for new records
+	ExtID string
+	Err   error
+}
+
+// Connector external system the entities are synced with
+type Connector interface {
+	// System name of the system, as stored in the external refs
+	System() string
+	Authenticate(ctx context.Context) error
+	// Map maps a local entity to the record of the system
+	Map(object entity.SyncObject, v any) (*Record, error)
+	// Push sends the records; returns the result of each in order
+	Push(ctx context.Context, records []*Record) ([]PushResult, error)
+	// Pull returns the records of the object changed after the cursor, and
+	// the cursor to pull from next time
+	Pull(ctx context.Context, object entity.SyncObject, cursor string) ([]*Record, string, error)
+}
+
+// Reader interface
+type Reader interface {
+	GetByEntity(system string, object entity.SyncObject, entityID entity.ID) (*entity.ExternalRef, error)
+	GetByExtID(system string, object entity.SyncObject, extID string) (*entity.ExternalRef, error)
+	List(object entity.SyncObject, entityID entity.ID) ([]*entity.ExternalRef, error)
+}
+
+// Writer external ref writer
+type Writer interface {
+	Create(e *entity.ExternalRef) (entity.ID, error)
+	Update(e *entity.ExternalRef) error
+	Delete(id entity.ID) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// PulledRecord pulled record and the local entity it is linked to
+type PulledRecord struct {
+	*Record
+	// IDInvalid if the record is not linked yet
+	EntityID entity.ID
+}
+
+// UseCase interface
+type UseCase interface {
+	// LinkEntity links the entity to its record in the system
+	LinkEntity(tenantID entity.ID, object entity.SyncObject, entityID entity.ID, system, extID string) (*entity.ExternalRef, error)
+	ListExternalRefs(object entity.SyncObject, entityID entity.ID) ([]*entity.ExternalRef, error)
+	UnlinkEntity(system string, object entity.SyncObject, entityID entity.ID) error
+	// Push pushes the entity through the connector and links it to the
+	// record it got
+	Push(ctx context.Context, c Connector, tenantID entity.ID, object entity.SyncObject, entityID entity.ID, v any) (*entity.ExternalRef, error)
+	// Pull pulls the changed records through the connector, resolving the
+	// entities they are linked to
+	Pull(ctx context.Context, c Connector, object entity.SyncObject, cursor string) ([]*PulledRecord, string, error)
+}
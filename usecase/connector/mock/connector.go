@@ -0,0 +1,454 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/connector/interface.go
+
+// Package mock_connector is a generated GoMock package.
+package mock_connector
+
+import (
+	context "context"
+	reflect "reflect"
+	entity "sudhagar/glad/entity"
+	connector "sudhagar/glad/usecase/connector"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConnector is a mock of Connector interface.
+type MockConnector struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectorMockRecorder
+}
+
+// MockConnectorMockRecorder is the mock recorder for MockConnector.
+type MockConnectorMockRecorder struct {
+	mock *MockConnector
+}
+
+// NewMockConnector creates a new mock instance.
+func NewMockConnector(ctrl *gomock.Controller) *MockConnector {
+	mock := &MockConnector{ctrl: ctrl}
+	mock.recorder = &MockConnectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnector) EXPECT() *MockConnectorMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockConnector) Authenticate(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockConnectorMockRecorder) Authenticate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockConnector)(nil).Authenticate), ctx)
+}
+
+// Map mocks base method.
+func (m *MockConnector) Map(object entity.SyncObject, v any) (*connector.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Map", object, v)
+	ret0, _ := ret[0].(*connector.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Map indicates an expected call of Map.
+func (mr *MockConnectorMockRecorder) Map(object, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Map", reflect.TypeOf((*MockConnector)(nil).Map), object, v)
+}
+
+// Pull mocks base method.
+func (m *MockConnector) Pull(ctx context.Context, object entity.SyncObject, cursor string) ([]*connector.Record, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", ctx, object, cursor)
+	ret0, _ := ret[0].([]*connector.Record)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockConnectorMockRecorder) Pull(ctx, object, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockConnector)(nil).Pull), ctx, object, cursor)
+}
+
+// Push mocks base method.
+func (m *MockConnector) Push(ctx context.Context, records []*connector.Record) ([]connector.PushResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", ctx, records)
+	ret0, _ := ret[0].([]connector.PushResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockConnectorMockRecorder) Push(ctx, records interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockConnector)(nil).Push), ctx, records)
+}
+
+// System mocks base method.
+func (m *MockConnector) System() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "System")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// System indicates an expected call of System.
+func (mr *MockConnectorMockRecorder) System() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "System", reflect.TypeOf((*MockConnector)(nil).System))
+}
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// GetByEntity mocks base method.
+func (m *MockReader) GetByEntity(system string, object entity.SyncObject, entityID entity.ID) (*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEntity", system, object, entityID)
+	ret0, _ := ret[0].(*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEntity indicates an expected call of GetByEntity.
+func (mr *MockReaderMockRecorder) GetByEntity(system, object, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEntity", reflect.TypeOf((*MockReader)(nil).GetByEntity), system, object, entityID)
+}
+
+// GetByExtID mocks base method.
+func (m *MockReader) GetByExtID(system string, object entity.SyncObject, extID string) (*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", system, object, extID)
+	ret0, _ := ret[0].(*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockReaderMockRecorder) GetByExtID(system, object, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockReader)(nil).GetByExtID), system, object, extID)
+}
+
+// List mocks base method.
+func (m *MockReader) List(object entity.SyncObject, entityID entity.ID) ([]*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", object, entityID)
+	ret0, _ := ret[0].([]*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockReaderMockRecorder) List(object, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), object, entityID)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWriter) Create(e *entity.ExternalRef) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWriter)(nil).Create), e)
+}
+
+// Delete mocks base method.
+func (m *MockWriter) Delete(id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), id)
+}
+
+// Update mocks base method.
+func (m *MockWriter) Update(e *entity.ExternalRef) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWriterMockRecorder) Update(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(e *entity.ExternalRef) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), e)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+}
+
+// GetByEntity mocks base method.
+func (m *MockRepository) GetByEntity(system string, object entity.SyncObject, entityID entity.ID) (*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEntity", system, object, entityID)
+	ret0, _ := ret[0].(*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEntity indicates an expected call of GetByEntity.
+func (mr *MockRepositoryMockRecorder) GetByEntity(system, object, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEntity", reflect.TypeOf((*MockRepository)(nil).GetByEntity), system, object, entityID)
+}
+
+// GetByExtID mocks base method.
+func (m *MockRepository) GetByExtID(system string, object entity.SyncObject, extID string) (*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", system, object, extID)
+	ret0, _ := ret[0].(*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockRepositoryMockRecorder) GetByExtID(system, object, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockRepository)(nil).GetByExtID), system, object, extID)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(object entity.SyncObject, entityID entity.ID) ([]*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", object, entityID)
+	ret0, _ := ret[0].([]*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(object, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), object, entityID)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(e *entity.ExternalRef) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// LinkEntity mocks base method.
+func (m *MockUseCase) LinkEntity(tenantID entity.ID, object entity.SyncObject, entityID entity.ID, system, extID string) (*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkEntity", tenantID, object, entityID, system, extID)
+	ret0, _ := ret[0].(*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkEntity indicates an expected call of LinkEntity.
+func (mr *MockUseCaseMockRecorder) LinkEntity(tenantID, object, entityID, system, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkEntity", reflect.TypeOf((*MockUseCase)(nil).LinkEntity), tenantID, object, entityID, system, extID)
+}
+
+// ListExternalRefs mocks base method.
+func (m *MockUseCase) ListExternalRefs(object entity.SyncObject, entityID entity.ID) ([]*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExternalRefs", object, entityID)
+	ret0, _ := ret[0].([]*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExternalRefs indicates an expected call of ListExternalRefs.
+func (mr *MockUseCaseMockRecorder) ListExternalRefs(object, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExternalRefs", reflect.TypeOf((*MockUseCase)(nil).ListExternalRefs), object, entityID)
+}
+
+// Pull mocks base method.
+func (m *MockUseCase) Pull(ctx context.Context, c connector.Connector, object entity.SyncObject, cursor string) ([]*connector.PulledRecord, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", ctx, c, object, cursor)
+	ret0, _ := ret[0].([]*connector.PulledRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockUseCaseMockRecorder) Pull(ctx, c, object, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockUseCase)(nil).Pull), ctx, c, object, cursor)
+}
+
+// Push mocks base method.
+func (m *MockUseCase) Push(ctx context.Context, c connector.Connector, tenantID entity.ID, object entity.SyncObject, entityID entity.ID, v any) (*entity.ExternalRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", ctx, c, tenantID, object, entityID, v)
+	ret0, _ := ret[0].(*entity.ExternalRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockUseCaseMockRecorder) Push(ctx, c, tenantID, object, entityID, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockUseCase)(nil).Push), ctx, c, tenantID, object, entityID, v)
+}
+
+// UnlinkEntity mocks base method.
+func (m *MockUseCase) UnlinkEntity(system string, object entity.SyncObject, entityID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkEntity", system, object, entityID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkEntity indicates an expected call of UnlinkEntity.
+func (mr *MockUseCaseMockRecorder) UnlinkEntity(system, object, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkEntity", reflect.TypeOf((*MockUseCase)(nil).UnlinkEntity), system, object, entityID)
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"sudhagar/glad/entity"
+)
+
+// Service connector usecase
+type Service struct {
+	repo Repository
+}
+
+// NewService create new service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// LinkEntity links the entity to its record in the system; an existing link
+// is moved to the new record
+func (s *Service) LinkEntity(tenantID entity.ID,
+	object entity.SyncObject,
+	entityID entity.ID,
+	system string,
+	extID string,
+) (*entity.ExternalRef, error) {
+	ref, err := s.repo.GetByEntity(system, object, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if ref != nil {
+		if ref.ExtID == extID {
+			return ref, nil
+		}
+		ref.ExtID = extID
+		return ref, s.repo.Update(ref)
+	}
+
+	ref, err = entity.NewExternalRef(tenantID, object, entityID, system, extID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.repo.Create(ref)
+	if err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// ListExternalRefs lists the refs of the entity
+func (s *Service) ListExternalRefs(object entity.SyncObject, entityID entity.ID) ([]*entity.ExternalRef, error) {
+	refs, err := s.repo.List(object, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return refs, nil
+}
+
+// UnlinkEntity removes the link of the entity to the system
+func (s *Service) UnlinkEntity(system string, object entity.SyncObject, entityID entity.ID) error {
+	ref, err := s.repo.GetByEntity(system, object, entityID)
+	if err != nil {
+		return err
+	}
+	if ref == nil {
+		return entity.ErrNotFound
+	}
+	return s.repo.Delete(ref.ID)
+}
+
+// Push pushes the entity through the connector; an entity already linked
+// to the system is updated, others are inserted and linked. Returns nil when
+// the system did not report the id of a new record.
+func (s *Service) Push(ctx context.Context,
+	c Connector,
+	tenantID entity.ID,
+	object entity.SyncObject,
+	entityID entity.ID,
+	v any,
+) (*entity.ExternalRef, error) {
+	record, err := c.Map(object, v)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := s.repo.GetByEntity(c.System(), object, entityID)
+	if err != nil {
+		return nil, err
+	}
+	// Note: an entity the system already knows but not yet linked is updated
+	// and linked to the id the connector mapped
+	if ref != nil {
+		record.ExtID = ref.ExtID
+	}
+	record.Operation = entity.SyncInsert
+	if record.ExtID != "" {
+		record.Operation = entity.SyncUpdate
+	}
+
+	results, err := c.Push(ctx, []*Record{record})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("%s returned %d results for 1 record", c.System(), len(results))
+	}
+	if results[0].Err != nil {
+		return nil, results[0].Err
+	}
+	// Note: systems may not report the id of new records; these are linked
+	// once the id is known
+	if results[0].ExtID == "" {
+		return ref, nil
+	}
+	return s.LinkEntity(tenantID, object, entityID, c.System(), results[0].ExtID)
+}
+
+// Pull pulls the records changed after the cursor
+func (s *Service) Pull(ctx context.Context,
+	c Connector,
+	object entity.SyncObject,
+	cursor string,
+) ([]*PulledRecord, string, error) {
+	records, next, err := c.Pull(ctx, object, cursor)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	pulled := make([]*PulledRecord, 0, len(records))
+	for _, r := range records {
+		p := &PulledRecord{Record: r}
+		if r.ExtID != "" {
+			ref, err := s.repo.GetByExtID(c.System(), object, r.ExtID)
+			if err != nil {
+				return nil, cursor, err
+			}
+			if ref != nil {
+				p.EntityID = ref.EntityID
+			}
+		}
+		pulled = append(pulled, p)
+	}
+	return pulled, next, nil
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package connector_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/connector"
+	"sudhagar/glad/usecase/connector/file"
+	mock "sudhagar/glad/usecase/connector/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantAlice entity.ID = 1
+
+func newFixtureCenter() *entity.Center {
+	return &entity.Center{
+		ID:       entity.NewID(),
+		TenantID: tenantAlice,
+		Name:     "Bangalore Ashram",
+	}
+}
+
+func Test_Push(t *testing.T) {
+	repo := connector.NewInmem()
+	m := connector.NewService(repo)
+	dir := t.TempDir()
+	c := file.New(dir)
+	assert.Nil(t, c.Authenticate(context.Background()))
+
+	center := newFixtureCenter()
+	ref, err := m.Push(context.Background(), c, tenantAlice, entity.SyncObjectCenter, center.ID, center)
+	assert.Nil(t, err)
+	assert.Equal(t, center.ID.String(), ref.ExtID)
+	assert.Equal(t, entity.ExternalSystemFile, ref.System)
+	_, err = os.Stat(filepath.Join(dir, "out", "center", ref.ExtID+".json"))
+	assert.Nil(t, err)
+
+	// pushed again as an update of the same record
+	center.Name = "Boone Center"
+	again, err := m.Push(context.Background(), c, tenantAlice, entity.SyncObjectCenter, center.ID, center)
+	assert.Nil(t, err)
+	assert.Equal(t, ref.ID, again.ID)
+
+	refs, err := m.ListExternalRefs(entity.SyncObjectCenter, center.ID)
+	assert.Nil(t, err)
+	assert.Len(t, refs, 1)
+
+	// an entity links to one record per system
+	_, err = m.LinkEntity(tenantAlice, entity.SyncObjectCenter, center.ID, entity.ExternalSystemSalesforce, "a0C000000000001")
+	assert.Nil(t, err)
+	refs, _ = m.ListExternalRefs(entity.SyncObjectCenter, center.ID)
+	assert.Len(t, refs, 2)
+
+	assert.Nil(t, m.UnlinkEntity(entity.ExternalSystemFile, entity.SyncObjectCenter, center.ID))
+	assert.Equal(t, entity.ErrNotFound, m.UnlinkEntity(entity.ExternalSystemFile, entity.SyncObjectCenter, center.ID))
+}
+
+func Test_PushFailed(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	c := mock.NewMockConnector(controller)
+	c.EXPECT().System().Return("crm").AnyTimes()
+	c.EXPECT().Map(entity.SyncObjectCenter, gomock.Any()).Return(&connector.Record{Object: entity.SyncObjectCenter}, nil)
+	c.EXPECT().Push(gomock.Any(), gomock.Any()).Return([]connector.PushResult{{Err: errors.New("rejected")}}, nil)
+
+	repo := connector.NewInmem()
+	m := connector.NewService(repo)
+	center := newFixtureCenter()
+	_, err := m.Push(context.Background(), c, tenantAlice, entity.SyncObjectCenter, center.ID, center)
+	assert.NotNil(t, err)
+	_, err = m.ListExternalRefs(entity.SyncObjectCenter, center.ID)
+	assert.Equal(t, entity.ErrNotFound, err)
+}
+
+func Test_PushMappedExtID(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	c := mock.NewMockConnector(controller)
+	c.EXPECT().System().Return("crm").AnyTimes()
+	c.EXPECT().Map(entity.SyncObjectCenter, gomock.Any()).Return(&connector.Record{Object: entity.SyncObjectCenter, ExtID: "c-1"}, nil)
+	c.EXPECT().Push(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, records []*connector.Record) ([]connector.PushResult, error) {
+		assert.Equal(t, entity.SyncUpdate, records[0].Operation)
+		return []connector.PushResult{{ExtID: records[0].ExtID}}, nil
+	})
+
+	// a record the system already has is updated and linked
+	m := connector.NewService(connector.NewInmem())
+	center := newFixtureCenter()
+	ref, err := m.Push(context.Background(), c, tenantAlice, entity.SyncObjectCenter, center.ID, center)
+	assert.Nil(t, err)
+	assert.Equal(t, "c-1", ref.ExtID)
+}
+
+func Test_Pull(t *testing.T) {
+	repo := connector.NewInmem()
+	m := connector.NewService(repo)
+	dir := t.TempDir()
+	c := file.New(dir)
+
+	in := filepath.Join(dir, "in", "center")
+	assert.Nil(t, os.MkdirAll(in, 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(in, "001.json"),
+		[]byte(`[{"ID": 11, "Name": "Boone Center"}, {"ID": 12, "Name": "Bangalore Ashram"}]`), 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(in, "002.csv"),
+		[]byte("ID,Name\n13,Montreal Center\n"), 0o644))
+
+	_, err := m.LinkEntity(tenantAlice, entity.SyncObjectCenter, 100, entity.ExternalSystemFile, "12")
+	assert.Nil(t, err)
+
+	records, cursor, err := m.Pull(context.Background(), c, entity.SyncObjectCenter, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "002.csv", cursor)
+	assert.Len(t, records, 3)
+	assert.Equal(t, "11", records[0].ExtID)
+	assert.Equal(t, entity.ID(entity.IDInvalid), records[0].EntityID)
+	assert.Equal(t, entity.ID(100), records[1].EntityID)
+	assert.Equal(t, "Montreal Center", records[2].Fields["Name"])
+
+	// nothing new after the cursor
+	records, _, err = m.Pull(context.Background(), c, entity.SyncObjectCenter, cursor)
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package control
+
+import (
+	"sort"
+
+	"sudhagar/glad/entity"
+)
+
+// Inmem in memory repo
+type Inmem struct {
+	controls map[string]*entity.SyncControl
+	held     map[entity.ID]*entity.InboxItem
+}
+
+// NewInmem create new repository
+func NewInmem() *Inmem {
+	return &Inmem{
+		controls: map[string]*entity.SyncControl{},
+		held:     map[entity.ID]*entity.InboxItem{},
+	}
+}
+
+func controlKey(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) string {
+	return tenantID.String() + "/" + string(object) + "/" + string(direction)
+}
+
+// GetControl gets the control of the scope
+func (r *Inmem) GetControl(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+) (*entity.SyncControl, error) {
+	c, ok := r.controls[controlKey(tenantID, object, direction)]
+	if !ok {
+		return nil, nil
+	}
+	copied := *c
+	return &copied, nil
+}
+
+// ListControls lists the controls
+func (r *Inmem) ListControls() ([]*entity.SyncControl, error) {
+	var controls []*entity.SyncControl
+	for _, c := range r.controls {
+		copied := *c
+		controls = append(controls, &copied)
+	}
+	sort.Slice(controls, func(i, j int) bool {
+		return controlKey(controls[i].TenantID, controls[i].Object, controls[i].Direction) <
+			controlKey(controls[j].TenantID, controls[j].Object, controls[j].Direction)
+	})
+	return controls, nil
+}
+
+// SaveControl creates or replaces a control
+func (r *Inmem) SaveControl(e *entity.SyncControl) error {
+	copied := *e
+	r.controls[controlKey(e.TenantID, e.Object, e.Direction)] = &copied
+	return nil
+}
+
+// DeleteControl deletes a control
+func (r *Inmem) DeleteControl(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+) error {
+	key := controlKey(tenantID, object, direction)
+	if r.controls[key] == nil {
+		return entity.ErrNotFound
+	}
+	delete(r.controls, key)
+	return nil
+}
+
+// ListHeld lists held records
+func (r *Inmem) ListHeld(tenantID entity.ID, object entity.SyncObject, limit int) ([]*entity.InboxItem, error) {
+	var items []*entity.InboxItem
+	for _, i := range r.held {
+		if (tenantID == entity.IDInvalid || i.TenantID == tenantID) &&
+			(object == entity.SyncObjectAll || i.Object == object) {
+			items = append(items, i)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// HeldStats gets the depth and the oldest held record
+func (r *Inmem) HeldStats() (*entity.QueueStats, error) {
+	stats := &entity.QueueStats{}
+	for _, i := range r.held {
+		stats.Depth++
+		if stats.Oldest == nil || i.CreatedAt.Before(*stats.Oldest) {
+			createdAt := i.CreatedAt
+			stats.Oldest = &createdAt
+		}
+	}
+	return stats, nil
+}
+
+// Hold a record
+func (r *Inmem) Hold(e *entity.InboxItem) (entity.ID, error) {
+	r.held[e.ID] = e
+	return e.ID, nil
+}
+
+// DeleteHeld deletes a held record
+func (r *Inmem) DeleteHeld(id entity.ID) error {
+	if r.held[id] == nil {
+		return entity.ErrNotFound
+	}
+	delete(r.held, id)
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package control
+
+import (
+	"errors"
+
+	"sudhagar/glad/entity"
+)
+
+// ErrPaused the sync of the record is paused; it is left queued
+var ErrPaused = errors.New("sync is paused")
+
+// Reader interface
+type Reader interface {
+	// GetControl returns nil if the scope has no control
+	GetControl(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) (*entity.SyncControl, error)
+	ListControls() ([]*entity.SyncControl, error)
+	// ListHeld lists held inbound records, oldest first. IDInvalid and
+	// SyncObjectAll match every tenant and object.
+	ListHeld(tenantID entity.ID, object entity.SyncObject, limit int) ([]*entity.InboxItem, error)
+	HeldStats() (*entity.QueueStats, error)
+}
+
+// Writer control writer
+type Writer interface {
+	// SaveControl creates or replaces the control of its scope
+	SaveControl(e *entity.SyncControl) error
+	DeleteControl(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error
+	Hold(e *entity.InboxItem) (entity.ID, error)
+	DeleteHeld(id entity.ID) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	// Pause stops the sync of the scope; the work stays queued
+	Pause(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection, reason string) (*entity.SyncControl, error)
+	// Resume lifts the pause of the scope; its throttle is kept
+	Resume(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error
+	// Throttle limits the records synced per run; 0 lifts the limit
+	Throttle(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection, limit int) (*entity.SyncControl, error)
+	ListControls() (entity.SyncControls, error)
+	// ControlFor merges the controls in effect for the object of the tenant
+	ControlFor(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) (entity.SyncControl, error)
+	// HoldInbound queues an inbound record while its sync is paused
+	HoldInbound(tenantID entity.ID, object entity.SyncObject, record []byte) (entity.ID, error)
+	ListHeld(tenantID entity.ID, object entity.SyncObject, limit int) ([]*entity.InboxItem, error)
+	GetHeldStats() (*entity.QueueStats, error)
+	// ReleaseHeld removes a held record once it is applied
+	ReleaseHeld(id entity.ID) error
+}
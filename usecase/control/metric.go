@@ -0,0 +1,42 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package control
+
+import (
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/metric"
+)
+
+// SaveMetrics records the controls in effect and the held inbound records
+func SaveMetrics(s UseCase, m metric.SyncService) error {
+	controls, err := s.ListControls()
+	if err != nil {
+		return err
+	}
+	recorded := make([]*metric.Control, 0, len(controls))
+	for _, c := range controls {
+		tenant := "*"
+		if c.TenantID != entity.IDInvalid {
+			tenant = c.TenantID.String()
+		}
+		recorded = append(recorded, &metric.Control{
+			Tenant:    tenant,
+			Object:    string(c.Object),
+			Direction: string(c.Direction),
+			Paused:    c.Paused,
+			Throttle:  c.Throttle,
+		})
+	}
+	m.SaveControls(recorded)
+
+	stats, err := s.GetHeldStats()
+	if err != nil {
+		return err
+	}
+	m.SaveQueue(metric.NewQueue("inbox", stats.Depth, stats.Oldest))
+	return nil
+}
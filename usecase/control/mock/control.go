@@ -0,0 +1,471 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/control/interface.go
+
+// Package mock_control is a generated GoMock package.
+package mock_control
+
+import (
+	reflect "reflect"
+	entity "sudhagar/glad/entity"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// GetControl mocks base method.
+func (m *MockReader) GetControl(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) (*entity.SyncControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetControl", tenantID, object, direction)
+	ret0, _ := ret[0].(*entity.SyncControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetControl indicates an expected call of GetControl.
+func (mr *MockReaderMockRecorder) GetControl(tenantID, object, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControl", reflect.TypeOf((*MockReader)(nil).GetControl), tenantID, object, direction)
+}
+
+// HeldStats mocks base method.
+func (m *MockReader) HeldStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeldStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeldStats indicates an expected call of HeldStats.
+func (mr *MockReaderMockRecorder) HeldStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeldStats", reflect.TypeOf((*MockReader)(nil).HeldStats))
+}
+
+// ListControls mocks base method.
+func (m *MockReader) ListControls() ([]*entity.SyncControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListControls")
+	ret0, _ := ret[0].([]*entity.SyncControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListControls indicates an expected call of ListControls.
+func (mr *MockReaderMockRecorder) ListControls() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListControls", reflect.TypeOf((*MockReader)(nil).ListControls))
+}
+
+// ListHeld mocks base method.
+func (m *MockReader) ListHeld(tenantID entity.ID, object entity.SyncObject, limit int) ([]*entity.InboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHeld", tenantID, object, limit)
+	ret0, _ := ret[0].([]*entity.InboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHeld indicates an expected call of ListHeld.
+func (mr *MockReaderMockRecorder) ListHeld(tenantID, object, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHeld", reflect.TypeOf((*MockReader)(nil).ListHeld), tenantID, object, limit)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// DeleteControl mocks base method.
+func (m *MockWriter) DeleteControl(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteControl", tenantID, object, direction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteControl indicates an expected call of DeleteControl.
+func (mr *MockWriterMockRecorder) DeleteControl(tenantID, object, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteControl", reflect.TypeOf((*MockWriter)(nil).DeleteControl), tenantID, object, direction)
+}
+
+// DeleteHeld mocks base method.
+func (m *MockWriter) DeleteHeld(id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHeld", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHeld indicates an expected call of DeleteHeld.
+func (mr *MockWriterMockRecorder) DeleteHeld(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHeld", reflect.TypeOf((*MockWriter)(nil).DeleteHeld), id)
+}
+
+// Hold mocks base method.
+func (m *MockWriter) Hold(e *entity.InboxItem) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockWriterMockRecorder) Hold(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockWriter)(nil).Hold), e)
+}
+
+// SaveControl mocks base method.
+func (m *MockWriter) SaveControl(e *entity.SyncControl) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveControl", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveControl indicates an expected call of SaveControl.
+func (mr *MockWriterMockRecorder) SaveControl(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveControl", reflect.TypeOf((*MockWriter)(nil).SaveControl), e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteControl mocks base method.
+func (m *MockRepository) DeleteControl(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteControl", tenantID, object, direction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteControl indicates an expected call of DeleteControl.
+func (mr *MockRepositoryMockRecorder) DeleteControl(tenantID, object, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteControl", reflect.TypeOf((*MockRepository)(nil).DeleteControl), tenantID, object, direction)
+}
+
+// DeleteHeld mocks base method.
+func (m *MockRepository) DeleteHeld(id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHeld", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHeld indicates an expected call of DeleteHeld.
+func (mr *MockRepositoryMockRecorder) DeleteHeld(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHeld", reflect.TypeOf((*MockRepository)(nil).DeleteHeld), id)
+}
+
+// GetControl mocks base method.
+func (m *MockRepository) GetControl(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) (*entity.SyncControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetControl", tenantID, object, direction)
+	ret0, _ := ret[0].(*entity.SyncControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetControl indicates an expected call of GetControl.
+func (mr *MockRepositoryMockRecorder) GetControl(tenantID, object, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControl", reflect.TypeOf((*MockRepository)(nil).GetControl), tenantID, object, direction)
+}
+
+// HeldStats mocks base method.
+func (m *MockRepository) HeldStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeldStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeldStats indicates an expected call of HeldStats.
+func (mr *MockRepositoryMockRecorder) HeldStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeldStats", reflect.TypeOf((*MockRepository)(nil).HeldStats))
+}
+
+// Hold mocks base method.
+func (m *MockRepository) Hold(e *entity.InboxItem) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockRepositoryMockRecorder) Hold(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockRepository)(nil).Hold), e)
+}
+
+// ListControls mocks base method.
+func (m *MockRepository) ListControls() ([]*entity.SyncControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListControls")
+	ret0, _ := ret[0].([]*entity.SyncControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListControls indicates an expected call of ListControls.
+func (mr *MockRepositoryMockRecorder) ListControls() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListControls", reflect.TypeOf((*MockRepository)(nil).ListControls))
+}
+
+// ListHeld mocks base method.
+func (m *MockRepository) ListHeld(tenantID entity.ID, object entity.SyncObject, limit int) ([]*entity.InboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHeld", tenantID, object, limit)
+	ret0, _ := ret[0].([]*entity.InboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHeld indicates an expected call of ListHeld.
+func (mr *MockRepositoryMockRecorder) ListHeld(tenantID, object, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHeld", reflect.TypeOf((*MockRepository)(nil).ListHeld), tenantID, object, limit)
+}
+
+// SaveControl mocks base method.
+func (m *MockRepository) SaveControl(e *entity.SyncControl) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveControl", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveControl indicates an expected call of SaveControl.
+func (mr *MockRepositoryMockRecorder) SaveControl(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveControl", reflect.TypeOf((*MockRepository)(nil).SaveControl), e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ControlFor mocks base method.
+func (m *MockUseCase) ControlFor(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) (entity.SyncControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlFor", tenantID, object, direction)
+	ret0, _ := ret[0].(entity.SyncControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ControlFor indicates an expected call of ControlFor.
+func (mr *MockUseCaseMockRecorder) ControlFor(tenantID, object, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlFor", reflect.TypeOf((*MockUseCase)(nil).ControlFor), tenantID, object, direction)
+}
+
+// GetHeldStats mocks base method.
+func (m *MockUseCase) GetHeldStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeldStats indicates an expected call of GetHeldStats.
+func (mr *MockUseCaseMockRecorder) GetHeldStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldStats", reflect.TypeOf((*MockUseCase)(nil).GetHeldStats))
+}
+
+// HoldInbound mocks base method.
+func (m *MockUseCase) HoldInbound(tenantID entity.ID, object entity.SyncObject, record []byte) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldInbound", tenantID, object, record)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldInbound indicates an expected call of HoldInbound.
+func (mr *MockUseCaseMockRecorder) HoldInbound(tenantID, object, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldInbound", reflect.TypeOf((*MockUseCase)(nil).HoldInbound), tenantID, object, record)
+}
+
+// ListControls mocks base method.
+func (m *MockUseCase) ListControls() (entity.SyncControls, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListControls")
+	ret0, _ := ret[0].(entity.SyncControls)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListControls indicates an expected call of ListControls.
+func (mr *MockUseCaseMockRecorder) ListControls() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListControls", reflect.TypeOf((*MockUseCase)(nil).ListControls))
+}
+
+// ListHeld mocks base method.
+func (m *MockUseCase) ListHeld(tenantID entity.ID, object entity.SyncObject, limit int) ([]*entity.InboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHeld", tenantID, object, limit)
+	ret0, _ := ret[0].([]*entity.InboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHeld indicates an expected call of ListHeld.
+func (mr *MockUseCaseMockRecorder) ListHeld(tenantID, object, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHeld", reflect.TypeOf((*MockUseCase)(nil).ListHeld), tenantID, object, limit)
+}
+
+// Pause mocks base method.
+func (m *MockUseCase) Pause(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection, reason string) (*entity.SyncControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", tenantID, object, direction, reason)
+	ret0, _ := ret[0].(*entity.SyncControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockUseCaseMockRecorder) Pause(tenantID, object, direction, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockUseCase)(nil).Pause), tenantID, object, direction, reason)
+}
+
+// ReleaseHeld mocks base method.
+func (m *MockUseCase) ReleaseHeld(id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHeld", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseHeld indicates an expected call of ReleaseHeld.
+func (mr *MockUseCaseMockRecorder) ReleaseHeld(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHeld", reflect.TypeOf((*MockUseCase)(nil).ReleaseHeld), id)
+}
+
+// Resume mocks base method.
+func (m *MockUseCase) Resume(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", tenantID, object, direction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockUseCaseMockRecorder) Resume(tenantID, object, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockUseCase)(nil).Resume), tenantID, object, direction)
+}
+
+// Throttle mocks base method.
+func (m *MockUseCase) Throttle(tenantID entity.ID, object entity.SyncObject, direction entity.SyncDirection, limit int) (*entity.SyncControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Throttle", tenantID, object, direction, limit)
+	ret0, _ := ret[0].(*entity.SyncControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Throttle indicates an expected call of Throttle.
+func (mr *MockUseCaseMockRecorder) Throttle(tenantID, object, direction, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Throttle", reflect.TypeOf((*MockUseCase)(nil).Throttle), tenantID, object, direction, limit)
+}
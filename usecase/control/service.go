@@ -0,0 +1,160 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package control
+
+import (
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// Service sync control usecase
+type Service struct {
+	repo Repository
+}
+
+// NewService create new service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// Pause pauses the sync of the scope. IDInvalid and SyncObjectAll pause
+// every tenant and object.
+func (s *Service) Pause(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+	reason string,
+) (*entity.SyncControl, error) {
+	return s.update(tenantID, object, direction, func(c *entity.SyncControl) {
+		c.Paused = true
+		c.Reason = reason
+	})
+}
+
+// Resume resumes the sync of the scope. Controls of other scopes, e.g. a
+// pause of all the objects of the tenant, still apply.
+func (s *Service) Resume(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+) error {
+	c, err := s.repo.GetControl(tenantID, object, direction)
+	if err != nil {
+		return err
+	}
+	if c == nil || !c.Paused {
+		return entity.ErrNotFound
+	}
+	if c.Throttle == 0 {
+		return s.repo.DeleteControl(tenantID, object, direction)
+	}
+
+	c.Paused = false
+	c.Reason = ""
+	c.UpdatedAt = time.Now()
+	return s.repo.SaveControl(c)
+}
+
+// Throttle limits the records synced per run of the scope; 0 lifts the limit
+func (s *Service) Throttle(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+	limit int,
+) (*entity.SyncControl, error) {
+	if limit != 0 {
+		return s.update(tenantID, object, direction, func(c *entity.SyncControl) {
+			c.Throttle = limit
+		})
+	}
+
+	c, err := s.repo.GetControl(tenantID, object, direction)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || c.Throttle == 0 {
+		return nil, entity.ErrNotFound
+	}
+	c.Throttle = 0
+	c.UpdatedAt = time.Now()
+	if !c.Paused {
+		return c, s.repo.DeleteControl(tenantID, object, direction)
+	}
+	return c, s.repo.SaveControl(c)
+}
+
+// update applies set to the control of the scope, creating it if needed
+func (s *Service) update(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+	set func(c *entity.SyncControl),
+) (*entity.SyncControl, error) {
+	c, err := s.repo.GetControl(tenantID, object, direction)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		if c, err = entity.NewSyncControl(tenantID, object, direction, false, 0, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	set(c)
+	c.UpdatedAt = time.Now()
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, s.repo.SaveControl(c)
+}
+
+// ListControls lists the controls in effect
+func (s *Service) ListControls() (entity.SyncControls, error) {
+	return s.repo.ListControls()
+}
+
+// ControlFor merges the controls in effect for the object of the tenant
+func (s *Service) ControlFor(tenantID entity.ID,
+	object entity.SyncObject,
+	direction entity.SyncDirection,
+) (entity.SyncControl, error) {
+	controls, err := s.ListControls()
+	if err != nil {
+		return entity.SyncControl{}, err
+	}
+	return controls.For(tenantID, object, direction), nil
+}
+
+// HoldInbound holds an inbound record until its sync is resumed
+func (s *Service) HoldInbound(tenantID entity.ID, object entity.SyncObject, record []byte) (entity.ID, error) {
+	i, err := entity.NewInboxItem(tenantID, object, record)
+	if err != nil {
+		return entity.IDInvalid, err
+	}
+	return s.repo.Hold(i)
+}
+
+// ListHeld lists the held inbound records of the scope, oldest first
+func (s *Service) ListHeld(tenantID entity.ID, object entity.SyncObject, limit int) ([]*entity.InboxItem, error) {
+	items, err := s.repo.ListHeld(tenantID, object, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return items, nil
+}
+
+// GetHeldStats gets the depth and the oldest item of the held records
+func (s *Service) GetHeldStats() (*entity.QueueStats, error) {
+	return s.repo.HeldStats()
+}
+
+// ReleaseHeld removes a held record once it is applied
+func (s *Service) ReleaseHeld(id entity.ID) error {
+	return s.repo.DeleteHeld(id)
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package control
+
+import (
+	"testing"
+
+	"sudhagar/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice entity.ID = 13790492210917015554
+	tenantBob   entity.ID = 13790492210917015555
+)
+
+func Test_PauseAndResume(t *testing.T) {
+	m := NewService(NewInmem())
+
+	c, err := m.Pause(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound, "data fix")
+	assert.Nil(t, err)
+	assert.True(t, c.Paused)
+
+	ctl, err := m.ControlFor(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound)
+	assert.Nil(t, err)
+	assert.True(t, ctl.Paused)
+	assert.Equal(t, "data fix", ctl.Reason)
+
+	// other objects, directions and tenants are not paused
+	ctl, _ = m.ControlFor(tenantAlice, entity.SyncObjectCenter, entity.SyncOutbound)
+	assert.False(t, ctl.Paused)
+	ctl, _ = m.ControlFor(tenantAlice, entity.SyncObjectCourse, entity.SyncInbound)
+	assert.False(t, ctl.Paused)
+	ctl, _ = m.ControlFor(tenantBob, entity.SyncObjectCourse, entity.SyncOutbound)
+	assert.False(t, ctl.Paused)
+
+	err = m.Resume(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound)
+	assert.Nil(t, err)
+	ctl, _ = m.ControlFor(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound)
+	assert.False(t, ctl.Paused)
+	controls, _ := m.ListControls()
+	assert.Empty(t, controls)
+
+	err = m.Resume(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	_, err = m.Pause(tenantAlice, entity.SyncObjectCourse, "sideways", "")
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+}
+
+func Test_PauseAll(t *testing.T) {
+	m := NewService(NewInmem())
+
+	// Salesforce maintenance window
+	_, err := m.Pause(entity.IDInvalid, entity.SyncObjectAll, entity.SyncInbound, "maintenance")
+	assert.Nil(t, err)
+	_, err = m.Pause(tenantAlice, entity.SyncObjectCourse, entity.SyncInbound, "data fix")
+	assert.Nil(t, err)
+
+	ctl, _ := m.ControlFor(tenantBob, entity.SyncObjectAccount, entity.SyncInbound)
+	assert.True(t, ctl.Paused)
+
+	// still paused for the course of alice
+	err = m.Resume(entity.IDInvalid, entity.SyncObjectAll, entity.SyncInbound)
+	assert.Nil(t, err)
+	ctl, _ = m.ControlFor(tenantBob, entity.SyncObjectAccount, entity.SyncInbound)
+	assert.False(t, ctl.Paused)
+	ctl, _ = m.ControlFor(tenantAlice, entity.SyncObjectCourse, entity.SyncInbound)
+	assert.True(t, ctl.Paused)
+}
+
+func Test_Throttle(t *testing.T) {
+	m := NewService(NewInmem())
+
+	_, err := m.Throttle(tenantAlice, entity.SyncObjectAll, entity.SyncOutbound, 100)
+	assert.Nil(t, err)
+	_, err = m.Throttle(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound, 10)
+	assert.Nil(t, err)
+	ctl, _ := m.ControlFor(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound)
+	assert.Equal(t, 10, ctl.Throttle)
+	ctl, _ = m.ControlFor(tenantAlice, entity.SyncObjectCenter, entity.SyncOutbound)
+	assert.Equal(t, 100, ctl.Throttle)
+
+	// the throttle is kept when resumed
+	_, err = m.Pause(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound, "")
+	assert.Nil(t, err)
+	err = m.Resume(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound)
+	assert.Nil(t, err)
+	ctl, _ = m.ControlFor(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound)
+	assert.False(t, ctl.Paused)
+	assert.Equal(t, 10, ctl.Throttle)
+
+	_, err = m.Throttle(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound, 0)
+	assert.Nil(t, err)
+	_, err = m.Throttle(tenantAlice, entity.SyncObjectAll, entity.SyncOutbound, 0)
+	assert.Nil(t, err)
+	controls, _ := m.ListControls()
+	assert.Empty(t, controls)
+
+	_, err = m.Throttle(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound, 0)
+	assert.Equal(t, entity.ErrNotFound, err)
+	_, err = m.Throttle(tenantAlice, entity.SyncObjectCourse, entity.SyncOutbound, -1)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+}
+
+func Test_HoldInbound(t *testing.T) {
+	m := NewService(NewInmem())
+
+	first, err := m.HoldInbound(tenantAlice, entity.SyncObjectCourse, []byte(`{"value": {"Name": "first"}}`))
+	assert.Nil(t, err)
+	_, err = m.HoldInbound(tenantAlice, entity.SyncObjectCourse, []byte(`{"value": {"Name": "second"}}`))
+	assert.Nil(t, err)
+	_, err = m.HoldInbound(tenantBob, entity.SyncObjectCenter, []byte(`{}`))
+	assert.Nil(t, err)
+	_, err = m.HoldInbound(tenantBob, entity.SyncObjectCenter, nil)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+
+	items, err := m.ListHeld(tenantAlice, entity.SyncObjectCourse, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(items))
+	assert.Equal(t, first, items[0].ID)
+
+	items, err = m.ListHeld(entity.IDInvalid, entity.SyncObjectAll, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(items))
+
+	stats, _ := m.GetHeldStats()
+	assert.Equal(t, 3, stats.Depth)
+
+	err = m.ReleaseHeld(first)
+	assert.Nil(t, err)
+	items, _ = m.ListHeld(tenantAlice, entity.SyncObjectAll, 0)
+	assert.Equal(t, 1, len(items))
+
+	_, err = m.ListHeld(tenantBob, entity.SyncObjectCourse, 0)
+	assert.Equal(t, entity.ErrNotFound, err)
+}
@@ -7,6 +7,7 @@
 package course
 
 import (
+	"slices"
 	"strings"
 
 	"sudhagar/glad/entity"
@@ -14,14 +15,16 @@ import (
 
 // inmem in memory repo
 type inmem struct {
-	m map[entity.ID]*entity.Course
+	m        map[entity.ID]*entity.Course
+	accounts map[entity.ID]*entity.CourseAccounts
 }
 
 // newInmem create new repository
 func newInmem() *inmem {
 	var m = map[entity.ID]*entity.Course{}
 	return &inmem{
-		m: m,
+		m:        m,
+		accounts: map[entity.ID]*entity.CourseAccounts{},
 	}
 }
 
@@ -32,8 +35,8 @@ func (r *inmem) Create(e *entity.Course) (entity.ID, error) {
 }
 
 // Get a course
-func (r *inmem) Get(id entity.ID) (*entity.Course, error) {
-	if r.m[id] == nil {
+func (r *inmem) Get(tenantID, id entity.ID) (*entity.Course, error) {
+	if r.m[id] == nil || r.m[id].TenantID != tenantID {
 		return nil, entity.ErrNotFound
 	}
 	return r.m[id], nil
@@ -41,7 +44,7 @@ func (r *inmem) Get(id entity.ID) (*entity.Course, error) {
 
 // Update a course
 func (r *inmem) Update(e *entity.Course) error {
-	_, err := r.Get(e.ID)
+	_, err := r.Get(e.TenantID, e.ID)
 	if err != nil {
 		return err
 	}
@@ -87,11 +90,57 @@ func (r *inmem) List(tenantID entity.ID, page, limit int) ([]*entity.Course, err
 	return courses, nil
 }
 
-// Delete a course
-func (r *inmem) Delete(id entity.ID) error {
+// ListBySyncStatus lists courses with the given sync status
+func (r *inmem) ListBySyncStatus(tenantID entity.ID,
+	status entity.SyncStatus, page, limit int,
+) ([]*entity.Course, error) {
+	var courses []*entity.Course
+	for _, j := range r.m {
+		if j.TenantID == tenantID && j.Sync.Status == status {
+			courses = append(courses, j)
+		}
+	}
+
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(courses) {
+			return []*entity.Course{}, nil
+		}
+		if end > len(courses) {
+			end = len(courses)
+		}
+		return courses[start:end], nil
+	}
+
+	return courses, nil
+}
+
+// CountBySyncStatus counts the courses of the tenant per sync status
+func (r *inmem) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	counts := map[entity.SyncStatus]int{}
+	for _, j := range r.m {
+		if j.TenantID == tenantID {
+			counts[j.Sync.Status]++
+		}
+	}
+	return counts, nil
+}
+
+// UpdateSyncState updates the sync meta data of a course
+func (r *inmem) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
 	if r.m[id] == nil {
 		return entity.ErrNotFound
 	}
+	r.m[id].Sync = *s
+	return nil
+}
+
+// Delete a course
+func (r *inmem) Delete(tenantID, id entity.ID) error {
+	if r.m[id] == nil || r.m[id].TenantID != tenantID {
+		return entity.ErrNotFound
+	}
 	r.m[id] = nil
 	delete(r.m, id)
 	return nil
@@ -107,3 +156,71 @@ func (r *inmem) GetCount(tenantID entity.ID) (int, error) {
 	}
 	return count, nil
 }
+
+// GetAccounts gets the accounts of a course
+func (r *inmem) GetAccounts(courseID entity.ID) (*entity.CourseAccounts, error) {
+	return r.accounts[courseID], nil
+}
+
+// UpdateAccounts replaces the accounts of a course
+func (r *inmem) UpdateAccounts(a *entity.CourseAccounts) error {
+	if r.m[a.CourseID] == nil {
+		return entity.ErrNotFound
+	}
+	r.accounts[a.CourseID] = a
+	return nil
+}
+
+// FindByAccounts lists the courses of the accounts; notify is not kept in
+// memory
+func (r *inmem) FindByAccounts(tenantID entity.ID,
+	accountIDs []entity.ID,
+	roles []entity.CourseRole,
+	statuses []entity.CourseStatus,
+	page, limit int,
+) ([]*entity.Course, error) {
+	var courses []*entity.Course
+	for id, c := range r.m {
+		a := r.accounts[id]
+		if c.TenantID != tenantID || a == nil {
+			continue
+		}
+		if len(statuses) > 0 && !slices.Contains(statuses, c.Status) {
+			continue
+		}
+
+		var ids []entity.ID
+		for _, role := range roles {
+			switch role {
+			case entity.CourseRoleTeacher:
+				for _, t := range a.Teachers {
+					ids = append(ids, t.ID)
+				}
+			case entity.CourseRoleOrganizer:
+				ids = append(ids, a.Organizers...)
+			case entity.CourseRoleContact:
+				ids = append(ids, a.Contacts...)
+			}
+		}
+		for _, accountID := range accountIDs {
+			if slices.Contains(ids, accountID) {
+				courses = append(courses, c)
+				break
+			}
+		}
+	}
+
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(courses) {
+			return []*entity.Course{}, nil
+		}
+		if end > len(courses) {
+			end = len(courses)
+		}
+		return courses[start:end], nil
+	}
+
+	return courses, nil
+}
@@ -12,17 +12,36 @@ import (
 
 // Reader interface
 type Reader interface {
-	Get(id entity.ID) (*entity.Course, error)
+	// Get gets a course of the tenant
+	Get(tenantID, id entity.ID) (*entity.Course, error)
 	Search(tenantID entity.ID, query string, page, limit int) ([]*entity.Course, error)
 	List(tenantID entity.ID, page, limit int) ([]*entity.Course, error)
 	GetCount(id entity.ID) (int, error)
+	ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Course, error)
+	// CountBySyncStatus counts the courses of the tenant per sync status
+	CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error)
+	GetAccounts(courseID entity.ID) (*entity.CourseAccounts, error)
+	// FindByAccounts lists the courses in which any of the accounts has any
+	// of the roles; the courses in any of the statuses if statuses is set
+	FindByAccounts(tenantID entity.ID,
+		accountIDs []entity.ID,
+		roles []entity.CourseRole,
+		statuses []entity.CourseStatus,
+		page, limit int,
+	) ([]*entity.Course, error)
 }
 
 // Writer course writer
 type Writer interface {
 	Create(e *entity.Course) (entity.ID, error)
+	// Update updates the course if it is of its tenant
 	Update(e *entity.Course) error
-	Delete(id entity.ID) error
+	Delete(tenantID, id entity.ID) error
+	// UpdateSyncState is not scoped by tenant: only the sync paths call it,
+	// with the id of a record they read, and it is not in the UseCase
+	UpdateSyncState(id entity.ID, s *entity.SyncState) error
+	// UpdateAccounts replaces the teachers, organizers and contacts of a course
+	UpdateAccounts(a *entity.CourseAccounts) error
 }
 
 // Repository interface
@@ -33,9 +52,17 @@ type Repository interface {
 
 // UseCase interface
 type UseCase interface {
-	GetCourse(id entity.ID) (*entity.Course, error)
+	GetCourse(tenantID, id entity.ID) (*entity.Course, error)
 	SearchCourses(tenantID entity.ID, query string, page, limit int) ([]*entity.Course, error)
 	ListCourses(tenantID entity.ID, page, limit int) ([]*entity.Course, error)
+	ListCoursesBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Course, error)
+	CountCoursesBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error)
+	FindCoursesByUser(tenantID entity.ID,
+		userIDs []entity.ID,
+		roles []entity.CourseRole,
+		statuses []entity.CourseStatus,
+		page, limit int,
+	) ([]*entity.Course, error)
 	CreateCourse(tenantID entity.ID,
 		extID *string,
 		centerID entity.ID,
@@ -46,7 +73,13 @@ type UseCase interface {
 		mode entity.CourseMode,
 		maxAttendees, numAttendees int32,
 	) (entity.ID, error)
-	UpdateCourse(e *entity.Course) error
-	DeleteCourse(id entity.ID) error
+	// UpdateCourse updates the course; a are the accounts to be saved with
+	// it, nil if the saved ones are kept
+	UpdateCourse(e *entity.Course, a *entity.CourseAccounts) error
+	DeleteCourse(tenantID, id entity.ID) error
 	GetCount(id entity.ID) int
+	GetCourseAccounts(courseID entity.ID) (*entity.CourseAccounts, error)
+	CheckCourseAccounts(c *entity.Course, a *entity.CourseAccounts) error
+	UpdateCourseAccounts(c *entity.Course, a *entity.CourseAccounts) error
+	CheckCourseEditor(a *entity.Account, courseID entity.ID) error
 }
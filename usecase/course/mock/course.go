@@ -34,19 +34,64 @@ func (m *MockReader) EXPECT() *MockReaderMockRecorder {
 	return m.recorder
 }
 
+// CountBySyncStatus mocks base method.
+func (m *MockReader) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySyncStatus indicates an expected call of CountBySyncStatus.
+func (mr *MockReaderMockRecorder) CountBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySyncStatus", reflect.TypeOf((*MockReader)(nil).CountBySyncStatus), tenantID)
+}
+
+// FindByAccounts mocks base method.
+func (m *MockReader) FindByAccounts(tenantID entity.ID, accountIDs []entity.ID, roles []entity.CourseRole, statuses []entity.CourseStatus, page, limit int) ([]*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAccounts", tenantID, accountIDs, roles, statuses, page, limit)
+	ret0, _ := ret[0].([]*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAccounts indicates an expected call of FindByAccounts.
+func (mr *MockReaderMockRecorder) FindByAccounts(tenantID, accountIDs, roles, statuses, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccounts", reflect.TypeOf((*MockReader)(nil).FindByAccounts), tenantID, accountIDs, roles, statuses, page, limit)
+}
+
 // Get mocks base method.
-func (m *MockReader) Get(id entity.ID) (*entity.Course, error) {
+func (m *MockReader) Get(tenantID, id entity.ID) (*entity.Course, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
 	ret0, _ := ret[0].(*entity.Course)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockReaderMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, id)
+}
+
+// GetAccounts mocks base method.
+func (m *MockReader) GetAccounts(courseID entity.ID) (*entity.CourseAccounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", courseID)
+	ret0, _ := ret[0].(*entity.CourseAccounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockReaderMockRecorder) GetAccounts(courseID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockReader)(nil).GetAccounts), courseID)
 }
 
 // GetCount mocks base method.
@@ -79,6 +124,21 @@ func (mr *MockReaderMockRecorder) List(tenantID, page, limit interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), tenantID, page, limit)
 }
 
+// ListBySyncStatus mocks base method.
+func (m *MockReader) ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySyncStatus indicates an expected call of ListBySyncStatus.
+func (mr *MockReaderMockRecorder) ListBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySyncStatus", reflect.TypeOf((*MockReader)(nil).ListBySyncStatus), tenantID, status, page, limit)
+}
+
 // Search mocks base method.
 func (m *MockReader) Search(tenantID entity.ID, query string, page, limit int) ([]*entity.Course, error) {
 	m.ctrl.T.Helper()
@@ -133,17 +193,17 @@ func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockWriter) Delete(id entity.ID) error {
+func (m *MockWriter) Delete(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockWriterMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, id)
 }
 
 // Update mocks base method.
@@ -160,6 +220,34 @@ func (mr *MockWriterMockRecorder) Update(e interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), e)
 }
 
+// UpdateAccounts mocks base method.
+func (m *MockWriter) UpdateAccounts(a *entity.CourseAccounts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccounts", a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccounts indicates an expected call of UpdateAccounts.
+func (mr *MockWriterMockRecorder) UpdateAccounts(a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccounts", reflect.TypeOf((*MockWriter)(nil).UpdateAccounts), a)
+}
+
+// UpdateSyncState mocks base method.
+func (m *MockWriter) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncState", id, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncState indicates an expected call of UpdateSyncState.
+func (mr *MockWriterMockRecorder) UpdateSyncState(id, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncState", reflect.TypeOf((*MockWriter)(nil).UpdateSyncState), id, s)
+}
+
 // MockRepository is a mock of Repository interface.
 type MockRepository struct {
 	ctrl     *gomock.Controller
@@ -183,6 +271,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountBySyncStatus mocks base method.
+func (m *MockRepository) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySyncStatus indicates an expected call of CountBySyncStatus.
+func (mr *MockRepositoryMockRecorder) CountBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySyncStatus", reflect.TypeOf((*MockRepository)(nil).CountBySyncStatus), tenantID)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(e *entity.Course) (entity.ID, error) {
 	m.ctrl.T.Helper()
@@ -199,32 +302,62 @@ func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(id entity.ID) error {
+func (m *MockRepository) Delete(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, id)
+}
+
+// FindByAccounts mocks base method.
+func (m *MockRepository) FindByAccounts(tenantID entity.ID, accountIDs []entity.ID, roles []entity.CourseRole, statuses []entity.CourseStatus, page, limit int) ([]*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAccounts", tenantID, accountIDs, roles, statuses, page, limit)
+	ret0, _ := ret[0].([]*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAccounts indicates an expected call of FindByAccounts.
+func (mr *MockRepositoryMockRecorder) FindByAccounts(tenantID, accountIDs, roles, statuses, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccounts", reflect.TypeOf((*MockRepository)(nil).FindByAccounts), tenantID, accountIDs, roles, statuses, page, limit)
 }
 
 // Get mocks base method.
-func (m *MockRepository) Get(id entity.ID) (*entity.Course, error) {
+func (m *MockRepository) Get(tenantID, id entity.ID) (*entity.Course, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
 	ret0, _ := ret[0].(*entity.Course)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, id)
+}
+
+// GetAccounts mocks base method.
+func (m *MockRepository) GetAccounts(courseID entity.ID) (*entity.CourseAccounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", courseID)
+	ret0, _ := ret[0].(*entity.CourseAccounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockRepositoryMockRecorder) GetAccounts(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockRepository)(nil).GetAccounts), courseID)
 }
 
 // GetCount mocks base method.
@@ -257,6 +390,21 @@ func (mr *MockRepositoryMockRecorder) List(tenantID, page, limit interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), tenantID, page, limit)
 }
 
+// ListBySyncStatus mocks base method.
+func (m *MockRepository) ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySyncStatus indicates an expected call of ListBySyncStatus.
+func (mr *MockRepositoryMockRecorder) ListBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySyncStatus", reflect.TypeOf((*MockRepository)(nil).ListBySyncStatus), tenantID, status, page, limit)
+}
+
 // Search mocks base method.
 func (m *MockRepository) Search(tenantID entity.ID, query string, page, limit int) ([]*entity.Course, error) {
 	m.ctrl.T.Helper()
@@ -286,6 +434,34 @@ func (mr *MockRepositoryMockRecorder) Update(e interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), e)
 }
 
+// UpdateAccounts mocks base method.
+func (m *MockRepository) UpdateAccounts(a *entity.CourseAccounts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccounts", a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccounts indicates an expected call of UpdateAccounts.
+func (mr *MockRepositoryMockRecorder) UpdateAccounts(a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccounts", reflect.TypeOf((*MockRepository)(nil).UpdateAccounts), a)
+}
+
+// UpdateSyncState mocks base method.
+func (m *MockRepository) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncState", id, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncState indicates an expected call of UpdateSyncState.
+func (mr *MockRepositoryMockRecorder) UpdateSyncState(id, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncState", reflect.TypeOf((*MockRepository)(nil).UpdateSyncState), id, s)
+}
+
 // MockUseCase is a mock of UseCase interface.
 type MockUseCase struct {
 	ctrl     *gomock.Controller
@@ -309,6 +485,49 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// CheckCourseAccounts mocks base method.
+func (m *MockUseCase) CheckCourseAccounts(c *entity.Course, a *entity.CourseAccounts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCourseAccounts", c, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckCourseAccounts indicates an expected call of CheckCourseAccounts.
+func (mr *MockUseCaseMockRecorder) CheckCourseAccounts(c, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCourseAccounts", reflect.TypeOf((*MockUseCase)(nil).CheckCourseAccounts), c, a)
+}
+
+// CheckCourseEditor mocks base method.
+func (m *MockUseCase) CheckCourseEditor(a *entity.Account, courseID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCourseEditor", a, courseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckCourseEditor indicates an expected call of CheckCourseEditor.
+func (mr *MockUseCaseMockRecorder) CheckCourseEditor(a, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCourseEditor", reflect.TypeOf((*MockUseCase)(nil).CheckCourseEditor), a, courseID)
+}
+
+// CountCoursesBySyncStatus mocks base method.
+func (m *MockUseCase) CountCoursesBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCoursesBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCoursesBySyncStatus indicates an expected call of CountCoursesBySyncStatus.
+func (mr *MockUseCaseMockRecorder) CountCoursesBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCoursesBySyncStatus", reflect.TypeOf((*MockUseCase)(nil).CountCoursesBySyncStatus), tenantID)
+}
+
 // CreateCourse mocks base method.
 func (m *MockUseCase) CreateCourse(tenantID entity.ID, extID *string, centerID, productID entity.ID, name, notes, timezone string, address entity.CourseAddress, status entity.CourseStatus, mode entity.CourseMode, maxAttendees, numAttendees int32) (entity.ID, error) {
 	m.ctrl.T.Helper()
@@ -325,17 +544,32 @@ func (mr *MockUseCaseMockRecorder) CreateCourse(tenantID, extID, centerID, produ
 }
 
 // DeleteCourse mocks base method.
-func (m *MockUseCase) DeleteCourse(id entity.ID) error {
+func (m *MockUseCase) DeleteCourse(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourse", id)
+	ret := m.ctrl.Call(m, "DeleteCourse", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCourse indicates an expected call of DeleteCourse.
-func (mr *MockUseCaseMockRecorder) DeleteCourse(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteCourse(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourse", reflect.TypeOf((*MockUseCase)(nil).DeleteCourse), tenantID, id)
+}
+
+// FindCoursesByUser mocks base method.
+func (m *MockUseCase) FindCoursesByUser(tenantID entity.ID, userIDs []entity.ID, roles []entity.CourseRole, statuses []entity.CourseStatus, page, limit int) ([]*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCoursesByUser", tenantID, userIDs, roles, statuses, page, limit)
+	ret0, _ := ret[0].([]*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCoursesByUser indicates an expected call of FindCoursesByUser.
+func (mr *MockUseCaseMockRecorder) FindCoursesByUser(tenantID, userIDs, roles, statuses, page, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourse", reflect.TypeOf((*MockUseCase)(nil).DeleteCourse), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCoursesByUser", reflect.TypeOf((*MockUseCase)(nil).FindCoursesByUser), tenantID, userIDs, roles, statuses, page, limit)
 }
 
 // GetCount mocks base method.
@@ -353,18 +587,33 @@ func (mr *MockUseCaseMockRecorder) GetCount(id interface{}) *gomock.Call {
 }
 
 // GetCourse mocks base method.
-func (m *MockUseCase) GetCourse(id entity.ID) (*entity.Course, error) {
+func (m *MockUseCase) GetCourse(tenantID, id entity.ID) (*entity.Course, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourse", id)
+	ret := m.ctrl.Call(m, "GetCourse", tenantID, id)
 	ret0, _ := ret[0].(*entity.Course)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCourse indicates an expected call of GetCourse.
-func (mr *MockUseCaseMockRecorder) GetCourse(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) GetCourse(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourse", reflect.TypeOf((*MockUseCase)(nil).GetCourse), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourse", reflect.TypeOf((*MockUseCase)(nil).GetCourse), tenantID, id)
+}
+
+// GetCourseAccounts mocks base method.
+func (m *MockUseCase) GetCourseAccounts(courseID entity.ID) (*entity.CourseAccounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseAccounts", courseID)
+	ret0, _ := ret[0].(*entity.CourseAccounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseAccounts indicates an expected call of GetCourseAccounts.
+func (mr *MockUseCaseMockRecorder) GetCourseAccounts(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseAccounts", reflect.TypeOf((*MockUseCase)(nil).GetCourseAccounts), courseID)
 }
 
 // ListCourses mocks base method.
@@ -382,6 +631,21 @@ func (mr *MockUseCaseMockRecorder) ListCourses(tenantID, page, limit interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCourses", reflect.TypeOf((*MockUseCase)(nil).ListCourses), tenantID, page, limit)
 }
 
+// ListCoursesBySyncStatus mocks base method.
+func (m *MockUseCase) ListCoursesBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoursesBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoursesBySyncStatus indicates an expected call of ListCoursesBySyncStatus.
+func (mr *MockUseCaseMockRecorder) ListCoursesBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoursesBySyncStatus", reflect.TypeOf((*MockUseCase)(nil).ListCoursesBySyncStatus), tenantID, status, page, limit)
+}
+
 // SearchCourses mocks base method.
 func (m *MockUseCase) SearchCourses(tenantID entity.ID, query string, page, limit int) ([]*entity.Course, error) {
 	m.ctrl.T.Helper()
@@ -398,15 +662,29 @@ func (mr *MockUseCaseMockRecorder) SearchCourses(tenantID, query, page, limit in
 }
 
 // UpdateCourse mocks base method.
-func (m *MockUseCase) UpdateCourse(e *entity.Course) error {
+func (m *MockUseCase) UpdateCourse(e *entity.Course, a *entity.CourseAccounts) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCourse", e)
+	ret := m.ctrl.Call(m, "UpdateCourse", e, a)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCourse indicates an expected call of UpdateCourse.
-func (mr *MockUseCaseMockRecorder) UpdateCourse(e interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpdateCourse(e, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourse", reflect.TypeOf((*MockUseCase)(nil).UpdateCourse), e, a)
+}
+
+// UpdateCourseAccounts mocks base method.
+func (m *MockUseCase) UpdateCourseAccounts(c *entity.Course, a *entity.CourseAccounts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCourseAccounts", c, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCourseAccounts indicates an expected call of UpdateCourseAccounts.
+func (mr *MockUseCaseMockRecorder) UpdateCourseAccounts(c, a interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourse", reflect.TypeOf((*MockUseCase)(nil).UpdateCourse), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourseAccounts", reflect.TypeOf((*MockUseCase)(nil).UpdateCourseAccounts), c, a)
 }
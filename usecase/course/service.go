@@ -7,21 +7,36 @@
 package course
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/account"
+	"sudhagar/glad/usecase/eligibility"
+	"sudhagar/glad/usecase/tombstone"
 )
 
 // Service course usecase
 type Service struct {
-	repo Repository
+	repo        Repository
+	tombstone   tombstone.UseCase
+	accounts    account.UseCase
+	eligibility eligibility.UseCase
 }
 
-// NewService create new service
-func NewService(r Repository) *Service {
+// NewService create new service. Deletes of records synced to Salesforce
+// are held back until tb confirms them; a nil tb deletes right away. The
+// teachers, organizers and contacts of the courses are checked against
+// accounts, and the teachers against their eligibility for the product of
+// the course; a nil accounts or eligibility skips the check.
+func NewService(r Repository, tb tombstone.UseCase, accounts account.UseCase, eligibility eligibility.UseCase) *Service {
 	return &Service{
-		repo: r,
+		repo:        r,
+		tombstone:   tb,
+		accounts:    accounts,
+		eligibility: eligibility,
 	}
 }
 
@@ -46,9 +61,9 @@ func (s *Service) CreateCourse(tenantID entity.ID,
 	return s.repo.Create(c)
 }
 
-// GetCourse retrieves a course
-func (s *Service) GetCourse(id entity.ID) (*entity.Course, error) {
-	t, err := s.repo.Get(id)
+// GetCourse retrieves a course of the tenant
+func (s *Service) GetCourse(tenantID, id entity.ID) (*entity.Course, error) {
+	t, err := s.repo.Get(tenantID, id)
 	if t == nil {
 		return nil, entity.ErrNotFound
 	}
@@ -85,9 +100,65 @@ func (s *Service) ListCourses(tenantID entity.ID, page, limit int) ([]*entity.Co
 	return courses, nil
 }
 
-// DeleteCourse Delete a course
-func (s *Service) DeleteCourse(id entity.ID) error {
-	t, err := s.GetCourse(id)
+// ListCoursesBySyncStatus list courses with the given sync status
+func (s *Service) ListCoursesBySyncStatus(tenantID entity.ID,
+	status entity.SyncStatus, page, limit int,
+) ([]*entity.Course, error) {
+	if !status.IsValid() {
+		return nil, entity.ErrInvalidEntity
+	}
+	courses, err := s.repo.ListBySyncStatus(tenantID, status, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(courses) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return courses, nil
+}
+
+// CountCoursesBySyncStatus counts the courses of the tenant per sync status
+func (s *Service) CountCoursesBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	return s.repo.CountBySyncStatus(tenantID)
+}
+
+// FindCoursesByUser lists the courses of the users; the courses in which
+// they have any role if roles is empty
+func (s *Service) FindCoursesByUser(tenantID entity.ID,
+	userIDs []entity.ID,
+	roles []entity.CourseRole,
+	statuses []entity.CourseStatus,
+	page, limit int,
+) ([]*entity.Course, error) {
+	if len(userIDs) == 0 {
+		return nil, entity.ErrInvalidEntity
+	}
+	for _, r := range roles {
+		if !r.IsValid() {
+			return nil, entity.ErrInvalidEntity
+		}
+	}
+	for _, st := range statuses {
+		if !st.IsValid() {
+			return nil, entity.ErrInvalidEntity
+		}
+	}
+	if len(roles) == 0 {
+		roles = entity.CourseRoles
+	}
+	courses, err := s.repo.FindByAccounts(tenantID, userIDs, roles, statuses, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(courses) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return courses, nil
+}
+
+// DeleteCourse Delete a course of the tenant
+func (s *Service) DeleteCourse(tenantID, id entity.ID) error {
+	t, err := s.GetCourse(tenantID, id)
 	if t == nil {
 		return entity.ErrNotFound
 	}
@@ -95,15 +166,49 @@ func (s *Service) DeleteCourse(id entity.ID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if s.tombstone != nil && t.ExtID != nil && *t.ExtID != "" {
+		_, err = s.tombstone.CreateTombstone(t.TenantID, entity.SyncObjectCourse, id, *t.ExtID)
+		if err != nil {
+			return err
+		}
+		err = s.repo.UpdateSyncState(id, &entity.SyncState{
+			LastSyncedAt:      t.Sync.LastSyncedAt,
+			LastSyncDirection: t.Sync.LastSyncDirection,
+			Status:            entity.SyncPending,
+		})
+		if err != nil {
+			return err
+		}
+		return entity.ErrDeletePending
+	}
+
+	return s.repo.Delete(tenantID, id)
 }
 
-// UpdateCourse Update a course
-func (s *Service) UpdateCourse(c *entity.Course) error {
+// UpdateCourse Update a course of its tenant. The teachers of a course moved
+// to another product must be eligible for it; a are the accounts to be saved
+// with the course, nil if the saved ones are kept.
+func (s *Service) UpdateCourse(c *entity.Course, a *entity.CourseAccounts) error {
 	err := c.Validate()
 	if err != nil {
 		return err
 	}
+	saved, err := s.GetCourse(c.TenantID, c.ID)
+	if err != nil {
+		return err
+	}
+	if s.eligibility != nil && saved.ProductID != c.ProductID {
+		if a == nil {
+			a, err = s.GetCourseAccounts(c.ID)
+			if err != nil {
+				return err
+			}
+		}
+		err = s.checkEligibility(c.ProductID, a.Teachers)
+		if err != nil {
+			return err
+		}
+	}
 	c.UpdatedAt = time.Now()
 	return s.repo.Update(c)
 }
@@ -117,3 +222,144 @@ func (s *Service) GetCount(tenantID entity.ID) int {
 
 	return count
 }
+
+// GetCourseAccounts gets the teachers, organizers and contacts of a course
+func (s *Service) GetCourseAccounts(courseID entity.ID) (*entity.CourseAccounts, error) {
+	a, err := s.repo.GetAccounts(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return &entity.CourseAccounts{CourseID: courseID}, nil
+	}
+	return a, nil
+}
+
+// course account types allowed in each role
+var (
+	teacherTypes        = []entity.AccountType{entity.AccountTeacher, entity.AccountAssistantTeacher}
+	primaryTeacherTypes = []entity.AccountType{entity.AccountTeacher}
+	organizerTypes      = []entity.AccountType{entity.AccountOrganizer, entity.AccountTeacher}
+)
+
+// CheckCourseAccounts checks the number of accounts in each role, that the
+// accounts belong to the tenant of the course and are of the types of their
+// role, and that the teachers are eligible for the product of the course.
+// Contacts can be of any type.
+func (s *Service) CheckCourseAccounts(c *entity.Course, a *entity.CourseAccounts) error {
+	err := a.Validate()
+	if err != nil {
+		return err
+	}
+	err = s.checkEligibility(c.ProductID, a.Teachers)
+	if err != nil {
+		return err
+	}
+	if s.accounts == nil {
+		return nil
+	}
+	tenantID := c.TenantID
+
+	for _, t := range a.Teachers {
+		types := teacherTypes
+		if t.IsPrimary {
+			types = primaryTeacherTypes
+		}
+		if err := s.checkAccount(tenantID, t.ID, "teacher", types); err != nil {
+			return err
+		}
+	}
+	for _, id := range a.Organizers {
+		if err := s.checkAccount(tenantID, id, "organizer", organizerTypes); err != nil {
+			return err
+		}
+	}
+	for _, id := range a.Contacts {
+		if err := s.checkAccount(tenantID, id, "contact", nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAccount checks that the account belongs to the tenant and is of one
+// of the types; any type if types is empty
+func (s *Service) checkAccount(tenantID entity.ID, id entity.ID, role string, types []entity.AccountType) error {
+	acc, err := s.accounts.GetAccount(tenantID, id)
+	if err == entity.ErrNotFound {
+		return fmt.Errorf("%w: %s %v doesn't exist", entity.ErrInvalidEntity, role, id)
+	}
+	if err != nil {
+		return err
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	for _, t := range types {
+		if acc.Type == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s %v is of type %s", entity.ErrInvalidEntity, role, id, acc.Type)
+}
+
+// checkEligibility checks that the teachers can teach the product; the
+// primary teacher needs primary eligibility
+func (s *Service) checkEligibility(productID entity.ID, teachers []entity.CourseTeacher) error {
+	if s.eligibility == nil {
+		return nil
+	}
+	for _, t := range teachers {
+		e, err := s.eligibility.GetEligibility(t.ID, productID)
+		if err == entity.ErrNotFound {
+			return fmt.Errorf("%w: teacher %v isn't eligible for product %v", entity.ErrInvalidEntity, t.ID, productID)
+		}
+		if err != nil {
+			return err
+		}
+		if !e.Allows(t.IsPrimary) {
+			return fmt.Errorf("%w: teacher %v is eligible to assist with product %v only",
+				entity.ErrInvalidEntity, t.ID, productID)
+		}
+	}
+	return nil
+}
+
+// UpdateCourseAccounts replaces the teachers, organizers and contacts of a
+// course
+func (s *Service) UpdateCourseAccounts(c *entity.Course, a *entity.CourseAccounts) error {
+	err := s.CheckCourseAccounts(c, a)
+	if err != nil {
+		return err
+	}
+	return s.repo.UpdateAccounts(a)
+}
+
+// CheckCourseEditor checks that the account can edit the course: any course
+// of its tenant with PermissionEditAnyCourse, the courses it teaches or
+// organizes with PermissionEditOwnCourse
+func (s *Service) CheckCourseEditor(a *entity.Account, courseID entity.ID) error {
+	_, err := s.GetCourse(a.TenantID, courseID)
+	if err != nil {
+		return err
+	}
+	if a.Type.Can(entity.PermissionEditAnyCourse) {
+		return nil
+	}
+	if !a.Type.Can(entity.PermissionEditOwnCourse) {
+		return entity.ErrForbidden
+	}
+	ca, err := s.GetCourseAccounts(courseID)
+	if err != nil {
+		return err
+	}
+	for _, t := range ca.Teachers {
+		if t.ID == a.ID {
+			return nil
+		}
+	}
+	if slices.Contains(ca.Organizers, a.ID) {
+		return nil
+	}
+	return entity.ErrForbidden
+}
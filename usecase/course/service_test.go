@@ -11,7 +11,11 @@ import (
 	"time"
 
 	"sudhagar/glad/entity"
+	accountmock "sudhagar/glad/usecase/account/mock"
+	"sudhagar/glad/usecase/eligibility"
+	"sudhagar/glad/usecase/tombstone"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,10 +26,9 @@ const (
 	aliceCenterID            = 13790493495087075501
 	aliceProductID           = 13790493495087076601
 
-	// todo: add multi-tenant support
-	// tenantBob    entity.ID = 13790492210917015555
-	bobExtID    = "000bobExtID"
-	bobCenterID = 13790493495087075502
+	tenantBob   entity.ID = 13790492210917015555
+	bobExtID              = "000bobExtID"
+	bobCenterID           = 13790493495087075502
 )
 
 func newFixtureCourse() *entity.Course {
@@ -58,7 +61,7 @@ func newFixtureCourse() *entity.Course {
 
 func Test_Create(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil, nil, nil)
 	tmpl := newFixtureCourse()
 	_, err := m.CreateCourse(tmpl.TenantID, tmpl.ExtID, tmpl.CenterID,
 		tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
@@ -71,7 +74,7 @@ func Test_Create(t *testing.T) {
 
 func Test_SearchAndFind(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil, nil, nil)
 	tmpl1 := newFixtureCourse()
 	tmpl2 := newFixtureCourse()
 	tmpl2.Name = "Course Sahaj Meditation"
@@ -114,7 +117,7 @@ func Test_SearchAndFind(t *testing.T) {
 	})
 
 	t.Run("get", func(t *testing.T) {
-		saved, err := m.GetCourse(tID)
+		saved, err := m.GetCourse(tenantAlice, tID)
 		assert.Nil(t, err)
 		assert.Equal(t, tmpl1.TenantID, saved.TenantID)
 		assert.Equal(t, tmpl1.ExtID, saved.ExtID)
@@ -126,7 +129,7 @@ func Test_SearchAndFind(t *testing.T) {
 
 func Test_Update(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil, nil, nil)
 	tmpl := newFixtureCourse()
 	id, err := m.CreateCourse(tmpl.TenantID, tmpl.ExtID, tmpl.CenterID,
 		tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
@@ -136,18 +139,18 @@ func Test_Update(t *testing.T) {
 
 	assert.Nil(t, err)
 
-	saved, _ := m.GetCourse(id)
+	saved, _ := m.GetCourse(tenantAlice, id)
 	saved.Mode = entity.CourseOnline
-	assert.Nil(t, m.UpdateCourse(saved))
+	assert.Nil(t, m.UpdateCourse(saved, nil))
 
-	updated, err := m.GetCourse(id)
+	updated, err := m.GetCourse(tenantAlice, id)
 	assert.Nil(t, err)
 	assert.Equal(t, entity.CourseOnline, updated.Mode)
 }
 
 func TestDelete(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil, nil, nil)
 
 	tmpl1 := newFixtureCourse()
 	tmpl2 := newFixtureCourse()
@@ -159,11 +162,369 @@ func TestDelete(t *testing.T) {
 		tmpl1.MaxAttendees, tmpl1.NumAttendees,
 	)
 
-	err := m.DeleteCourse(tmpl1.ID)
+	err := m.DeleteCourse(tenantAlice, tmpl1.ID)
 	assert.Equal(t, entity.ErrNotFound, err)
 
-	err = m.DeleteCourse(t2ID)
+	err = m.DeleteCourse(tenantAlice, t2ID)
 	assert.Nil(t, err)
-	_, err = m.GetCourse(t2ID)
+	_, err = m.GetCourse(tenantAlice, t2ID)
+	assert.Equal(t, entity.ErrNotFound, err)
+}
+
+func Test_OtherTenant(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, nil, nil, nil)
+	tmpl := newFixtureCourse()
+	id, err := m.CreateCourse(tmpl.TenantID, tmpl.ExtID, tmpl.CenterID,
+		tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
+		tmpl.Address, tmpl.Status, tmpl.Mode,
+		tmpl.MaxAttendees, tmpl.NumAttendees,
+	)
+	assert.Nil(t, err)
+
+	_, err = m.GetCourse(tenantBob, id)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	c, _ := m.GetCourse(tenantAlice, id)
+	other := *c
+	other.TenantID = tenantBob
+	other.Name = "Renamed"
+	assert.Equal(t, entity.ErrNotFound, m.UpdateCourse(&other, nil))
+	assert.Equal(t, entity.ErrNotFound, m.DeleteCourse(tenantBob, id))
+
+	saved, err := m.GetCourse(tenantAlice, id)
+	assert.Nil(t, err)
+	assert.Equal(t, tmpl.Name, saved.Name)
+}
+
+func Test_ListBySyncStatus(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, nil, nil, nil)
+	tmpl1 := newFixtureCourse()
+	tmpl2 := newFixtureCourse()
+	extID := bobExtID
+	tmpl2.ExtID = &extID
+
+	t1ID, _ := m.CreateCourse(tmpl1.TenantID, tmpl1.ExtID, tmpl1.CenterID,
+		tmpl1.ProductID, tmpl1.Name, tmpl1.Notes, tmpl1.Timezone,
+		tmpl1.Address, tmpl1.Status, tmpl1.Mode,
+		tmpl1.MaxAttendees, tmpl1.NumAttendees,
+	)
+	_, _ = m.CreateCourse(tmpl2.TenantID, tmpl2.ExtID, tmpl2.CenterID,
+		tmpl2.ProductID, tmpl2.Name, tmpl1.Notes, tmpl1.Timezone,
+		tmpl1.Address, tmpl1.Status, tmpl1.Mode,
+		tmpl1.MaxAttendees, tmpl1.NumAttendees,
+	)
+
+	t.Run("new courses are pending", func(t *testing.T) {
+		res, err := m.ListCoursesBySyncStatus(tmpl1.TenantID, entity.SyncPending, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(res))
+	})
+
+	t.Run("failed export", func(t *testing.T) {
+		err := repo.UpdateSyncState(t1ID, entity.NewSyncState(entity.SyncOutbound, entity.ErrNotFound))
+		assert.Nil(t, err)
+
+		res, err := m.ListCoursesBySyncStatus(tmpl1.TenantID, entity.SyncFailed, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(res))
+		assert.Equal(t, t1ID, res[0].ID)
+		assert.Equal(t, entity.SyncOutbound, res[0].Sync.LastSyncDirection)
+		assert.Equal(t, entity.ErrNotFound.Error(), res[0].Sync.LastError)
+		assert.NotNil(t, res[0].Sync.LastSyncedAt)
+
+		res, err = m.ListCoursesBySyncStatus(tmpl1.TenantID, entity.SyncSynced, 0, 0)
+		assert.Equal(t, entity.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		counts, err := m.CountCoursesBySyncStatus(tmpl1.TenantID)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, counts[entity.SyncPending])
+		assert.Equal(t, 1, counts[entity.SyncFailed])
+		assert.Equal(t, 0, counts[entity.SyncSynced])
+
+		counts, err = m.CountCoursesBySyncStatus(tenantBob)
+		assert.Nil(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		res, err := m.ListCoursesBySyncStatus(tmpl1.TenantID, entity.SyncStatus("unknown"), 0, 0)
+		assert.Equal(t, entity.ErrInvalidEntity, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestDelete_Pending(t *testing.T) {
+	repo := newInmem()
+	tb := tombstone.NewService(tombstone.NewInmem(), nil)
+	m := NewService(repo, tb, nil, nil)
+
+	tmpl := newFixtureCourse()
+	id, _ := m.CreateCourse(tmpl.TenantID, tmpl.ExtID, tmpl.CenterID,
+		tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
+		tmpl.Address, tmpl.Status, tmpl.Mode,
+		tmpl.MaxAttendees, tmpl.NumAttendees,
+	)
+
+	// synced to salesforce; kept until the delete is confirmed
+	err := m.DeleteCourse(tenantAlice, id)
+	assert.Equal(t, entity.ErrDeletePending, err)
+	c, err := m.GetCourse(tenantAlice, id)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.SyncPending, c.Sync.Status)
+
+	// repeated delete reuses the tombstone
+	err = m.DeleteCourse(tenantAlice, id)
+	assert.Equal(t, entity.ErrDeletePending, err)
+	tombstones, err := tb.ListPendingTombstones(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tombstones))
+	assert.Equal(t, entity.SyncObjectCourse, tombstones[0].Object)
+	assert.Equal(t, aliceExtID, tombstones[0].ExtID)
+
+	// never synced; deleted right away
+	id, _ = m.CreateCourse(tmpl.TenantID, nil, tmpl.CenterID,
+		tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
+		tmpl.Address, tmpl.Status, tmpl.Mode,
+		tmpl.MaxAttendees, tmpl.NumAttendees,
+	)
+	err = m.DeleteCourse(tenantAlice, id)
+	assert.Nil(t, err)
+	_, err = m.GetCourse(tenantAlice, id)
+	assert.Equal(t, entity.ErrNotFound, err)
+}
+
+func Test_CourseAccounts(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	accounts := accountmock.NewMockUseCase(controller)
+	m := NewService(newInmem(), nil, accounts, nil)
+
+	tmpl := newFixtureCourse()
+	id, _ := m.CreateCourse(tmpl.TenantID, tmpl.ExtID, tmpl.CenterID,
+		tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
+		tmpl.Address, tmpl.Status, tmpl.Mode,
+		tmpl.MaxAttendees, tmpl.NumAttendees,
+	)
+
+	const (
+		teacher entity.ID = 13790493495087077701 + iota
+		assistant
+		organizer
+		member
+		bobTeacher
+	)
+	for id, a := range map[entity.ID]*entity.Account{
+		teacher:    {ID: teacher, TenantID: tenantAlice, Type: entity.AccountTeacher},
+		assistant:  {ID: assistant, TenantID: tenantAlice, Type: entity.AccountAssistantTeacher},
+		organizer:  {ID: organizer, TenantID: tenantAlice, Type: entity.AccountOrganizer},
+		member:     {ID: member, TenantID: tenantAlice, Type: entity.AccountMember},
+		bobTeacher: {ID: bobTeacher, TenantID: tenantAlice + 1, Type: entity.AccountTeacher},
+	} {
+		accounts.EXPECT().GetAccount(a.TenantID, id).Return(a, nil).AnyTimes()
+	}
+	accounts.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(nil, entity.ErrNotFound).AnyTimes()
+
+	c, _ := m.GetCourse(tenantAlice, id)
+
+	// none assigned yet
+	saved, err := m.GetCourseAccounts(id)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(saved.Teachers))
+
+	a := &entity.CourseAccounts{
+		CourseID:   id,
+		Teachers:   []entity.CourseTeacher{{ID: teacher, IsPrimary: true}, {ID: assistant}},
+		Organizers: []entity.ID{organizer, teacher},
+		Contacts:   []entity.ID{member},
+	}
+	err = m.UpdateCourseAccounts(c, a)
+	assert.Nil(t, err)
+	saved, _ = m.GetCourseAccounts(id)
+	assert.Equal(t, a, saved)
+
+	for name, invalid := range map[string]*entity.CourseAccounts{
+		"too many teachers": {CourseID: id, Teachers: []entity.CourseTeacher{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}},
+		"too many contacts": {CourseID: id, Contacts: []entity.ID{member, organizer}},
+		"two primaries":     {CourseID: id, Teachers: []entity.CourseTeacher{{ID: teacher, IsPrimary: true}, {ID: assistant, IsPrimary: true}}},
+		"duplicate":         {CourseID: id, Organizers: []entity.ID{organizer, organizer}},
+		"primary assistant": {CourseID: id, Teachers: []entity.CourseTeacher{{ID: assistant, IsPrimary: true}}},
+		"member teacher":    {CourseID: id, Teachers: []entity.CourseTeacher{{ID: member}}},
+		"member organizer":  {CourseID: id, Organizers: []entity.ID{member}},
+		"other tenant":      {CourseID: id, Teachers: []entity.CourseTeacher{{ID: bobTeacher}}},
+	} {
+		err = m.UpdateCourseAccounts(c, invalid)
+		assert.ErrorIs(t, err, entity.ErrInvalidEntity, name)
+	}
+
+	// unchanged
+	saved, _ = m.GetCourseAccounts(id)
+	assert.Equal(t, a, saved)
+}
+
+func Test_FindCoursesByUser(t *testing.T) {
+	m := NewService(newInmem(), nil, nil, nil)
+
+	const (
+		teacher entity.ID = 13790493495087077701 + iota
+		organizer
+		other
+	)
+	tmpl := newFixtureCourse()
+	var courses []*entity.Course
+	for _, status := range []entity.CourseStatus{entity.CourseActive, entity.CourseDraft} {
+		id, _ := m.CreateCourse(tmpl.TenantID, tmpl.ExtID, tmpl.CenterID,
+			tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
+			tmpl.Address, status, tmpl.Mode,
+			tmpl.MaxAttendees, tmpl.NumAttendees,
+		)
+		c, _ := m.GetCourse(tenantAlice, id)
+		courses = append(courses, c)
+	}
+	active, draft := courses[0], courses[1]
+	assert.Nil(t, m.UpdateCourseAccounts(active, &entity.CourseAccounts{
+		CourseID:   active.ID,
+		Teachers:   []entity.CourseTeacher{{ID: teacher, IsPrimary: true}},
+		Organizers: []entity.ID{organizer},
+	}))
+	assert.Nil(t, m.UpdateCourseAccounts(draft, &entity.CourseAccounts{
+		CourseID:   draft.ID,
+		Organizers: []entity.ID{teacher},
+	}))
+
+	found, err := m.FindCoursesByUser(tenantAlice, []entity.ID{teacher}, nil, nil, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(found))
+
+	found, err = m.FindCoursesByUser(tenantAlice, []entity.ID{teacher},
+		[]entity.CourseRole{entity.CourseRoleTeacher}, nil, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []*entity.Course{active}, found)
+
+	found, err = m.FindCoursesByUser(tenantAlice, []entity.ID{other, organizer},
+		nil, []entity.CourseStatus{entity.CourseActive}, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []*entity.Course{active}, found)
+
+	found, err = m.FindCoursesByUser(tenantAlice, []entity.ID{teacher}, nil, nil, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(found))
+
+	_, err = m.FindCoursesByUser(tenantAlice, []entity.ID{organizer},
+		nil, []entity.CourseStatus{entity.CourseDraft}, 0, 0)
+	assert.Equal(t, entity.ErrNotFound, err)
+	_, err = m.FindCoursesByUser(tenantAlice+1, []entity.ID{teacher}, nil, nil, 0, 0)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	_, err = m.FindCoursesByUser(tenantAlice, nil, nil, nil, 0, 0)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+	_, err = m.FindCoursesByUser(tenantAlice, []entity.ID{teacher},
+		[]entity.CourseRole{"attendee"}, nil, 0, 0)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+	_, err = m.FindCoursesByUser(tenantAlice, []entity.ID{teacher},
+		nil, []entity.CourseStatus{"unknown"}, 0, 0)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+}
+
+func Test_TeacherEligibility(t *testing.T) {
+	el := eligibility.NewService(eligibility.NewInmem(), nil, nil)
+	m := NewService(newInmem(), nil, nil, el)
+
+	tmpl := newFixtureCourse()
+	id, _ := m.CreateCourse(tmpl.TenantID, tmpl.ExtID, tmpl.CenterID,
+		tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
+		tmpl.Address, tmpl.Status, tmpl.Mode,
+		tmpl.MaxAttendees, tmpl.NumAttendees,
+	)
+	c, _ := m.GetCourse(tenantAlice, id)
+
+	const (
+		teacher   entity.ID = 13790493495087077701
+		assistant entity.ID = 13790493495087077702
+	)
+	primary := []entity.CourseTeacher{{ID: teacher, IsPrimary: true}, {ID: assistant}}
+
+	// not eligible
+	err := m.UpdateCourseAccounts(c, &entity.CourseAccounts{CourseID: id, Teachers: primary})
+	assert.ErrorIs(t, err, entity.ErrInvalidEntity)
+
+	_ = el.GrantEligibility(tenantAlice, teacher, aliceProductID, entity.EligibilityPrimary)
+	_ = el.GrantEligibility(tenantAlice, assistant, aliceProductID, entity.EligibilityAssistant)
+	err = m.UpdateCourseAccounts(c, &entity.CourseAccounts{CourseID: id, Teachers: primary})
+	assert.Nil(t, err)
+
+	// assistants can't lead
+	err = m.UpdateCourseAccounts(c, &entity.CourseAccounts{CourseID: id,
+		Teachers: []entity.CourseTeacher{{ID: assistant, IsPrimary: true}}})
+	assert.ErrorIs(t, err, entity.ErrInvalidEntity)
+
+	// the teachers must be eligible for the new product
+	moved := *c
+	moved.ProductID = aliceProductID + 1
+	err = m.UpdateCourse(&moved, nil)
+	assert.ErrorIs(t, err, entity.ErrInvalidEntity)
+
+	// unless they are replaced by teachers eligible for it
+	other := entity.NewID()
+	err = m.UpdateCourse(&moved, &entity.CourseAccounts{CourseID: id,
+		Teachers: []entity.CourseTeacher{{ID: other, IsPrimary: true}}})
+	assert.ErrorIs(t, err, entity.ErrInvalidEntity)
+	_ = el.GrantEligibility(tenantAlice, other, moved.ProductID, entity.EligibilityPrimary)
+	err = m.UpdateCourse(&moved, &entity.CourseAccounts{CourseID: id,
+		Teachers: []entity.CourseTeacher{{ID: other, IsPrimary: true}}})
+	assert.Nil(t, err)
+	moved.ProductID = aliceProductID + 2
+	_ = el.GrantEligibility(tenantAlice, teacher, moved.ProductID, entity.EligibilityPrimary)
+	_ = el.GrantEligibility(tenantAlice, assistant, moved.ProductID, entity.EligibilityPrimary)
+	err = m.UpdateCourse(&moved, nil)
+	assert.Nil(t, err)
+}
+
+func Test_CheckCourseEditor(t *testing.T) {
+	m := NewService(newInmem(), nil, nil, nil)
+
+	tmpl := newFixtureCourse()
+	id, _ := m.CreateCourse(tmpl.TenantID, tmpl.ExtID, tmpl.CenterID,
+		tmpl.ProductID, tmpl.Name, tmpl.Notes, tmpl.Timezone,
+		tmpl.Address, tmpl.Status, tmpl.Mode,
+		tmpl.MaxAttendees, tmpl.NumAttendees,
+	)
+	c, _ := m.GetCourse(tenantAlice, id)
+
+	const (
+		teacher entity.ID = 13790493495087077701 + iota
+		assistant
+		organizer
+		otherTeacher
+	)
+	err := m.UpdateCourseAccounts(c, &entity.CourseAccounts{
+		CourseID:   id,
+		Teachers:   []entity.CourseTeacher{{ID: teacher, IsPrimary: true}, {ID: assistant}},
+		Organizers: []entity.ID{organizer},
+	})
+	assert.Nil(t, err)
+
+	for _, tc := range []struct {
+		name string
+		a    *entity.Account
+		err  error
+	}{
+		{"teacher", &entity.Account{ID: teacher, TenantID: tenantAlice, Type: entity.AccountTeacher}, nil},
+		{"assistant", &entity.Account{ID: assistant, TenantID: tenantAlice, Type: entity.AccountAssistantTeacher}, nil},
+		{"organizer", &entity.Account{ID: organizer, TenantID: tenantAlice, Type: entity.AccountOrganizer}, nil},
+		{"admin", &entity.Account{ID: entity.NewID(), TenantID: tenantAlice, Type: entity.AccountAdmin}, nil},
+		{"other teacher", &entity.Account{ID: otherTeacher, TenantID: tenantAlice, Type: entity.AccountTeacher}, entity.ErrForbidden},
+		{"member", &entity.Account{ID: entity.NewID(), TenantID: tenantAlice, Type: entity.AccountMember}, entity.ErrForbidden},
+		// a member listed as a teacher still can't edit
+		{"demoted teacher", &entity.Account{ID: teacher, TenantID: tenantAlice, Type: entity.AccountMember}, entity.ErrForbidden},
+		{"other tenant", &entity.Account{ID: teacher, TenantID: tenantAlice + 1, Type: entity.AccountAdmin}, entity.ErrNotFound},
+	} {
+		assert.Equal(t, tc.err, m.CheckCourseEditor(tc.a, id), tc.name)
+	}
+	err = m.CheckCourseEditor(&entity.Account{TenantID: tenantAlice, Type: entity.AccountAdmin}, entity.NewID())
 	assert.Equal(t, entity.ErrNotFound, err)
 }
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package eligibility
+
+import (
+	"sort"
+
+	"sudhagar/glad/entity"
+)
+
+// key of an eligibility
+type key struct {
+	teacherID entity.ID
+	productID entity.ID
+}
+
+// Inmem in memory repo
+type Inmem struct {
+	m map[key]*entity.TeacherEligibility
+}
+
+// NewInmem create new repository
+func NewInmem() *Inmem {
+	var m = map[key]*entity.TeacherEligibility{}
+	return &Inmem{
+		m: m,
+	}
+}
+
+// Get an eligibility
+func (r *Inmem) Get(teacherID, productID entity.ID) (*entity.TeacherEligibility, error) {
+	return r.m[key{teacherID, productID}], nil
+}
+
+// ListByTeacher lists the eligibility of a teacher
+func (r *Inmem) ListByTeacher(teacherID entity.ID) ([]*entity.TeacherEligibility, error) {
+	var eligibility []*entity.TeacherEligibility
+	for k, e := range r.m {
+		if k.teacherID == teacherID {
+			eligibility = append(eligibility, e)
+		}
+	}
+	sort.Slice(eligibility, func(i, j int) bool {
+		return eligibility[i].ProductID < eligibility[j].ProductID
+	})
+	return eligibility, nil
+}
+
+// Save an eligibility
+func (r *Inmem) Save(e *entity.TeacherEligibility) error {
+	r.m[key{e.TeacherID, e.ProductID}] = e
+	return nil
+}
+
+// Delete an eligibility
+func (r *Inmem) Delete(teacherID, productID entity.ID) error {
+	if r.m[key{teacherID, productID}] == nil {
+		return entity.ErrNotFound
+	}
+	delete(r.m, key{teacherID, productID})
+	return nil
+}
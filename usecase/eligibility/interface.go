@@ -0,0 +1,38 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package eligibility
+
+import (
+	"sudhagar/glad/entity"
+)
+
+// Reader interface
+type Reader interface {
+	Get(teacherID, productID entity.ID) (*entity.TeacherEligibility, error)
+	ListByTeacher(teacherID entity.ID) ([]*entity.TeacherEligibility, error)
+}
+
+// Writer teacher eligibility writer
+type Writer interface {
+	// Save creates the eligibility or updates its type
+	Save(e *entity.TeacherEligibility) error
+	Delete(teacherID, productID entity.ID) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	GetEligibility(teacherID, productID entity.ID) (*entity.TeacherEligibility, error)
+	ListEligibility(teacherID entity.ID) ([]*entity.TeacherEligibility, error)
+	GrantEligibility(tenantID, teacherID, productID entity.ID, t entity.EligibilityType) error
+	RevokeEligibility(teacherID, productID entity.ID) error
+}
@@ -0,0 +1,278 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/eligibility/interface.go
+
+// Package mock_eligibility is a generated GoMock package.
+package mock_eligibility
+
+import (
+	reflect "reflect"
+	entity "sudhagar/glad/entity"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReader) Get(teacherID, productID entity.ID) (*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", teacherID, productID)
+	ret0, _ := ret[0].(*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReaderMockRecorder) Get(teacherID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), teacherID, productID)
+}
+
+// ListByTeacher mocks base method.
+func (m *MockReader) ListByTeacher(teacherID entity.ID) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTeacher", teacherID)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTeacher indicates an expected call of ListByTeacher.
+func (mr *MockReaderMockRecorder) ListByTeacher(teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTeacher", reflect.TypeOf((*MockReader)(nil).ListByTeacher), teacherID)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWriter) Delete(teacherID, productID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", teacherID, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWriterMockRecorder) Delete(teacherID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), teacherID, productID)
+}
+
+// Save mocks base method.
+func (m *MockWriter) Save(e *entity.TeacherEligibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockWriterMockRecorder) Save(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockWriter)(nil).Save), e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(teacherID, productID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", teacherID, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(teacherID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), teacherID, productID)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(teacherID, productID entity.ID) (*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", teacherID, productID)
+	ret0, _ := ret[0].(*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(teacherID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), teacherID, productID)
+}
+
+// ListByTeacher mocks base method.
+func (m *MockRepository) ListByTeacher(teacherID entity.ID) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTeacher", teacherID)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTeacher indicates an expected call of ListByTeacher.
+func (mr *MockRepositoryMockRecorder) ListByTeacher(teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTeacher", reflect.TypeOf((*MockRepository)(nil).ListByTeacher), teacherID)
+}
+
+// Save mocks base method.
+func (m *MockRepository) Save(e *entity.TeacherEligibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder) Save(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetEligibility mocks base method.
+func (m *MockUseCase) GetEligibility(teacherID, productID entity.ID) (*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEligibility", teacherID, productID)
+	ret0, _ := ret[0].(*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEligibility indicates an expected call of GetEligibility.
+func (mr *MockUseCaseMockRecorder) GetEligibility(teacherID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEligibility", reflect.TypeOf((*MockUseCase)(nil).GetEligibility), teacherID, productID)
+}
+
+// GrantEligibility mocks base method.
+func (m *MockUseCase) GrantEligibility(tenantID, teacherID, productID entity.ID, t entity.EligibilityType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantEligibility", tenantID, teacherID, productID, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantEligibility indicates an expected call of GrantEligibility.
+func (mr *MockUseCaseMockRecorder) GrantEligibility(tenantID, teacherID, productID, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantEligibility", reflect.TypeOf((*MockUseCase)(nil).GrantEligibility), tenantID, teacherID, productID, t)
+}
+
+// ListEligibility mocks base method.
+func (m *MockUseCase) ListEligibility(teacherID entity.ID) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEligibility", teacherID)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEligibility indicates an expected call of ListEligibility.
+func (mr *MockUseCaseMockRecorder) ListEligibility(teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEligibility", reflect.TypeOf((*MockUseCase)(nil).ListEligibility), teacherID)
+}
+
+// RevokeEligibility mocks base method.
+func (m *MockUseCase) RevokeEligibility(teacherID, productID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeEligibility", teacherID, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeEligibility indicates an expected call of RevokeEligibility.
+func (mr *MockUseCaseMockRecorder) RevokeEligibility(teacherID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeEligibility", reflect.TypeOf((*MockUseCase)(nil).RevokeEligibility), teacherID, productID)
+}
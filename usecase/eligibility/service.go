@@ -0,0 +1,110 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package eligibility
+
+import (
+	"fmt"
+	"time"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/account"
+	"sudhagar/glad/usecase/product"
+)
+
+// Service teacher eligibility usecase
+type Service struct {
+	repo     Repository
+	accounts account.UseCase
+	products product.UseCase
+}
+
+// NewService create new service. Eligibility is granted to the teacher
+// accounts of accounts only, for the products of their tenant in products;
+// a nil accounts or products skips the check.
+func NewService(r Repository, accounts account.UseCase, products product.UseCase) *Service {
+	return &Service{
+		repo:     r,
+		accounts: accounts,
+		products: products,
+	}
+}
+
+// GetEligibility gets the eligibility of a teacher for a product
+func (s *Service) GetEligibility(teacherID, productID entity.ID) (*entity.TeacherEligibility, error) {
+	e, err := s.repo.Get(teacherID, productID)
+	if e == nil {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// ListEligibility lists the products a teacher can teach
+func (s *Service) ListEligibility(teacherID entity.ID) ([]*entity.TeacherEligibility, error) {
+	eligibility, err := s.repo.ListByTeacher(teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if len(eligibility) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return eligibility, nil
+}
+
+// GrantEligibility lets a teacher of the tenant teach a product of the
+// tenant; assistant teachers can be granted assistant eligibility only
+func (s *Service) GrantEligibility(tenantID, teacherID, productID entity.ID, t entity.EligibilityType) error {
+	e, err := entity.NewTeacherEligibility(productID, teacherID, t)
+	if err != nil {
+		return err
+	}
+
+	if s.accounts != nil {
+		acc, err := s.accounts.GetAccount(tenantID, teacherID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case acc.Type == entity.AccountTeacher:
+		case acc.Type == entity.AccountAssistantTeacher && t == entity.EligibilityAssistant:
+		default:
+			return fmt.Errorf("%w: %s account can't be granted %s eligibility",
+				entity.ErrInvalidEntity, acc.Type, t)
+		}
+	}
+
+	if s.products != nil {
+		_, err := s.products.GetProduct(tenantID, productID)
+		if err != nil {
+			return err
+		}
+	}
+
+	saved, err := s.repo.Get(teacherID, productID)
+	if err != nil {
+		return err
+	}
+	if saved != nil {
+		saved.Type = t
+		saved.UpdatedAt = time.Now()
+		return s.repo.Save(saved)
+	}
+	return s.repo.Save(e)
+}
+
+// RevokeEligibility stops a teacher from teaching a product; the courses
+// the teacher is assigned to already are left as they are
+func (s *Service) RevokeEligibility(teacherID, productID entity.ID) error {
+	_, err := s.GetEligibility(teacherID, productID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(teacherID, productID)
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package eligibility
+
+import (
+	"testing"
+
+	"sudhagar/glad/entity"
+	accountmock "sudhagar/glad/usecase/account/mock"
+	productmock "sudhagar/glad/usecase/product/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice    entity.ID = 13790492210917015554
+	teacherAlice   entity.ID = 13790493495087077701
+	assistantAlice entity.ID = 13790493495087077702
+	memberAlice    entity.ID = 13790493495087077703
+	productPart1   entity.ID = 13790493495087076601
+	productPart2   entity.ID = 13790493495087076602
+)
+
+func Test_GrantAndRevoke(t *testing.T) {
+	m := NewService(NewInmem(), nil, nil)
+
+	err := m.GrantEligibility(tenantAlice, teacherAlice, productPart1, entity.EligibilityAssistant)
+	assert.Nil(t, err)
+	e, err := m.GetEligibility(teacherAlice, productPart1)
+	assert.Nil(t, err)
+	assert.False(t, e.Allows(true))
+	assert.True(t, e.Allows(false))
+
+	// upgraded
+	err = m.GrantEligibility(tenantAlice, teacherAlice, productPart1, entity.EligibilityPrimary)
+	assert.Nil(t, err)
+	e, _ = m.GetEligibility(teacherAlice, productPart1)
+	assert.True(t, e.Allows(true))
+
+	err = m.GrantEligibility(tenantAlice, teacherAlice, productPart2, entity.EligibilityPrimary)
+	assert.Nil(t, err)
+	products, err := m.ListEligibility(teacherAlice)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(products))
+
+	err = m.GrantEligibility(tenantAlice, teacherAlice, productPart2, "lead")
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+
+	err = m.RevokeEligibility(teacherAlice, productPart1)
+	assert.Nil(t, err)
+	_, err = m.GetEligibility(teacherAlice, productPart1)
+	assert.Equal(t, entity.ErrNotFound, err)
+	err = m.RevokeEligibility(teacherAlice, productPart1)
+	assert.Equal(t, entity.ErrNotFound, err)
+	products, _ = m.ListEligibility(teacherAlice)
+	assert.Equal(t, 1, len(products))
+}
+
+func Test_GrantAccountTypes(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	accounts := accountmock.NewMockUseCase(controller)
+	m := NewService(NewInmem(), accounts, nil)
+
+	for id, at := range map[entity.ID]entity.AccountType{
+		teacherAlice:   entity.AccountTeacher,
+		assistantAlice: entity.AccountAssistantTeacher,
+		memberAlice:    entity.AccountMember,
+	} {
+		accounts.EXPECT().GetAccount(tenantAlice, id).
+			Return(&entity.Account{ID: id, TenantID: tenantAlice, Type: at}, nil).AnyTimes()
+	}
+
+	assert.Nil(t, m.GrantEligibility(tenantAlice, teacherAlice, productPart1, entity.EligibilityPrimary))
+	assert.Nil(t, m.GrantEligibility(tenantAlice, assistantAlice, productPart1, entity.EligibilityAssistant))
+	assert.ErrorIs(t, m.GrantEligibility(tenantAlice, assistantAlice, productPart1, entity.EligibilityPrimary), entity.ErrInvalidEntity)
+	assert.ErrorIs(t, m.GrantEligibility(tenantAlice, memberAlice, productPart1, entity.EligibilityAssistant), entity.ErrInvalidEntity)
+}
+
+func Test_GrantTenantProducts(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	products := productmock.NewMockUseCase(controller)
+	m := NewService(NewInmem(), nil, products)
+
+	products.EXPECT().GetProduct(tenantAlice, productPart1).
+		Return(&entity.Product{ID: productPart1, TenantID: tenantAlice}, nil)
+	products.EXPECT().GetProduct(tenantAlice, productPart2).Return(nil, entity.ErrNotFound)
+
+	assert.Nil(t, m.GrantEligibility(tenantAlice, teacherAlice, productPart1, entity.EligibilityPrimary))
+	// a product of another tenant
+	assert.Equal(t, entity.ErrNotFound, m.GrantEligibility(tenantAlice, teacherAlice, productPart2, entity.EligibilityPrimary))
+	_, err := m.GetEligibility(teacherAlice, productPart2)
+	assert.Equal(t, entity.ErrNotFound, err)
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package notify
+
+import (
+	"slices"
+
+	"sudhagar/glad/entity"
+)
+
+// Inmem in memory repo
+type Inmem struct {
+	m      map[entity.ID][]entity.ID
+	queue  []entity.ID
+	states map[entity.ID]*entity.CourseNotifyState
+}
+
+// NewInmem create new repository
+func NewInmem() *Inmem {
+	return &Inmem{
+		m:      map[entity.ID][]entity.ID{},
+		states: map[entity.ID]*entity.CourseNotifyState{},
+	}
+}
+
+// ListSubscribers lists the accounts subscribed to the course
+func (r *Inmem) ListSubscribers(courseID entity.ID) ([]entity.ID, error) {
+	return r.m[courseID], nil
+}
+
+// Subscribe subscribes the account to the course
+func (r *Inmem) Subscribe(courseID, accountID entity.ID) error {
+	if !slices.Contains(r.m[courseID], accountID) {
+		r.m[courseID] = append(r.m[courseID], accountID)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes the account from the course
+func (r *Inmem) Unsubscribe(courseID, accountID entity.ID) error {
+	i := slices.Index(r.m[courseID], accountID)
+	if i < 0 {
+		return entity.ErrNotFound
+	}
+	r.m[courseID] = slices.Delete(r.m[courseID], i, i+1)
+	return nil
+}
+
+// GetState gets what the subscribers of the course were last notified of
+func (r *Inmem) GetState(courseID entity.ID) (*entity.CourseNotifyState, error) {
+	return r.states[courseID], nil
+}
+
+// SaveState saves what the subscribers of the course were notified of
+func (r *Inmem) SaveState(s *entity.CourseNotifyState) error {
+	r.states[s.CourseID] = s
+	return nil
+}
+
+// Queue queues the course to notify its changes
+func (r *Inmem) Queue(courseID entity.ID) error {
+	if !slices.Contains(r.queue, courseID) {
+		r.queue = append(r.queue, courseID)
+	}
+	return nil
+}
+
+// Dequeue takes up to limit queued courses, oldest first
+func (r *Inmem) Dequeue(limit int) ([]entity.ID, error) {
+	n := len(r.queue)
+	if limit > 0 && n > limit {
+		n = limit
+	}
+	ids := slices.Clone(r.queue[:n])
+	r.queue = r.queue[n:]
+	return ids, nil
+}
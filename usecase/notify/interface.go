@@ -0,0 +1,65 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package notify
+
+import (
+	"sudhagar/glad/entity"
+)
+
+// Reader interface
+type Reader interface {
+	ListSubscribers(courseID entity.ID) ([]entity.ID, error)
+	// GetState gets what the subscribers of the course were last notified
+	// of; nil if none
+	GetState(courseID entity.ID) (*entity.CourseNotifyState, error)
+}
+
+// Writer course notification subscription writer
+type Writer interface {
+	// Subscribe subscribes the account to the course; subscribing twice is
+	// not an error
+	Subscribe(courseID, accountID entity.ID) error
+	Unsubscribe(courseID, accountID entity.ID) error
+	// Queue queues the course to notify its changes; queuing twice is not an
+	// error. The database queues the changed courses itself.
+	Queue(courseID entity.ID) error
+	// Dequeue takes up to limit queued courses, oldest first; other
+	// instances don't get them
+	Dequeue(limit int) ([]entity.ID, error)
+	SaveState(s *entity.CourseNotifyState) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// CourseReader reads the courses notified about, of any tenant; the course
+// repository is one
+type CourseReader interface {
+	GetByID(id entity.ID) (*entity.Course, error)
+}
+
+// TimingReader reads the timings of the courses notified about; the course
+// timing repository is one
+type TimingReader interface {
+	ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error)
+}
+
+// UseCase interface
+type UseCase interface {
+	ListSubscribers(courseID entity.ID) ([]entity.ID, error)
+	Subscribe(courseID, accountID entity.ID) error
+	// CheckSubscribers checks the accounts can subscribe to a course of the
+	// tenant
+	CheckSubscribers(tenantID entity.ID, accountIDs []entity.ID) error
+	Unsubscribe(courseID, accountID entity.ID) error
+	// NotifyQueuedChanges notifies the subscribers of up to limit queued
+	// courses of their changes; returns the number of changes notified
+	NotifyQueuedChanges(limit int) (int, error)
+}
@@ -0,0 +1,454 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/notify/interface.go
+
+// Package mock_notify is a generated GoMock package.
+package mock_notify
+
+import (
+	reflect "reflect"
+	entity "sudhagar/glad/entity"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// GetState mocks base method.
+func (m *MockReader) GetState(courseID entity.ID) (*entity.CourseNotifyState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", courseID)
+	ret0, _ := ret[0].(*entity.CourseNotifyState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockReaderMockRecorder) GetState(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockReader)(nil).GetState), courseID)
+}
+
+// ListSubscribers mocks base method.
+func (m *MockReader) ListSubscribers(courseID entity.ID) ([]entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscribers", courseID)
+	ret0, _ := ret[0].([]entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscribers indicates an expected call of ListSubscribers.
+func (mr *MockReaderMockRecorder) ListSubscribers(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscribers", reflect.TypeOf((*MockReader)(nil).ListSubscribers), courseID)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Dequeue mocks base method.
+func (m *MockWriter) Dequeue(limit int) ([]entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dequeue", limit)
+	ret0, _ := ret[0].([]entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dequeue indicates an expected call of Dequeue.
+func (mr *MockWriterMockRecorder) Dequeue(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dequeue", reflect.TypeOf((*MockWriter)(nil).Dequeue), limit)
+}
+
+// Queue mocks base method.
+func (m *MockWriter) Queue(courseID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Queue", courseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Queue indicates an expected call of Queue.
+func (mr *MockWriterMockRecorder) Queue(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Queue", reflect.TypeOf((*MockWriter)(nil).Queue), courseID)
+}
+
+// SaveState mocks base method.
+func (m *MockWriter) SaveState(s *entity.CourseNotifyState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveState", s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveState indicates an expected call of SaveState.
+func (mr *MockWriterMockRecorder) SaveState(s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveState", reflect.TypeOf((*MockWriter)(nil).SaveState), s)
+}
+
+// Subscribe mocks base method.
+func (m *MockWriter) Subscribe(courseID, accountID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", courseID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockWriterMockRecorder) Subscribe(courseID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockWriter)(nil).Subscribe), courseID, accountID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockWriter) Unsubscribe(courseID, accountID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", courseID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockWriterMockRecorder) Unsubscribe(courseID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockWriter)(nil).Unsubscribe), courseID, accountID)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Dequeue mocks base method.
+func (m *MockRepository) Dequeue(limit int) ([]entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dequeue", limit)
+	ret0, _ := ret[0].([]entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dequeue indicates an expected call of Dequeue.
+func (mr *MockRepositoryMockRecorder) Dequeue(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dequeue", reflect.TypeOf((*MockRepository)(nil).Dequeue), limit)
+}
+
+// GetState mocks base method.
+func (m *MockRepository) GetState(courseID entity.ID) (*entity.CourseNotifyState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", courseID)
+	ret0, _ := ret[0].(*entity.CourseNotifyState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockRepositoryMockRecorder) GetState(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockRepository)(nil).GetState), courseID)
+}
+
+// ListSubscribers mocks base method.
+func (m *MockRepository) ListSubscribers(courseID entity.ID) ([]entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscribers", courseID)
+	ret0, _ := ret[0].([]entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscribers indicates an expected call of ListSubscribers.
+func (mr *MockRepositoryMockRecorder) ListSubscribers(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscribers", reflect.TypeOf((*MockRepository)(nil).ListSubscribers), courseID)
+}
+
+// Queue mocks base method.
+func (m *MockRepository) Queue(courseID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Queue", courseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Queue indicates an expected call of Queue.
+func (mr *MockRepositoryMockRecorder) Queue(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Queue", reflect.TypeOf((*MockRepository)(nil).Queue), courseID)
+}
+
+// SaveState mocks base method.
+func (m *MockRepository) SaveState(s *entity.CourseNotifyState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveState", s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveState indicates an expected call of SaveState.
+func (mr *MockRepositoryMockRecorder) SaveState(s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveState", reflect.TypeOf((*MockRepository)(nil).SaveState), s)
+}
+
+// Subscribe mocks base method.
+func (m *MockRepository) Subscribe(courseID, accountID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", courseID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockRepositoryMockRecorder) Subscribe(courseID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockRepository)(nil).Subscribe), courseID, accountID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockRepository) Unsubscribe(courseID, accountID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", courseID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockRepositoryMockRecorder) Unsubscribe(courseID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockRepository)(nil).Unsubscribe), courseID, accountID)
+}
+
+// MockCourseReader is a mock of CourseReader interface.
+type MockCourseReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseReaderMockRecorder
+}
+
+// MockCourseReaderMockRecorder is the mock recorder for MockCourseReader.
+type MockCourseReaderMockRecorder struct {
+	mock *MockCourseReader
+}
+
+// NewMockCourseReader creates a new mock instance.
+func NewMockCourseReader(ctrl *gomock.Controller) *MockCourseReader {
+	mock := &MockCourseReader{ctrl: ctrl}
+	mock.recorder = &MockCourseReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseReader) EXPECT() *MockCourseReaderMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockCourseReader) GetByID(id entity.ID) (*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCourseReaderMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCourseReader)(nil).GetByID), id)
+}
+
+// MockTimingReader is a mock of TimingReader interface.
+type MockTimingReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimingReaderMockRecorder
+}
+
+// MockTimingReaderMockRecorder is the mock recorder for MockTimingReader.
+type MockTimingReaderMockRecorder struct {
+	mock *MockTimingReader
+}
+
+// NewMockTimingReader creates a new mock instance.
+func NewMockTimingReader(ctrl *gomock.Controller) *MockTimingReader {
+	mock := &MockTimingReader{ctrl: ctrl}
+	mock.recorder = &MockTimingReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimingReader) EXPECT() *MockTimingReaderMockRecorder {
+	return m.recorder
+}
+
+// ListByCourse mocks base method.
+func (m *MockTimingReader) ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCourse", courseID)
+	ret0, _ := ret[0].([]*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCourse indicates an expected call of ListByCourse.
+func (mr *MockTimingReaderMockRecorder) ListByCourse(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCourse", reflect.TypeOf((*MockTimingReader)(nil).ListByCourse), courseID)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CheckSubscribers mocks base method.
+func (m *MockUseCase) CheckSubscribers(tenantID entity.ID, accountIDs []entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSubscribers", tenantID, accountIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSubscribers indicates an expected call of CheckSubscribers.
+func (mr *MockUseCaseMockRecorder) CheckSubscribers(tenantID, accountIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSubscribers", reflect.TypeOf((*MockUseCase)(nil).CheckSubscribers), tenantID, accountIDs)
+}
+
+// ListSubscribers mocks base method.
+func (m *MockUseCase) ListSubscribers(courseID entity.ID) ([]entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscribers", courseID)
+	ret0, _ := ret[0].([]entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscribers indicates an expected call of ListSubscribers.
+func (mr *MockUseCaseMockRecorder) ListSubscribers(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscribers", reflect.TypeOf((*MockUseCase)(nil).ListSubscribers), courseID)
+}
+
+// NotifyQueuedChanges mocks base method.
+func (m *MockUseCase) NotifyQueuedChanges(limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyQueuedChanges", limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotifyQueuedChanges indicates an expected call of NotifyQueuedChanges.
+func (mr *MockUseCaseMockRecorder) NotifyQueuedChanges(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyQueuedChanges", reflect.TypeOf((*MockUseCase)(nil).NotifyQueuedChanges), limit)
+}
+
+// Subscribe mocks base method.
+func (m *MockUseCase) Subscribe(courseID, accountID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", courseID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockUseCaseMockRecorder) Subscribe(courseID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockUseCase)(nil).Subscribe), courseID, accountID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockUseCase) Unsubscribe(courseID, accountID entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", courseID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockUseCaseMockRecorder) Unsubscribe(courseID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockUseCase)(nil).Unsubscribe), courseID, accountID)
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/mailer"
+	"sudhagar/glad/usecase/account"
+)
+
+// Service course notification usecase
+type Service struct {
+	repo     Repository
+	courses  CourseReader
+	timings  TimingReader
+	accounts account.UseCase
+	sender   mailer.Sender
+}
+
+// NewService create new service. The subscribers are accounts of the
+// tenant of the course; the ones with an email are sent the notifications
+// through sender, none if sender is nil.
+func NewService(r Repository,
+	courses CourseReader,
+	timings TimingReader,
+	accounts account.UseCase,
+	sender mailer.Sender,
+) *Service {
+	return &Service{
+		repo:     r,
+		courses:  courses,
+		timings:  timings,
+		accounts: accounts,
+		sender:   sender,
+	}
+}
+
+// ListSubscribers lists the accounts subscribed to the course
+func (s *Service) ListSubscribers(courseID entity.ID) ([]entity.ID, error) {
+	return s.repo.ListSubscribers(courseID)
+}
+
+// Subscribe subscribes the account to the course
+func (s *Service) Subscribe(courseID, accountID entity.ID) error {
+	c, err := s.getCourse(courseID)
+	if err != nil {
+		return err
+	}
+	err = s.CheckSubscribers(c.TenantID, []entity.ID{accountID})
+	if err != nil {
+		return err
+	}
+	return s.repo.Subscribe(courseID, accountID)
+}
+
+// CheckSubscribers checks the accounts can subscribe to a course of the
+// tenant, before the course is written
+func (s *Service) CheckSubscribers(tenantID entity.ID, accountIDs []entity.ID) error {
+	for _, id := range accountIDs {
+		// the accounts of other tenants are not found
+		_, err := s.accounts.GetAccount(tenantID, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes the account from the course
+func (s *Service) Unsubscribe(courseID, accountID entity.ID) error {
+	ids, err := s.repo.ListSubscribers(courseID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(ids, accountID) {
+		return entity.ErrNotFound
+	}
+	return s.repo.Unsubscribe(courseID, accountID)
+}
+
+// NotifyQueuedChanges notifies the subscribers of up to limit queued
+// courses of the changes since they were last notified. A course that fails
+// is queued again for the next run. Returns the number of changes notified.
+func (s *Service) NotifyQueuedChanges(limit int) (int, error) {
+	ids, err := s.repo.Dequeue(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	notified := 0
+	var errs []error
+	for _, id := range ids {
+		n, err := s.notifyCourse(id)
+		notified += n
+		if err == nil {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("course %s: %w", id, err))
+		if err := s.repo.Queue(id); err != nil {
+			errs = append(errs, fmt.Errorf("course %s: %w", id, err))
+		}
+	}
+	return notified, errors.Join(errs...)
+}
+
+// notifyCourse notifies the changes of the course against the state last
+// notified, and saves the new state. A course without a state, a new one,
+// has nothing to notify.
+func (s *Service) notifyCourse(id entity.ID) (int, error) {
+	c, err := s.courses.GetByID(id)
+	if err != nil {
+		return 0, err
+	}
+	if c == nil {
+		// deleted since
+		return 0, nil
+	}
+	timings, err := s.timings.ListByCourse(id)
+	if err != nil {
+		return 0, err
+	}
+	state := &entity.CourseNotifyState{
+		CourseID:  id,
+		Status:    string(c.Status),
+		Location:  c.Address.String(),
+		Timing:    schedule(timings),
+		UpdatedAt: time.Now(),
+	}
+	saved, err := s.repo.GetState(id)
+	if err != nil {
+		return 0, err
+	}
+
+	changes := saved.Changes(state)
+	for _, change := range changes {
+		// Note: a failed message is not sent again
+		if err := s.send(c, change); err != nil {
+			log.Printf("unable to notify the %s change of course %s: %v", change.Type, id, err)
+		}
+	}
+	return len(changes), s.repo.SaveState(state)
+}
+
+// schedule formats the timings of a course as shown in the notifications
+func schedule(timings []*entity.CourseTiming) string {
+	var dates []string
+	for _, t := range timings {
+		dates = append(dates, t.DateTime.String())
+	}
+	return strings.Join(dates, "; ")
+}
+
+// send sends the message of the change to each subscriber of the course; a
+// failed message doesn't stop the others
+func (s *Service) send(c *entity.Course, change *entity.CourseChange) error {
+	if s.sender == nil {
+		return nil
+	}
+	ids, err := s.repo.ListSubscribers(c.ID)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		a, err := s.accounts.GetAccount(c.TenantID, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("account %s: %w", id, err))
+			continue
+		}
+		if a.Email == "" {
+			continue
+		}
+		m, err := render(c, change, a)
+		if err != nil {
+			return err
+		}
+		if err := s.sender.Send(m); err != nil {
+			errs = append(errs, fmt.Errorf("account %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Service) getCourse(id entity.ID) (*entity.Course, error) {
+	c, err := s.courses.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, entity.ErrNotFound
+	}
+	return c, nil
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/mailer"
+	accountmock "sudhagar/glad/usecase/account/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice entity.ID = 13790492210917015554
+	courseAlice entity.ID = 13790493495087071234
+	alice       entity.ID = 13790493495087077701
+	bob         entity.ID = 13790493495087077702
+	noEmail     entity.ID = 13790493495087077703
+	otherTenant entity.ID = 13790493495087077704
+)
+
+// courses course reader
+type courses map[entity.ID]*entity.Course
+
+func (c courses) GetByID(id entity.ID) (*entity.Course, error) {
+	return c[id], nil
+}
+
+// timings course timing reader
+type timings map[entity.ID][]*entity.CourseTiming
+
+func (t timings) ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	return t[courseID], nil
+}
+
+// outbox sender keeping the messages
+type outbox struct {
+	sent []*mailer.Message
+	fail map[string]bool
+}
+
+func (o *outbox) Send(m *mailer.Message) error {
+	if o.fail[m.To[0]] {
+		return errors.New("mailbox unavailable")
+	}
+	o.sent = append(o.sent, m)
+	return nil
+}
+
+func newFixture(t *testing.T) (*Service, *outbox, courses, timings) {
+	controller := gomock.NewController(t)
+	t.Cleanup(controller.Finish)
+	accounts := accountmock.NewMockUseCase(controller)
+	for id, a := range map[entity.ID]*entity.Account{
+		alice:       {ID: alice, TenantID: tenantAlice, FirstName: "Alice", Email: "alice@example.org"},
+		bob:         {ID: bob, TenantID: tenantAlice, FirstName: "Bob", Email: "bob@example.org"},
+		noEmail:     {ID: noEmail, TenantID: tenantAlice, FirstName: "Carol"},
+		otherTenant: {ID: otherTenant, TenantID: tenantAlice + 1, FirstName: "Dave", Email: "dave@example.org"},
+	} {
+		accounts.EXPECT().GetAccount(a.TenantID, id).Return(a, nil).AnyTimes()
+	}
+	accounts.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(nil, entity.ErrNotFound).AnyTimes()
+
+	c := courses{courseAlice: {
+		ID:       courseAlice,
+		TenantID: tenantAlice,
+		Name:     "Happiness Program",
+		Timezone: "PST",
+		Status:   entity.CourseActive,
+	}}
+	tm := timings{}
+	o := &outbox{fail: map[string]bool{}}
+	return NewService(NewInmem(), c, tm, accounts, o), o, c, tm
+}
+
+func Test_Subscribe(t *testing.T) {
+	m, _, _, _ := newFixture(t)
+
+	assert.Nil(t, m.Subscribe(courseAlice, alice))
+	assert.Nil(t, m.Subscribe(courseAlice, bob))
+	// twice
+	assert.Nil(t, m.Subscribe(courseAlice, alice))
+	ids, err := m.ListSubscribers(courseAlice)
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.ID{alice, bob}, ids)
+
+	assert.Equal(t, entity.ErrNotFound, m.Subscribe(courseAlice, otherTenant))
+	assert.Equal(t, entity.ErrNotFound, m.Subscribe(courseAlice, alice+100))
+	assert.Equal(t, entity.ErrNotFound, m.Subscribe(courseAlice+1, alice))
+
+	assert.Nil(t, m.CheckSubscribers(tenantAlice, []entity.ID{alice, bob}))
+	assert.Equal(t, entity.ErrNotFound, m.CheckSubscribers(tenantAlice, []entity.ID{alice, otherTenant}))
+
+	assert.Nil(t, m.Unsubscribe(courseAlice, alice))
+	assert.Equal(t, entity.ErrNotFound, m.Unsubscribe(courseAlice, alice))
+	ids, _ = m.ListSubscribers(courseAlice)
+	assert.Equal(t, []entity.ID{bob}, ids)
+}
+
+func Test_NotifyQueuedChanges(t *testing.T) {
+	m, o, c, tm := newFixture(t)
+	for _, id := range []entity.ID{alice, bob, noEmail} {
+		assert.Nil(t, m.Subscribe(courseAlice, id))
+	}
+
+	// a new course has nothing to notify
+	assert.Nil(t, m.repo.Queue(courseAlice))
+	n, err := m.NotifyQueuedChanges(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 0, len(o.sent))
+
+	c[courseAlice].Status = entity.CourseCanceled
+	assert.Nil(t, m.repo.Queue(courseAlice))
+	n, err = m.NotifyQueuedChanges(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	// no email, no message
+	assert.Equal(t, 2, len(o.sent))
+	assert.Equal(t, []string{"alice@example.org"}, o.sent[0].To)
+	assert.Equal(t, "Happiness Program is canceled", o.sent[0].Subject)
+	assert.Contains(t, o.sent[0].Body, "Hi Alice,")
+	assert.Contains(t, o.sent[0].Body, "changed from active to canceled")
+
+	// notified once
+	n, err = m.NotifyQueuedChanges(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	o.sent = nil
+	tm[courseAlice] = []*entity.CourseTiming{{CourseID: courseAlice,
+		DateTime: entity.CourseDateTime{Date: "2024-05-02", StartTime: "09:00", EndTime: "12:00"}}}
+	assert.Nil(t, m.repo.Queue(courseAlice))
+	_, err = m.NotifyQueuedChanges(0)
+	assert.Nil(t, err)
+	assert.Equal(t, "New schedule for Happiness Program", o.sent[1].Subject)
+	assert.Contains(t, o.sent[1].Body, "Now: 2024-05-02 09:00-12:00")
+	assert.NotContains(t, o.sent[1].Body, "Before:")
+	assert.Contains(t, o.sent[1].Body, "All the times are in PST.")
+
+	// a failed message doesn't stop the others
+	o.sent = nil
+	o.fail["alice@example.org"] = true
+	c[courseAlice].Address.Street1 = "2 Street Way"
+	assert.Nil(t, m.repo.Queue(courseAlice))
+	n, err = m.NotifyQueuedChanges(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, len(o.sent))
+	assert.Equal(t, []string{"bob@example.org"}, o.sent[0].To)
+	assert.Contains(t, o.sent[0].Body, "Now: 2 Street Way")
+
+	// deleted since queued
+	assert.Nil(t, m.repo.Queue(courseAlice+1))
+	n, err = m.NotifyQueuedChanges(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func Test_NotifyQueuedChangesFailed(t *testing.T) {
+	m, _, c, _ := newFixture(t)
+	assert.Nil(t, m.repo.Queue(courseAlice))
+	_, _ = m.NotifyQueuedChanges(0)
+
+	// queued again when the state can't be read
+	c[courseAlice].Status = entity.CourseCanceled
+	m.timings = failingTimings{}
+	assert.Nil(t, m.repo.Queue(courseAlice))
+	_, err := m.NotifyQueuedChanges(0)
+	assert.NotNil(t, err)
+	ids, _ := m.repo.Dequeue(0)
+	assert.Equal(t, []entity.ID{courseAlice}, ids)
+}
+
+// failingTimings a timing reader that fails
+type failingTimings struct{}
+
+func (failingTimings) ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	return nil, errors.New("connection refused")
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/mailer"
+)
+
+// courseTemplate the subject and the body of the message of a change type
+type courseTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// templateData the data the templates are executed with
+type templateData struct {
+	Course    *entity.Course
+	Change    *entity.CourseChange
+	Recipient *entity.Account
+}
+
+func newCourseTemplate(subject, body string) courseTemplate {
+	return courseTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// templates the messages per change type
+var templates = map[entity.CourseChangeType]courseTemplate{
+	entity.CourseChangeStatus: newCourseTemplate(
+		`{{.Course.Name}} is {{.Change.To}}`,
+		`Hi {{.Recipient.FirstName}},
+
+the status of the course {{.Course.Name}} changed from {{.Change.From}} to {{.Change.To}}.
+`),
+	entity.CourseChangeTiming: newCourseTemplate(
+		`New schedule for {{.Course.Name}}`,
+		`Hi {{.Recipient.FirstName}},
+
+the schedule of the course {{.Course.Name}} changed.
+{{if .Change.From}}
+Before: {{.Change.From}}{{end}}
+Now: {{if .Change.To}}{{.Change.To}}{{else}}not scheduled{{end}}
+{{if .Course.Timezone}}
+All the times are in {{.Course.Timezone}}.
+{{end}}`),
+	entity.CourseChangeLocation: newCourseTemplate(
+		`New location for {{.Course.Name}}`,
+		`Hi {{.Recipient.FirstName}},
+
+the course {{.Course.Name}} moved.
+{{if .Change.From}}
+Before: {{.Change.From}}{{end}}
+Now: {{.Change.To}}
+`),
+}
+
+// render renders the message of the change to the recipient
+func render(c *entity.Course, change *entity.CourseChange, recipient *entity.Account) (*mailer.Message, error) {
+	t, ok := templates[change.Type]
+	if !ok {
+		return nil, fmt.Errorf("no template for course change %q", change.Type)
+	}
+	data := templateData{
+		Course:    c,
+		Change:    change,
+		Recipient: recipient,
+	}
+
+	var subject, body strings.Builder
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return nil, err
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return nil, err
+	}
+	return &mailer.Message{
+		To:      []string{recipient.Email},
+		Subject: subject.String(),
+		Body:    body.String(),
+	}, nil
+}
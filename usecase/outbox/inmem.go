@@ -0,0 +1,215 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package outbox
+
+import (
+	"sort"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// Inmem in memory repo
+type Inmem struct {
+	m map[entity.ID]*entity.OutboxItem
+}
+
+// NewInmem create new repository
+func NewInmem() *Inmem {
+	var m = map[entity.ID]*entity.OutboxItem{}
+	return &Inmem{
+		m: m,
+	}
+}
+
+// Get an outbox item
+func (r *Inmem) Get(id entity.ID) (*entity.OutboxItem, error) {
+	if r.m[id] == nil {
+		return nil, entity.ErrNotFound
+	}
+	return r.m[id], nil
+}
+
+// ListPending lists pending outbox items
+func (r *Inmem) ListPending(limit int) ([]*entity.OutboxItem, error) {
+	var items []*entity.OutboxItem
+	for _, j := range r.m {
+		if j.Status == entity.SyncPending {
+			items = append(items, j)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// Enqueue an outbox item
+func (r *Inmem) Enqueue(e *entity.OutboxItem) (entity.ID, error) {
+	for _, j := range r.m {
+		if j.Status == entity.SyncPending && j.Object == e.Object && j.EntityID == e.EntityID {
+			j.Operation = e.Operation
+			j.UpdatedAt = time.Now()
+			return j.ID, nil
+		}
+	}
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// Claim pending outbox items and failed ones due for a retry
+func (r *Inmem) Claim(owner string, limit int, lease time.Duration, retry entity.OutboxRetry) ([]*entity.OutboxItem, error) {
+	now := time.Now()
+	var due []*entity.OutboxItem
+	for _, j := range r.m {
+		at := retry.RetryAt(j)
+		if j.Status == entity.SyncPending || (at != nil && at.Before(now)) {
+			due = append(due, j)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+
+	var items []*entity.OutboxItem
+	for _, j := range due {
+		if j.ClaimedUntil != nil && !j.ClaimedUntil.Before(now) {
+			continue
+		}
+		if limit > 0 && len(items) == limit {
+			break
+		}
+		claimedAt, until := now, now.Add(lease)
+		j.ClaimedBy = owner
+		j.ClaimedAt = &claimedAt
+		j.ClaimedUntil = &until
+		items = append(items, j)
+	}
+	return items, nil
+}
+
+// Update an outbox item
+func (r *Inmem) Update(e *entity.OutboxItem) error {
+	_, err := r.Get(e.ID)
+	if err != nil {
+		return err
+	}
+	r.m[e.ID] = e
+	return nil
+}
+
+// claimed gets the item claimed by owner
+func (r *Inmem) claimed(id entity.ID, owner string) (*entity.OutboxItem, error) {
+	o := r.m[id]
+	if o == nil || o.ClaimedBy != owner {
+		return nil, entity.ErrClaimLost
+	}
+	return o, nil
+}
+
+// Renew extends the claim of owner on the item
+func (r *Inmem) Renew(id entity.ID, owner string, lease time.Duration) error {
+	o, err := r.claimed(id, owner)
+	if err != nil {
+		return err
+	}
+	until := time.Now().Add(lease)
+	o.ClaimedUntil = &until
+	return nil
+}
+
+// Release drops the claim of owner on the item
+func (r *Inmem) Release(id entity.ID, owner string) error {
+	o, err := r.claimed(id, owner)
+	if err != nil {
+		return err
+	}
+	releaseClaim(o)
+	return nil
+}
+
+// Complete marks the item claimed by owner as synced and drops the claim;
+// an item changed since it was claimed is left pending
+func (r *Inmem) Complete(id entity.ID, owner string) error {
+	o, err := r.claimed(id, owner)
+	if err != nil {
+		return err
+	}
+	changed := o.ChangedSinceClaim()
+	releaseClaim(o)
+	if changed {
+		return nil
+	}
+	o.Status = entity.SyncSynced
+	o.Attempts++
+	o.LastError = ""
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// Fail marks the item claimed by owner as failed
+func (r *Inmem) Fail(id entity.ID, owner string, cause string) error {
+	o, err := r.claimed(id, owner)
+	if err != nil {
+		return err
+	}
+	releaseClaim(o)
+	o.Status = entity.SyncFailed
+	o.Attempts++
+	o.LastError = cause
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// PendingStats gets the depth, the oldest pending item and the failed count
+func (r *Inmem) PendingStats() (*entity.QueueStats, error) {
+	stats := &entity.QueueStats{}
+	for _, j := range r.m {
+		if j.Status == entity.SyncFailed {
+			stats.Failed++
+		}
+		if j.Status == entity.SyncPending {
+			stats.Depth++
+			if stats.Oldest == nil || j.CreatedAt.Before(*stats.Oldest) {
+				createdAt := j.CreatedAt
+				stats.Oldest = &createdAt
+			}
+		}
+	}
+	return stats, nil
+}
+
+// ListFailed lists failed outbox items
+func (r *Inmem) ListFailed(limit int) ([]*entity.OutboxItem, error) {
+	var items []*entity.OutboxItem
+	for _, j := range r.m {
+		if j.Status == entity.SyncFailed {
+			items = append(items, j)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// Delete an outbox item
+func (r *Inmem) Delete(id entity.ID) error {
+	if r.m[id] == nil {
+		return entity.ErrNotFound
+	}
+	delete(r.m, id)
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package outbox
+
+import (
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// Reader interface
+type Reader interface {
+	Get(id entity.ID) (*entity.OutboxItem, error)
+	// ListPending lists items not yet exported, oldest first; items whose
+	// outbound sync is paused may be left out
+	ListPending(limit int) ([]*entity.OutboxItem, error)
+	PendingStats() (*entity.QueueStats, error)
+	// ListFailed lists the failed items, those waiting for a retry and the
+	// dead letters
+	ListFailed(limit int) ([]*entity.OutboxItem, error)
+}
+
+// Writer outbox writer
+type Writer interface {
+	// Enqueue adds the item; folds it into the pending item of the same
+	// record, if any, and returns the id of the pending item
+	Enqueue(e *entity.OutboxItem) (entity.ID, error)
+	// Claim claims up to limit pending items, and failed items due for a
+	// retry, for owner until now+lease, oldest first. Items claimed by others
+	// are skipped until their claim expires, as are those whose outbound sync
+	// is paused.
+	Claim(owner string, limit int, lease time.Duration, retry entity.OutboxRetry) ([]*entity.OutboxItem, error)
+	// Renew, Release, Complete and Fail update the item only while owner
+	// holds its claim; entity.ErrClaimLost otherwise. Complete drops the
+	// claim and leaves the item pending if it changed since it was claimed.
+	Renew(id entity.ID, owner string, lease time.Duration) error
+	Release(id entity.ID, owner string) error
+	Complete(id entity.ID, owner string) error
+	Fail(id entity.ID, owner string, cause string) error
+	Update(e *entity.OutboxItem) error
+	Delete(id entity.ID) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	EnqueueChange(tenantID entity.ID, object entity.SyncObject, entityID entity.ID, op entity.SyncOperation) (entity.ID, error)
+	// HandleChange turns a sync_change notification payload into export work
+	HandleChange(payload string) error
+	GetOutboxItem(id entity.ID) (*entity.OutboxItem, error)
+	ListPendingOutbox(limit int) ([]*entity.OutboxItem, error)
+	// ClaimPendingOutbox claims pending items, and failed ones due for a
+	// retry, to export; other syncer instances skip them for the lease
+	ClaimPendingOutbox(owner string, limit int, lease time.Duration) ([]*entity.OutboxItem, error)
+	// RenewOutboxItem extends the claim of owner before the item is
+	// exported; entity.ErrClaimLost if another instance claimed it since
+	RenewOutboxItem(id entity.ID, owner string, lease time.Duration) error
+	// ReleaseOutboxItem drops the claim; the item is left pending
+	ReleaseOutboxItem(id entity.ID, owner string) error
+	GetPendingStats() (*entity.QueueStats, error)
+	// CompleteOutboxItem marks the item as exported; it is left pending if
+	// changed while it was being exported
+	CompleteOutboxItem(id entity.ID, owner string) error
+	// FailOutboxItem records a failed export attempt; the item is retried
+	// with a backoff
+	FailOutboxItem(id entity.ID, owner string, cause error) error
+	ListFailedOutbox(limit int) ([]*entity.OutboxItem, error)
+	// ReplayOutboxItem queues a failed item for export again
+	ReplayOutboxItem(id entity.ID) (entity.ID, error)
+}
@@ -0,0 +1,628 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/outbox/interface.go
+
+// Package mock_outbox is a generated GoMock package.
+package mock_outbox
+
+import (
+	reflect "reflect"
+	entity "sudhagar/glad/entity"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReader) Get(id entity.ID) (*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReaderMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), id)
+}
+
+// ListFailed mocks base method.
+func (m *MockReader) ListFailed(limit int) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailed", limit)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFailed indicates an expected call of ListFailed.
+func (mr *MockReaderMockRecorder) ListFailed(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailed", reflect.TypeOf((*MockReader)(nil).ListFailed), limit)
+}
+
+// ListPending mocks base method.
+func (m *MockReader) ListPending(limit int) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", limit)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockReaderMockRecorder) ListPending(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockReader)(nil).ListPending), limit)
+}
+
+// PendingStats mocks base method.
+func (m *MockReader) PendingStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingStats indicates an expected call of PendingStats.
+func (mr *MockReaderMockRecorder) PendingStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingStats", reflect.TypeOf((*MockReader)(nil).PendingStats))
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockWriter) Claim(owner string, limit int, lease time.Duration, retry entity.OutboxRetry) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", owner, limit, lease, retry)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockWriterMockRecorder) Claim(owner, limit, lease, retry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockWriter)(nil).Claim), owner, limit, lease, retry)
+}
+
+// Complete mocks base method.
+func (m *MockWriter) Complete(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockWriterMockRecorder) Complete(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockWriter)(nil).Complete), id, owner)
+}
+
+// Delete mocks base method.
+func (m *MockWriter) Delete(id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), id)
+}
+
+// Enqueue mocks base method.
+func (m *MockWriter) Enqueue(e *entity.OutboxItem) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockWriterMockRecorder) Enqueue(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockWriter)(nil).Enqueue), e)
+}
+
+// Fail mocks base method.
+func (m *MockWriter) Fail(id entity.ID, owner, cause string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", id, owner, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockWriterMockRecorder) Fail(id, owner, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockWriter)(nil).Fail), id, owner, cause)
+}
+
+// Release mocks base method.
+func (m *MockWriter) Release(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockWriterMockRecorder) Release(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockWriter)(nil).Release), id, owner)
+}
+
+// Renew mocks base method.
+func (m *MockWriter) Renew(id entity.ID, owner string, lease time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", id, owner, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockWriterMockRecorder) Renew(id, owner, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockWriter)(nil).Renew), id, owner, lease)
+}
+
+// Update mocks base method.
+func (m *MockWriter) Update(e *entity.OutboxItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWriterMockRecorder) Update(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockRepository) Claim(owner string, limit int, lease time.Duration, retry entity.OutboxRetry) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", owner, limit, lease, retry)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockRepositoryMockRecorder) Claim(owner, limit, lease, retry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockRepository)(nil).Claim), owner, limit, lease, retry)
+}
+
+// Complete mocks base method.
+func (m *MockRepository) Complete(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockRepositoryMockRecorder) Complete(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockRepository)(nil).Complete), id, owner)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+}
+
+// Enqueue mocks base method.
+func (m *MockRepository) Enqueue(e *entity.OutboxItem) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockRepositoryMockRecorder) Enqueue(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockRepository)(nil).Enqueue), e)
+}
+
+// Fail mocks base method.
+func (m *MockRepository) Fail(id entity.ID, owner, cause string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", id, owner, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockRepositoryMockRecorder) Fail(id, owner, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockRepository)(nil).Fail), id, owner, cause)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(id entity.ID) (*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
+}
+
+// ListFailed mocks base method.
+func (m *MockRepository) ListFailed(limit int) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailed", limit)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFailed indicates an expected call of ListFailed.
+func (mr *MockRepositoryMockRecorder) ListFailed(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailed", reflect.TypeOf((*MockRepository)(nil).ListFailed), limit)
+}
+
+// ListPending mocks base method.
+func (m *MockRepository) ListPending(limit int) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", limit)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockRepositoryMockRecorder) ListPending(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockRepository)(nil).ListPending), limit)
+}
+
+// PendingStats mocks base method.
+func (m *MockRepository) PendingStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingStats indicates an expected call of PendingStats.
+func (mr *MockRepositoryMockRecorder) PendingStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingStats", reflect.TypeOf((*MockRepository)(nil).PendingStats))
+}
+
+// Release mocks base method.
+func (m *MockRepository) Release(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRepositoryMockRecorder) Release(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRepository)(nil).Release), id, owner)
+}
+
+// Renew mocks base method.
+func (m *MockRepository) Renew(id entity.ID, owner string, lease time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", id, owner, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockRepositoryMockRecorder) Renew(id, owner, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockRepository)(nil).Renew), id, owner, lease)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(e *entity.OutboxItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ClaimPendingOutbox mocks base method.
+func (m *MockUseCase) ClaimPendingOutbox(owner string, limit int, lease time.Duration) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingOutbox", owner, limit, lease)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingOutbox indicates an expected call of ClaimPendingOutbox.
+func (mr *MockUseCaseMockRecorder) ClaimPendingOutbox(owner, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingOutbox", reflect.TypeOf((*MockUseCase)(nil).ClaimPendingOutbox), owner, limit, lease)
+}
+
+// CompleteOutboxItem mocks base method.
+func (m *MockUseCase) CompleteOutboxItem(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOutboxItem", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteOutboxItem indicates an expected call of CompleteOutboxItem.
+func (mr *MockUseCaseMockRecorder) CompleteOutboxItem(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOutboxItem", reflect.TypeOf((*MockUseCase)(nil).CompleteOutboxItem), id, owner)
+}
+
+// EnqueueChange mocks base method.
+func (m *MockUseCase) EnqueueChange(tenantID entity.ID, object entity.SyncObject, entityID entity.ID, op entity.SyncOperation) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueChange", tenantID, object, entityID, op)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueChange indicates an expected call of EnqueueChange.
+func (mr *MockUseCaseMockRecorder) EnqueueChange(tenantID, object, entityID, op interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueChange", reflect.TypeOf((*MockUseCase)(nil).EnqueueChange), tenantID, object, entityID, op)
+}
+
+// FailOutboxItem mocks base method.
+func (m *MockUseCase) FailOutboxItem(id entity.ID, owner string, cause error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailOutboxItem", id, owner, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailOutboxItem indicates an expected call of FailOutboxItem.
+func (mr *MockUseCaseMockRecorder) FailOutboxItem(id, owner, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailOutboxItem", reflect.TypeOf((*MockUseCase)(nil).FailOutboxItem), id, owner, cause)
+}
+
+// GetOutboxItem mocks base method.
+func (m *MockUseCase) GetOutboxItem(id entity.ID) (*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxItem", id)
+	ret0, _ := ret[0].(*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxItem indicates an expected call of GetOutboxItem.
+func (mr *MockUseCaseMockRecorder) GetOutboxItem(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxItem", reflect.TypeOf((*MockUseCase)(nil).GetOutboxItem), id)
+}
+
+// GetPendingStats mocks base method.
+func (m *MockUseCase) GetPendingStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingStats indicates an expected call of GetPendingStats.
+func (mr *MockUseCaseMockRecorder) GetPendingStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStats", reflect.TypeOf((*MockUseCase)(nil).GetPendingStats))
+}
+
+// HandleChange mocks base method.
+func (m *MockUseCase) HandleChange(payload string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleChange", payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleChange indicates an expected call of HandleChange.
+func (mr *MockUseCaseMockRecorder) HandleChange(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleChange", reflect.TypeOf((*MockUseCase)(nil).HandleChange), payload)
+}
+
+// ListFailedOutbox mocks base method.
+func (m *MockUseCase) ListFailedOutbox(limit int) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailedOutbox", limit)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFailedOutbox indicates an expected call of ListFailedOutbox.
+func (mr *MockUseCaseMockRecorder) ListFailedOutbox(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailedOutbox", reflect.TypeOf((*MockUseCase)(nil).ListFailedOutbox), limit)
+}
+
+// ListPendingOutbox mocks base method.
+func (m *MockUseCase) ListPendingOutbox(limit int) ([]*entity.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingOutbox", limit)
+	ret0, _ := ret[0].([]*entity.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingOutbox indicates an expected call of ListPendingOutbox.
+func (mr *MockUseCaseMockRecorder) ListPendingOutbox(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingOutbox", reflect.TypeOf((*MockUseCase)(nil).ListPendingOutbox), limit)
+}
+
+// ReleaseOutboxItem mocks base method.
+func (m *MockUseCase) ReleaseOutboxItem(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseOutboxItem", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseOutboxItem indicates an expected call of ReleaseOutboxItem.
+func (mr *MockUseCaseMockRecorder) ReleaseOutboxItem(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseOutboxItem", reflect.TypeOf((*MockUseCase)(nil).ReleaseOutboxItem), id, owner)
+}
+
+// RenewOutboxItem mocks base method.
+func (m *MockUseCase) RenewOutboxItem(id entity.ID, owner string, lease time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewOutboxItem", id, owner, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewOutboxItem indicates an expected call of RenewOutboxItem.
+func (mr *MockUseCaseMockRecorder) RenewOutboxItem(id, owner, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewOutboxItem", reflect.TypeOf((*MockUseCase)(nil).RenewOutboxItem), id, owner, lease)
+}
+
+// ReplayOutboxItem mocks base method.
+func (m *MockUseCase) ReplayOutboxItem(id entity.ID) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayOutboxItem", id)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayOutboxItem indicates an expected call of ReplayOutboxItem.
+func (mr *MockUseCaseMockRecorder) ReplayOutboxItem(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayOutboxItem", reflect.TypeOf((*MockUseCase)(nil).ReplayOutboxItem), id)
+}
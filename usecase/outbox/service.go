@@ -0,0 +1,181 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// SyncChangeChannel channel notified by the enqueue_sync_change trigger
+const SyncChangeChannel = "sync_change"
+
+// Service outbox usecase
+type Service struct {
+	repo  Repository
+	retry entity.OutboxRetry
+}
+
+// NewService create new service; the failed items are retried as per retry
+func NewService(r Repository, retry entity.OutboxRetry) *Service {
+	return &Service{
+		repo:  r,
+		retry: retry,
+	}
+}
+
+// EnqueueChange adds a change to be exported
+func (s *Service) EnqueueChange(tenantID entity.ID,
+	object entity.SyncObject,
+	entityID entity.ID,
+	op entity.SyncOperation,
+) (entity.ID, error) {
+	o, err := entity.NewOutboxItem(tenantID, object, entityID, op)
+	if err != nil {
+		return entity.IDInvalid, err
+	}
+	return s.repo.Enqueue(o)
+}
+
+// HandleChange enqueues the change of a sync_change notification. Changes
+// written by the inbound path came from Salesforce and are skipped, as are
+// those of courses and timings, which the trigger queued along with the
+// change.
+func (s *Service) HandleChange(payload string) error {
+	var c entity.SyncChange
+	if err := json.Unmarshal([]byte(payload), &c); err != nil {
+		return err
+	}
+	if c.Origin == entity.SyncOriginSalesforce {
+		return nil
+	}
+	switch entity.SyncObject(c.Table) {
+	case entity.SyncObjectCourse, entity.SyncObjectTiming:
+		return nil
+	}
+
+	id, err := entity.StringToID(c.ID)
+	if err != nil {
+		return entity.ErrInvalidEntity
+	}
+	tenantID := entity.ID(entity.IDInvalid)
+	if c.TenantID != "" {
+		if tenantID, err = entity.StringToID(c.TenantID); err != nil {
+			return entity.ErrInvalidEntity
+		}
+	}
+	_, err = s.EnqueueChange(tenantID, entity.SyncObject(c.Table), id, c.Operation)
+	return err
+}
+
+// GetOutboxItem retrieves an outbox item
+func (s *Service) GetOutboxItem(id entity.ID) (*entity.OutboxItem, error) {
+	o, err := s.repo.Get(id)
+	if o == nil {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// ListPendingOutbox lists the changes to be exported
+func (s *Service) ListPendingOutbox(limit int) ([]*entity.OutboxItem, error) {
+	items, err := s.repo.ListPending(limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return items, nil
+}
+
+// ClaimPendingOutbox claims up to limit changes to be exported for owner,
+// the failed ones due for a retry included
+func (s *Service) ClaimPendingOutbox(owner string, limit int, lease time.Duration) ([]*entity.OutboxItem, error) {
+	items, err := s.repo.Claim(owner, limit, lease, s.retry)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return items, nil
+}
+
+// RenewOutboxItem extends the claim of owner on an item to the lease
+func (s *Service) RenewOutboxItem(id entity.ID, owner string, lease time.Duration) error {
+	return s.repo.Renew(id, owner, lease)
+}
+
+// ReleaseOutboxItem drops the claim of owner on an item not exported
+func (s *Service) ReleaseOutboxItem(id entity.ID, owner string) error {
+	return s.repo.Release(id, owner)
+}
+
+// releaseClaim clears the claim of the item
+func releaseClaim(o *entity.OutboxItem) {
+	o.ClaimedBy = ""
+	o.ClaimedAt = nil
+	o.ClaimedUntil = nil
+}
+
+// GetPendingStats gets the depth and the oldest pending item of the queue
+func (s *Service) GetPendingStats() (*entity.QueueStats, error) {
+	return s.repo.PendingStats()
+}
+
+// CompleteOutboxItem marks an outbox item claimed by owner as synced. An
+// item changed while it was being exported is left pending, so that the
+// change is exported too.
+func (s *Service) CompleteOutboxItem(id entity.ID, owner string) error {
+	return s.repo.Complete(id, owner)
+}
+
+// FailOutboxItem marks an outbox item claimed by owner as failed
+func (s *Service) FailOutboxItem(id entity.ID, owner string, cause error) error {
+	lastError := ""
+	if cause != nil {
+		lastError = cause.Error()
+	}
+	return s.repo.Fail(id, owner, lastError)
+}
+
+// ListFailedOutbox lists the changes that failed to export
+func (s *Service) ListFailedOutbox(limit int) ([]*entity.OutboxItem, error) {
+	items, err := s.repo.ListFailed(limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return items, nil
+}
+
+// ReplayOutboxItem moves a failed item back to the queue. It is folded into
+// the pending item of the same record, if any. Returns the queued item id.
+func (s *Service) ReplayOutboxItem(id entity.ID) (entity.ID, error) {
+	o, err := s.GetOutboxItem(id)
+	if err != nil {
+		return entity.IDInvalid, err
+	}
+	if o.Status != entity.SyncFailed {
+		return entity.IDInvalid, entity.ErrInvalidEntity
+	}
+
+	queued, err := s.EnqueueChange(o.TenantID, o.Object, o.EntityID, o.Operation)
+	if err != nil {
+		return entity.IDInvalid, err
+	}
+	return queued, s.repo.Delete(id)
+}
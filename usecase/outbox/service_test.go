@@ -0,0 +1,224 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package outbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"sudhagar/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice entity.ID = 13790492210917015554
+	courseAlice entity.ID = 13790493495087071234
+	centerAlice entity.ID = 13790493495087075501
+)
+
+func Test_Enqueue(t *testing.T) {
+	m := NewService(NewInmem(), entity.OutboxRetry{})
+
+	id1, err := m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncInsert)
+	assert.Nil(t, err)
+
+	// folded into the pending item
+	id2, err := m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncUpdate)
+	assert.Nil(t, err)
+	assert.Equal(t, id1, id2)
+	item, _ := m.GetOutboxItem(id1)
+	assert.Equal(t, entity.SyncUpdate, item.Operation)
+
+	_, err = m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, "truncate")
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+
+	// a new change after the export is a new item
+	_, _ = m.ClaimPendingOutbox("syncer-1", 0, time.Minute)
+	err = m.CompleteOutboxItem(id1, "syncer-1")
+	assert.Nil(t, err)
+	id3, err := m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncUpdate)
+	assert.Nil(t, err)
+	assert.NotEqual(t, id1, id3)
+}
+
+func Test_HandleChange(t *testing.T) {
+	m := NewService(NewInmem(), entity.OutboxRetry{})
+
+	err := m.HandleChange(`{"table": "center", "id": "13790493495087075501", "tenant_id": "13790492210917015554",
+		"operation": "update", "origin": "local"}`)
+	assert.Nil(t, err)
+	// written by the inbound path
+	err = m.HandleChange(`{"table": "product", "id": "13790493495087071234", "operation": "update", "origin": "salesforce"}`)
+	assert.Nil(t, err)
+	// queued by the trigger
+	err = m.HandleChange(`{"table": "course", "id": "13790493495087071234", "operation": "update", "origin": "local"}`)
+	assert.Nil(t, err)
+	err = m.HandleChange(`{"table": "course_timing", "id": "13790493495087071234", "operation": "insert", "origin": "local"}`)
+	assert.Nil(t, err)
+
+	items, err := m.ListPendingOutbox(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, entity.SyncObjectCenter, items[0].Object)
+	assert.Equal(t, centerAlice, items[0].EntityID)
+	assert.Equal(t, tenantAlice, items[0].TenantID)
+
+	err = m.HandleChange(`{"table": "account", "id": "abc", "operation": "update"}`)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+	err = m.HandleChange(`not json`)
+	assert.NotNil(t, err)
+}
+
+func Test_Fail(t *testing.T) {
+	m := NewService(NewInmem(), entity.OutboxRetry{})
+
+	id, _ := m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncUpdate)
+	_, _ = m.ClaimPendingOutbox("syncer-1", 0, time.Minute)
+	err := m.FailOutboxItem(id, "syncer-1", errors.New("timeout"))
+	assert.Nil(t, err)
+
+	item, _ := m.GetOutboxItem(id)
+	assert.Equal(t, entity.SyncFailed, item.Status)
+	assert.Equal(t, "timeout", item.LastError)
+	assert.Equal(t, int32(1), item.Attempts)
+
+	_, err = m.ListPendingOutbox(0)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	stats, err := m.GetPendingStats()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, stats.Depth)
+	assert.Equal(t, 1, stats.Failed)
+}
+
+func Test_Retry(t *testing.T) {
+	m := NewService(NewInmem(), entity.OutboxRetry{
+		Backoff:     time.Minute,
+		MaxBackoff:  time.Hour,
+		MaxAttempts: 2,
+	})
+
+	id, _ := m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncUpdate)
+	_, _ = m.ClaimPendingOutbox("syncer-1", 0, time.Minute)
+	_ = m.FailOutboxItem(id, "syncer-1", errors.New("timeout"))
+
+	// not due yet
+	_, err := m.ClaimPendingOutbox("syncer-1", 0, time.Minute)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	item, _ := m.GetOutboxItem(id)
+	item.UpdatedAt = time.Now().Add(-90 * time.Second)
+	items, err := m.ClaimPendingOutbox("syncer-1", 0, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, id, items[0].ID)
+
+	// the backoff doubles
+	_ = m.FailOutboxItem(id, "syncer-1", errors.New("timeout"))
+	item, _ = m.GetOutboxItem(id)
+	assert.Equal(t, int32(2), item.Attempts)
+	item.UpdatedAt = time.Now().Add(-90 * time.Second)
+	assert.True(t, entity.OutboxRetry{Backoff: time.Minute, MaxBackoff: time.Hour, MaxAttempts: 3}.
+		RetryAt(item).After(time.Now()))
+
+	// a dead letter after the max attempts
+	item.UpdatedAt = time.Now().Add(-24 * time.Hour)
+	_, err = m.ClaimPendingOutbox("syncer-1", 0, time.Minute)
+	assert.Equal(t, entity.ErrNotFound, err)
+	items, err = m.ListFailedOutbox(0)
+	assert.Nil(t, err)
+	assert.Equal(t, id, items[0].ID)
+}
+
+func Test_Replay(t *testing.T) {
+	m := NewService(NewInmem(), entity.OutboxRetry{})
+
+	failedID, _ := m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncUpdate)
+	_, _ = m.ClaimPendingOutbox("syncer-1", 0, time.Minute)
+	_ = m.FailOutboxItem(failedID, "syncer-1", errors.New("timeout"))
+
+	items, err := m.ListFailedOutbox(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(items))
+
+	_, err = m.ReplayOutboxItem(entity.NewID())
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	// folded into the pending item of the record
+	pendingID, _ := m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncUpdate)
+	queuedID, err := m.ReplayOutboxItem(failedID)
+	assert.Nil(t, err)
+	assert.Equal(t, pendingID, queuedID)
+
+	_, err = m.ListFailedOutbox(0)
+	assert.Equal(t, entity.ErrNotFound, err)
+	_, err = m.GetOutboxItem(failedID)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	// only failed items are replayed
+	_, err = m.ReplayOutboxItem(pendingID)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+}
+
+func Test_Claim(t *testing.T) {
+	m := NewService(NewInmem(), entity.OutboxRetry{})
+
+	id1, _ := m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncInsert)
+	id2, _ := m.EnqueueChange(tenantAlice, entity.SyncObjectCenter, centerAlice, entity.SyncInsert)
+
+	// each instance claims other items
+	items, err := m.ClaimPendingOutbox("syncer-1", 1, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, id1, items[0].ID)
+	assert.Equal(t, "syncer-1", items[0].ClaimedBy)
+	items, err = m.ClaimPendingOutbox("syncer-2", 0, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, id2, items[0].ID)
+	_, err = m.ClaimPendingOutbox("syncer-2", 0, time.Minute)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	// released items are claimed again
+	err = m.ReleaseOutboxItem(id2, "syncer-1")
+	assert.Equal(t, entity.ErrClaimLost, err)
+	err = m.ReleaseOutboxItem(id2, "syncer-2")
+	assert.Nil(t, err)
+	items, err = m.ClaimPendingOutbox("syncer-2", 0, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, id2, items[0].ID)
+
+	// changed while exported; stays pending for the change
+	_, _ = m.EnqueueChange(tenantAlice, entity.SyncObjectCourse, courseAlice, entity.SyncUpdate)
+	err = m.CompleteOutboxItem(id1, "syncer-1")
+	assert.Nil(t, err)
+	item, _ := m.GetOutboxItem(id1)
+	assert.Equal(t, entity.SyncPending, item.Status)
+	assert.Equal(t, "", item.ClaimedBy)
+
+	err = m.CompleteOutboxItem(id2, "syncer-2")
+	assert.Nil(t, err)
+	item, _ = m.GetOutboxItem(id2)
+	assert.Equal(t, entity.SyncSynced, item.Status)
+
+	// expired claims are claimed by others
+	_, _ = m.ClaimPendingOutbox("syncer-1", 0, -time.Second)
+	items, err = m.ClaimPendingOutbox("syncer-2", 0, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, id1, items[0].ID)
+	assert.Equal(t, "syncer-2", items[0].ClaimedBy)
+
+	// the instance whose claim expired can neither renew nor complete it
+	assert.Equal(t, entity.ErrClaimLost, m.RenewOutboxItem(id1, "syncer-1", time.Minute))
+	assert.Equal(t, entity.ErrClaimLost, m.CompleteOutboxItem(id1, "syncer-1"))
+	assert.Equal(t, entity.ErrClaimLost, m.FailOutboxItem(id1, "syncer-1", errors.New("timeout")))
+	assert.Nil(t, m.RenewOutboxItem(id1, "syncer-2", time.Minute))
+	item, _ = m.GetOutboxItem(id1)
+	assert.Equal(t, entity.SyncPending, item.Status)
+	assert.Equal(t, "syncer-2", item.ClaimedBy)
+}
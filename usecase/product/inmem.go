@@ -37,11 +37,11 @@ func (r *inmem) Create(e *entity.Product) (entity.ID, error) {
 }
 
 // Get retrieves a product from memory
-func (r *inmem) Get(id entity.ID) (*entity.Product, error) {
+func (r *inmem) Get(tenantID, id entity.ID) (*entity.Product, error) {
 	r.mut.RLock()
 	defer r.mut.RUnlock()
 
-	if product, ok := r.m[id]; ok {
+	if product, ok := r.m[id]; ok && product.TenantID == tenantID {
 		return product, nil
 	}
 	return nil, entity.ErrNotFound
@@ -52,8 +52,8 @@ func (r *inmem) Update(e *entity.Product) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	_, ok := r.m[e.ID]
-	if !ok {
+	saved, ok := r.m[e.ID]
+	if !ok || saved.TenantID != e.TenantID {
 		return entity.ErrNotFound
 	}
 
@@ -90,11 +90,11 @@ func (r *inmem) List(tenantID entity.ID, page, limit int) ([]*entity.Product, er
 }
 
 // Delete marks a product as deleted in memory
-func (r *inmem) Delete(id entity.ID) error {
+func (r *inmem) Delete(tenantID, id entity.ID) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	if _, ok := r.m[id]; ok {
+	if product, ok := r.m[id]; ok && product.TenantID == tenantID {
 		r.m[id] = nil
 		delete(r.m, id)
 		return nil
@@ -132,6 +132,61 @@ func (r *inmem) Search(tenantID entity.ID, query string, page, limit int) ([]*en
 	return products, nil
 }
 
+// ListBySyncStatus returns products from memory with the given sync status
+func (r *inmem) ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Product, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	var products []*entity.Product
+	for _, product := range r.m {
+		if product.TenantID == tenantID && product.Sync.Status == status {
+			products = append(products, product)
+		}
+	}
+
+	// Handle pagination if needed
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(products) {
+			return []*entity.Product{}, nil
+		}
+		if end > len(products) {
+			end = len(products)
+		}
+		return products[start:end], nil
+	}
+
+	return products, nil
+}
+
+// CountBySyncStatus counts the products of the tenant per sync status
+func (r *inmem) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	counts := map[entity.SyncStatus]int{}
+	for _, j := range r.m {
+		if j.TenantID == tenantID {
+			counts[j.Sync.Status]++
+		}
+	}
+	return counts, nil
+}
+
+// UpdateSyncState updates the sync meta data of a product in memory
+func (r *inmem) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	product, ok := r.m[id]
+	if !ok {
+		return entity.ErrNotFound
+	}
+	product.Sync = *s
+	return nil
+}
+
 // GetCount returns count of products for a specific tenant
 func (r *inmem) GetCount(tenantID entity.ID) (int, error) {
 	r.mut.RLock()
@@ -10,17 +10,25 @@ import "sudhagar/glad/entity"
 
 // Reader defines read-only operations for products
 type Reader interface {
-	Get(id entity.ID) (*entity.Product, error)
+	// Get gets a product of the tenant
+	Get(tenantID, id entity.ID) (*entity.Product, error)
 	List(tenantID entity.ID, page, limit int) ([]*entity.Product, error)
 	Search(tenantID entity.ID, q string, page, limit int) ([]*entity.Product, error)
 	GetCount(tenantID entity.ID) (int, error)
+	ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Product, error)
+	// CountBySyncStatus counts the products of the tenant per sync status
+	CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error)
 }
 
 // Writer defines write-only operations for products
 type Writer interface {
 	Create(product *entity.Product) (entity.ID, error)
+	// Update updates the product if it is of its tenant
 	Update(product *entity.Product) error
-	Delete(id entity.ID) error
+	Delete(tenantID, id entity.ID) error
+	// UpdateSyncState is not scoped by tenant: only the sync paths call it,
+	// with the id of a record they read, and it is not in the UseCase
+	UpdateSyncState(id entity.ID, s *entity.SyncState) error
 }
 
 // Repository interface
@@ -31,9 +39,11 @@ type Repository interface {
 
 // UseCase defines the interface for product business logic
 type UseCase interface {
-	GetProduct(id entity.ID) (*entity.Product, error)
+	GetProduct(tenantID, id entity.ID) (*entity.Product, error)
 	SearchProducts(tenantID entity.ID, q string, page, limit int) ([]*entity.Product, error)
 	ListProducts(tenantID entity.ID, page, limit int) ([]*entity.Product, error)
+	ListProductsBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Product, error)
+	CountProductsBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error)
 	CreateProduct(tenantID entity.ID,
 		extID string,
 		extName string,
@@ -47,6 +57,6 @@ type UseCase interface {
 		isAutoApprove bool,
 	) (entity.ID, error)
 	UpdateProduct(e *entity.Product) error
-	DeleteProduct(id entity.ID) error
+	DeleteProduct(tenantID, id entity.ID) error
 	GetCount(id entity.ID) int
 }
@@ -34,19 +34,34 @@ func (m *MockReader) EXPECT() *MockReaderMockRecorder {
 	return m.recorder
 }
 
+// CountBySyncStatus mocks base method.
+func (m *MockReader) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySyncStatus indicates an expected call of CountBySyncStatus.
+func (mr *MockReaderMockRecorder) CountBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySyncStatus", reflect.TypeOf((*MockReader)(nil).CountBySyncStatus), tenantID)
+}
+
 // Get mocks base method.
-func (m *MockReader) Get(id entity.ID) (*entity.Product, error) {
+func (m *MockReader) Get(tenantID, id entity.ID) (*entity.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
 	ret0, _ := ret[0].(*entity.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockReaderMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, id)
 }
 
 // GetCount mocks base method.
@@ -79,6 +94,21 @@ func (mr *MockReaderMockRecorder) List(tenantID, page, limit interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), tenantID, page, limit)
 }
 
+// ListBySyncStatus mocks base method.
+func (m *MockReader) ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySyncStatus indicates an expected call of ListBySyncStatus.
+func (mr *MockReaderMockRecorder) ListBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySyncStatus", reflect.TypeOf((*MockReader)(nil).ListBySyncStatus), tenantID, status, page, limit)
+}
+
 // Search mocks base method.
 func (m *MockReader) Search(tenantID entity.ID, q string, page, limit int) ([]*entity.Product, error) {
 	m.ctrl.T.Helper()
@@ -133,17 +163,17 @@ func (mr *MockWriterMockRecorder) Create(product interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockWriter) Delete(id entity.ID) error {
+func (m *MockWriter) Delete(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockWriterMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, id)
 }
 
 // Update mocks base method.
@@ -160,6 +190,20 @@ func (mr *MockWriterMockRecorder) Update(product interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), product)
 }
 
+// UpdateSyncState mocks base method.
+func (m *MockWriter) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncState", id, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncState indicates an expected call of UpdateSyncState.
+func (mr *MockWriterMockRecorder) UpdateSyncState(id, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncState", reflect.TypeOf((*MockWriter)(nil).UpdateSyncState), id, s)
+}
+
 // MockRepository is a mock of Repository interface.
 type MockRepository struct {
 	ctrl     *gomock.Controller
@@ -183,6 +227,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountBySyncStatus mocks base method.
+func (m *MockRepository) CountBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySyncStatus indicates an expected call of CountBySyncStatus.
+func (mr *MockRepositoryMockRecorder) CountBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySyncStatus", reflect.TypeOf((*MockRepository)(nil).CountBySyncStatus), tenantID)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(product *entity.Product) (entity.ID, error) {
 	m.ctrl.T.Helper()
@@ -199,32 +258,32 @@ func (mr *MockRepositoryMockRecorder) Create(product interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(id entity.ID) error {
+func (m *MockRepository) Delete(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, id)
 }
 
 // Get mocks base method.
-func (m *MockRepository) Get(id entity.ID) (*entity.Product, error) {
+func (m *MockRepository) Get(tenantID, id entity.ID) (*entity.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
 	ret0, _ := ret[0].(*entity.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, id)
 }
 
 // GetCount mocks base method.
@@ -257,6 +316,21 @@ func (mr *MockRepositoryMockRecorder) List(tenantID, page, limit interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), tenantID, page, limit)
 }
 
+// ListBySyncStatus mocks base method.
+func (m *MockRepository) ListBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySyncStatus indicates an expected call of ListBySyncStatus.
+func (mr *MockRepositoryMockRecorder) ListBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySyncStatus", reflect.TypeOf((*MockRepository)(nil).ListBySyncStatus), tenantID, status, page, limit)
+}
+
 // Search mocks base method.
 func (m *MockRepository) Search(tenantID entity.ID, q string, page, limit int) ([]*entity.Product, error) {
 	m.ctrl.T.Helper()
@@ -286,6 +360,20 @@ func (mr *MockRepositoryMockRecorder) Update(product interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), product)
 }
 
+// UpdateSyncState mocks base method.
+func (m *MockRepository) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncState", id, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncState indicates an expected call of UpdateSyncState.
+func (mr *MockRepositoryMockRecorder) UpdateSyncState(id, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncState", reflect.TypeOf((*MockRepository)(nil).UpdateSyncState), id, s)
+}
+
 // MockUseCase is a mock of UseCase interface.
 type MockUseCase struct {
 	ctrl     *gomock.Controller
@@ -309,6 +397,21 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// CountProductsBySyncStatus mocks base method.
+func (m *MockUseCase) CountProductsBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsBySyncStatus", tenantID)
+	ret0, _ := ret[0].(map[entity.SyncStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsBySyncStatus indicates an expected call of CountProductsBySyncStatus.
+func (mr *MockUseCaseMockRecorder) CountProductsBySyncStatus(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsBySyncStatus", reflect.TypeOf((*MockUseCase)(nil).CountProductsBySyncStatus), tenantID)
+}
+
 // CreateProduct mocks base method.
 func (m *MockUseCase) CreateProduct(tenantID entity.ID, extID, extName, title, ctype, baseProductExtID string, durationDays int32, visibility entity.ProductVisibility, maxAttendees int32, format entity.ProductFormat, isAutoApprove bool) (entity.ID, error) {
 	m.ctrl.T.Helper()
@@ -325,17 +428,17 @@ func (mr *MockUseCaseMockRecorder) CreateProduct(tenantID, extID, extName, title
 }
 
 // DeleteProduct mocks base method.
-func (m *MockUseCase) DeleteProduct(id entity.ID) error {
+func (m *MockUseCase) DeleteProduct(tenantID, id entity.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", id)
+	ret := m.ctrl.Call(m, "DeleteProduct", tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockUseCaseMockRecorder) DeleteProduct(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteProduct(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockUseCase)(nil).DeleteProduct), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockUseCase)(nil).DeleteProduct), tenantID, id)
 }
 
 // GetCount mocks base method.
@@ -353,18 +456,18 @@ func (mr *MockUseCaseMockRecorder) GetCount(id interface{}) *gomock.Call {
 }
 
 // GetProduct mocks base method.
-func (m *MockUseCase) GetProduct(id entity.ID) (*entity.Product, error) {
+func (m *MockUseCase) GetProduct(tenantID, id entity.ID) (*entity.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", id)
+	ret := m.ctrl.Call(m, "GetProduct", tenantID, id)
 	ret0, _ := ret[0].(*entity.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProduct indicates an expected call of GetProduct.
-func (mr *MockUseCaseMockRecorder) GetProduct(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) GetProduct(tenantID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockUseCase)(nil).GetProduct), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockUseCase)(nil).GetProduct), tenantID, id)
 }
 
 // ListProducts mocks base method.
@@ -382,6 +485,21 @@ func (mr *MockUseCaseMockRecorder) ListProducts(tenantID, page, limit interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockUseCase)(nil).ListProducts), tenantID, page, limit)
 }
 
+// ListProductsBySyncStatus mocks base method.
+func (m *MockUseCase) ListProductsBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsBySyncStatus", tenantID, status, page, limit)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductsBySyncStatus indicates an expected call of ListProductsBySyncStatus.
+func (mr *MockUseCaseMockRecorder) ListProductsBySyncStatus(tenantID, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsBySyncStatus", reflect.TypeOf((*MockUseCase)(nil).ListProductsBySyncStatus), tenantID, status, page, limit)
+}
+
 // SearchProducts mocks base method.
 func (m *MockUseCase) SearchProducts(tenantID entity.ID, q string, page, limit int) ([]*entity.Product, error) {
 	m.ctrl.T.Helper()
@@ -5,17 +5,21 @@ import (
 	"time"
 
 	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/tombstone"
 )
 
 // Service product usecase
 type Service struct {
-	repo Repository
+	repo      Repository
+	tombstone tombstone.UseCase
 }
 
-// NewService create new service
-func NewService(r Repository) *Service {
+// NewService create new service. Deletes of records synced to Salesforce
+// are held back until tb confirms them; a nil tb deletes right away.
+func NewService(r Repository, tb tombstone.UseCase) *Service {
 	return &Service{
-		repo: r,
+		repo:      r,
+		tombstone: tb,
 	}
 }
 
@@ -51,9 +55,9 @@ func (s *Service) CreateProduct(tenantID entity.ID,
 	return s.repo.Create(p)
 }
 
-// GetProduct retrieves a product
-func (s *Service) GetProduct(id entity.ID) (*entity.Product, error) {
-	p, err := s.repo.Get(id)
+// GetProduct retrieves a product of the tenant
+func (s *Service) GetProduct(tenantID, id entity.ID) (*entity.Product, error) {
+	p, err := s.repo.Get(tenantID, id)
 	if p == nil {
 		return nil, entity.ErrNotFound
 	}
@@ -88,19 +92,43 @@ func (s *Service) ListProducts(tenantID entity.ID, page, limit int) ([]*entity.P
 	return products, nil
 }
 
-// UpdateProduct Update a product
+// ListProductsBySyncStatus list products with the given sync status
+func (s *Service) ListProductsBySyncStatus(tenantID entity.ID, status entity.SyncStatus, page, limit int) ([]*entity.Product, error) {
+	if !status.IsValid() {
+		return nil, entity.ErrInvalidEntity
+	}
+	products, err := s.repo.ListBySyncStatus(tenantID, status, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return products, nil
+}
+
+// CountProductsBySyncStatus counts the products of the tenant per sync status
+func (s *Service) CountProductsBySyncStatus(tenantID entity.ID) (map[entity.SyncStatus]int, error) {
+	return s.repo.CountBySyncStatus(tenantID)
+}
+
+// UpdateProduct Update a product of its tenant
 func (s *Service) UpdateProduct(p *entity.Product) error {
 	err := p.Validate()
 	if err != nil {
 		return err
 	}
+	_, err = s.GetProduct(p.TenantID, p.ID)
+	if err != nil {
+		return err
+	}
 	p.UpdatedAt = time.Now()
 	return s.repo.Update(p)
 }
 
-// DeleteProduct Delete a product
-func (s *Service) DeleteProduct(id entity.ID) error {
-	p, err := s.GetProduct(id)
+// DeleteProduct Delete a product of the tenant
+func (s *Service) DeleteProduct(tenantID, id entity.ID) error {
+	p, err := s.GetProduct(tenantID, id)
 	if p == nil {
 		return entity.ErrNotFound
 	}
@@ -108,7 +136,23 @@ func (s *Service) DeleteProduct(id entity.ID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if s.tombstone != nil && p.ExtID != "" {
+		_, err = s.tombstone.CreateTombstone(p.TenantID, entity.SyncObjectProduct, id, p.ExtID)
+		if err != nil {
+			return err
+		}
+		err = s.repo.UpdateSyncState(id, &entity.SyncState{
+			LastSyncedAt:      p.Sync.LastSyncedAt,
+			LastSyncDirection: p.Sync.LastSyncDirection,
+			Status:            entity.SyncPending,
+		})
+		if err != nil {
+			return err
+		}
+		return entity.ErrDeletePending
+	}
+
+	return s.repo.Delete(tenantID, id)
 }
 
 // GetCount gets total product count
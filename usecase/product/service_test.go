@@ -12,6 +12,7 @@ import (
 const (
 	productDefault entity.ID = 13790493495087071234
 	tenantAlice    entity.ID = 13790492210917015554
+	tenantBob      entity.ID = 13790492210917015555
 	aliceExtID               = "000aliceExtID"
 	bobExtID                 = "000bobExtID"
 )
@@ -34,7 +35,7 @@ func newFixtureProduct() *entity.Product {
 
 func Test_CreateProduct(t *testing.T) {
 	repo := NewInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	tmpl := newFixtureProduct()
 	_, err := m.CreateProduct(
 		tmpl.TenantID,
@@ -56,7 +57,7 @@ func Test_CreateProduct(t *testing.T) {
 // TODO: Add test cases for page and limit
 func Test_SearchAndFind(t *testing.T) {
 	repo := NewInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	tmpl1 := newFixtureProduct()
 	tmpl2 := newFixtureProduct()
 	tmpl2.ExtName = "default2"
@@ -115,7 +116,7 @@ func Test_SearchAndFind(t *testing.T) {
 	})
 
 	t.Run("get", func(t *testing.T) {
-		saved, err := m.GetProduct(tID)
+		saved, err := m.GetProduct(tenantAlice, tID)
 		assert.Nil(t, err)
 		assert.Equal(t, tmpl2.TenantID, saved.TenantID)
 		assert.Equal(t, tmpl2.ExtID, saved.ExtID)
@@ -127,7 +128,7 @@ func Test_SearchAndFind(t *testing.T) {
 
 func Test_UpdateProduct(t *testing.T) {
 	repo := NewInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 	tmpl := newFixtureProduct()
 	id, err := m.CreateProduct(
 		tmpl.TenantID,
@@ -144,18 +145,18 @@ func Test_UpdateProduct(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	saved, _ := m.GetProduct(id)
+	saved, _ := m.GetProduct(tenantAlice, id)
 	saved.Format = entity.ProductFormatOnline
 	assert.Nil(t, m.UpdateProduct(saved))
 
-	updated, err := m.GetProduct(id)
+	updated, err := m.GetProduct(tenantAlice, id)
 	assert.Nil(t, err)
 	assert.Equal(t, entity.ProductFormatOnline, updated.Format)
 }
 
 func TestDeleteProduct(t *testing.T) {
 	repo := NewInmem()
-	m := NewService(repo)
+	m := NewService(repo, nil)
 
 	tmpl1 := newFixtureProduct()
 	tmpl2 := newFixtureProduct()
@@ -175,11 +176,45 @@ func TestDeleteProduct(t *testing.T) {
 		tmpl2.IsAutoApprove,
 	)
 
-	err := m.DeleteProduct(tmpl1.ID)
+	err := m.DeleteProduct(tenantAlice, tmpl1.ID)
 	assert.Equal(t, entity.ErrNotFound, err)
 
-	err = m.DeleteProduct(id2)
+	err = m.DeleteProduct(tenantAlice, id2)
 	assert.Nil(t, err)
-	_, err = m.GetProduct(id2)
+	_, err = m.GetProduct(tenantAlice, id2)
 	assert.Equal(t, entity.ErrNotFound, err)
 }
+
+func Test_OtherTenant(t *testing.T) {
+	repo := NewInmem()
+	m := NewService(repo, nil)
+	tmpl := newFixtureProduct()
+	id, err := m.CreateProduct(
+		tmpl.TenantID,
+		tmpl.ExtID,
+		tmpl.ExtName,
+		tmpl.Title,
+		tmpl.CType,
+		tmpl.BaseProductExtID,
+		tmpl.DurationDays,
+		tmpl.Visibility,
+		tmpl.MaxAttendees,
+		tmpl.Format,
+		tmpl.IsAutoApprove,
+	)
+	assert.Nil(t, err)
+
+	_, err = m.GetProduct(tenantBob, id)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	p, _ := m.GetProduct(tenantAlice, id)
+	other := *p
+	other.TenantID = tenantBob
+	other.Title = "Renamed"
+	assert.Equal(t, entity.ErrNotFound, m.UpdateProduct(&other))
+	assert.Equal(t, entity.ErrNotFound, m.DeleteProduct(tenantBob, id))
+
+	saved, err := m.GetProduct(tenantAlice, id)
+	assert.Nil(t, err)
+	assert.Equal(t, tmpl.Title, saved.Title)
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/sfbulk"
+)
+
+// BulkResult outcome of a bulk export of an object
+type BulkResult struct {
+	Object entity.SyncObject
+	Jobs   []string
+	Sent   int
+	// Applied records accepted by SF
+	Applied int
+	// Failed error per record rejected locally or by SF
+	Failed map[entity.ID]string
+}
+
+// bulkRecord a record and its SF fields
+type bulkRecord struct {
+	id     entity.ID
+	extID  string
+	fields map[string]string
+	// payload sent by ExportToSF; saved as the snapshot once SF accepts it
+	payload []entity.SFPayload
+}
+
+// bulkJob an ingest job and the local record of each row
+type bulkJob struct {
+	req  sfbulk.JobRequest
+	data []byte
+	// key column of the rows echoed in the results
+	key     string
+	records map[string]*bulkRecord
+}
+
+// Bulk jobs per object: the SF object and the fields sent
+var bulkObjects = map[entity.SyncObject]struct {
+	object  string
+	columns []string
+}{
+	entity.SyncObjectCourse: {
+		object: entity.SFObjectEvent,
+		columns: []string{"Number_Of_Students__c", "Max_Attendees__c", "Status__c", "Notes__c",
+			"Street_Address_1__c", "Street_Address_2__c", "City__c", "State__c",
+			"Zip_Postal_Code__c", "Country__c"},
+	},
+	entity.SyncObjectAccount: {
+		object: entity.SFObjectAccount,
+		columns: []string{"FirstName", "LastName", "Phone", "PersonEmail",
+			"Account_Type__c", "Cognito_User_Id__c"},
+	},
+}
+
+// ExportBulk exports all the records of the object of the tenant with the
+// Bulk API. Records already in SF are updated by their SF id; new ones are
+// upserted on the external id field so reruns do not duplicate them. The per
+// record outcome is recorded in the sync state. With dryRun the jobs are
+// built but not sent.
+func (s *SFExportService) ExportBulk(ctx context.Context,
+	object entity.SyncObject,
+	tenantID entity.ID,
+	dryRun bool,
+) (*BulkResult, error) {
+	mapping, ok := bulkObjects[object]
+	if !ok {
+		return nil, fmt.Errorf("bulk export of %s is not supported", object)
+	}
+	if err := s.checkPaused(tenantID, object); err != nil {
+		return nil, err
+	}
+	records, failed, err := s.bulkRecords(object, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkResult{Object: object, Failed: failed}
+	for id, msg := range failed {
+		s.applyBulkRow(object, &bulkRecord{id: id}, "", msg)
+	}
+	// Note: new records are sent all the fields
+	var updateColumns []string
+	for _, c := range mapping.columns {
+		if s.ownership.Accepts(object, c, entity.SyncOutbound) {
+			updateColumns = append(updateColumns, c)
+		}
+	}
+	jobs, err := bulkJobs(mapping.object, mapping.columns, updateColumns, s.bulkExternalID, records)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		result.Sent += len(job.records)
+		if dryRun {
+			continue
+		}
+
+		res, err := s.bulk.Run(ctx, job.req, job.data)
+		if res != nil && res.Job != nil {
+			result.Jobs = append(result.Jobs, res.Job.ID)
+		}
+		if err != nil {
+			return result, fmt.Errorf("bulk %s of %s failed: %w", job.req.Operation, mapping.object, err)
+		}
+		for _, row := range res.Successful {
+			if r := job.records[row[job.key]]; r != nil {
+				s.applyBulkRow(object, r, row[sfbulk.ColumnID], "")
+				result.Applied++
+			}
+		}
+		for _, row := range res.Failed {
+			if r := job.records[row[job.key]]; r != nil {
+				s.applyBulkRow(object, r, "", row[sfbulk.ColumnError])
+				result.Failed[r.id] = row[sfbulk.ColumnError]
+			}
+		}
+	}
+	return result, nil
+}
+
+// bulkRecords lists the records of the tenant; invalid ones are returned
+// with their errors
+func (s *SFExportService) bulkRecords(object entity.SyncObject, tenantID entity.ID) ([]*bulkRecord, map[entity.ID]string, error) {
+	var records []*bulkRecord
+	failed := map[entity.ID]string{}
+
+	switch object {
+	case entity.SyncObjectCourse:
+		courses, err := s.courseRepo.List(tenantID, 0, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list courses: %w", err)
+		}
+		for _, c := range courses {
+			r, err := courseBulkRecord(c)
+			if err != nil {
+				failed[c.ID] = err.Error()
+				continue
+			}
+			records = append(records, r)
+		}
+	case entity.SyncObjectAccount:
+		accounts, err := s.accountRepo.List(tenantID, 0, 0, "")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list accounts: %w", err)
+		}
+		for _, a := range accounts {
+			records = append(records, accountBulkRecord(a))
+		}
+	}
+	return records, failed, nil
+}
+
+// applyBulkRow records the outcome of a row. Accounts carry no sync state.
+func (s *SFExportService) applyBulkRow(object entity.SyncObject, r *bulkRecord, sfID, msg string) {
+	var sendErr error
+	if msg != "" {
+		sendErr = fmt.Errorf("SF bulk: %s", msg)
+	}
+	s.saveRecord(object, resultOf(sendErr))
+	if object != entity.SyncObjectCourse {
+		return
+	}
+
+	err := s.courseRepo.UpdateSyncState(r.id, entity.NewSyncState(entity.SyncOutbound, sendErr))
+	if err != nil {
+		log.Println("unable to update the course sync state", err)
+	}
+	if sendErr != nil {
+		return
+	}
+	if r.extID == "" && sfID != "" {
+		if err := s.courseRepo.UpdateExtID(r.id, sfID); err != nil {
+			log.Println("unable to update the course ext id", err)
+		}
+	}
+	if err := s.saveSnapshot(object, r.id, r.payload); err != nil {
+		log.Println("unable to save the export snapshot", err)
+	}
+}
+
+// courseBulkRecord maps a course to the fields of the REST payload
+func courseBulkRecord(c *entity.Course) (*bulkRecord, error) {
+	payload := buildCoursePayload(c)
+	// Note: courses not yet in SF are created by the upsert
+	event := payload[0].Items[0].Value.(entity.SFEventData)
+	if errs := validateEventFields(event); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid SF payload: %s", strings.Join(errs, "; "))
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string, len(values))
+	for k, v := range values {
+		if v != nil {
+			fields[k] = fmt.Sprint(v)
+		}
+	}
+
+	r := &bulkRecord{id: c.ID, fields: fields, payload: payload}
+	if c.ExtID != nil {
+		r.extID = *c.ExtID
+	}
+	return r, nil
+}
+
+// accountBulkRecord maps an account to the fields read by the account handler
+func accountBulkRecord(a *entity.Account) *bulkRecord {
+	return &bulkRecord{
+		id:    a.ID,
+		extID: a.ExtID,
+		fields: map[string]string{
+			"FirstName":          a.FirstName,
+			"LastName":           a.LastName,
+			"Phone":              a.Phone,
+			"PersonEmail":        a.Email,
+			"Account_Type__c":    string(a.Type),
+			"Cognito_User_Id__c": a.CognitoID,
+		},
+	}
+}
+
+// bulkJobs splits the records into an update by SF id of the records already
+// in SF and an upsert on the external id field of the others. Records already
+// in SF are sent the updateColumns only.
+func bulkJobs(object string,
+	columns, updateColumns []string,
+	externalID string,
+	records []*bulkRecord,
+) ([]*bulkJob, error) {
+	update := &bulkJob{
+		req:     sfbulk.JobRequest{Object: object, Operation: sfbulk.OpUpdate},
+		key:     "Id",
+		records: map[string]*bulkRecord{},
+	}
+	upsert := &bulkJob{
+		req:     sfbulk.JobRequest{Object: object, Operation: sfbulk.OpUpsert, ExternalIDFieldName: externalID},
+		key:     externalID,
+		records: map[string]*bulkRecord{},
+	}
+
+	var updateRows, upsertRows [][]string
+	for _, r := range records {
+		if r.extID != "" {
+			update.records[r.extID] = r
+			updateRows = append(updateRows, r.row(r.extID, updateColumns))
+		} else {
+			key := strconv.FormatUint(uint64(r.id), 10)
+			upsert.records[key] = r
+			upsertRows = append(upsertRows, r.row(key, columns))
+		}
+	}
+
+	var jobs []*bulkJob
+	for _, j := range []struct {
+		job     *bulkJob
+		columns []string
+		rows    [][]string
+	}{{update, updateColumns, updateRows}, {upsert, columns, upsertRows}} {
+		if len(j.rows) == 0 {
+			continue
+		}
+		data, err := sfbulk.WriteCSV(append([]string{j.job.key}, j.columns...), j.rows)
+		if err != nil {
+			return nil, err
+		}
+		j.job.data = data
+		jobs = append(jobs, j.job)
+	}
+	return jobs, nil
+}
+
+// row returns the CSV row of the record
+func (r *bulkRecord) row(key string, columns []string) []string {
+	row := make([]string, 0, len(columns)+1)
+	row = append(row, key)
+	for _, c := range columns {
+		row = append(row, r.fields[c])
+	}
+	return row
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/sfbulk"
+	"sudhagar/glad/pkg/sfbulk/sffake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_courseBulkRecord(t *testing.T) {
+	course := newFixtureCourse()
+	course.ID = 1
+	course.ExtID = nil
+	r, err := courseBulkRecord(course)
+	assert.Nil(t, err)
+	assert.Empty(t, r.extID)
+	assert.Equal(t, "50", r.fields["Max_Attendees__c"])
+	assert.Equal(t, "open", r.fields["Status__c"])
+
+	course.NumAttendees = 60
+	_, err = courseBulkRecord(course)
+	assert.NotNil(t, err)
+}
+
+func Test_bulkJobs(t *testing.T) {
+	s := sffake.NewServer()
+	defer s.Close()
+	s.Reject = func(object string, row map[string]string) string {
+		if row["Status__c"] == "closed" {
+			return "FIELD_CUSTOM_VALIDATION_EXCEPTION:course is closed"
+		}
+		return ""
+	}
+	client := &sfbulk.Client{
+		InstanceURL:  s.URL,
+		Version:      "v60.0",
+		Token:        func() (string, error) { return "token", nil },
+		PollInterval: time.Millisecond,
+	}
+	mapping := bulkObjects[entity.SyncObjectCourse]
+
+	var records []*bulkRecord
+	for i, status := range []entity.CourseStatus{entity.CourseOpen, entity.CourseClosed, entity.CourseOpen} {
+		course := newFixtureCourse()
+		course.ID = entity.ID(i + 1)
+		course.ExtID = nil
+		course.Status = status
+		r, err := courseBulkRecord(course)
+		assert.Nil(t, err)
+		records = append(records, r)
+	}
+
+	// new courses are upserted
+	jobs, err := bulkJobs(mapping.object, mapping.columns, mapping.columns, "Glad_Id__c", records)
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, sfbulk.OpUpsert, jobs[0].req.Operation)
+	res, err := client.Run(context.Background(), jobs[0].req, jobs[0].data)
+	assert.Nil(t, err)
+	assert.Len(t, res.Successful, 2)
+	assert.Len(t, res.Failed, 1)
+	assert.Equal(t, entity.ID(2), jobs[0].records[res.Failed[0][jobs[0].key]].id)
+
+	// courses already in SF are updated by their SF id
+	for _, row := range res.Successful {
+		jobs[0].records[row[jobs[0].key]].extID = row[sfbulk.ColumnID]
+	}
+	jobs, err = bulkJobs(mapping.object, mapping.columns, mapping.columns, "Glad_Id__c", records)
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, sfbulk.OpUpdate, jobs[0].req.Operation)
+	assert.Len(t, jobs[0].records, 2)
+	res, err = client.Run(context.Background(), jobs[0].req, jobs[0].data)
+	assert.Nil(t, err)
+	assert.Len(t, res.Successful, 2)
+	for _, row := range res.Successful {
+		assert.NotNil(t, jobs[0].records[row[jobs[0].key]])
+	}
+	assert.Len(t, s.Records(entity.SFObjectEvent), 2)
+}
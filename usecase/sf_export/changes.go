@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+
+	"sudhagar/glad/entity"
+)
+
+// identifierFields are sent with every record
+var identifierFields = map[string]bool{
+	"Id":     true,
+	"Ext_Id": true,
+}
+
+// lastExported returns the fields of the last export of the record, keyed as
+// by flattenPayload; nil if the record was never exported
+func (s *SFExportService) lastExported(object entity.SyncObject, entityID entity.ID) (map[string]any, error) {
+	snapshot, err := s.snapshotRepo.Get(object, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export snapshot: %w", err)
+	}
+	if snapshot == nil {
+		return nil, nil
+	}
+	return flattenPayload(snapshot.Payload)
+}
+
+// outboundPayload returns the payload to send for the record: the fields we
+// own that changed since the last export. Returns nil when nothing changed.
+func (s *SFExportService) outboundPayload(object entity.SyncObject,
+	entityID entity.ID,
+	extID string,
+	payload []entity.SFPayload,
+) ([]entity.SFPayload, error) {
+	last, err := s.lastExported(object, entityID)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := s.ownedPayload(object, entityID, extID, payload, last)
+	if err != nil {
+		return nil, err
+	}
+	send, changed := changedFields(owned, last)
+	if changed == 0 {
+		return nil, nil
+	}
+	return send, nil
+}
+
+// changedFields reduces the records of a filtered payload to the fields that
+// changed since the last export, last as by flattenPayload; inserts and
+// records never exported keep all their fields. Null fields are never sent
+// so that they do not clear the SF values. Returns the number of fields
+// left, not counting the identifiers.
+func changedFields(payload []entity.SFPayload, last map[string]any) ([]entity.SFPayload, int) {
+	changed := 0
+	reduced := make([]entity.SFPayload, 0, len(payload))
+	for _, p := range payload {
+		items := make([]entity.SFRecord, 0, len(p.Items))
+		for i, item := range p.Items {
+			value, ok := item.Value.(map[string]any)
+			if !ok {
+				items = append(items, item)
+				continue
+			}
+
+			update := item.Operation == entity.SFOperationUpdate && last != nil
+			fields := map[string]any{}
+			for field, v := range value {
+				if identifierFields[field] {
+					fields[field] = v
+					continue
+				}
+				if v == nil {
+					continue
+				}
+				if update {
+					old, ok := last[fmt.Sprintf("%s[%d].%s", p.Object, i, field)]
+					if ok && fmt.Sprint(old) == fmt.Sprint(v) {
+						continue
+					}
+				}
+				fields[field] = v
+				changed++
+			}
+			items = append(items, entity.SFRecord{Operation: item.Operation, Value: fields})
+		}
+		reduced = append(reduced, entity.SFPayload{Object: p.Object, Items: items})
+	}
+	return reduced, changed
+}
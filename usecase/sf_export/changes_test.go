@@ -0,0 +1,46 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"sudhagar/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_changedFields(t *testing.T) {
+	course := newFixtureCourse()
+	rules := entity.FieldOwnership{}
+	payload, _, err := filterPayload(entity.SyncObjectCourse, buildCoursePayload(course), rules)
+	assert.Nil(t, err)
+
+	// never exported: all the fields but the null ones
+	send, changed := changedFields(payload, nil)
+	value := send[0].Items[0].Value.(map[string]any)
+	assert.NotContains(t, value, "Timezone__c")
+	assert.Equal(t, "notes", value["Notes__c"])
+	assert.Equal(t, len(value)-1, changed)
+
+	data, _ := json.Marshal(buildCoursePayload(course))
+	last, err := flattenPayload(data)
+	assert.Nil(t, err)
+
+	// nothing changed
+	_, changed = changedFields(payload, last)
+	assert.Equal(t, 0, changed)
+
+	// the changed fields and the id only
+	course.Notes = "updated notes"
+	payload, _, _ = filterPayload(entity.SyncObjectCourse, buildCoursePayload(course), rules)
+	send, changed = changedFields(payload, last)
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, map[string]any{"Ext_Id": *course.ExtID, "Notes__c": "updated notes"}, send[0].Items[0].Value)
+
+	// inserts keep all the fields
+	course.ExtID = nil
+	payload, _, _ = filterPayload(entity.SyncObjectCourse, buildCoursePayload(course), rules)
+	assert.Equal(t, entity.SFOperationInsert, payload[0].Items[0].Operation)
+	_, changed = changedFields(payload, last)
+	assert.Greater(t, changed, 1)
+}
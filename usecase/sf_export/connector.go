@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/ratelimit"
+	"sudhagar/glad/usecase/connector"
+)
+
+// sfConnector Salesforce as a connector; records are sent to the Apex REST
+// endpoint of the exporter
+type sfConnector struct {
+	s *SFExportService
+}
+
+// Connector returns the Salesforce connector
+func (s *SFExportService) Connector() connector.Connector {
+	return &sfConnector{s: s}
+}
+
+// System name of the system
+func (c *sfConnector) System() string {
+	return entity.ExternalSystemSalesforce
+}
+
+// Authenticate gets an access token
+func (c *sfConnector) Authenticate(ctx context.Context) error {
+	_, err := c.s.token()
+	return err
+}
+
+// Map maps a course to the SF event fields we own
+func (c *sfConnector) Map(object entity.SyncObject, v any) (*connector.Record, error) {
+	course, ok := v.(*entity.Course)
+	if object != entity.SyncObjectCourse || !ok {
+		return nil, fmt.Errorf("%s: %w", object, connector.ErrNotSupported)
+	}
+
+	payload := buildCoursePayload(course)
+	if errs := validatePayload(payload); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid SF payload: %v", errs)
+	}
+	owned, _, err := filterPayload(object, payload, c.s.ownership)
+	if err != nil {
+		return nil, err
+	}
+	return &connector.Record{
+		Object: object,
+		ExtID:  extIDOf(course),
+		Fields: owned[0].Items[0].Value.(map[string]any),
+	}, nil
+}
+
+// Push sends each record; stops while the API usage is high
+func (c *sfConnector) Push(ctx context.Context, records []*connector.Record) ([]connector.PushResult, error) {
+	results := make([]connector.PushResult, len(records))
+	for i, r := range records {
+		if r.Object != entity.SyncObjectCourse {
+			results[i].Err = fmt.Errorf("%s: %w", r.Object, connector.ErrNotSupported)
+			continue
+		}
+		operation := entity.SFOperationInsert
+		if r.ExtID != "" {
+			operation = entity.SFOperationUpdate
+		}
+
+		sent, err := c.s.sendToSF([]entity.SFPayload{{
+			Object: entity.SFObjectEvent,
+			Items:  []entity.SFRecord{{Operation: operation, Value: r.Fields}},
+		}}, false)
+		if errors.Is(err, ratelimit.ErrPaused) {
+			return nil, err
+		}
+		extID := r.ExtID
+		if err == nil && operation == entity.SFOperationInsert {
+			extID, err = insertedID(sent)
+		}
+		results[i] = connector.PushResult{ExtID: extID, Err: err}
+	}
+	return results, nil
+}
+
+// Pull reads the events changed in SF after the cursor, a SystemModstamp,
+// with a bulk query of the fields we send. The next cursor is the latest
+// SystemModstamp read; it is queried to the second, so the events of that
+// second are read again.
+func (c *sfConnector) Pull(ctx context.Context, object entity.SyncObject, cursor string) ([]*connector.Record, string, error) {
+	mapping, ok := bulkObjects[object]
+	if object != entity.SyncObjectCourse || !ok {
+		return nil, cursor, fmt.Errorf("%s: %w", object, connector.ErrNotSupported)
+	}
+
+	soql := fmt.Sprintf("SELECT %s FROM %s",
+		strings.Join(append([]string{"Id", "SystemModstamp"}, mapping.columns...), ", "), mapping.object)
+	var since time.Time
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339, cursor)
+		if err != nil {
+			return nil, cursor, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+		}
+		since = t
+		soql += " WHERE SystemModstamp > " + t.UTC().Format(time.RFC3339)
+	}
+	rows, err := c.s.bulk.Query(ctx, soql)
+	if err != nil {
+		return nil, cursor, fmt.Errorf("failed to query %s: %w", mapping.object, err)
+	}
+
+	next := cursor
+	records := make([]*connector.Record, 0, len(rows))
+	for _, row := range rows {
+		fields := make(map[string]any, len(mapping.columns))
+		for _, col := range mapping.columns {
+			fields[col] = row[col]
+		}
+		records = append(records, &connector.Record{
+			Object:    object,
+			ExtID:     row["Id"],
+			Operation: entity.SyncUpdate,
+			Fields:    fields,
+		})
+		if t, err := time.Parse(time.RFC3339, row["SystemModstamp"]); err == nil && t.After(since) {
+			since, next = t, row["SystemModstamp"]
+		}
+	}
+	return records, next, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/ratelimit"
+	"sudhagar/glad/pkg/sfbulk"
+	"sudhagar/glad/pkg/sfbulk/sffake"
+	"sudhagar/glad/usecase/connector"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_connectorMap(t *testing.T) {
+	c := (&SFExportService{}).Connector()
+
+	// a course not yet in SF maps to an insert
+	course := newFixtureCourse()
+	course.ExtID = nil
+	r, err := c.Map(entity.SyncObjectCourse, course)
+	assert.Nil(t, err)
+	assert.Equal(t, "", r.ExtID)
+
+	_, err = c.Map(entity.SyncObjectCenter, &entity.Center{})
+	assert.NotNil(t, err)
+}
+
+func Test_connectorPush(t *testing.T) {
+	sf := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"Id": "a0X000000000002"}]`))
+	}))
+	defer sf.Close()
+	s := &SFExportService{
+		metric:     nopMetric{},
+		limiter:    ratelimit.New(ratelimit.Config{}),
+		sfEndpoint: sf.URL,
+		token:      func() (string, error) { return "token", nil },
+	}
+
+	// the SF id of an inserted event is returned
+	results, err := s.Connector().Push(context.Background(), []*connector.Record{
+		{Object: entity.SyncObjectCourse, Fields: map[string]any{"Status__c": "open"}},
+		{Object: entity.SyncObjectCourse, ExtID: "a0X000000000001", Fields: map[string]any{"Status__c": "open"}},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "a0X000000000002", results[0].ExtID)
+	assert.Nil(t, results[1].Err)
+	assert.Equal(t, "a0X000000000001", results[1].ExtID)
+}
+
+func Test_connectorPull(t *testing.T) {
+	sf := sffake.NewServer()
+	defer sf.Close()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	sf.Now = func() time.Time { return now }
+	s := &SFExportService{bulk: &sfbulk.Client{
+		InstanceURL:  sf.URL,
+		Version:      "v60.0",
+		Token:        func() (string, error) { return "token", nil },
+		PollInterval: time.Millisecond,
+	}}
+	c := s.Connector()
+	object := bulkObjects[entity.SyncObjectCourse].object
+
+	insert, _ := sfbulk.WriteCSV([]string{"Status__c"}, [][]string{{"open"}, {"open"}})
+	res, err := s.bulk.Run(context.Background(), sfbulk.JobRequest{Object: object, Operation: sfbulk.OpInsert}, insert)
+	assert.Nil(t, err)
+
+	records, cursor, err := c.Pull(context.Background(), entity.SyncObjectCourse, "")
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "open", records[0].Fields["Status__c"])
+	assert.Equal(t, entity.SyncUpdate, records[0].Operation)
+	assert.Equal(t, "2024-05-01T10:00:00.000Z", cursor)
+
+	// only the events changed after the cursor
+	now = now.Add(time.Minute)
+	changed := res.Successful[1][sfbulk.ColumnID]
+	update, _ := sfbulk.WriteCSV([]string{"Id", "Status__c"}, [][]string{{changed, "closed"}})
+	_, err = s.bulk.Run(context.Background(), sfbulk.JobRequest{Object: object, Operation: sfbulk.OpUpdate}, update)
+	assert.Nil(t, err)
+	records, cursor, err = c.Pull(context.Background(), entity.SyncObjectCourse, cursor)
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, changed, records[0].ExtID)
+	assert.Equal(t, "closed", records[0].Fields["Status__c"])
+	assert.Equal(t, "2024-05-01T10:01:00.000Z", cursor)
+
+	_, _, err = c.Pull(context.Background(), entity.SyncObjectCourse, "yesterday")
+	assert.NotNil(t, err)
+}
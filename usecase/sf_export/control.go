@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/usecase/control"
+)
+
+// checkPaused returns control.ErrPaused if the outbound sync of the object of
+// the tenant is paused
+func (s *SFExportService) checkPaused(tenantID entity.ID, object entity.SyncObject) error {
+	c, err := s.control.ControlFor(tenantID, object, entity.SyncOutbound)
+	if err != nil {
+		return fmt.Errorf("failed to get the sync controls: %w", err)
+	}
+	if c.Paused {
+		return fmt.Errorf("%s of tenant %v: %w", object, tenantID, control.ErrPaused)
+	}
+	return nil
+}
+
+// throttle admits the records of a run within the outbound controls
+type throttle struct {
+	controls entity.SyncControls
+	taken    map[string]int
+}
+
+func (s *SFExportService) newThrottle() (*throttle, error) {
+	controls, err := s.control.ListControls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the sync controls: %w", err)
+	}
+	return &throttle{controls: controls, taken: map[string]int{}}, nil
+}
+
+// take admits a record of the object of the tenant unless its sync is
+// paused or the run reached its throttle
+func (t *throttle) take(tenantID entity.ID, object entity.SyncObject) bool {
+	c := t.controls.For(tenantID, object, entity.SyncOutbound)
+	if c.Paused {
+		return false
+	}
+	key := tenantID.String() + "/" + string(object)
+	if c.Throttle > 0 && t.taken[key] >= c.Throttle {
+		return false
+	}
+	t.taken[key]++
+	return true
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"testing"
+
+	"sudhagar/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_throttle(t *testing.T) {
+	tenantAlice, tenantBob := entity.ID(13790492210917015554), entity.ID(13790492210917015555)
+	th := &throttle{
+		controls: entity.SyncControls{
+			{TenantID: tenantAlice, Object: entity.SyncObjectCourse, Direction: entity.SyncOutbound, Throttle: 2},
+			{TenantID: tenantBob, Object: entity.SyncObjectAll, Direction: entity.SyncOutbound, Paused: true},
+			// inbound controls do not apply
+			{TenantID: tenantAlice, Object: entity.SyncObjectCenter, Direction: entity.SyncInbound, Paused: true},
+		},
+		taken: map[string]int{},
+	}
+
+	assert.True(t, th.take(tenantAlice, entity.SyncObjectCourse))
+	assert.True(t, th.take(tenantAlice, entity.SyncObjectCourse))
+	assert.False(t, th.take(tenantAlice, entity.SyncObjectCourse))
+	assert.True(t, th.take(tenantAlice, entity.SyncObjectCenter))
+	assert.False(t, th.take(tenantBob, entity.SyncObjectCourse))
+	assert.False(t, th.take(tenantBob, entity.SyncObjectAccount))
+}
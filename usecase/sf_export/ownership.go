@@ -0,0 +1,85 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"sudhagar/glad/entity"
+)
+
+// filterPayload drops the fields the ownership rules do not let us export.
+// Returns the payload to send and the dropped fields, keyed as by
+// flattenPayload.
+func filterPayload(object entity.SyncObject,
+	payload []entity.SFPayload,
+	rules entity.FieldOwnership,
+) ([]entity.SFPayload, map[string]any, error) {
+	dropped := map[string]any{}
+	filtered := make([]entity.SFPayload, 0, len(payload))
+	for _, p := range payload {
+		items := make([]entity.SFRecord, 0, len(p.Items))
+		for i, item := range p.Items {
+			data, err := json.Marshal(item.Value)
+			if err != nil {
+				return nil, nil, err
+			}
+			var value map[string]any
+			if err := json.Unmarshal(data, &value); err != nil {
+				return nil, nil, err
+			}
+
+			for field, v := range value {
+				if !rules.Accepts(object, field, entity.SyncOutbound) {
+					dropped[fmt.Sprintf("%s[%d].%s", p.Object, i, field)] = v
+					delete(value, field)
+				}
+			}
+			items = append(items, entity.SFRecord{Operation: item.Operation, Value: value})
+		}
+		filtered = append(filtered, entity.SFPayload{Object: p.Object, Items: items})
+	}
+	return filtered, dropped, nil
+}
+
+// withheld lists the dropped fields
+func withheld(dropped map[string]any) []string {
+	fields := make([]string, 0, len(dropped))
+	for f := range dropped {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ownedPayload returns the payload to send for the record. A dropped field
+// that changed locally since the last export, last as by flattenPayload, is
+// a write SF owns; it is recorded as a conflict and not sent.
+func (s *SFExportService) ownedPayload(object entity.SyncObject,
+	entityID entity.ID,
+	extID string,
+	payload []entity.SFPayload,
+	last map[string]any,
+) ([]entity.SFPayload, error) {
+	filtered, dropped, err := filterPayload(object, payload, s.ownership)
+	if err != nil || last == nil {
+		return filtered, err
+	}
+
+	for _, field := range withheld(dropped) {
+		old, ok := last[field]
+		if !ok || fmt.Sprint(old) == fmt.Sprint(dropped[field]) {
+			continue
+		}
+		name := field[strings.LastIndex(field, ".")+1:]
+		c := entity.NewSyncConflict(object, entityID, extID, name, entity.SyncOutbound,
+			s.ownership.Owner(object, name), fmt.Sprint(dropped[field]), fmt.Sprint(old))
+		log.Printf("sync conflict: %s %v %s is owned by %s, not exporting %q", object, entityID, name, c.Owner, c.Attempted)
+		if err := s.conflictRepo.Create(c); err != nil {
+			log.Println("unable to record the sync conflict", err)
+		}
+	}
+	return filtered, nil
+}
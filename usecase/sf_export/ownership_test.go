@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"sudhagar/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterPayload(t *testing.T) {
+	rules, err := entity.ParseFieldOwnership("course:Status__c=salesforce, course:Notes__c=local")
+	assert.Nil(t, err)
+
+	payload := buildCoursePayload(newFixtureCourse())
+	send, dropped, err := filterPayload(entity.SyncObjectCourse, payload, rules)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Event__c[0].Status__c"}, withheld(dropped))
+	assert.Equal(t, "open", dropped["Event__c[0].Status__c"])
+
+	value := send[0].Items[0].Value.(map[string]any)
+	assert.NotContains(t, value, "Status__c")
+	assert.Equal(t, "notes", value["Notes__c"])
+	assert.Equal(t, entity.SFOperationUpdate, send[0].Items[0].Operation)
+
+	// rules of other objects do not apply
+	_, dropped, _ = filterPayload(entity.SyncObjectCenter, payload, rules)
+	assert.Empty(t, dropped)
+
+	_, err = entity.ParseFieldOwnership("course:Status__c=sf")
+	assert.NotNil(t, err)
+}
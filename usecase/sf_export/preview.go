@@ -0,0 +1,223 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// buildCoursePayload maps a course to the SF payload sent by ExportToSF
+func buildCoursePayload(course *entity.Course) []entity.SFPayload {
+	sfEvent := entity.SFEventData{
+		NumStudents:  int(course.NumAttendees),
+		MaxAttendees: int(course.MaxAttendees),
+		Notes:        &course.Notes,
+		Status:       string(course.Status),
+	}
+	operation := entity.SFOperationInsert
+	if course.ExtID != nil {
+		sfEvent.ExtId = *course.ExtID
+		operation = entity.SFOperationUpdate
+	}
+
+	if course.Address.Validate() == nil {
+		sfEvent.Country = course.Address.Country
+		sfEvent.City = course.Address.City
+		sfEvent.State = course.Address.State
+		sfEvent.ZipCode = course.Address.Zip
+		sfEvent.StreetAddress2 = course.Address.Street2
+		sfEvent.StreetAddress1 = &course.Address.Street1
+	}
+
+	return []entity.SFPayload{
+		{
+			Object: entity.SFObjectEvent,
+			Items: []entity.SFRecord{
+				{
+					Operation: operation,
+					Value:     sfEvent,
+				},
+			},
+		},
+	}
+}
+
+// validateEvent checks the event against the SF field mapping; the SF id is
+// required by the operations other than insert
+func validateEvent(e entity.SFEventData, operation string) []string {
+	var errs []string
+	if e.ExtId == "" && operation != entity.SFOperationInsert {
+		errs = append(errs, "Ext_Id: missing salesforce id")
+	}
+	return append(errs, validateEventFields(e)...)
+}
+
+// validateEventFields checks the event fields other than the SF id
+func validateEventFields(e entity.SFEventData) []string {
+	var errs []string
+	if e.Status == "" {
+		errs = append(errs, "Status__c: missing status")
+	}
+	if e.MaxAttendees < 0 || e.NumStudents < 0 {
+		errs = append(errs, "Max_Attendees__c/Number_Of_Students__c: negative count")
+	}
+	if e.MaxAttendees > 0 && e.NumStudents > e.MaxAttendees {
+		errs = append(errs, fmt.Sprintf("Number_Of_Students__c: %d exceeds Max_Attendees__c %d",
+			e.NumStudents, e.MaxAttendees))
+	}
+	return errs
+}
+
+// validatePayload validates each record of the payload
+func validatePayload(payload []entity.SFPayload) []string {
+	var errs []string
+	for _, p := range payload {
+		for _, item := range p.Items {
+			if e, ok := item.Value.(entity.SFEventData); ok {
+				errs = append(errs, validateEvent(e, item.Operation)...)
+			}
+		}
+	}
+	return errs
+}
+
+// flattenPayload maps "<object>[<item>].<field>" to the JSON value sent to SF
+func flattenPayload(payload []byte) (map[string]any, error) {
+	var decoded []struct {
+		Object string `json:"object"`
+		Items  []struct {
+			Value map[string]any `json:"value"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	for _, p := range decoded {
+		for i, item := range p.Items {
+			for k, v := range item.Value {
+				fields[fmt.Sprintf("%s[%d].%s", p.Object, i, k)] = v
+			}
+		}
+	}
+	return fields, nil
+}
+
+// diffPayload lists the fields that differ between two encoded payloads
+func diffPayload(old, new []byte) ([]entity.SFFieldDiff, error) {
+	oldFields, err := flattenPayload(old)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := flattenPayload(new)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff []entity.SFFieldDiff
+	for k, nv := range newFields {
+		ov, ok := oldFields[k]
+		if !ok || fmt.Sprint(ov) != fmt.Sprint(nv) {
+			diff = append(diff, entity.SFFieldDiff{Field: k, Old: ov, New: nv})
+		}
+	}
+	for k, ov := range oldFields {
+		if _, ok := newFields[k]; !ok {
+			diff = append(diff, entity.SFFieldDiff{Field: k, Old: ov})
+		}
+	}
+
+	sort.Slice(diff, func(i, j int) bool { return diff[i].Field < diff[j].Field })
+	return diff, nil
+}
+
+// PreviewCourse builds and validates the payload ExportToSF would send for
+// the course, and diffs it against the last export. The payload is empty when
+// nothing would be sent; the diff includes the fields withheld by the
+// ownership rules. SF is not called.
+func (s *SFExportService) PreviewCourse(courseID entity.ID) (*entity.SFPreview, error) {
+	course, err := s.courseRepo.GetByID(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course: %w", err)
+	}
+	if course == nil {
+		return nil, entity.ErrNotFound
+	}
+
+	payload := buildCoursePayload(course)
+	send, dropped, err := filterPayload(entity.SyncObjectCourse, payload, s.ownership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter payload: %w", err)
+	}
+	preview := &entity.SFPreview{
+		Object:   entity.SyncObjectCourse,
+		EntityID: courseID,
+		Errors:   validatePayload(payload),
+		Withheld: withheld(dropped),
+	}
+
+	snapshot, err := s.snapshotRepo.Get(entity.SyncObjectCourse, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export snapshot: %w", err)
+	}
+
+	last := []byte("[]")
+	var lastFields map[string]any
+	if snapshot != nil {
+		exportedAt := snapshot.ExportedAt
+		preview.LastExportedAt = &exportedAt
+		last = snapshot.Payload
+		if lastFields, err = flattenPayload(last); err != nil {
+			return nil, fmt.Errorf("failed to read export snapshot: %w", err)
+		}
+	}
+	if send, changed := changedFields(send, lastFields); changed > 0 {
+		preview.Payload = send
+	}
+
+	current, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	preview.Diff, err = diffPayload(last, current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff payload: %w", err)
+	}
+	return preview, nil
+}
+
+// PreviewCourses previews a set of courses; a course that can not be
+// previewed is reported in its Errors
+func (s *SFExportService) PreviewCourses(courseIDs []entity.ID) []*entity.SFPreview {
+	previews := make([]*entity.SFPreview, 0, len(courseIDs))
+	for _, id := range courseIDs {
+		preview, err := s.PreviewCourse(id)
+		if err != nil {
+			preview = &entity.SFPreview{
+				Object:   entity.SyncObjectCourse,
+				EntityID: id,
+				Errors:   []string{err.Error()},
+			}
+		}
+		previews = append(previews, preview)
+	}
+	return previews
+}
+
+// saveSnapshot records the payload accepted by SF
+func (s *SFExportService) saveSnapshot(object entity.SyncObject, entityID entity.ID, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return s.snapshotRepo.Save(&entity.ExportSnapshot{
+		Object:     object,
+		EntityID:   entityID,
+		Payload:    data,
+		ExportedAt: time.Now(),
+	})
+}
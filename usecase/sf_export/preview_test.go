@@ -0,0 +1,66 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"sudhagar/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFixtureCourse() *entity.Course {
+	extID := "a0B000000000001"
+	return &entity.Course{
+		ExtID:        &extID,
+		Notes:        "notes",
+		Status:       entity.CourseOpen,
+		MaxAttendees: 50,
+		NumAttendees: 12,
+	}
+}
+
+func Test_validatePayload(t *testing.T) {
+	course := newFixtureCourse()
+	assert.Empty(t, validatePayload(buildCoursePayload(course)))
+
+	// new courses are inserted without an SF id
+	course.ExtID = nil
+	assert.Empty(t, validatePayload(buildCoursePayload(course)))
+
+	course.Status = ""
+	course.NumAttendees = 60
+	errs := validatePayload(buildCoursePayload(course))
+	assert.Equal(t, 2, len(errs))
+
+	payload := buildCoursePayload(newFixtureCourse())
+	event := payload[0].Items[0].Value.(entity.SFEventData)
+	event.ExtId = ""
+	payload[0].Items[0].Value = event
+	assert.Equal(t, []string{"Ext_Id: missing salesforce id"}, validatePayload(payload))
+}
+
+func Test_diffPayload(t *testing.T) {
+	course := newFixtureCourse()
+	old, _ := json.Marshal(buildCoursePayload(course))
+
+	diff, err := diffPayload(old, old)
+	assert.Nil(t, err)
+	assert.Empty(t, diff)
+
+	course.Status = entity.CourseClosed
+	course.NumAttendees = 13
+	current, _ := json.Marshal(buildCoursePayload(course))
+	diff, err = diffPayload(old, current)
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.SFFieldDiff{
+		{Field: "Event__c[0].Number_Of_Students__c", Old: float64(12), New: float64(13)},
+		{Field: "Event__c[0].Status__c", Old: "open", New: "closed"},
+	}, diff)
+
+	// never exported; every field is new
+	diff, err = diffPayload([]byte("[]"), current)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, diff)
+	assert.Nil(t, diff[0].Old)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/ratelimit"
+	"sudhagar/glad/usecase/control"
+)
+
+// ReconcileResult drift found for an object
+type ReconcileResult struct {
+	Object  entity.SyncObject
+	Checked int
+	Drifted []entity.ID
+}
+
+// ExportCourses exports all the courses of the tenant. Stops early while the
+// API usage is high or the sync of the courses is paused. Returns the number
+// of courses exported.
+func (s *SFExportService) ExportCourses(tenantID entity.ID) (int, error) {
+	courses, err := s.courseRepo.List(tenantID, 0, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list courses: %w", err)
+	}
+
+	exported, failed := 0, 0
+	for _, c := range courses {
+		err := s.exportCourse(c.ID, false)
+		if errors.Is(err, ratelimit.ErrPaused) || errors.Is(err, control.ErrPaused) {
+			return exported, err
+		}
+		if err != nil {
+			log.Printf("unable to export course %v: %v", c.ID, err)
+			failed++
+			continue
+		}
+		exported++
+	}
+	if failed > 0 {
+		return exported, fmt.Errorf("%d of %d courses failed to export", failed, len(courses))
+	}
+	return exported, nil
+}
+
+// reconcileBatch SF ids per query; keeps the SOQL under its length limit
+const reconcileBatch = 200
+
+// Reconcile finds the courses of the tenant that drifted from Salesforce and,
+// unless dryRun, queues them for export. The fields sent by the export are
+// read back from Salesforce with a bulk query; a course drifted if it is not
+// in Salesforce, its last export failed or a field differs. Fields owned by
+// Salesforce are not compared.
+func (s *SFExportService) Reconcile(ctx context.Context, tenantID entity.ID, dryRun bool) ([]*ReconcileResult, error) {
+	courses, err := s.courseRepo.List(tenantID, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list courses: %w", err)
+	}
+
+	result := &ReconcileResult{Object: entity.SyncObjectCourse, Checked: len(courses)}
+	result.Drifted, err = s.driftedCourses(ctx, courses)
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		for _, id := range result.Drifted {
+			if _, err := s.outbox.EnqueueChange(tenantID, entity.SyncObjectCourse, id, entity.SyncUpdate); err != nil {
+				return nil, fmt.Errorf("failed to queue course %v: %w", id, err)
+			}
+		}
+	}
+	s.metric.SaveDrift(string(result.Object), len(result.Drifted))
+	return []*ReconcileResult{result}, nil
+}
+
+// driftedCourses compares the courses with their SF records
+func (s *SFExportService) driftedCourses(ctx context.Context, courses []*entity.Course) ([]entity.ID, error) {
+	mapping := bulkObjects[entity.SyncObjectCourse]
+	var columns []string
+	for _, c := range mapping.columns {
+		if s.ownership.Accepts(entity.SyncObjectCourse, c, entity.SyncOutbound) {
+			columns = append(columns, c)
+		}
+	}
+
+	local := map[entity.ID]*bulkRecord{}
+	var extIDs []string
+	for _, c := range courses {
+		// Note: invalid courses and courses not yet in SF are drifted
+		r, err := courseBulkRecord(c)
+		if err != nil || r.extID == "" || c.Sync.Status == entity.SyncFailed {
+			continue
+		}
+		local[c.ID] = r
+		extIDs = append(extIDs, r.extID)
+	}
+	remote, err := s.queryRecords(ctx, mapping.object, columns, extIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifted []entity.ID
+	for _, c := range courses {
+		r := local[c.ID]
+		if r == nil || !sameFields(r.fields, remote[r.extID], columns) {
+			drifted = append(drifted, c.ID)
+		}
+	}
+	return drifted, nil
+}
+
+// queryRecords reads the columns of the SF records by their SF id
+func (s *SFExportService) queryRecords(ctx context.Context,
+	object string,
+	columns []string,
+	ids []string,
+) (map[string]map[string]string, error) {
+	records := map[string]map[string]string{}
+	fields := strings.Join(append([]string{"Id"}, columns...), ", ")
+	for start := 0; start < len(ids); start += reconcileBatch {
+		end := min(start+reconcileBatch, len(ids))
+		quoted := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			quoted = append(quoted, "'"+strings.ReplaceAll(id, "'", `\'`)+"'")
+		}
+
+		rows, err := s.bulk.Query(ctx, fmt.Sprintf("SELECT %s FROM %s WHERE Id IN (%s)",
+			fields, object, strings.Join(quoted, ",")))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", object, err)
+		}
+		for _, row := range rows {
+			records[row["Id"]] = row
+		}
+	}
+	return records, nil
+}
+
+// sameFields checks whether the SF record has the local values of the
+// columns; numbers are compared by value as SF formats them with decimals
+func sameFields(local, remote map[string]string, columns []string) bool {
+	if remote == nil {
+		return false
+	}
+	for _, c := range columns {
+		l, r := local[c], remote[c]
+		if l == r {
+			continue
+		}
+		lf, lerr := strconv.ParseFloat(l, 64)
+		rf, rerr := strconv.ParseFloat(r, 64)
+		if lerr != nil || rerr != nil || lf != rf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/sfbulk"
+	"sudhagar/glad/pkg/sfbulk/sffake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_driftedCourses(t *testing.T) {
+	sf := sffake.NewServer()
+	defer sf.Close()
+	s := &SFExportService{bulk: &sfbulk.Client{
+		InstanceURL:  sf.URL,
+		Version:      "v60.0",
+		Token:        func() (string, error) { return "token", nil },
+		PollInterval: time.Millisecond,
+	}}
+	mapping := bulkObjects[entity.SyncObjectCourse]
+
+	// in sync, changed in SF, deleted in SF
+	var courses []*entity.Course
+	var records []*bulkRecord
+	for i := 1; i <= 3; i++ {
+		course := newFixtureCourse()
+		course.ID = entity.ID(i)
+		course.ExtID = nil
+		r, err := courseBulkRecord(course)
+		assert.Nil(t, err)
+		courses = append(courses, course)
+		records = append(records, r)
+	}
+	jobs, err := bulkJobs(mapping.object, mapping.columns, mapping.columns, "Glad_Id__c", records)
+	assert.Nil(t, err)
+	res, err := s.bulk.Run(context.Background(), jobs[0].req, jobs[0].data)
+	assert.Nil(t, err)
+	for _, row := range res.Successful {
+		r := jobs[0].records[row[jobs[0].key]]
+		extID := row[sfbulk.ColumnID]
+		courses[r.id-1].ExtID = &extID
+	}
+
+	update, _ := sfbulk.WriteCSV([]string{"Id", "Status__c"}, [][]string{{*courses[1].ExtID, "closed"}})
+	_, err = s.bulk.Run(context.Background(), sfbulk.JobRequest{Object: mapping.object, Operation: sfbulk.OpUpdate}, update)
+	assert.Nil(t, err)
+	remove, _ := sfbulk.WriteCSV([]string{"Id"}, [][]string{{*courses[2].ExtID}})
+	_, err = s.bulk.Run(context.Background(), sfbulk.JobRequest{Object: mapping.object, Operation: sfbulk.OpDelete}, remove)
+	assert.Nil(t, err)
+
+	// not yet in SF, last export failed
+	pending := newFixtureCourse()
+	pending.ID = 4
+	pending.ExtID = nil
+	failed := newFixtureCourse()
+	failed.ID = 5
+	failed.ExtID = courses[0].ExtID
+	failed.Sync.Status = entity.SyncFailed
+	courses = append(courses, pending, failed)
+
+	drifted, err := s.driftedCourses(context.Background(), courses)
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.ID{2, 3, 4, 5}, drifted)
+}
+
+func Test_sameFields(t *testing.T) {
+	columns := []string{"Max_Attendees__c", "Status__c"}
+	local := map[string]string{"Max_Attendees__c": "50", "Status__c": "open"}
+	assert.True(t, sameFields(local, map[string]string{"Max_Attendees__c": "50.0", "Status__c": "open"}, columns))
+	assert.False(t, sameFields(local, map[string]string{"Max_Attendees__c": "50", "Status__c": "closed"}, columns))
+	assert.False(t, sameFields(local, nil, columns))
+}
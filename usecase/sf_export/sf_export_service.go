@@ -2,20 +2,76 @@ package service
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"sudhagar/glad/config"
 	"sudhagar/glad/entity"
 	infra "sudhagar/glad/ops/db"
+	"sudhagar/glad/pkg/metric"
+	"sudhagar/glad/pkg/ratelimit"
+	"sudhagar/glad/pkg/sfbulk"
 	util "sudhagar/glad/pkg/util"
 	"sudhagar/glad/repository"
+	"sudhagar/glad/usecase/control"
+	"sudhagar/glad/usecase/outbox"
+	"sudhagar/glad/usecase/tombstone"
+	"time"
 )
 
+// courseStore the course reads and writes of the export; the ids are of
+// the courses the export read, so the writes by id are not scoped by tenant
+type courseStore interface {
+	GetByID(id entity.ID) (*entity.Course, error)
+	List(tenantID entity.ID, page, limit int) ([]*entity.Course, error)
+	Delete(tenantID, id entity.ID) error
+	UpdateSyncState(id entity.ID, s *entity.SyncState) error
+	UpdateExtID(id entity.ID, extID string) error
+}
+
+// snapshotStore the payloads last accepted by SF
+type snapshotStore interface {
+	Get(object entity.SyncObject, entityID entity.ID) (*entity.ExportSnapshot, error)
+	Save(e *entity.ExportSnapshot) error
+	Delete(object entity.SyncObject, entityID entity.ID) error
+}
+
+// conflictStore the writes refused by the field ownership rules
+type conflictStore interface {
+	Create(e *entity.SyncConflict) error
+}
+
 type SFExportService struct {
-	courseRepo *repository.CoursePGSQL
-	timingRepo *repository.TimingPGSQL
-	sfEndpoint string
+	courseRepo   courseStore
+	timingRepo   *repository.TimingPGSQL
+	centerRepo   *repository.CenterPGSQL
+	productRepo  *repository.ProductPGSQL
+	accountRepo  *repository.AccountPGSQL
+	tombstone    tombstone.UseCase
+	outbox       outbox.UseCase
+	control      control.UseCase
+	snapshotRepo snapshotStore
+	conflictRepo conflictStore
+	ownership    entity.FieldOwnership
+	metric       metric.SyncService
+	limiter      *ratelimit.Limiter
+	sfEndpoint   string
+	// token returns the access token of the org
+	token func() (string, error)
+	// pending work is claimed for the lease, so that other syncer instances
+	// do not export it too
+	instance   string
+	claimLease time.Duration
+	// Bulk API jobs upsert new records on this field
+	bulk           *sfbulk.Client
+	bulkExternalID string
 }
 
 func NewSFExportService() (*SFExportService, error) {
@@ -27,81 +83,442 @@ func NewSFExportService() (*SFExportService, error) {
 	if err != nil {
 		return nil, err
 	}
+	metricService, err := metric.NewPrometheusService()
+	if err != nil {
+		return nil, err
+	}
+	// limits are shared by all the clients of the org of the SF endpoint
+	limiter := ratelimit.ForOrg(util.GetStrEnvOrConfig("SF_ORG", config.SF_ORG), ratelimit.Config{
+		Rate:           float64(util.GetIntEnvOrConfig("SF_RATE_LIMIT", config.SF_RATE_LIMIT)),
+		Burst:          util.GetIntEnvOrConfig("SF_RATE_BURST", config.SF_RATE_BURST),
+		MaxConcurrent:  util.GetIntEnvOrConfig("SF_MAX_CONCURRENT", config.SF_MAX_CONCURRENT),
+		PauseThreshold: float64(util.GetIntEnvOrConfig("SF_QUOTA_PAUSE_PERCENT", config.SF_QUOTA_PAUSE_PERCENT)) / 100,
+		UsageTTL:       time.Duration(util.GetIntEnvOrConfig("SF_QUOTA_USAGE_TTL_SECONDS", config.SF_QUOTA_USAGE_TTL_SECONDS)) * time.Second,
+	})
+	instanceURL := util.GetStrEnvOrConfig("SF_INSTANCE_URL", config.SF_INSTANCE_URL)
+	ownership, err := entity.ParseFieldOwnership(util.GetStrEnvOrConfig("SF_FIELD_OWNERS", config.SF_FIELD_OWNERS))
+	if err != nil {
+		return nil, err
+	}
 	return &SFExportService{
-		courseRepo: repository.NewCoursePGSQL(db),
-		timingRepo: repository.NewTimingPGSQL(db),
-		sfEndpoint: "https://aol-dev--awspoc.sandbox.my.salesforce.com/services/apexrest/handleAolEvent",
+		courseRepo:  repository.NewCoursePGSQL(db),
+		timingRepo:  repository.NewTimingPGSQL(db),
+		centerRepo:  repository.NewCenterPGSQL(db),
+		productRepo: repository.NewProductPGSQL(db),
+		accountRepo: repository.NewAccountPGSQL(db),
+		// Note: action is stored on the tombstone when the record is deleted
+		tombstone:    tombstone.NewService(repository.NewTombstonePGSQL(db), nil),
+		outbox:       outbox.NewService(repository.NewOutboxPGSQL(db), OutboxRetry()),
+		control:      control.NewService(repository.NewSyncControlPGSQL(db)),
+		snapshotRepo: repository.NewExportSnapshotPGSQL(db),
+		conflictRepo: repository.NewConflictPGSQL(db),
+		ownership:    ownership,
+		metric:       metricService,
+		limiter:      limiter,
+		sfEndpoint:   instanceURL + "/services/apexrest/handleAolEvent",
+		token:        util.GenerateTokens,
+		instance:     infra.Instance,
+		claimLease:   time.Duration(util.GetIntEnvOrConfig("SYNC_CLAIM_LEASE_SECONDS", config.SYNC_CLAIM_LEASE_SECONDS)) * time.Second,
+		bulk: &sfbulk.Client{
+			InstanceURL:  instanceURL,
+			Version:      util.GetStrEnvOrConfig("SF_API_VERSION", config.SF_API_VERSION),
+			Token:        util.GenerateTokens,
+			Limiter:      limiter,
+			PollInterval: time.Duration(util.GetIntEnvOrConfig("SF_BULK_POLL_SECONDS", config.SF_BULK_POLL_SECONDS)) * time.Second,
+		},
+		bulkExternalID: util.GetStrEnvOrConfig("SF_BULK_EXTERNAL_ID_FIELD", config.SF_BULK_EXTERNAL_ID_FIELD),
 	}, nil
 }
 
+// OutboxRetry gives the configured retry of the failed exports
+func OutboxRetry() entity.OutboxRetry {
+	return entity.OutboxRetry{
+		Backoff:     time.Duration(util.GetIntEnvOrConfig("SYNC_RETRY_BACKOFF_SECONDS", config.SYNC_RETRY_BACKOFF_SECONDS)) * time.Second,
+		MaxBackoff:  time.Duration(util.GetIntEnvOrConfig("SYNC_RETRY_MAX_BACKOFF_SECONDS", config.SYNC_RETRY_MAX_BACKOFF_SECONDS)) * time.Second,
+		MaxAttempts: int32(util.GetIntEnvOrConfig("SYNC_RETRY_MAX_ATTEMPTS", config.SYNC_RETRY_MAX_ATTEMPTS)),
+	}
+}
+
+// ExportToSF exports the course right away; it is not paused when the API
+// usage is high
 func (s *SFExportService) ExportToSF(courseID entity.ID) error {
+	return s.exportCourse(courseID, true)
+}
+
+func (s *SFExportService) exportCourse(courseID entity.ID, urgent bool) error {
 	// Get course data
-	course, err := s.courseRepo.Get(courseID)
+	course, err := s.courseRepo.GetByID(courseID)
 	if err != nil {
 		return fmt.Errorf("failed to get course: %w", err)
 	}
+	if course == nil {
+		return entity.ErrNotFound
+	}
+	if err := s.checkPaused(course.TenantID, entity.SyncObjectCourse); err != nil {
+		// not attempted; the course is exported once resumed
+		return err
+	}
 
 	// Transform course data to SF format
-	sfEvent := entity.SFEventData{
-		ExtId:        *course.ExtID,
-		NumStudents:  int(course.NumAttendees),
-		MaxAttendees: int(course.MaxAttendees),
-		Notes:        &course.Notes,
-		Status:       string(course.Status),
-	}
-
-	if course.Address.Validate() == nil {
-		sfEvent.Country = course.Address.Country
-		sfEvent.City = course.Address.City
-		sfEvent.State = course.Address.State
-		sfEvent.ZipCode = course.Address.Zip
-		sfEvent.StreetAddress2 = course.Address.Street2
-		sfEvent.StreetAddress1 = &course.Address.Street1
-	}
-
-	// Create payload
-	payload := []entity.SFPayload{
-		{
-			Object: "Event__c",
-			Items: []entity.SFRecord{
-				{
-					Operation: "Insert", // or "Update" based on logic
-					Value:     sfEvent,
-				},
-			},
-		},
+	payload := buildCoursePayload(course)
+	s.saveRecord(entity.SyncObjectCourse, metric.SyncReceived)
+
+	// Send to SF and record the outcome on the course
+	var sendErr error
+	var sfID string
+	if errs := validatePayload(payload); len(errs) > 0 {
+		sendErr = fmt.Errorf("invalid SF payload: %s", strings.Join(errs, "; "))
+	} else {
+		var send []entity.SFPayload
+		send, sendErr = s.outboundPayload(entity.SyncObjectCourse, courseID, extIDOf(course), payload)
+		// Note: nothing to send when no field we own changed
+		if sendErr == nil && send != nil {
+			var results []entity.SFResult
+			results, sendErr = s.sendToSF(send, urgent)
+			if sendErr == nil && extIDOf(course) == "" {
+				sfID, sendErr = insertedID(results)
+			}
+		}
+	}
+	if errors.Is(sendErr, ratelimit.ErrPaused) {
+		// not attempted; the course is exported once resumed
+		return sendErr
+	}
+	err = s.courseRepo.UpdateSyncState(courseID, entity.NewSyncState(entity.SyncOutbound, sendErr))
+	if err != nil {
+		log.Println("unable to update the course sync state", err)
+	}
+	s.saveRecord(entity.SyncObjectCourse, resultOf(sendErr))
+	if sfID != "" {
+		// Note: the later exports update the event instead of inserting another
+		if err := s.courseRepo.UpdateExtID(courseID, sfID); err != nil {
+			log.Println("unable to update the course ext id", err)
+		}
+	}
+	if sendErr == nil {
+		if err := s.saveSnapshot(entity.SyncObjectCourse, courseID, payload); err != nil {
+			log.Println("unable to save the export snapshot", err)
+		}
+	}
+	return sendErr
+}
+
+// insertedID returns the SF id of the record inserted by a send
+func insertedID(results []entity.SFResult) (string, error) {
+	if len(results) == 0 || results[0].Id == "" {
+		return "", errors.New("SF returned no id for the inserted record")
+	}
+	return results[0].Id, nil
+}
+
+// extIDOf returns the SF id of the course, empty if not in SF
+func extIDOf(course *entity.Course) string {
+	if course.ExtID == nil {
+		return ""
+	}
+	return *course.ExtID
+}
+
+// ExportTombstones sends up to limit pending deletes to SF. The local record
+// is removed once SF accepts the delete; failed ones are retried on the next
+// run. The tombstones are claimed, so that concurrent runs of other instances
+// send others. Returns the number of tombstones confirmed.
+func (s *SFExportService) ExportTombstones(limit int) (int, error) {
+	tombstones, err := s.tombstone.ClaimPendingTombstones(s.instance, limit, s.claimLease)
+	if err == entity.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tombstones: %w", err)
+	}
+
+	defer s.saveQueues()
+
+	// Note: the tombstones not sent are left pending for a later run
+	unsent := map[entity.ID]bool{}
+	for _, t := range tombstones {
+		unsent[t.ID] = true
+	}
+	defer func() {
+		for id := range unsent {
+			if err := s.tombstone.ReleaseTombstone(id, s.instance); err != nil {
+				log.Println("unable to release the tombstone", err)
+			}
+		}
+	}()
+
+	throttle, err := s.newThrottle()
+	if err != nil {
+		return 0, err
+	}
+	confirmed := 0
+	for _, t := range tombstones {
+		if !throttle.take(t.TenantID, t.Object) {
+			continue
+		}
+		// Note: the claim is renewed before the send, so that a claim that
+		// expired during the run is not sent twice
+		if err := s.tombstone.RenewTombstone(t.ID, s.instance, s.claimLease); err != nil {
+			log.Printf("skipping tombstone %v: %v", t.ID, err)
+			delete(unsent, t.ID)
+			continue
+		}
+		s.saveRecord(t.Object, metric.SyncReceived)
+		payload, err := tombstonePayload(t)
+		if err == nil {
+			_, err = s.sendToSF([]entity.SFPayload{*payload}, false)
+		}
+		if errors.Is(err, ratelimit.ErrPaused) {
+			log.Println("tombstone export paused:", err)
+			break
+		}
+		delete(unsent, t.ID)
+		s.saveRecord(t.Object, resultOf(err))
+		if err != nil {
+			log.Printf("unable to export tombstone %v: %v", t.ID, err)
+			if err := s.tombstone.FailTombstone(t.ID, s.instance, err); err != nil {
+				log.Println("unable to update the tombstone", err)
+			}
+			continue
+		}
+
+		// Note: confirmed only once the local record is gone; otherwise the
+		// delete is retried
+		if err := s.deleteLocal(t); err != nil && err != sql.ErrNoRows {
+			log.Printf("unable to delete %v %v: %v", t.Object, t.EntityID, err)
+			if err := s.tombstone.FailTombstone(t.ID, s.instance, err); err != nil {
+				log.Println("unable to update the tombstone", err)
+			}
+			continue
+		}
+		if err := s.tombstone.ConfirmTombstone(t.ID, s.instance); err != nil {
+			log.Println("unable to confirm the tombstone", err)
+			continue
+		}
+		if err := s.snapshotRepo.Delete(t.Object, t.EntityID); err != nil {
+			log.Println("unable to delete the export snapshot", err)
+		}
+		confirmed++
+	}
+	return confirmed, nil
+}
+
+// ExportOutbox exports up to limit pending changes captured from the
+// database. The changes are claimed, so that concurrent runs of other
+// instances export others. Returns the number of changes exported.
+func (s *SFExportService) ExportOutbox(limit int) (int, error) {
+	items, err := s.outbox.ClaimPendingOutbox(s.instance, limit, s.claimLease)
+	if err == entity.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list outbox: %w", err)
+	}
+
+	defer s.saveQueues()
+
+	// Note: the changes not exported are left pending for a later run
+	unsent := map[entity.ID]bool{}
+	for _, item := range items {
+		unsent[item.ID] = true
+	}
+	defer func() {
+		for id := range unsent {
+			if err := s.outbox.ReleaseOutboxItem(id, s.instance); err != nil {
+				log.Println("unable to release the outbox item", err)
+			}
+		}
+	}()
+
+	throttle, err := s.newThrottle()
+	if err != nil {
+		return 0, err
+	}
+	exported := 0
+	for _, item := range items {
+		if !throttle.take(item.TenantID, item.Object) {
+			continue
+		}
+		// Note: the claim is renewed before the export, so that a claim that
+		// expired during the run is not exported twice
+		if err := s.outbox.RenewOutboxItem(item.ID, s.instance, s.claimLease); err != nil {
+			log.Printf("skipping outbox item %v: %v", item.ID, err)
+			delete(unsent, item.ID)
+			continue
+		}
+		switch {
+		case item.Operation == entity.SyncDelete:
+			// Note: deletes are propagated by the tombstones
+			err = nil
+		case item.Object == entity.SyncObjectCourse:
+			err = s.exportCourse(item.EntityID, false)
+			if err == entity.ErrNotFound {
+				// deleted since; nothing to export
+				err = nil
+			}
+		default:
+			err = fmt.Errorf("export of %s is not supported", item.Object)
+		}
+
+		if errors.Is(err, ratelimit.ErrPaused) {
+			// Note: left pending for the next run
+			log.Println("outbox export paused:", err)
+			break
+		}
+		if errors.Is(err, control.ErrPaused) {
+			// paused since the run started
+			continue
+		}
+		delete(unsent, item.ID)
+		if err != nil {
+			log.Printf("unable to export %v %v: %v", item.Object, item.EntityID, err)
+			if err := s.outbox.FailOutboxItem(item.ID, s.instance, err); err != nil {
+				log.Println("unable to update the outbox item", err)
+			}
+			continue
+		}
+		if err := s.outbox.CompleteOutboxItem(item.ID, s.instance); err != nil {
+			log.Println("unable to complete the outbox item", err)
+			continue
+		}
+		exported++
+	}
+	return exported, nil
+}
+
+// deleteLocal removes the record of a confirmed tombstone
+func (s *SFExportService) deleteLocal(t *entity.Tombstone) error {
+	switch t.Object {
+	case entity.SyncObjectCourse:
+		return s.courseRepo.Delete(t.TenantID, t.EntityID)
+	case entity.SyncObjectCenter:
+		return s.centerRepo.Delete(t.TenantID, t.EntityID)
+	case entity.SyncObjectProduct:
+		return s.productRepo.Delete(t.TenantID, t.EntityID)
+	case entity.SyncObjectAccount:
+		return s.accountRepo.Delete(t.TenantID, t.EntityID)
+	}
+	return fmt.Errorf("unknown object %q", t.Object)
+}
+
+// tombstonePayload builds the SF payload for a tombstone. Cancel keeps the
+// SF record and marks it canceled/inactive; objects without such a status
+// are deleted instead.
+func tombstonePayload(t *entity.Tombstone) (*entity.SFPayload, error) {
+	var object string
+	var cancel map[string]any
+	switch t.Object {
+	case entity.SyncObjectCourse:
+		object = entity.SFObjectEvent
+		cancel = map[string]any{"Status__c": string(entity.CourseCanceled)}
+	case entity.SyncObjectCenter:
+		object = entity.SFObjectCenter
+		cancel = map[string]any{"Is_enable__c": false}
+	case entity.SyncObjectProduct:
+		object = entity.SFObjectProduct
+		cancel = map[string]any{"Listing_Visibity__c": string(entity.ProductVisibilityUnlisted)}
+	case entity.SyncObjectAccount:
+		object = entity.SFObjectAccount
+	default:
+		return nil, fmt.Errorf("unknown object %q", t.Object)
 	}
 
-	// Send to SF
-	return s.sendToSF(payload)
+	value := map[string]any{"Ext_Id": t.ExtID}
+	operation := entity.SFOperationDelete
+	if t.Action == entity.TombstoneCancel && cancel != nil {
+		operation = entity.SFOperationUpdate
+		for k, v := range cancel {
+			value[k] = v
+		}
+	}
+
+	return &entity.SFPayload{
+		Object: object,
+		Items: []entity.SFRecord{
+			{
+				Operation: operation,
+				Value:     value,
+			},
+		},
+	}, nil
 }
 
-func (s *SFExportService) sendToSF(payload interface{}) error {
+// sendToSF sends the payload within the org limits and returns the result of
+// each record sent. Non-urgent payloads are refused with ratelimit.ErrPaused
+// while the API usage is high.
+func (s *SFExportService) sendToSF(payload []entity.SFPayload, urgent bool) ([]entity.SFResult, error) {
+	release, err := s.limiter.Acquire(context.Background(), urgent)
+	if err != nil {
+		return nil, fmt.Errorf("SF request not sent: %w", err)
+	}
+	defer release()
+
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 	client := http.Client{}
 	request, err := http.NewRequest("POST", s.sfEndpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
-		log.Println("there was an error generating the request")
+		return nil, fmt.Errorf("failed to create SF request: %w", err)
 	}
-	token, err := util.GenerateTokens()
+	token, err := s.token()
 	if err != nil {
-		log.Println("error generating the tokens", err)
+		s.metric.SaveTokenRefresh(metric.SyncFailed)
+		return nil, fmt.Errorf("failed to generate SF token: %w", err)
 	}
+	s.metric.SaveTokenRefresh("success")
 	request.Header.Set("Authorization", "Bearer "+token)
-	if err != nil {
-		return fmt.Errorf("failed to send to SF: %w", err)
+
+	callout := metric.NewCallout("")
+	if len(payload) > 0 {
+		callout.Object = payload[0].Object
 	}
+	callout.Started()
 	resp, err := client.Do(request)
+	callout.Finished()
 	if err != nil {
-		log.Println("error performing the request", err)
+		callout.StatusCode = "error"
+		s.metric.SaveCallout(callout)
+		return nil, fmt.Errorf("failed to send to SF: %w", err)
 	}
 	defer resp.Body.Close()
+	s.limiter.Observe(resp.Header)
+	callout.StatusCode = strconv.Itoa(resp.StatusCode)
+	s.metric.SaveCallout(callout)
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("SF returned non-200 status: %d", resp.StatusCode)
+		return nil, fmt.Errorf("SF returned non-200 status: %d", resp.StatusCode)
+	}
+	var results []entity.SFResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil && err != io.EOF {
+		// Note: the records were accepted; only the ids of new records are missing
+		log.Println("unable to read the response from SF:", err)
+	}
+	return results, nil
+}
+
+// saveRecord counts an outbound record
+func (s *SFExportService) saveRecord(object entity.SyncObject, result string) {
+	s.metric.SaveSyncRecord(metric.NewSyncRecord(string(object), string(entity.SyncOutbound), result))
+}
+
+// saveQueues records the depth and age of the export queues
+func (s *SFExportService) saveQueues() {
+	if stats, err := s.outbox.GetPendingStats(); err == nil {
+		s.metric.SaveQueue(metric.NewQueue("outbox", stats.Depth, stats.Oldest))
+	}
+	if stats, err := s.tombstone.GetPendingStats(); err == nil {
+		s.metric.SaveQueue(metric.NewQueue("tombstone", stats.Depth, stats.Oldest))
+	}
+	if err := control.SaveMetrics(s.control, s.metric); err != nil {
+		log.Println("unable to record the sync controls", err)
+	}
+}
+
+// resultOf maps an error to the metric result label
+func resultOf(err error) string {
+	if err != nil {
+		return metric.SyncFailed
 	}
-	log.Println("response from SF:", resp, resp.Body)
-	return nil
+	return metric.SyncApplied
 }
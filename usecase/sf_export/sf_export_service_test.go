@@ -0,0 +1,117 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/metric"
+	"sudhagar/glad/pkg/ratelimit"
+	"sudhagar/glad/usecase/control"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// courses in memory course store
+type courses map[entity.ID]*entity.Course
+
+func (c courses) GetByID(id entity.ID) (*entity.Course, error) { return c[id], nil }
+func (c courses) List(tenantID entity.ID, page, limit int) ([]*entity.Course, error) {
+	var list []*entity.Course
+	for _, e := range c {
+		if e.TenantID == tenantID {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+func (c courses) Delete(tenantID, id entity.ID) error { delete(c, id); return nil }
+func (c courses) UpdateSyncState(id entity.ID, s *entity.SyncState) error {
+	c[id].Sync = *s
+	return nil
+}
+func (c courses) UpdateExtID(id entity.ID, extID string) error {
+	if c[id].ExtID == nil {
+		c[id].ExtID = &extID
+	}
+	return nil
+}
+
+// snapshots in memory snapshot store
+type snapshots map[entity.ID]*entity.ExportSnapshot
+
+func (s snapshots) Get(object entity.SyncObject, entityID entity.ID) (*entity.ExportSnapshot, error) {
+	return s[entityID], nil
+}
+func (s snapshots) Save(e *entity.ExportSnapshot) error { s[e.EntityID] = e; return nil }
+func (s snapshots) Delete(object entity.SyncObject, entityID entity.ID) error {
+	delete(s, entityID)
+	return nil
+}
+
+// nopMetric drops the sync metrics
+type nopMetric struct{}
+
+func (nopMetric) SaveSyncRecord(r *metric.SyncRecord) {}
+func (nopMetric) SaveCallout(c *metric.Callout)       {}
+func (nopMetric) SaveQueue(q *metric.Queue)           {}
+func (nopMetric) SaveTokenRefresh(result string)      {}
+func (nopMetric) SaveDrift(object string, count int)  {}
+func (nopMetric) SaveControls(c []*metric.Control)    {}
+
+func Test_exportCourse(t *testing.T) {
+	var sent []map[string]any
+	sf := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		var payload []struct {
+			Items []map[string]any `json:"items"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &payload))
+		sent = append(sent, payload[0].Items[0])
+		_, _ = w.Write([]byte(`[{"Id": "a0X000000000001"}]`))
+	}))
+	defer sf.Close()
+
+	course := newFixtureCourse()
+	course.ID = 1
+	course.ExtID = nil
+	store, exported := courses{course.ID: course}, snapshots{}
+	s := &SFExportService{
+		courseRepo:   store,
+		snapshotRepo: exported,
+		control:      control.NewService(control.NewInmem()),
+		metric:       nopMetric{},
+		limiter:      ratelimit.New(ratelimit.Config{}),
+		sfEndpoint:   sf.URL,
+		token:        func() (string, error) { return "token", nil },
+	}
+
+	// a course not yet in SF is inserted
+	err := s.exportCourse(course.ID, false)
+	assert.Nil(t, err)
+	assert.Len(t, sent, 1)
+	assert.Equal(t, entity.SFOperationInsert, sent[0]["operation"])
+	assert.NotContains(t, sent[0]["value"], "Ext_Id")
+	assert.Equal(t, entity.SyncSynced, store[course.ID].Sync.Status)
+	assert.NotNil(t, exported[course.ID])
+	assert.Equal(t, "a0X000000000001", *store[course.ID].ExtID)
+
+	// the next export updates the inserted event
+	course.Notes = "moved to the main hall"
+	err = s.exportCourse(course.ID, false)
+	assert.Nil(t, err)
+	assert.Len(t, sent, 2)
+	assert.Equal(t, entity.SFOperationUpdate, sent[1]["operation"])
+	assert.Equal(t, map[string]any{"Ext_Id": "a0X000000000001", "Notes__c": "moved to the main hall"}, sent[1]["value"])
+
+	// invalid courses are not sent
+	course.NumAttendees = 60
+	err = s.exportCourse(course.ID, false)
+	assert.NotNil(t, err)
+	assert.Len(t, sent, 2)
+	assert.Equal(t, entity.SyncFailed, store[course.ID].Sync.Status)
+}
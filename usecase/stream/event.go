@@ -0,0 +1,140 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sudhagar/glad/entity"
+)
+
+// Fields of the sync platform event; Record__c holds the record JSON as sent
+// by the Apex callouts
+const (
+	EventObjectField    = "Object__c"
+	EventOperationField = "Operation__c"
+	EventRecordField    = "Record__c"
+)
+
+// Change data capture channels start with this prefix; the others are
+// platform event channels
+const changeChannelPrefix = "/data/"
+
+// inboundObjects maps the SF objects to the inbound handlers
+var inboundObjects = map[string]string{
+	entity.SFObjectAccount: "account",
+	entity.SFObjectEvent:   "course",
+	entity.SFObjectCenter:  "center",
+	entity.SFObjectProduct: "product",
+}
+
+// event data of a streaming event
+type event struct {
+	Event struct {
+		ReplayID int64 `json:"replayId"`
+	} `json:"event"`
+	Payload map[string]any `json:"payload"`
+}
+
+// inboundRecord record as sent by the Apex callouts
+type inboundRecord struct {
+	Operation string         `json:"operation"`
+	Object    string         `json:"object"`
+	Value     map[string]any `json:"value"`
+}
+
+// decodeEvent decodes the event data keeping the numbers as sent
+func decodeEvent(data []byte) (*event, error) {
+	var e event
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// changeRecords maps a change data capture event to the inbound records of
+// the changed records. Update events carry the changed fields only. Returns
+// no records for the events that cannot be applied: deletes, gaps and
+// overflows.
+func changeRecords(payload map[string]any) (string, []inboundRecord, error) {
+	header, ok := payload["ChangeEventHeader"].(map[string]any)
+	if !ok {
+		return "", nil, fmt.Errorf("missing ChangeEventHeader")
+	}
+	sfObject, _ := header["entityName"].(string)
+	changeType, _ := header["changeType"].(string)
+	object, ok := inboundObjects[sfObject]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported object %q", sfObject)
+	}
+	switch changeType {
+	case "CREATE", "UPDATE", "UNDELETE":
+	default:
+		return object, nil, nil
+	}
+
+	fields := map[string]any{}
+	for k, v := range payload {
+		if k == "ChangeEventHeader" {
+			continue
+		}
+		// compound fields, e.g. Name of person accounts, are sent nested
+		if nested, ok := v.(map[string]any); ok {
+			for nk, nv := range nested {
+				if _, ok := payload[nk]; !ok {
+					fields[nk] = nv
+				}
+			}
+			continue
+		}
+		fields[k] = v
+	}
+
+	ids, _ := header["recordIds"].([]any)
+	records := make([]inboundRecord, 0, len(ids))
+	for _, id := range ids {
+		value := make(map[string]any, len(fields)+1)
+		for k, v := range fields {
+			value[k] = v
+		}
+		value["Id"] = id
+		records = append(records, inboundRecord{
+			Operation: strings.ToLower(changeType),
+			Object:    sfObject,
+			Value:     value,
+		})
+	}
+	return object, records, nil
+}
+
+// platformRecords maps a sync platform event to its inbound record
+func platformRecords(payload map[string]any) (string, []inboundRecord, error) {
+	sfObject, _ := payload[EventObjectField].(string)
+	object, ok := inboundObjects[sfObject]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported object %q", sfObject)
+	}
+	record, _ := payload[EventRecordField].(string)
+
+	var value map[string]any
+	d := json.NewDecoder(strings.NewReader(record))
+	d.UseNumber()
+	if err := d.Decode(&value); err != nil {
+		return "", nil, fmt.Errorf("invalid %s: %w", EventRecordField, err)
+	}
+	operation, _ := payload[EventOperationField].(string)
+	return object, []inboundRecord{{
+		Operation: strings.ToLower(operation),
+		Object:    sfObject,
+		Value:     value,
+	}}, nil
+}
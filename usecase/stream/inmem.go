@@ -0,0 +1,36 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package stream
+
+import (
+	"sudhagar/glad/entity"
+)
+
+// Inmem in memory repo
+type Inmem struct {
+	m map[string]*entity.ReplayPosition
+}
+
+// NewInmem create new repository
+func NewInmem() *Inmem {
+	var m = map[string]*entity.ReplayPosition{}
+	return &Inmem{
+		m: m,
+	}
+}
+
+// Get a replay position
+func (r *Inmem) Get(tenantID entity.ID, channel string) (*entity.ReplayPosition, error) {
+	return r.m[tenantID.String()+channel], nil
+}
+
+// Save a replay position
+func (r *Inmem) Save(e *entity.ReplayPosition) error {
+	p := *e
+	r.m[e.TenantID.String()+e.Channel] = &p
+	return nil
+}
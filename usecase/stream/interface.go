@@ -0,0 +1,32 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package stream
+
+import (
+	"sudhagar/glad/entity"
+)
+
+// Reader interface
+type Reader interface {
+	// Get returns nil when the channel has no saved position
+	Get(tenantID entity.ID, channel string) (*entity.ReplayPosition, error)
+}
+
+// Writer replay position writer
+type Writer interface {
+	Save(e *entity.ReplayPosition) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// Dispatcher applies inbound records of an object, encoded as sent by the
+// Apex callouts, e.g. through the inbound handlers
+type Dispatcher func(object string, records []byte) error
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/cometd"
+)
+
+// Delay before reconnecting after a failed session; doubled up to maxBackoff
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// Subscriber applies the Salesforce streaming events of a tenant through the
+// inbound pipeline. The replay id of each channel is saved once its event is
+// applied, so a restarted subscriber resumes after the last applied event.
+type Subscriber struct {
+	tenantID   entity.ID
+	channels   []string
+	client     *cometd.Client
+	repo       Repository
+	dispatch   Dispatcher
+	replayFrom int64
+}
+
+// NewSubscriber create new subscriber; replayFrom is the replay id of the
+// channels without a saved position, cometd.ReplayNew or cometd.ReplayAll
+func NewSubscriber(tenantID entity.ID,
+	channels []string,
+	client *cometd.Client,
+	repo Repository,
+	dispatch Dispatcher,
+	replayFrom int64,
+) *Subscriber {
+	return &Subscriber{
+		tenantID:   tenantID,
+		channels:   channels,
+		client:     client,
+		repo:       repo,
+		dispatch:   dispatch,
+		replayFrom: replayFrom,
+	}
+}
+
+// Run applies the events until ctx is done, reconnecting on failures
+func (s *Subscriber) Run(ctx context.Context) error {
+	backoff := minBackoff
+	for {
+		subscribed, err := s.session(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if subscribed {
+			backoff = minBackoff
+		}
+		log.Printf("streaming session of tenant %v ended, reconnecting in %v: %v", s.tenantID, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// session subscribes to the channels and polls until an error
+func (s *Subscriber) session(ctx context.Context) (bool, error) {
+	if err := s.client.Handshake(ctx); err != nil {
+		return false, err
+	}
+	for _, channel := range s.channels {
+		replayID := s.replayFrom
+		pos, err := s.repo.Get(s.tenantID, channel)
+		if err != nil {
+			return false, err
+		}
+		if pos != nil {
+			replayID = pos.ReplayID
+		}
+		if err := s.client.Subscribe(ctx, channel, replayID); err != nil {
+			return false, fmt.Errorf("subscribe to %s: %w", channel, err)
+		}
+	}
+
+	for {
+		events, err := s.client.Connect(ctx)
+		for _, e := range events {
+			if hErr := s.Handle(e); hErr != nil {
+				// not saved; the event is replayed by the next session
+				return true, hErr
+			}
+		}
+		if err != nil {
+			return true, err
+		}
+	}
+}
+
+// Handle applies an event and saves its replay id. Events that cannot be
+// applied are logged and skipped.
+func (s *Subscriber) Handle(msg cometd.Message) error {
+	e, err := decodeEvent(msg.Data)
+	if err != nil {
+		return fmt.Errorf("invalid event on %s: %w", msg.Channel, err)
+	}
+
+	var object string
+	var records []inboundRecord
+	if strings.HasPrefix(msg.Channel, changeChannelPrefix) {
+		object, records, err = changeRecords(e.Payload)
+	} else {
+		object, records, err = platformRecords(e.Payload)
+	}
+	switch {
+	case err != nil:
+		log.Printf("skipped event %d on %s: %v", e.Event.ReplayID, msg.Channel, err)
+	case len(records) == 0:
+		log.Printf("skipped event %d on %s: nothing to apply", e.Event.ReplayID, msg.Channel)
+	default:
+		for i := range records {
+			records[i].Value["Tenant_id"] = s.tenantID
+		}
+		data, err := json.Marshal(records)
+		if err != nil {
+			return err
+		}
+		if err := s.dispatch(object, data); err != nil {
+			return fmt.Errorf("apply event %d on %s: %w", e.Event.ReplayID, msg.Channel, err)
+		}
+	}
+
+	return s.repo.Save(&entity.ReplayPosition{
+		TenantID:  s.tenantID,
+		Channel:   msg.Channel,
+		ReplayID:  e.Event.ReplayID,
+		UpdatedAt: time.Now(),
+	})
+}
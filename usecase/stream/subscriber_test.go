@@ -0,0 +1,136 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sudhagar/glad/entity"
+	"sudhagar/glad/pkg/cometd"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const accountCreated = `{
+	"event": {"replayId": 11},
+	"payload": {
+		"ChangeEventHeader": {"entityName": "Account", "changeType": "CREATE", "recordIds": ["001A"]},
+		"Name": {"FirstName": "Jane", "LastName": "Doe"},
+		"PersonEmail": "jane@example.com"
+	}
+}`
+
+const courseEvent = `{
+	"event": {"replayId": 12},
+	"payload": {"Object__c": "Event__c", "Operation__c": "Insert", "Record__c": "{\"Id\":\"a0B1\",\"Name\":\"Happiness Program\"}"}
+}`
+
+const accountDeleted = `{
+	"event": {"replayId": 13},
+	"payload": {
+		"ChangeEventHeader": {"entityName": "Account", "changeType": "DELETE", "recordIds": ["001A"]}
+	}
+}`
+
+// fakeStream fake streaming endpoint; serves the events on the first connect
+// and expires the client on the next one
+type fakeStream struct {
+	events    []cometd.Message
+	connects  int
+	subscribe []map[string]any
+}
+
+func (f *fakeStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var msgs []cometd.Message
+	json.NewDecoder(r.Body).Decode(&msgs)
+	m := msgs[0]
+
+	reply := cometd.Message{Channel: m.Channel, ClientID: "c1", Successful: true}
+	var out []cometd.Message
+	switch m.Channel {
+	case cometd.ChannelSubscribe:
+		f.subscribe = append(f.subscribe, m.Ext["replay"].(map[string]any))
+	case cometd.ChannelConnect:
+		f.connects++
+		if f.connects == 1 {
+			out = append(out, f.events...)
+		} else {
+			reply.Successful = false
+			reply.Error = "403::Unknown client"
+			reply.Advice = &cometd.Advice{Reconnect: cometd.ReconnectHandshake}
+		}
+	}
+	json.NewEncoder(w).Encode(append(out, reply))
+}
+
+func Test_changeRecords(t *testing.T) {
+	e, err := decodeEvent([]byte(accountCreated))
+	assert.Nil(t, err)
+	object, records, err := changeRecords(e.Payload)
+	assert.Nil(t, err)
+	assert.Equal(t, "account", object)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "001A", records[0].Value["Id"])
+	assert.Equal(t, "Jane", records[0].Value["FirstName"])
+	assert.Equal(t, "create", records[0].Operation)
+
+	e, _ = decodeEvent([]byte(accountDeleted))
+	_, records, err = changeRecords(e.Payload)
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+}
+
+func Test_session(t *testing.T) {
+	f := &fakeStream{}
+	for _, data := range []string{accountCreated, courseEvent, accountDeleted} {
+		f.events = append(f.events,
+			cometd.Message{Channel: "/data/ChangeEvents", Data: json.RawMessage(data)})
+	}
+	f.events[1].Channel = "/event/Glad_Sync__e"
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	applied := map[string][]inboundRecord{}
+	dispatch := func(object string, data []byte) error {
+		var records []inboundRecord
+		if err := json.Unmarshal(data, &records); err != nil {
+			return err
+		}
+		applied[object] = append(applied[object], records...)
+		return nil
+	}
+	client := &cometd.Client{URL: srv.URL, Token: func() (string, error) { return "token", nil }}
+	repo := NewInmem()
+	tenantID := entity.ID(7)
+	s := NewSubscriber(tenantID, []string{"/data/ChangeEvents", "/event/Glad_Sync__e"},
+		client, repo, dispatch, cometd.ReplayNew)
+
+	subscribed, err := s.session(context.Background())
+	assert.True(t, subscribed)
+	assert.ErrorIs(t, err, cometd.ErrRehandshake)
+	assert.Equal(t, float64(cometd.ReplayNew), f.subscribe[0]["/data/ChangeEvents"])
+
+	assert.Len(t, applied["account"], 1)
+	assert.Equal(t, float64(7), applied["account"][0].Value["Tenant_id"])
+	assert.Len(t, applied["course"], 1)
+	assert.Equal(t, "Happiness Program", applied["course"][0].Value["Name"])
+
+	pos, _ := repo.Get(tenantID, "/data/ChangeEvents")
+	assert.Equal(t, int64(13), pos.ReplayID)
+	pos, _ = repo.Get(tenantID, "/event/Glad_Sync__e")
+	assert.Equal(t, int64(12), pos.ReplayID)
+
+	// resumes after the saved positions
+	_, err = s.session(context.Background())
+	assert.ErrorIs(t, err, cometd.ErrRehandshake)
+	assert.Equal(t, float64(13), f.subscribe[2]["/data/ChangeEvents"])
+	assert.Equal(t, float64(12), f.subscribe[3]["/event/Glad_Sync__e"])
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package timing
+
+import (
+	"sort"
+
+	"sudhagar/glad/entity"
+)
+
+// Inmem in memory repo
+type Inmem struct {
+	m map[entity.ID]*entity.CourseTiming
+	// tenants is the tenant of each course
+	tenants map[entity.ID]entity.ID
+}
+
+// NewInmem create new repository for the courses of the tenants
+func NewInmem(tenants map[entity.ID]entity.ID) *Inmem {
+	var m = map[entity.ID]*entity.CourseTiming{}
+	return &Inmem{
+		m:       m,
+		tenants: tenants,
+	}
+}
+
+// Create a course timing
+func (r *Inmem) Create(e *entity.CourseTiming) (entity.ID, error) {
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// Get a timing of a course of the tenant
+func (r *Inmem) Get(tenantID, id entity.ID) (*entity.CourseTiming, error) {
+	t := r.m[id]
+	if t == nil || r.tenants[t.CourseID] != tenantID {
+		return nil, entity.ErrNotFound
+	}
+	return t, nil
+}
+
+// ListByCourse lists the timings of a course, earliest first
+func (r *Inmem) ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	var timings []*entity.CourseTiming
+	for _, j := range r.m {
+		if j.CourseID == courseID {
+			timings = append(timings, j)
+		}
+	}
+	sort.Slice(timings, func(i, j int) bool {
+		a, b := timings[i].DateTime, timings[j].DateTime
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		return a.StartTime < b.StartTime
+	})
+	return timings, nil
+}
+
+// Update a timing of a course of the tenant
+func (r *Inmem) Update(tenantID entity.ID, e *entity.CourseTiming) error {
+	_, err := r.Get(tenantID, e.ID)
+	if err != nil {
+		return err
+	}
+	r.m[e.ID] = e
+	return nil
+}
+
+// Delete a timing of a course of the tenant
+func (r *Inmem) Delete(tenantID, id entity.ID) error {
+	if _, err := r.Get(tenantID, id); err != nil {
+		return err
+	}
+	delete(r.m, id)
+	return nil
+}
+
+// ReplaceByCourse replaces all the timings of a course
+func (r *Inmem) ReplaceByCourse(courseID entity.ID, timings []*entity.CourseTiming) error {
+	for id, j := range r.m {
+		if j.CourseID == courseID {
+			delete(r.m, id)
+		}
+	}
+	for _, t := range timings {
+		r.m[t.ID] = t
+	}
+	return nil
+}
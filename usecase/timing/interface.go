@@ -0,0 +1,45 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package timing
+
+import (
+	"sudhagar/glad/entity"
+)
+
+// Reader interface
+type Reader interface {
+	// Get gets a timing of a course of the tenant
+	Get(tenantID, id entity.ID) (*entity.CourseTiming, error)
+	ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error)
+}
+
+// Writer course timing writer
+type Writer interface {
+	Create(e *entity.CourseTiming) (entity.ID, error)
+	// Update updates a timing of a course of the tenant
+	Update(tenantID entity.ID, e *entity.CourseTiming) error
+	// Delete deletes a timing of a course of the tenant
+	Delete(tenantID, id entity.ID) error
+	// ReplaceByCourse replaces all the timings of the course
+	ReplaceByCourse(courseID entity.ID, timings []*entity.CourseTiming) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	GetTiming(tenantID, id entity.ID) (*entity.CourseTiming, error)
+	ListTimings(courseID entity.ID) ([]*entity.CourseTiming, error)
+	CreateTiming(courseID entity.ID, extID *string, dateTime entity.CourseDateTime) (entity.ID, error)
+	UpdateTiming(tenantID entity.ID, e *entity.CourseTiming) error
+	DeleteTiming(tenantID, id entity.ID) error
+	ReplaceTimings(courseID entity.ID, dateTimes []entity.CourseDateTime) ([]*entity.CourseTiming, error)
+}
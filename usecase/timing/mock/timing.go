@@ -0,0 +1,366 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/timing/interface.go
+
+// Package mock_timing is a generated GoMock package.
+package mock_timing
+
+import (
+	reflect "reflect"
+	entity "sudhagar/glad/entity"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReader) Get(tenantID, id entity.ID) (*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
+	ret0, _ := ret[0].(*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReaderMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, id)
+}
+
+// ListByCourse mocks base method.
+func (m *MockReader) ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCourse", courseID)
+	ret0, _ := ret[0].([]*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCourse indicates an expected call of ListByCourse.
+func (mr *MockReaderMockRecorder) ListByCourse(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCourse", reflect.TypeOf((*MockReader)(nil).ListByCourse), courseID)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWriter) Create(e *entity.CourseTiming) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWriter)(nil).Create), e)
+}
+
+// Delete mocks base method.
+func (m *MockWriter) Delete(tenantID, id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWriterMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, id)
+}
+
+// ReplaceByCourse mocks base method.
+func (m *MockWriter) ReplaceByCourse(courseID entity.ID, timings []*entity.CourseTiming) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceByCourse", courseID, timings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceByCourse indicates an expected call of ReplaceByCourse.
+func (mr *MockWriterMockRecorder) ReplaceByCourse(courseID, timings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceByCourse", reflect.TypeOf((*MockWriter)(nil).ReplaceByCourse), courseID, timings)
+}
+
+// Update mocks base method.
+func (m *MockWriter) Update(tenantID entity.ID, e *entity.CourseTiming) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWriterMockRecorder) Update(tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), tenantID, e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(e *entity.CourseTiming) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), e)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(tenantID, id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, id)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(tenantID, id entity.ID) (*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, id)
+	ret0, _ := ret[0].(*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, id)
+}
+
+// ListByCourse mocks base method.
+func (m *MockRepository) ListByCourse(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCourse", courseID)
+	ret0, _ := ret[0].([]*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCourse indicates an expected call of ListByCourse.
+func (mr *MockRepositoryMockRecorder) ListByCourse(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCourse", reflect.TypeOf((*MockRepository)(nil).ListByCourse), courseID)
+}
+
+// ReplaceByCourse mocks base method.
+func (m *MockRepository) ReplaceByCourse(courseID entity.ID, timings []*entity.CourseTiming) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceByCourse", courseID, timings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceByCourse indicates an expected call of ReplaceByCourse.
+func (mr *MockRepositoryMockRecorder) ReplaceByCourse(courseID, timings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceByCourse", reflect.TypeOf((*MockRepository)(nil).ReplaceByCourse), courseID, timings)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(tenantID entity.ID, e *entity.CourseTiming) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), tenantID, e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateTiming mocks base method.
+func (m *MockUseCase) CreateTiming(courseID entity.ID, extID *string, dateTime entity.CourseDateTime) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTiming", courseID, extID, dateTime)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTiming indicates an expected call of CreateTiming.
+func (mr *MockUseCaseMockRecorder) CreateTiming(courseID, extID, dateTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTiming", reflect.TypeOf((*MockUseCase)(nil).CreateTiming), courseID, extID, dateTime)
+}
+
+// DeleteTiming mocks base method.
+func (m *MockUseCase) DeleteTiming(tenantID, id entity.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTiming", tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTiming indicates an expected call of DeleteTiming.
+func (mr *MockUseCaseMockRecorder) DeleteTiming(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTiming", reflect.TypeOf((*MockUseCase)(nil).DeleteTiming), tenantID, id)
+}
+
+// GetTiming mocks base method.
+func (m *MockUseCase) GetTiming(tenantID, id entity.ID) (*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTiming", tenantID, id)
+	ret0, _ := ret[0].(*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTiming indicates an expected call of GetTiming.
+func (mr *MockUseCaseMockRecorder) GetTiming(tenantID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTiming", reflect.TypeOf((*MockUseCase)(nil).GetTiming), tenantID, id)
+}
+
+// ListTimings mocks base method.
+func (m *MockUseCase) ListTimings(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimings", courseID)
+	ret0, _ := ret[0].([]*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimings indicates an expected call of ListTimings.
+func (mr *MockUseCaseMockRecorder) ListTimings(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimings", reflect.TypeOf((*MockUseCase)(nil).ListTimings), courseID)
+}
+
+// ReplaceTimings mocks base method.
+func (m *MockUseCase) ReplaceTimings(courseID entity.ID, dateTimes []entity.CourseDateTime) ([]*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTimings", courseID, dateTimes)
+	ret0, _ := ret[0].([]*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceTimings indicates an expected call of ReplaceTimings.
+func (mr *MockUseCaseMockRecorder) ReplaceTimings(courseID, dateTimes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTimings", reflect.TypeOf((*MockUseCase)(nil).ReplaceTimings), courseID, dateTimes)
+}
+
+// UpdateTiming mocks base method.
+func (m *MockUseCase) UpdateTiming(tenantID entity.ID, e *entity.CourseTiming) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTiming", tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTiming indicates an expected call of UpdateTiming.
+func (mr *MockUseCaseMockRecorder) UpdateTiming(tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTiming", reflect.TypeOf((*MockUseCase)(nil).UpdateTiming), tenantID, e)
+}
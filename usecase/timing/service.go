@@ -0,0 +1,96 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package timing
+
+import (
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// Service course timing usecase
+type Service struct {
+	repo Repository
+}
+
+// NewService create new service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// CreateTiming adds a timing to a course
+func (s *Service) CreateTiming(courseID entity.ID, extID *string, dateTime entity.CourseDateTime) (entity.ID, error) {
+	t, err := entity.NewCourseTiming(courseID, extID, dateTime)
+	if err != nil {
+		return entity.IDInvalid, err
+	}
+	return s.repo.Create(t)
+}
+
+// GetTiming retrieves a timing of a course of the tenant
+func (s *Service) GetTiming(tenantID, id entity.ID) (*entity.CourseTiming, error) {
+	t, err := s.repo.Get(tenantID, id)
+	if t == nil {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ListTimings lists the timings of a course, earliest first
+func (s *Service) ListTimings(courseID entity.ID) ([]*entity.CourseTiming, error) {
+	timings, err := s.repo.ListByCourse(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(timings) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return timings, nil
+}
+
+// UpdateTiming updates a timing of a course of the tenant
+func (s *Service) UpdateTiming(tenantID entity.ID, t *entity.CourseTiming) error {
+	err := t.Validate()
+	if err != nil {
+		return err
+	}
+	t.UpdatedAt = time.Now()
+	return s.repo.Update(tenantID, t)
+}
+
+// DeleteTiming deletes a timing of a course of the tenant
+func (s *Service) DeleteTiming(tenantID, id entity.ID) error {
+	_, err := s.GetTiming(tenantID, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(tenantID, id)
+}
+
+// ReplaceTimings replaces all the timings of a course; none are replaced if
+// any of them is invalid
+func (s *Service) ReplaceTimings(courseID entity.ID, dateTimes []entity.CourseDateTime) ([]*entity.CourseTiming, error) {
+	var timings []*entity.CourseTiming
+	for _, dt := range dateTimes {
+		t, err := entity.NewCourseTiming(courseID, nil, dt)
+		if err != nil {
+			return nil, err
+		}
+		timings = append(timings, t)
+	}
+	err := s.repo.ReplaceByCourse(courseID, timings)
+	if err != nil {
+		return nil, err
+	}
+	return timings, nil
+}
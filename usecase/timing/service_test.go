@@ -0,0 +1,123 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package timing
+
+import (
+	"testing"
+
+	"sudhagar/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	courseAlice entity.ID = 13790493495087071234
+	courseBob   entity.ID = 13790493495087071235
+	tenantAlice entity.ID = 13790492210917015554
+	tenantBob   entity.ID = 13790492210917015555
+)
+
+func newInmem() *Inmem {
+	return NewInmem(map[entity.ID]entity.ID{courseAlice: tenantAlice, courseBob: tenantBob})
+}
+
+func Test_Create(t *testing.T) {
+	m := NewService(newInmem())
+
+	id, err := m.CreateTiming(courseAlice, nil, entity.CourseDateTime{Date: "2024-05-02", StartTime: "09:00", EndTime: "12:30:00"})
+	assert.Nil(t, err)
+	saved, err := m.GetTiming(tenantAlice, id)
+	assert.Nil(t, err)
+	assert.Equal(t, courseAlice, saved.CourseID)
+	assert.Equal(t, "2024-05-02", saved.DateTime.Date)
+
+	// the time is optional
+	_, err = m.CreateTiming(courseAlice, nil, entity.CourseDateTime{Date: "2024-05-03"})
+	assert.Nil(t, err)
+
+	for _, dt := range []entity.CourseDateTime{
+		{},
+		{Date: "05/02/2024"},
+		{Date: "2024-05-02", StartTime: "9am"},
+		{Date: "2024-05-02", StartTime: "12:00", EndTime: "09:00"},
+	} {
+		_, err = m.CreateTiming(courseAlice, nil, dt)
+		assert.Equal(t, entity.ErrInvalidEntity, err, dt)
+	}
+	_, err = m.CreateTiming(entity.IDInvalid, nil, entity.CourseDateTime{Date: "2024-05-02"})
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+}
+
+func Test_ListUpdateDelete(t *testing.T) {
+	m := NewService(newInmem())
+
+	id1, _ := m.CreateTiming(courseAlice, nil, entity.CourseDateTime{Date: "2024-05-03"})
+	id2, _ := m.CreateTiming(courseAlice, nil, entity.CourseDateTime{Date: "2024-05-02", StartTime: "09:00"})
+	_, _ = m.CreateTiming(courseBob, nil, entity.CourseDateTime{Date: "2024-05-01"})
+
+	timings, err := m.ListTimings(courseAlice)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(timings))
+	assert.Equal(t, id2, timings[0].ID)
+	assert.Equal(t, id1, timings[1].ID)
+
+	saved, _ := m.GetTiming(tenantAlice, id1)
+	saved.DateTime.EndTime = "17:00"
+	err = m.UpdateTiming(tenantAlice, saved)
+	assert.Nil(t, err)
+	saved.DateTime.Date = ""
+	err = m.UpdateTiming(tenantAlice, saved)
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+
+	// the timings of another tenant's course don't exist for the tenant
+	_, err = m.GetTiming(tenantBob, id1)
+	assert.Equal(t, entity.ErrNotFound, err)
+	other := *saved
+	other.DateTime.Date = "2024-06-01"
+	err = m.UpdateTiming(tenantBob, &other)
+	assert.Equal(t, entity.ErrNotFound, err)
+	err = m.DeleteTiming(tenantBob, id1)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	err = m.DeleteTiming(tenantAlice, id1)
+	assert.Nil(t, err)
+	err = m.DeleteTiming(tenantAlice, id1)
+	assert.Equal(t, entity.ErrNotFound, err)
+	timings, _ = m.ListTimings(courseAlice)
+	assert.Equal(t, 1, len(timings))
+}
+
+func Test_Replace(t *testing.T) {
+	m := NewService(newInmem())
+
+	_, _ = m.CreateTiming(courseAlice, nil, entity.CourseDateTime{Date: "2024-05-01"})
+	_, _ = m.CreateTiming(courseBob, nil, entity.CourseDateTime{Date: "2024-05-01"})
+
+	timings, err := m.ReplaceTimings(courseAlice, []entity.CourseDateTime{
+		{Date: "2024-06-01"},
+		{Date: "2024-06-02"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(timings))
+	saved, _ := m.ListTimings(courseAlice)
+	assert.Equal(t, "2024-06-01", saved[0].DateTime.Date)
+	assert.Equal(t, 2, len(saved))
+
+	// all or none
+	_, err = m.ReplaceTimings(courseAlice, []entity.CourseDateTime{{Date: "2024-07-01"}, {}})
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+	saved, _ = m.ListTimings(courseAlice)
+	assert.Equal(t, 2, len(saved))
+
+	// cleared
+	_, err = m.ReplaceTimings(courseAlice, nil)
+	assert.Nil(t, err)
+	_, err = m.ListTimings(courseAlice)
+	assert.Equal(t, entity.ErrNotFound, err)
+	saved, _ = m.ListTimings(courseBob)
+	assert.Equal(t, 1, len(saved))
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package tombstone
+
+import (
+	"sort"
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// Inmem in memory repo
+type Inmem struct {
+	m map[entity.ID]*entity.Tombstone
+}
+
+// NewInmem create new repository
+func NewInmem() *Inmem {
+	var m = map[entity.ID]*entity.Tombstone{}
+	return &Inmem{
+		m: m,
+	}
+}
+
+// Create a tombstone
+func (r *Inmem) Create(e *entity.Tombstone) (entity.ID, error) {
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// Get a tombstone
+func (r *Inmem) Get(id entity.ID) (*entity.Tombstone, error) {
+	if r.m[id] == nil {
+		return nil, entity.ErrNotFound
+	}
+	return r.m[id], nil
+}
+
+// GetByEntity gets the tombstone of a deleted record
+func (r *Inmem) GetByEntity(object entity.SyncObject, entityID entity.ID) (*entity.Tombstone, error) {
+	for _, j := range r.m {
+		if j.Object == object && j.EntityID == entityID {
+			return j, nil
+		}
+	}
+	return nil, entity.ErrNotFound
+}
+
+// ListPending lists pending and failed tombstones
+func (r *Inmem) ListPending(limit int) ([]*entity.Tombstone, error) {
+	var tombstones []*entity.Tombstone
+	for _, j := range r.m {
+		if j.Status != entity.SyncSynced {
+			tombstones = append(tombstones, j)
+		}
+	}
+
+	sort.Slice(tombstones, func(i, j int) bool {
+		return tombstones[i].CreatedAt.Before(tombstones[j].CreatedAt)
+	})
+	if limit > 0 && len(tombstones) > limit {
+		tombstones = tombstones[:limit]
+	}
+	return tombstones, nil
+}
+
+// Claim pending tombstones
+func (r *Inmem) Claim(owner string, limit int, now time.Time, lease time.Duration) ([]*entity.Tombstone, error) {
+	pending, _ := r.ListPending(0)
+	var tombstones []*entity.Tombstone
+	for _, j := range pending {
+		if j.ClaimedUntil != nil && !j.ClaimedUntil.Before(now) {
+			continue
+		}
+		if limit > 0 && len(tombstones) == limit {
+			break
+		}
+		until := now.Add(lease)
+		j.ClaimedBy = owner
+		j.ClaimedUntil = &until
+		tombstones = append(tombstones, j)
+	}
+	return tombstones, nil
+}
+
+// Update a tombstone
+func (r *Inmem) Update(e *entity.Tombstone) error {
+	_, err := r.Get(e.ID)
+	if err != nil {
+		return err
+	}
+	r.m[e.ID] = e
+	return nil
+}
+
+// claimed gets the tombstone claimed by owner
+func (r *Inmem) claimed(id entity.ID, owner string) (*entity.Tombstone, error) {
+	t := r.m[id]
+	if t == nil || t.ClaimedBy != owner {
+		return nil, entity.ErrClaimLost
+	}
+	return t, nil
+}
+
+// Renew extends the claim of owner on the tombstone
+func (r *Inmem) Renew(id entity.ID, owner string, now time.Time, lease time.Duration) error {
+	t, err := r.claimed(id, owner)
+	if err != nil {
+		return err
+	}
+	until := now.Add(lease)
+	t.ClaimedUntil = &until
+	return nil
+}
+
+// Release drops the claim of owner on the tombstone
+func (r *Inmem) Release(id entity.ID, owner string) error {
+	t, err := r.claimed(id, owner)
+	if err != nil {
+		return err
+	}
+	releaseClaim(t)
+	return nil
+}
+
+// Confirm marks the tombstone claimed by owner as synced
+func (r *Inmem) Confirm(id entity.ID, owner string, now time.Time) error {
+	t, err := r.claimed(id, owner)
+	if err != nil {
+		return err
+	}
+	releaseClaim(t)
+	t.Status = entity.SyncSynced
+	t.Attempts++
+	t.LastError = ""
+	t.UpdatedAt = now
+	return nil
+}
+
+// Fail marks the tombstone claimed by owner as failed
+func (r *Inmem) Fail(id entity.ID, owner string, now time.Time, cause string) error {
+	t, err := r.claimed(id, owner)
+	if err != nil {
+		return err
+	}
+	releaseClaim(t)
+	t.Status = entity.SyncFailed
+	t.Attempts++
+	t.LastError = cause
+	t.UpdatedAt = now
+	return nil
+}
+
+// PendingStats gets the depth and the oldest pending item
+func (r *Inmem) PendingStats() (*entity.QueueStats, error) {
+	stats := &entity.QueueStats{}
+	for _, j := range r.m {
+		if j.Status != entity.SyncSynced {
+			stats.Depth++
+			if stats.Oldest == nil || j.CreatedAt.Before(*stats.Oldest) {
+				createdAt := j.CreatedAt
+				stats.Oldest = &createdAt
+			}
+		}
+	}
+	return stats, nil
+}
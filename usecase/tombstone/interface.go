@@ -0,0 +1,70 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package tombstone
+
+import (
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// Reader interface
+type Reader interface {
+	Get(id entity.ID) (*entity.Tombstone, error)
+	GetByEntity(object entity.SyncObject, entityID entity.ID) (*entity.Tombstone, error)
+	// ListPending lists tombstones not yet confirmed by Salesforce, oldest
+	// first; tombstones whose outbound sync is paused may be left out
+	ListPending(limit int) ([]*entity.Tombstone, error)
+	PendingStats() (*entity.QueueStats, error)
+}
+
+// Writer tombstone writer
+type Writer interface {
+	Create(e *entity.Tombstone) (entity.ID, error)
+	// Claim claims up to limit pending tombstones for owner until
+	// now+lease, oldest first. Tombstones claimed by others are skipped
+	// until their claim expires, as are those whose outbound sync is paused.
+	Claim(owner string, limit int, now time.Time, lease time.Duration) ([]*entity.Tombstone, error)
+	// Renew, Release, Confirm and Fail update the tombstone only while owner
+	// holds its claim; entity.ErrClaimLost otherwise
+	Renew(id entity.ID, owner string, now time.Time, lease time.Duration) error
+	Release(id entity.ID, owner string) error
+	Confirm(id entity.ID, owner string, now time.Time) error
+	Fail(id entity.ID, owner string, now time.Time, cause string) error
+	Update(e *entity.Tombstone) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	// CreateTombstone records the delete of a synced record; idempotent
+	CreateTombstone(tenantID entity.ID,
+		object entity.SyncObject,
+		entityID entity.ID,
+		extID string,
+	) (*entity.Tombstone, error)
+	GetTombstone(id entity.ID) (*entity.Tombstone, error)
+	ListPendingTombstones(limit int) ([]*entity.Tombstone, error)
+	// ClaimPendingTombstones claims tombstones to send; other syncer
+	// instances skip them for the lease
+	ClaimPendingTombstones(owner string, limit int, lease time.Duration) ([]*entity.Tombstone, error)
+	// RenewTombstone extends the claim of owner before the delete is sent;
+	// entity.ErrClaimLost if another instance claimed it since
+	RenewTombstone(id entity.ID, owner string, lease time.Duration) error
+	// ReleaseTombstone drops the claim; the tombstone is left pending
+	ReleaseTombstone(id entity.ID, owner string) error
+	GetPendingStats() (*entity.QueueStats, error)
+	// ConfirmTombstone marks the delete as confirmed by Salesforce
+	ConfirmTombstone(id entity.ID, owner string) error
+	// FailTombstone records a failed attempt; the tombstone is retried later
+	FailTombstone(id entity.ID, owner string, cause error) error
+}
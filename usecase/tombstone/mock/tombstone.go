@@ -0,0 +1,556 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/tombstone/interface.go
+
+// Package mock_tombstone is a generated GoMock package.
+package mock_tombstone
+
+import (
+	reflect "reflect"
+	entity "sudhagar/glad/entity"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReader) Get(id entity.ID) (*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReaderMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), id)
+}
+
+// GetByEntity mocks base method.
+func (m *MockReader) GetByEntity(object entity.SyncObject, entityID entity.ID) (*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEntity", object, entityID)
+	ret0, _ := ret[0].(*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEntity indicates an expected call of GetByEntity.
+func (mr *MockReaderMockRecorder) GetByEntity(object, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEntity", reflect.TypeOf((*MockReader)(nil).GetByEntity), object, entityID)
+}
+
+// ListPending mocks base method.
+func (m *MockReader) ListPending(limit int) ([]*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", limit)
+	ret0, _ := ret[0].([]*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockReaderMockRecorder) ListPending(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockReader)(nil).ListPending), limit)
+}
+
+// PendingStats mocks base method.
+func (m *MockReader) PendingStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingStats indicates an expected call of PendingStats.
+func (mr *MockReaderMockRecorder) PendingStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingStats", reflect.TypeOf((*MockReader)(nil).PendingStats))
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockWriter) Claim(owner string, limit int, now time.Time, lease time.Duration) ([]*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", owner, limit, now, lease)
+	ret0, _ := ret[0].([]*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockWriterMockRecorder) Claim(owner, limit, now, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockWriter)(nil).Claim), owner, limit, now, lease)
+}
+
+// Confirm mocks base method.
+func (m *MockWriter) Confirm(id entity.ID, owner string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", id, owner, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockWriterMockRecorder) Confirm(id, owner, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockWriter)(nil).Confirm), id, owner, now)
+}
+
+// Create mocks base method.
+func (m *MockWriter) Create(e *entity.Tombstone) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWriter)(nil).Create), e)
+}
+
+// Fail mocks base method.
+func (m *MockWriter) Fail(id entity.ID, owner string, now time.Time, cause string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", id, owner, now, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockWriterMockRecorder) Fail(id, owner, now, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockWriter)(nil).Fail), id, owner, now, cause)
+}
+
+// Release mocks base method.
+func (m *MockWriter) Release(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockWriterMockRecorder) Release(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockWriter)(nil).Release), id, owner)
+}
+
+// Renew mocks base method.
+func (m *MockWriter) Renew(id entity.ID, owner string, now time.Time, lease time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", id, owner, now, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockWriterMockRecorder) Renew(id, owner, now, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockWriter)(nil).Renew), id, owner, now, lease)
+}
+
+// Update mocks base method.
+func (m *MockWriter) Update(e *entity.Tombstone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWriterMockRecorder) Update(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockRepository) Claim(owner string, limit int, now time.Time, lease time.Duration) ([]*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", owner, limit, now, lease)
+	ret0, _ := ret[0].([]*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockRepositoryMockRecorder) Claim(owner, limit, now, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockRepository)(nil).Claim), owner, limit, now, lease)
+}
+
+// Confirm mocks base method.
+func (m *MockRepository) Confirm(id entity.ID, owner string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", id, owner, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockRepositoryMockRecorder) Confirm(id, owner, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockRepository)(nil).Confirm), id, owner, now)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(e *entity.Tombstone) (entity.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(entity.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), e)
+}
+
+// Fail mocks base method.
+func (m *MockRepository) Fail(id entity.ID, owner string, now time.Time, cause string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", id, owner, now, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockRepositoryMockRecorder) Fail(id, owner, now, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockRepository)(nil).Fail), id, owner, now, cause)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(id entity.ID) (*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
+}
+
+// GetByEntity mocks base method.
+func (m *MockRepository) GetByEntity(object entity.SyncObject, entityID entity.ID) (*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEntity", object, entityID)
+	ret0, _ := ret[0].(*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEntity indicates an expected call of GetByEntity.
+func (mr *MockRepositoryMockRecorder) GetByEntity(object, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEntity", reflect.TypeOf((*MockRepository)(nil).GetByEntity), object, entityID)
+}
+
+// ListPending mocks base method.
+func (m *MockRepository) ListPending(limit int) ([]*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", limit)
+	ret0, _ := ret[0].([]*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockRepositoryMockRecorder) ListPending(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockRepository)(nil).ListPending), limit)
+}
+
+// PendingStats mocks base method.
+func (m *MockRepository) PendingStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingStats indicates an expected call of PendingStats.
+func (mr *MockRepositoryMockRecorder) PendingStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingStats", reflect.TypeOf((*MockRepository)(nil).PendingStats))
+}
+
+// Release mocks base method.
+func (m *MockRepository) Release(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRepositoryMockRecorder) Release(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRepository)(nil).Release), id, owner)
+}
+
+// Renew mocks base method.
+func (m *MockRepository) Renew(id entity.ID, owner string, now time.Time, lease time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", id, owner, now, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockRepositoryMockRecorder) Renew(id, owner, now, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockRepository)(nil).Renew), id, owner, now, lease)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(e *entity.Tombstone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ClaimPendingTombstones mocks base method.
+func (m *MockUseCase) ClaimPendingTombstones(owner string, limit int, lease time.Duration) ([]*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingTombstones", owner, limit, lease)
+	ret0, _ := ret[0].([]*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingTombstones indicates an expected call of ClaimPendingTombstones.
+func (mr *MockUseCaseMockRecorder) ClaimPendingTombstones(owner, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingTombstones", reflect.TypeOf((*MockUseCase)(nil).ClaimPendingTombstones), owner, limit, lease)
+}
+
+// ConfirmTombstone mocks base method.
+func (m *MockUseCase) ConfirmTombstone(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTombstone", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTombstone indicates an expected call of ConfirmTombstone.
+func (mr *MockUseCaseMockRecorder) ConfirmTombstone(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTombstone", reflect.TypeOf((*MockUseCase)(nil).ConfirmTombstone), id, owner)
+}
+
+// CreateTombstone mocks base method.
+func (m *MockUseCase) CreateTombstone(tenantID entity.ID, object entity.SyncObject, entityID entity.ID, extID string) (*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTombstone", tenantID, object, entityID, extID)
+	ret0, _ := ret[0].(*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTombstone indicates an expected call of CreateTombstone.
+func (mr *MockUseCaseMockRecorder) CreateTombstone(tenantID, object, entityID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTombstone", reflect.TypeOf((*MockUseCase)(nil).CreateTombstone), tenantID, object, entityID, extID)
+}
+
+// FailTombstone mocks base method.
+func (m *MockUseCase) FailTombstone(id entity.ID, owner string, cause error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailTombstone", id, owner, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailTombstone indicates an expected call of FailTombstone.
+func (mr *MockUseCaseMockRecorder) FailTombstone(id, owner, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailTombstone", reflect.TypeOf((*MockUseCase)(nil).FailTombstone), id, owner, cause)
+}
+
+// GetPendingStats mocks base method.
+func (m *MockUseCase) GetPendingStats() (*entity.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingStats")
+	ret0, _ := ret[0].(*entity.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingStats indicates an expected call of GetPendingStats.
+func (mr *MockUseCaseMockRecorder) GetPendingStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStats", reflect.TypeOf((*MockUseCase)(nil).GetPendingStats))
+}
+
+// GetTombstone mocks base method.
+func (m *MockUseCase) GetTombstone(id entity.ID) (*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTombstone", id)
+	ret0, _ := ret[0].(*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTombstone indicates an expected call of GetTombstone.
+func (mr *MockUseCaseMockRecorder) GetTombstone(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTombstone", reflect.TypeOf((*MockUseCase)(nil).GetTombstone), id)
+}
+
+// ListPendingTombstones mocks base method.
+func (m *MockUseCase) ListPendingTombstones(limit int) ([]*entity.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingTombstones", limit)
+	ret0, _ := ret[0].([]*entity.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingTombstones indicates an expected call of ListPendingTombstones.
+func (mr *MockUseCaseMockRecorder) ListPendingTombstones(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingTombstones", reflect.TypeOf((*MockUseCase)(nil).ListPendingTombstones), limit)
+}
+
+// ReleaseTombstone mocks base method.
+func (m *MockUseCase) ReleaseTombstone(id entity.ID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseTombstone", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseTombstone indicates an expected call of ReleaseTombstone.
+func (mr *MockUseCaseMockRecorder) ReleaseTombstone(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseTombstone", reflect.TypeOf((*MockUseCase)(nil).ReleaseTombstone), id, owner)
+}
+
+// RenewTombstone mocks base method.
+func (m *MockUseCase) RenewTombstone(id entity.ID, owner string, lease time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewTombstone", id, owner, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewTombstone indicates an expected call of RenewTombstone.
+func (mr *MockUseCaseMockRecorder) RenewTombstone(id, owner, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewTombstone", reflect.TypeOf((*MockUseCase)(nil).RenewTombstone), id, owner, lease)
+}
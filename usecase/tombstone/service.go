@@ -0,0 +1,140 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package tombstone
+
+import (
+	"time"
+
+	"sudhagar/glad/entity"
+)
+
+// Service tombstone usecase
+type Service struct {
+	repo    Repository
+	actions map[entity.SyncObject]entity.TombstoneAction
+}
+
+// NewService create new service. actions selects how the delete of each
+// object is propagated to Salesforce; objects not listed are deleted.
+func NewService(r Repository, actions map[entity.SyncObject]entity.TombstoneAction) *Service {
+	return &Service{
+		repo:    r,
+		actions: actions,
+	}
+}
+
+// action returns the configured tombstone action for the object
+func (s *Service) action(object entity.SyncObject) entity.TombstoneAction {
+	if a, ok := s.actions[object]; ok && a.IsValid() {
+		return a
+	}
+	return entity.TombstoneDelete
+}
+
+// CreateTombstone creates a tombstone; returns the existing one if the
+// record is already waiting for a delete confirmation. A confirmed one, left
+// by a delete whose record is still there, is sent again.
+func (s *Service) CreateTombstone(tenantID entity.ID,
+	object entity.SyncObject,
+	entityID entity.ID,
+	extID string,
+) (*entity.Tombstone, error) {
+	t, err := s.repo.GetByEntity(object, entityID)
+	if err != nil && err != entity.ErrNotFound {
+		return nil, err
+	}
+	if t != nil && t.Status != entity.SyncSynced {
+		return t, nil
+	}
+
+	n, err := entity.NewTombstone(tenantID, object, entityID, extID, s.action(object))
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		n.ID = t.ID
+		n.CreatedAt = t.CreatedAt
+		return n, s.repo.Update(n)
+	}
+	_, err = s.repo.Create(n)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// GetTombstone retrieves a tombstone
+func (s *Service) GetTombstone(id entity.ID) (*entity.Tombstone, error) {
+	t, err := s.repo.Get(id)
+	if t == nil {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ListPendingTombstones lists tombstones to be sent to Salesforce
+func (s *Service) ListPendingTombstones(limit int) ([]*entity.Tombstone, error) {
+	tombstones, err := s.repo.ListPending(limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(tombstones) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return tombstones, nil
+}
+
+// ClaimPendingTombstones claims up to limit tombstones to send for owner
+func (s *Service) ClaimPendingTombstones(owner string, limit int, lease time.Duration) ([]*entity.Tombstone, error) {
+	tombstones, err := s.repo.Claim(owner, limit, time.Now(), lease)
+	if err != nil {
+		return nil, err
+	}
+	if len(tombstones) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return tombstones, nil
+}
+
+// RenewTombstone extends the claim of owner on a tombstone to the lease
+func (s *Service) RenewTombstone(id entity.ID, owner string, lease time.Duration) error {
+	return s.repo.Renew(id, owner, time.Now(), lease)
+}
+
+// ReleaseTombstone drops the claim of owner on a tombstone not sent
+func (s *Service) ReleaseTombstone(id entity.ID, owner string) error {
+	return s.repo.Release(id, owner)
+}
+
+// releaseClaim clears the claim of the tombstone
+func releaseClaim(t *entity.Tombstone) {
+	t.ClaimedBy = ""
+	t.ClaimedUntil = nil
+}
+
+// GetPendingStats gets the depth and the oldest pending item of the queue
+func (s *Service) GetPendingStats() (*entity.QueueStats, error) {
+	return s.repo.PendingStats()
+}
+
+// ConfirmTombstone marks a tombstone claimed by owner as synced
+func (s *Service) ConfirmTombstone(id entity.ID, owner string) error {
+	return s.repo.Confirm(id, owner, time.Now())
+}
+
+// FailTombstone marks a tombstone claimed by owner as failed
+func (s *Service) FailTombstone(id entity.ID, owner string, cause error) error {
+	lastError := ""
+	if cause != nil {
+		lastError = cause.Error()
+	}
+	return s.repo.Fail(id, owner, time.Now(), lastError)
+}
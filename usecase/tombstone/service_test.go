@@ -0,0 +1,129 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package tombstone
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"sudhagar/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice entity.ID = 13790492210917015554
+	courseAlice entity.ID = 13790493495087071234
+	centerAlice entity.ID = 13790493495087075501
+	aliceExtID            = "000aliceExtID"
+)
+
+func Test_Create(t *testing.T) {
+	m := NewService(NewInmem(), map[entity.SyncObject]entity.TombstoneAction{
+		entity.SyncObjectCourse: entity.TombstoneCancel,
+		entity.SyncObjectCenter: "bogus",
+	})
+
+	course, err := m.CreateTombstone(tenantAlice, entity.SyncObjectCourse, courseAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.TombstoneCancel, course.Action)
+	assert.Equal(t, entity.SyncPending, course.Status)
+
+	// invalid action falls back to delete
+	center, err := m.CreateTombstone(tenantAlice, entity.SyncObjectCenter, centerAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.TombstoneDelete, center.Action)
+
+	// idempotent
+	again, err := m.CreateTombstone(tenantAlice, entity.SyncObjectCourse, courseAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, course.ID, again.ID)
+
+	_, err = m.CreateTombstone(tenantAlice, entity.SyncObjectCourse, entity.NewID(), "")
+	assert.Equal(t, entity.ErrInvalidEntity, err)
+}
+
+func Test_ConfirmAndFail(t *testing.T) {
+	m := NewService(NewInmem(), nil)
+
+	course, _ := m.CreateTombstone(tenantAlice, entity.SyncObjectCourse, courseAlice, aliceExtID)
+	center, _ := m.CreateTombstone(tenantAlice, entity.SyncObjectCenter, centerAlice, aliceExtID)
+
+	tombstones, err := m.ListPendingTombstones(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(tombstones))
+
+	_, _ = m.ClaimPendingTombstones("syncer-1", 0, time.Minute)
+	err = m.FailTombstone(course.ID, "syncer-1", errors.New("timeout"))
+	assert.Nil(t, err)
+	saved, _ := m.GetTombstone(course.ID)
+	assert.Equal(t, entity.SyncFailed, saved.Status)
+	assert.Equal(t, "timeout", saved.LastError)
+	assert.Equal(t, int32(1), saved.Attempts)
+
+	// failed ones are retried
+	err = m.ConfirmTombstone(center.ID, "syncer-1")
+	assert.Nil(t, err)
+	tombstones, err = m.ListPendingTombstones(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tombstones))
+	assert.Equal(t, course.ID, tombstones[0].ID)
+
+	_, _ = m.ClaimPendingTombstones("syncer-1", 0, time.Minute)
+	err = m.ConfirmTombstone(course.ID, "syncer-1")
+	assert.Nil(t, err)
+	_, err = m.ListPendingTombstones(0)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	err = m.ConfirmTombstone(entity.NewID(), "syncer-1")
+	assert.Equal(t, entity.ErrClaimLost, err)
+
+	// deleted again, the record still being there
+	again, err := m.CreateTombstone(tenantAlice, entity.SyncObjectCourse, courseAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, course.ID, again.ID)
+	assert.Equal(t, entity.SyncPending, again.Status)
+	assert.Equal(t, int32(0), again.Attempts)
+	tombstones, err = m.ListPendingTombstones(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tombstones))
+}
+
+func Test_Claim(t *testing.T) {
+	m := NewService(NewInmem(), nil)
+
+	course, _ := m.CreateTombstone(tenantAlice, entity.SyncObjectCourse, courseAlice, aliceExtID)
+	center, _ := m.CreateTombstone(tenantAlice, entity.SyncObjectCenter, centerAlice, aliceExtID)
+
+	tombstones, err := m.ClaimPendingTombstones("syncer-1", 1, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tombstones))
+	claimed := tombstones[0].ID
+	tombstones, err = m.ClaimPendingTombstones("syncer-2", 0, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tombstones))
+	assert.NotEqual(t, claimed, tombstones[0].ID)
+	_, err = m.ClaimPendingTombstones("syncer-2", 0, time.Minute)
+	assert.Equal(t, entity.ErrNotFound, err)
+
+	// released or failed ones are claimed again
+	owners := map[entity.ID]string{claimed: "syncer-1", tombstones[0].ID: "syncer-2"}
+	err = m.ReleaseTombstone(course.ID, owners[course.ID])
+	assert.Nil(t, err)
+	err = m.FailTombstone(center.ID, owners[center.ID], errors.New("timeout"))
+	assert.Nil(t, err)
+	tombstones, err = m.ClaimPendingTombstones("syncer-2", 0, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(tombstones))
+
+	// only the owner of the claim updates the tombstone
+	assert.Equal(t, entity.ErrClaimLost, m.RenewTombstone(course.ID, "syncer-1", time.Minute))
+	assert.Equal(t, entity.ErrClaimLost, m.ConfirmTombstone(course.ID, "syncer-1"))
+	assert.Nil(t, m.RenewTombstone(course.ID, "syncer-2", time.Minute))
+	assert.Nil(t, m.ConfirmTombstone(course.ID, "syncer-2"))
+}